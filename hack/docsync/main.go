@@ -234,7 +234,8 @@ func parseCommands(dir string) ([]commandSpec, error) {
 	allowedFuncs := map[string]struct{}{
 		"createCommand": {}, "deleteCommand": {}, "cleanupCommand": {}, "renameCommand": {}, "listCommand": {},
 		"execCommand": {}, "noteCommand": {}, "describeCommand": {}, "doctorCommand": {},
-		"worktreesCommand": {}, "notesCommand": {}, "setupHooksCommand": {},
+		"worktreesCommand": {}, "notesCommand": {}, "setupHooksCommand": {}, "prCommand": {}, "mcpCommand": {},
+		"lockCommand": {}, "unlockCommand": {}, "repairCommand": {},
 	}
 	for _, file := range files {
 		for _, decl := range file.Decls {
@@ -286,15 +287,27 @@ func parseCommands(dir string) ([]commandSpec, error) {
 		}
 	}
 
+	prCmd := parseMergedParentCommand(files, "pr", map[string]string{
+		"prCreateCommand": "create",
+	}, "Manage the PR/MR of a worktree")
+	if prCmd != nil {
+		for i, cmd := range commands {
+			if cmd.Name == "pr" {
+				commands[i] = *prCmd
+				break
+			}
+		}
+	}
+
 	if len(commands) == 0 {
 		return nil, errors.New("no command definitions found")
 	}
 
 	order := map[string]int{
-		"list": 0, "create": 1, "delete": 2, "cleanup": 3, "rename": 4, "doctor": 5,
-		"worktrees": 6, "notes": 7, "exec": 8, "note": 9, "describe": 10,
+		"list": 0, "create": 1, "delete": 2, "cleanup": 3, "repair": 4, "rename": 5, "lock": 6, "unlock": 7,
+		"doctor": 8, "worktrees": 9, "notes": 10, "exec": 11, "note": 12, "pr": 13, "describe": 14, "mcp": 15,
 	}
-	sort.Slice(commands, func(i, j int) bool {
+	sort.SliceStable(commands, func(i, j int) bool {
 		return order[commands[i].Name] < order[commands[j].Name]
 	})
 	return commands, nil
@@ -374,6 +387,7 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"auto_refresh":                 "bool",
 		"ci_auto_refresh":              "bool",
 		"ci_remote":                    "string",
+		"forge_client":                 "enum(cli|http)",
 		"refresh_interval":             "int",
 		"search_auto_select":           "bool",
 		"fuzzy_finder_input":           "bool",
@@ -407,6 +421,7 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"worktree_note_type":           "enum(onejson|splitted)",
 		"agent_sessions":               "object",
 		"layout_sizes":                 "object",
+		"gitea":                        "object",
 	}
 
 	descByKey := map[string]string{
@@ -426,6 +441,7 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"auto_refresh":                 "Enable background refresh of repository state.",
 		"ci_auto_refresh":              "Enable periodic CI refresh for GitHub repositories.",
 		"ci_remote":                    "Git remote to target for CI and PR status queries (GitHub only). When unset or set to `auto`, an `upstream` remote is preferred when present, otherwise `origin`. Set to a remote name (e.g. `origin`) to target a specific remote. This setting does not change repository identity. Useful for fork workflows where pull requests live on the upstream repository.",
+		"forge_client":                 "How PR, issue, and CI data is fetched from GitHub and GitLab. `cli` runs `gh`/`glab`; `http` uses an in-process API client with connection reuse, ETag caching, and a single GraphQL query for all worktree PRs, taking the token from the environment, the `gh`/`glab` configuration, or the git credential helper. Falls back to the CLIs when no token is found.",
 		"refresh_interval":             "Background refresh cadence in seconds.",
 		"search_auto_select":           "Focus filter and auto-select first match.",
 		"fuzzy_finder_input":           "Enable fuzzy helper input in selection dialogues.",
@@ -447,7 +463,7 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"worktree_notes_path":          "Optional shared JSON file path for notes storage.",
 		"issue_branch_name_template":   "Template for issue-based branch naming.",
 		"pr_branch_name_template":      "Template for PR-based branch naming.",
-		"merge_method":                 "Absorb strategy for integrating a worktree.",
+		"merge_method":                 "Absorb strategy for integrating a worktree; default Merge PR/MR method.",
 		"session_prefix":               "Prefix for tmux/zellij session names.",
 		"palette_mru":                  "Enable MRU sorting in command palette.",
 		"palette_mru_limit":            "Maximum MRU items in command palette.",
@@ -457,8 +473,9 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"custom_create_menus":          "Custom create menu entries.",
 		"custom_themes":                "Custom theme definitions.",
 		"worktree_note_type":           "Note storage format strategy. Use `onejson` (default) for a single shared JSON file, or `splitted` for individual markdown files with YAML frontmatter.",
-		"agent_sessions":               "Agent-session pane settings. Nested options: `claude_root` and `pi_root` (custom transcript base directories); `disabled` (`bool`, default `false`) to turn off transcript watching and hide the Agent Sessions pane; `refresh_debounce_ms` (`int`, default `600`) to throttle transcript re-parsing — raise it to lower CPU while an agent is actively writing, set `0` to disable throttling; `process_scan` (`bool`, default `false`, deprecated) to re-enable the ps/lsof process-table liveness scan — prefer `lazyworktree setup-hooks` instead.",
+		"agent_sessions":               "Agent-session pane settings. Nested options: `claude_root`, `pi_root`, `codex_root`, `gemini_root` and `opencode_root` (custom transcript base directories); `disabled` (`bool`, default `false`) to turn off transcript watching and hide the Agent Sessions pane; `refresh_debounce_ms` (`int`, default `600`) to throttle transcript re-parsing — raise it to lower CPU while an agent is actively writing, set `0` to disable throttling; `launch_commands` (map of agent name to command) overrides the command run by the Start agent action, with the initial prompt appended; `prices` (map of model name prefix to `input`, `output`, `cache_write` and `cache_read` prices in US dollars per million tokens) is merged over the built-in price table used to estimate session costs; `notifications` (`channels`: `osc9`, `osc777`, `desktop`, `tmux`, `command`, default none; `events`: `waiting`, `approval`, `idle`, default all; `command`; `rate_limit_seconds`, default `60`) sends a notification when an agent waits for input, asks for approval or goes idle after working; `process_scan` (`bool`, default `false`, deprecated) to re-enable the ps/lsof process-table liveness scan — prefer `lazyworktree setup-hooks` instead.",
 		"layout_sizes":                 "Configurable baseline layout weights for panes (`worktrees`, `info`, `git_status`, `commit`, `agent_sessions`, `notes`). Relative weights are normalised at runtime.",
+		"gitea":                        "Gitea/Forgejo forge settings. Nested options: `token` (API token; falls back to the matching `tea` login) and `hosts` (extra hostnames or base URLs to treat as Gitea/Forgejo). `codeberg.org` and hosts from `tea` logins are recognised automatically.",
 	}
 
 	defaultByKey := map[string]string{
@@ -469,6 +486,7 @@ func parseConfigKeys(path string) ([]configKeySpec, error) {
 		"auto_refresh":               defaults["AutoRefresh"],
 		"ci_auto_refresh":            "false",
 		"ci_remote":                  "auto",
+		"forge_client":               defaults["ForgeClient"],
 		"refresh_interval":           defaults["RefreshIntervalSeconds"],
 		"search_auto_select":         defaults["SearchAutoSelect"],
 		"fuzzy_finder_input":         "false",
@@ -839,6 +857,8 @@ func orderedConfigKeys(keys []string) []string {
 		"refresh_interval",
 		"ci_auto_refresh",
 		"ci_remote",
+		"forge_client",
+		"gitea",
 		"auto_fetch_prs",
 		"disable_pr",
 		"prune_stale_branches",
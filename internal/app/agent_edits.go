@@ -0,0 +1,43 @@
+package app
+
+import (
+	"maps"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// agentEditsForSelectedWorktree maps the files of the selected worktree that
+// agents edited since its HEAD commit to the agent that touched them last.
+func (m *Model) agentEditsForSelectedWorktree() map[string]models.AgentKind {
+	wt := m.selectedWorktree()
+	if wt == nil || len(m.state.data.agentSessionsSnapshot) == 0 {
+		return nil
+	}
+	var since time.Time
+	if wt.LastActiveTS > 0 {
+		since = time.Unix(wt.LastActiveTS, 0)
+	}
+	return services.AgentEditedFiles(m.state.data.agentSessionsSnapshot, wt.Path, since)
+}
+
+// refreshStatusAgentEdits rebuilds the status tree when a session refresh
+// changed which of its files were edited by an agent.
+func (m *Model) refreshStatusAgentEdits() {
+	if len(m.state.data.statusFilesAll) == 0 {
+		return
+	}
+	if maps.Equal(m.agentEditsForSelectedWorktree(), m.state.data.statusAgentEdits) {
+		return
+	}
+	m.applyStatusFilter()
+}
+
+// toggleStatusAgentFilter limits the status pane to files changed by an
+// agent, or shows every file again.
+func (m *Model) toggleStatusAgentFilter() {
+	filter := m.state.services.filter
+	filter.StatusAgentOnly = !filter.StatusAgentOnly
+	m.applyStatusFilter()
+}
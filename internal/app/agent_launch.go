@@ -0,0 +1,243 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/multiplexer"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// agentLauncher describes how to start a coding agent with an initial prompt.
+type agentLauncher struct {
+	kind    models.AgentKind
+	label   string
+	command string
+	// promptFlag precedes the initial prompt; when empty the prompt is
+	// passed as the first positional argument.
+	promptFlag string
+}
+
+// agentLaunchers lists the agents offered by the start agent action. Aider is
+// left out: it has no way to open an interactive chat with a first message.
+var agentLaunchers = []agentLauncher{
+	{kind: models.AgentKindClaude, label: "Claude Code", command: "claude"},
+	{kind: models.AgentKindCodex, label: "Codex CLI", command: "codex"},
+	{kind: models.AgentKindGemini, label: "Gemini CLI", command: "gemini", promptFlag: "--prompt-interactive"},
+	{kind: models.AgentKindOpenCode, label: "OpenCode", command: "opencode", promptFlag: "--prompt"},
+	{kind: models.AgentKindCopilot, label: "Copilot CLI", command: "copilot", promptFlag: "--interactive"},
+	{kind: models.AgentKindPi, label: "pi", command: "pi"},
+}
+
+type agentLaunchPromptMsg struct {
+	launcher     agentLauncher
+	worktreePath string
+	prompt       string
+}
+
+// agentLaunchCommand returns the shell command starting the agent with the
+// prompt. A command from agent_sessions.launch_commands replaces the
+// built-in one; the prompt is still appended the way the agent expects it.
+func (m *Model) agentLaunchCommand(launcher agentLauncher, prompt string) string {
+	command := launcher.command
+	if custom := strings.TrimSpace(m.config.AgentLaunchCommands[string(launcher.kind)]); custom != "" {
+		command = custom
+	}
+	prompt = strings.TrimSpace(prompt)
+	if prompt == "" {
+		return command
+	}
+	if launcher.promptFlag != "" {
+		command += " " + launcher.promptFlag
+	}
+	return command + " " + shellQuote(prompt)
+}
+
+// showStartAgent asks which agent to start in the selected worktree.
+func (m *Model) showStartAgent() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+
+	launchers := make(map[string]agentLauncher, len(agentLaunchers))
+	items := make([]appscreen.SelectionItem, 0, len(agentLaunchers))
+	for _, launcher := range agentLaunchers {
+		id := string(launcher.kind)
+		launchers[id] = launcher
+		items = append(items, appscreen.SelectionItem{
+			ID:          id,
+			Label:       launcher.label,
+			Description: m.agentLaunchCommand(launcher, ""),
+		})
+	}
+	listScr := appscreen.NewListSelectionScreen(
+		items,
+		fmt.Sprintf("Start agent in %s", filepath.Base(wt.Path)),
+		"Filter agents...",
+		"No agents found.",
+		m.state.view.WindowWidth, m.state.view.WindowHeight,
+		"", m.theme,
+	)
+	worktreePath := wt.Path
+	branch := wt.Branch
+	listScr.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		launcher, ok := launchers[item.ID]
+		if !ok {
+			return nil
+		}
+		return m.loadAgentLaunchPrompt(launcher, worktreePath, branch)
+	}
+	m.state.ui.screenManager.Push(listScr)
+	return nil
+}
+
+// loadAgentLaunchPrompt prefills the initial prompt from the worktree note,
+// or from the issue the worktree was created from when it has no note.
+func (m *Model) loadAgentLaunchPrompt(launcher agentLauncher, worktreePath, branch string) tea.Cmd {
+	if note, ok := m.getWorktreeNote(worktreePath); ok && strings.TrimSpace(note.Note) != "" {
+		return m.showAgentPromptEditor(launcher, worktreePath, strings.TrimSpace(note.Note))
+	}
+
+	template := m.config.IssueBranchNameTemplate
+	if template == "" {
+		template = "issue-{number}-{title}"
+	}
+	number := utils.IssueNumberFromWorktreeName(branch, template)
+	if number == 0 {
+		number = utils.IssueNumberFromWorktreeName(filepath.Base(worktreePath), template)
+	}
+	if number == 0 || m.config.DisablePR {
+		return m.showAgentPromptEditor(launcher, worktreePath, "")
+	}
+
+	return func() tea.Msg {
+		msg := agentLaunchPromptMsg{launcher: launcher, worktreePath: worktreePath}
+		if !m.state.services.git.HasForge(m.ctx) {
+			return msg
+		}
+		issue, err := m.state.services.git.FetchIssue(m.ctx, number)
+		if err != nil {
+			m.debugf("start agent: fetching issue #%d failed: %v", number, err)
+			return msg
+		}
+		if issue != nil {
+			msg.prompt = strings.TrimSpace(fmt.Sprintf("%s\n\n%s", issue.Title, issue.Body))
+		}
+		return msg
+	}
+}
+
+// showAgentPromptEditor lets the user review the initial prompt before the
+// agent starts. An empty prompt starts the agent without one.
+func (m *Model) showAgentPromptEditor(launcher agentLauncher, worktreePath, prompt string) tea.Cmd {
+	textareaScr := appscreen.NewTextareaScreen(
+		fmt.Sprintf("Initial prompt for %s", launcher.label),
+		"Describe the task, or leave empty to start without a prompt...",
+		prompt,
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	textareaScr.OnSubmit = func(value string) tea.Cmd {
+		wt := m.worktreeByPath(worktreePath)
+		if wt == nil {
+			return func() tea.Msg {
+				return errMsg{err: fmt.Errorf("worktree %s no longer exists", worktreePath)}
+			}
+		}
+		return m.launchAgent(launcher, wt, value)
+	}
+	textareaScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(textareaScr)
+	return textarea.Blink
+}
+
+// launchAgent starts the agent in a new multiplexer window or terminal tab
+// and associates its session with the worktree straight away.
+func (m *Model) launchAgent(launcher agentLauncher, wt *models.WorktreeInfo, prompt string) tea.Cmd {
+	cmd := m.openAgentWindow(string(launcher.kind), m.agentLaunchCommand(launcher, prompt), wt)
+	if cmd == nil {
+		return nil
+	}
+	if service := m.state.services.agentSessions; service != nil && m.agentSessionsEnabled() {
+		service.RegisterLaunch(launcher.kind, wt.Path, prompt, time.Now())
+		m.refreshSelectedWorktreeAgentSessionsPane()
+	}
+	return cmd
+}
+
+// openAgentWindow runs an agent command in a new tmux session named
+// <session_prefix><worktree>-<agent>, switching the client to it, when running
+// inside tmux; a zellij tab inside zellij; or a new terminal tab otherwise,
+// falling back to a tmux session. It returns nil when none of these is
+// available.
+func (m *Model) openAgentWindow(name, command string, wt *models.WorktreeInfo) tea.Cmd {
+	layout := &config.TmuxCommand{
+		SessionName: fmt.Sprintf("%s%s-%s", m.config.SessionPrefix, filepath.Base(wt.Path), name),
+		Attach:      true,
+		OnExists:    multiplexer.OnExistsNew,
+		Windows:     []config.TmuxWindow{{Name: name, Command: command}},
+	}
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		return m.openTmuxSession(&config.CustomCommand{Tmux: layout}, wt)
+	case os.Getenv("ZELLIJ") != "" || os.Getenv("ZELLIJ_SESSION_NAME") != "":
+		return m.openZellijAgentTab(layout, wt)
+	case detectTerminalLauncher(m.commandRunner) != nil:
+		return m.openTerminalTab(&config.CustomCommand{
+			Command:     command,
+			Description: fmt.Sprintf("%s: %s", name, filepath.Base(wt.Path)),
+		}, wt)
+	}
+	if _, err := exec.LookPath("tmux"); err != nil {
+		m.showInfo("Starting an agent needs tmux, zellij, or a supported terminal (Kitty, WezTerm, or iTerm).", nil)
+		return nil
+	}
+	return m.openTmuxSession(&config.CustomCommand{Tmux: layout}, wt)
+}
+
+// openZellijAgentTab adds a tab running the agent to the current zellij
+// session.
+func (m *Model) openZellijAgentTab(layout *config.TmuxCommand, wt *models.WorktreeInfo) tea.Cmd {
+	env := m.buildCommandEnvForWorktree(wt)
+	resolved, ok := resolveTmuxWindows(layout.Windows, env, wt.Path)
+	if !ok {
+		return func() tea.Msg {
+			return errMsg{err: fmt.Errorf("failed to resolve zellij windows")}
+		}
+	}
+	layoutPaths, err := writeZellijLayouts(resolved)
+	if err != nil {
+		return func() tea.Msg { return errMsg{err: err} }
+	}
+	return func() tea.Msg {
+		defer multiplexer.CleanupZellijLayouts(layoutPaths)
+		for _, layoutPath := range layoutPaths {
+			// #nosec G204 -- the layout file is generated by lazyworktree.
+			c := m.commandRunner(m.ctx, "zellij", "action", "new-tab", "--layout", layoutPath)
+			c.Dir = wt.Path
+			c.Env = services.AppendCommandEnv(os.Environ(), env)
+			if out, err := c.CombinedOutput(); err != nil {
+				return errMsg{err: fmt.Errorf("zellij new-tab failed: %w: %s", err, strings.TrimSpace(string(out)))}
+			}
+		}
+		return nil
+	}
+}
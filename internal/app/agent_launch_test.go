@@ -0,0 +1,97 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func setupAgentLaunchTestModel(t *testing.T) (*Model, *models.WorktreeInfo) {
+	t.Helper()
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir(), SessionPrefix: "wt-"}
+	m := NewModel(cfg, "")
+	m.setWindowSize(120, 40)
+	wt := &models.WorktreeInfo{Path: filepath.Join(t.TempDir(), "feature"), Branch: "feature"}
+	m.state.data.worktrees = []*models.WorktreeInfo{wt}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+	return m, wt
+}
+
+func TestAgentLaunchCommand(t *testing.T) {
+	m, _ := setupAgentLaunchTestModel(t)
+	m.config.AgentLaunchCommands = map[string]string{"claude": "claude --permission-mode acceptEdits"}
+
+	claude := agentLaunchers[0]
+	if got := m.agentLaunchCommand(claude, "  "); got != "claude --permission-mode acceptEdits" {
+		t.Fatalf("expected the configured command without a prompt, got %q", got)
+	}
+	if got := m.agentLaunchCommand(claude, "Fix it's login"); got != `claude --permission-mode acceptEdits 'Fix it'"'"'s login'` {
+		t.Fatalf("expected a quoted positional prompt, got %q", got)
+	}
+	gemini := agentLauncher{kind: models.AgentKindGemini, command: "gemini", promptFlag: "--prompt-interactive"}
+	if got := m.agentLaunchCommand(gemini, "hello"); got != "gemini --prompt-interactive 'hello'" {
+		t.Fatalf("expected the prompt after its flag, got %q", got)
+	}
+}
+
+func TestShowStartAgentPrefillsPromptFromNote(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	m.setWorktreeNote(wt.Path, "Refactor the cache layer\n")
+
+	m.showStartAgent()
+	if m.state.ui.screenManager.Type() != appscreen.TypeListSelect {
+		t.Fatalf("expected the agent picker, got %v", m.state.ui.screenManager.Type())
+	}
+	listScr := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if len(listScr.Items) != len(agentLaunchers) || listScr.Items[0].ID != string(models.AgentKindClaude) {
+		t.Fatalf("unexpected agent items %#v", listScr.Items)
+	}
+
+	listScr.OnSelect(listScr.Items[1])
+	if m.state.ui.screenManager.Type() != appscreen.TypeTextarea {
+		t.Fatalf("expected the prompt editor, got %v", m.state.ui.screenManager.Type())
+	}
+	promptScr := m.state.ui.screenManager.Current().(*appscreen.TextareaScreen)
+	if got := promptScr.Input.Value(); got != "Refactor the cache layer" {
+		t.Fatalf("expected the prompt to be prefilled from the note, got %q", got)
+	}
+}
+
+func TestLoadAgentLaunchPromptWithoutNoteOrIssue(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+
+	if cmd := m.loadAgentLaunchPrompt(agentLaunchers[0], wt.Path, wt.Branch); cmd == nil {
+		t.Fatal("expected the prompt editor to blink its cursor")
+	}
+	promptScr, ok := m.state.ui.screenManager.Current().(*appscreen.TextareaScreen)
+	if !ok || promptScr.Input.Value() != "" {
+		t.Fatalf("expected an empty prompt editor, got %#v", m.state.ui.screenManager.Current())
+	}
+}
+
+func TestLaunchAgentAssociatesSessionWithWorktree(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	root := t.TempDir()
+	m.state.services.agentSessions = services.NewAgentSessionServiceWithStore(
+		services.AgentSessionRoots{},
+		services.NewTestSessionRegistryStore(filepath.Join(root, "registry.json")),
+		nil,
+	)
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+
+	if cmd := m.launchAgent(agentLaunchers[1], wt, "Write the changelog"); cmd == nil {
+		t.Fatal("expected a tmux command")
+	}
+	if len(m.state.data.agentSessions) != 1 {
+		t.Fatalf("expected the launched agent in the sessions pane, got %#v", m.state.data.agentSessions)
+	}
+	session := m.state.data.agentSessions[0]
+	if session.Agent != models.AgentKindCodex || session.LivenessSource != models.AgentSessionLivenessSourceLaunch {
+		t.Fatalf("unexpected launched session %#v", session)
+	}
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// notifyAgentTransitions feeds a refreshed session snapshot to the notifier
+// and delivers whatever it reports on every configured channel.
+func (m *Model) notifyAgentTransitions(sessions []*models.AgentSession) tea.Cmd {
+	notifier := m.state.services.agentNotifier
+	if notifier == nil {
+		return nil
+	}
+	notifications := notifier.Observe(sessions, m.worktreePathForAgentCWD, time.Now())
+	cmds := make([]tea.Cmd, 0, len(notifications))
+	for _, notification := range notifications {
+		if cmd := m.deliverAgentNotification(notification); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// worktreePathForAgentCWD returns the deepest worktree containing cwd, so
+// sessions started in a nested worktree are not attributed to its parent.
+func (m *Model) worktreePathForAgentCWD(cwd string) string {
+	cwd = filepath.Clean(strings.TrimSpace(cwd))
+	best := ""
+	for _, wt := range m.state.data.worktrees {
+		base := filepath.Clean(wt.Path)
+		if (cwd == base || strings.HasPrefix(cwd, base+string(filepath.Separator))) && len(base) > len(best) {
+			best = base
+		}
+	}
+	return best
+}
+
+func (m *Model) agentNotificationText(n services.AgentNotification) (string, string) {
+	label := agentKindLabel(n.Session.Agent)
+	var title string
+	switch n.Event {
+	case services.AgentNotifyApproval:
+		title = label + " needs approval"
+	case services.AgentNotifyWaiting:
+		title = label + " is waiting for input"
+	default:
+		title = label + " has gone idle"
+	}
+	body := m.agentSessionTitle(n.Session)
+	if n.WorktreePath != "" {
+		body = fmt.Sprintf("%s · %s", filepath.Base(n.WorktreePath), body)
+	}
+	return title, body
+}
+
+// agentNotifyCommand is an external notifier run off the UI goroutine.
+type agentNotifyCommand struct {
+	args []string
+	dir  string
+	env  []string
+}
+
+func (m *Model) deliverAgentNotification(n services.AgentNotification) tea.Cmd {
+	title, body := m.agentNotificationText(n)
+	m.debugf("agent notification: %s: %s", title, body)
+
+	var raw strings.Builder
+	var external []agentNotifyCommand
+	for _, channel := range m.config.AgentNotifyChannels {
+		switch channel {
+		case "osc9":
+			raw.WriteString(wrapTmuxPassthrough("\x1b]9;" + oscSafe(title+": "+body) + "\x1b\\"))
+		case "osc777":
+			raw.WriteString(wrapTmuxPassthrough("\x1b]777;notify;" + oscSafe(strings.ReplaceAll(title, ";", ",")) + ";" + oscSafe(body) + "\x1b\\"))
+		case "desktop":
+			if runtime.GOOS == "darwin" {
+				script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+				external = append(external, agentNotifyCommand{args: []string{"osascript", "-e", script}})
+			} else {
+				external = append(external, agentNotifyCommand{args: []string{"notify-send", "-a", "lazyworktree", title, body}})
+			}
+		case "tmux":
+			if os.Getenv("TMUX") != "" {
+				external = append(external, agentNotifyCommand{args: []string{"tmux", "display-message", title + ": " + body}})
+			}
+		case "command":
+			if strings.TrimSpace(m.config.AgentNotifyCommand) == "" {
+				continue
+			}
+			env := m.buildCommandEnvForWorktree(m.worktreeByPath(n.WorktreePath))
+			env["LAZYWORKTREE_AGENT"] = string(n.Session.Agent)
+			env["LAZYWORKTREE_AGENT_EVENT"] = string(n.Event)
+			env["LAZYWORKTREE_AGENT_SESSION"] = n.Session.ID
+			env["LAZYWORKTREE_NOTIFICATION_TITLE"] = title
+			env["LAZYWORKTREE_NOTIFICATION_BODY"] = body
+			external = append(external, agentNotifyCommand{
+				args: []string{"sh", "-c", m.config.AgentNotifyCommand},
+				dir:  n.WorktreePath,
+				env:  services.AppendCommandEnv(os.Environ(), env),
+			})
+		}
+	}
+
+	var cmds []tea.Cmd
+	if raw.Len() > 0 {
+		cmds = append(cmds, tea.Raw(raw.String()))
+	}
+	if len(external) > 0 {
+		cmds = append(cmds, func() tea.Msg {
+			for _, notify := range external {
+				// #nosec G204 -- the notification command comes from the user's config.
+				c := m.commandRunner(m.ctx, notify.args[0], notify.args[1:]...)
+				c.Dir = notify.dir
+				c.Env = notify.env
+				if out, err := c.CombinedOutput(); err != nil {
+					m.debugf("agent notification via %s failed: %v: %s", notify.args[0], err, strings.TrimSpace(string(out)))
+				}
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// oscSafe strips control characters that would terminate or corrupt an OSC
+// sequence.
+func oscSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestWorktreePathForAgentCWDPrefersNestedWorktree(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	nested := &models.WorktreeInfo{Path: filepath.Join(wt.Path, "nested"), Branch: "nested"}
+	m.state.data.worktrees = append(m.state.data.worktrees, nested)
+
+	if got := m.worktreePathForAgentCWD(filepath.Join(nested.Path, "pkg")); got != nested.Path {
+		t.Fatalf("expected the nested worktree, got %q", got)
+	}
+	if got := m.worktreePathForAgentCWD(wt.Path + "-other"); got != "" {
+		t.Fatalf("expected no worktree for a sibling directory, got %q", got)
+	}
+}
+
+func TestNotifyAgentTransitionsRunsCommand(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	if err := os.MkdirAll(wt.Path, 0o750); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(t.TempDir(), "notified")
+	m.config.AgentNotifyChannels = []string{"command"}
+	m.config.AgentNotifyCommand = `printf '%s|%s|%s' "$LAZYWORKTREE_AGENT_EVENT" "$WORKTREE_BRANCH" "$LAZYWORKTREE_NOTIFICATION_TITLE" > ` + out
+	m.commandRunner = (&commandRecorder{}).runner
+	m.state.services.agentNotifier = services.NewAgentNotifier([]string{"waiting"}, time.Minute)
+
+	session := &models.AgentSession{
+		SessionKey:    "claude:1",
+		Agent:         models.AgentKindClaude,
+		CWD:           wt.Path,
+		Title:         "Fix the build",
+		Activity:      models.AgentActivityWriting,
+		LivenessState: models.AgentSessionLivenessActive,
+	}
+	if cmd := m.notifyAgentTransitions([]*models.AgentSession{session}); cmd != nil {
+		t.Fatal("expected the first snapshot to only prime the notifier")
+	}
+
+	waiting := *session
+	waiting.Activity = models.AgentActivityWaiting
+	cmd := m.notifyAgentTransitions([]*models.AgentSession{&waiting})
+	if cmd == nil {
+		t.Fatal("expected a notification command")
+	}
+	_ = cmd()
+
+	data, err := os.ReadFile(out) // #nosec G304 -- out is in the test temp dir
+	if err != nil {
+		t.Fatalf("expected the notification command to run: %v", err)
+	}
+	if got := string(data); !strings.HasPrefix(got, "waiting|feature|") || !strings.HasSuffix(got, "is waiting for input") {
+		t.Fatalf("unexpected notification environment %q", got)
+	}
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// agentPaneMsg reports where a live agent process runs: a tmux pane or a
+// zellij session. Both are empty when neither could be found.
+type agentPaneMsg struct {
+	pid           int
+	tmuxSession   string
+	tmuxPane      string
+	zellijSession string
+	err           error
+}
+
+// tmuxPane is one line of `tmux list-panes -a`.
+type tmuxPane struct {
+	pid     int
+	session string
+	id      string
+}
+
+func (m *Model) selectedAgentSession() *models.AgentSession {
+	idx := m.state.data.agentSessionIndex
+	if idx < 0 || idx >= len(m.state.data.agentSessions) {
+		return nil
+	}
+	return m.state.data.agentSessions[idx]
+}
+
+// resumeSelectedAgentSession reopens the selected session in its agent, in a
+// new multiplexer window or terminal tab.
+func (m *Model) resumeSelectedAgentSession() tea.Cmd {
+	session := m.selectedAgentSession()
+	wt := m.selectedWorktree()
+	if session == nil || wt == nil {
+		return nil
+	}
+	if strings.TrimSpace(session.ResumeHint) == "" {
+		m.showInfo(fmt.Sprintf("%s cannot be resumed.", m.agentSessionTitle(session)), nil)
+		return nil
+	}
+	command := session.ResumeHint
+	// Agents look sessions up by project, so resume from the session cwd.
+	if cwd := filepath.Clean(session.CWD); session.CWD != "" && cwd != filepath.Clean(wt.Path) {
+		command = fmt.Sprintf("cd %s && %s", shellQuote(cwd), command)
+	}
+	return m.openAgentWindow(string(session.Agent), command, wt)
+}
+
+// attachSelectedAgentSession focuses the tmux pane or zellij session where
+// the selected session's agent runs. Sessions without a live process are
+// resumed instead.
+func (m *Model) attachSelectedAgentSession() tea.Cmd {
+	session := m.selectedAgentSession()
+	if session == nil {
+		return nil
+	}
+	pid := session.PID
+	if pid <= 0 || session.LivenessState != models.AgentSessionLivenessActive {
+		return m.resumeSelectedAgentSession()
+	}
+	return func() tea.Msg {
+		msg := agentPaneMsg{pid: pid}
+		if out, err := m.commandRunner(m.ctx, "ps", "-A", "-o", "pid=,ppid=").Output(); err == nil {
+			ancestors := processAncestors(pid, parseProcessParents(string(out)))
+			// #nosec G204 -- static tmux format string
+			if out, err := m.commandRunner(m.ctx, "tmux", "list-panes", "-a", "-F", "#{pane_pid}\t#{session_name}\t#{pane_id}").Output(); err == nil {
+				if pane, ok := findTmuxPane(ancestors, parseTmuxPanes(string(out))); ok {
+					msg.tmuxSession, msg.tmuxPane = pane.session, pane.id
+					msg.err = m.selectTmuxPane(pane.id)
+					return msg
+				}
+			}
+		}
+		// zellij cannot list pane processes, but its panes export the
+		// session name to the processes they run.
+		// #nosec G204 -- pid is an integer from the session registry
+		if out, err := m.commandRunner(m.ctx, "ps", "eww", "-o", "command=", "-p", strconv.Itoa(pid)).Output(); err == nil {
+			msg.zellijSession = processEnvValue(string(out), "ZELLIJ_SESSION_NAME")
+		}
+		return msg
+	}
+}
+
+func (m *Model) handleAgentPane(msg agentPaneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Error: %v", msg.err), nil)
+		return nil
+	}
+	switch {
+	case msg.tmuxPane != "":
+		return m.attachTmuxSessionCmd(msg.tmuxSession, os.Getenv("TMUX") != "")
+	case msg.zellijSession != "":
+		insideZellij := os.Getenv("ZELLIJ") != "" || os.Getenv("ZELLIJ_SESSION_NAME") != ""
+		if !insideZellij {
+			return m.attachZellijSessionCmd(msg.zellijSession)
+		}
+		if msg.zellijSession == os.Getenv("ZELLIJ_SESSION_NAME") {
+			m.showInfo(fmt.Sprintf("The agent (PID %d) runs in a tab of this zellij session.", msg.pid), nil)
+			return nil
+		}
+		m.showInfo(buildZellijInfoMessage(msg.zellijSession), nil)
+		return nil
+	}
+	m.showInfo(fmt.Sprintf("No tmux pane or zellij session found for the agent (PID %d).", msg.pid), nil)
+	return nil
+}
+
+// selectTmuxPane makes the pane the active one of its session, so attaching
+// to the session lands on it.
+func (m *Model) selectTmuxPane(paneID string) error {
+	for _, action := range []string{"select-window", "select-pane"} {
+		// #nosec G204 -- pane id comes from tmux list-panes
+		if out, err := m.commandRunner(m.ctx, "tmux", action, "-t", paneID).CombinedOutput(); err != nil {
+			return fmt.Errorf("tmux %s failed: %w: %s", action, err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// parseProcessParents parses `ps -o pid=,ppid=` output into a child to
+// parent map.
+func parseProcessParents(out string) map[int]int {
+	parents := make(map[int]int)
+	for line := range strings.SplitSeq(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		ppid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		parents[pid] = ppid
+	}
+	return parents
+}
+
+// processAncestors returns pid followed by its ancestors, nearest first.
+func processAncestors(pid int, parents map[int]int) []int {
+	ancestors := []int{pid}
+	for range 32 {
+		parent, ok := parents[pid]
+		if !ok || parent <= 1 || parent == pid {
+			break
+		}
+		ancestors = append(ancestors, parent)
+		pid = parent
+	}
+	return ancestors
+}
+
+func parseTmuxPanes(out string) []tmuxPane {
+	var panes []tmuxPane
+	for line := range strings.SplitSeq(out, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		panes = append(panes, tmuxPane{pid: pid, session: fields[1], id: fields[2]})
+	}
+	return panes
+}
+
+// findTmuxPane returns the pane whose shell is the nearest ancestor of the
+// agent process.
+func findTmuxPane(ancestors []int, panes []tmuxPane) (tmuxPane, bool) {
+	for _, pid := range ancestors {
+		for _, pane := range panes {
+			if pane.pid == pid {
+				return pane, true
+			}
+		}
+	}
+	return tmuxPane{}, false
+}
+
+// processEnvValue extracts an environment variable from `ps eww` output,
+// which appends the environment to the command line.
+func processEnvValue(out, key string) string {
+	for field := range strings.FieldsSeq(out) {
+		if value, ok := strings.CutPrefix(field, key+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
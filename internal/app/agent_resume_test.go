@@ -0,0 +1,55 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestFindTmuxPaneForAgentProcess(t *testing.T) {
+	parents := parseProcessParents("  1     0\n 100     1\n 200   100\n 300   200\n garbage\n 400 1\n")
+	ancestors := processAncestors(300, parents)
+	if len(ancestors) != 3 || ancestors[0] != 300 || ancestors[2] != 100 {
+		t.Fatalf("unexpected ancestors %v", ancestors)
+	}
+
+	panes := parseTmuxPanes("400\twork\t%1\n100\tfeature-claude\t%7\nbad line\n")
+	pane, ok := findTmuxPane(ancestors, panes)
+	if !ok || pane.session != "feature-claude" || pane.id != "%7" {
+		t.Fatalf("expected the pane whose shell started the agent, got %#v (%v)", pane, ok)
+	}
+	if _, ok := findTmuxPane([]int{999}, panes); ok {
+		t.Fatal("expected no pane for a process outside tmux")
+	}
+}
+
+func TestProcessEnvValue(t *testing.T) {
+	out := "claude --resume abc PATH=/usr/bin ZELLIJ=0 ZELLIJ_SESSION_NAME=dev-box TERM=xterm"
+	if got := processEnvValue(out, "ZELLIJ_SESSION_NAME"); got != "dev-box" {
+		t.Fatalf("expected the zellij session name, got %q", got)
+	}
+	if got := processEnvValue(out, "TMUX"); got != "" {
+		t.Fatalf("expected no value for a missing variable, got %q", got)
+	}
+}
+
+func TestResumeSelectedAgentSessionWithoutHint(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	m.state.data.agentSessions = []*models.AgentSession{{
+		ID:    "launch-1",
+		Agent: models.AgentKindClaude,
+		CWD:   wt.Path,
+		Title: "Fix the build",
+	}}
+	m.state.data.agentSessionIndex = 0
+
+	if cmd := m.attachSelectedAgentSession(); cmd != nil {
+		t.Fatal("expected no command for a session that cannot be resumed")
+	}
+	infoScr, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.HasSuffix(infoScr.Message, "cannot be resumed.") {
+		t.Fatalf("expected an info message explaining the session cannot be resumed, got %#v", m.state.ui.screenManager.Current())
+	}
+}
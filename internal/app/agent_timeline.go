@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// agentTimelineWindow is how far back the activity timeline looks.
+const agentTimelineWindow = 24 * time.Hour
+
+type agentTimelineMsg struct {
+	events []services.AgentTimelineEvent
+}
+
+// showAgentTimeline opens the activity feed of every agent session.
+func (m *Model) showAgentTimeline() tea.Cmd {
+	if !m.agentSessionsEnabled() {
+		m.showInfo("Agent sessions are disabled in configuration", nil)
+		return nil
+	}
+	return m.loadAgentTimelineCmd()
+}
+
+// loadAgentTimelineCmd reads the transcripts off the UI goroutine, from a
+// copy of the current sessions and hook history.
+func (m *Model) loadAgentTimelineCmd() tea.Cmd {
+	sessions := make([]*models.AgentSession, 0, len(m.state.data.agentSessionsSnapshot))
+	for _, session := range m.state.data.agentSessionsSnapshot {
+		if session != nil {
+			snapshot := *session
+			sessions = append(sessions, &snapshot)
+		}
+	}
+	var hookEvents []models.AgentHookEvent
+	if hooks := m.state.services.agentHooks; hooks != nil {
+		hookEvents = hooks.Events()
+	}
+	since := time.Now().Add(-agentTimelineWindow)
+	cache := m.state.services.agentTimeline
+	return func() tea.Msg {
+		return agentTimelineMsg{events: services.BuildAgentTimeline(sessions, hookEvents, since, cache)}
+	}
+}
+
+// handleAgentTimeline opens the timeline screen, or refreshes it when it is
+// already displayed.
+func (m *Model) handleAgentTimeline(msg agentTimelineMsg) {
+	entries := m.agentTimelineEntries(msg.events)
+	if scr, ok := m.state.ui.screenManager.Current().(*appscreen.AgentTimelineScreen); ok {
+		scr.SetEntries(entries)
+		return
+	}
+	scr := appscreen.NewAgentTimelineScreen(
+		entries,
+		"Agent activity (last 24 hours)",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnSelect = func(entry appscreen.TimelineEntry) tea.Cmd {
+		m.state.ui.screenManager.Clear()
+		m.selectWorktreeByPath(entry.WorktreePath)
+		m.statusContent = fmt.Sprintf("Switched to %s", entry.Worktree)
+		return m.updateDetailsView()
+	}
+	scr.OnRefresh = m.loadAgentTimelineCmd
+	m.state.ui.screenManager.Push(scr)
+}
+
+// agentTimelineEntries attributes each event to the worktree it happened
+// in. Events outside any worktree keep their directory name and cannot be
+// jumped to.
+func (m *Model) agentTimelineEntries(events []services.AgentTimelineEvent) []appscreen.TimelineEntry {
+	entries := make([]appscreen.TimelineEntry, 0, len(events))
+	for _, event := range events {
+		path := m.worktreePathForAgentCWD(event.CWD)
+		worktree := filepath.Base(path)
+		switch {
+		case path == "" && event.CWD != "":
+			worktree = filepath.Base(event.CWD)
+		case path == "":
+			worktree = "-"
+		}
+		entries = append(entries, appscreen.TimelineEntry{
+			When:         formatRelativeTime(event.At),
+			Agent:        string(event.Agent),
+			Worktree:     worktree,
+			WorktreePath: path,
+			Kind:         string(event.Kind),
+			Text:         event.Text,
+		})
+	}
+	return entries
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestHandleAgentTimelineOpensAndJumps(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	now := time.Now()
+
+	m.handleAgentTimeline(agentTimelineMsg{events: []services.AgentTimelineEvent{
+		{At: now, Agent: models.AgentKindClaude, CWD: wt.Path, Kind: services.AgentTimelineRun, Text: "ran `go test`"},
+		{At: now.Add(-time.Minute), Agent: models.AgentKindCodex, CWD: "/elsewhere/scratch", Kind: services.AgentTimelineWaiting, Text: "waiting for input"},
+	}})
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.AgentTimelineScreen)
+	if !ok {
+		t.Fatalf("expected the timeline screen, got %v", m.state.ui.screenManager.Type())
+	}
+	if len(scr.Entries) != 2 || scr.Entries[0].WorktreePath != wt.Path || scr.Entries[1].Worktree != "scratch" || scr.Entries[1].WorktreePath != "" {
+		t.Fatalf("unexpected entries %+v", scr.Entries)
+	}
+
+	// A refresh updates the open screen instead of stacking another one.
+	m.handleAgentTimeline(agentTimelineMsg{})
+	if m.state.ui.screenManager.Current() != scr || len(scr.Entries) != 0 {
+		t.Fatal("expected the refresh to reuse the open timeline")
+	}
+
+	scr.SetEntries(m.agentTimelineEntries([]services.AgentTimelineEvent{{At: now, Agent: models.AgentKindClaude, CWD: wt.Path, Text: "edited a.go"}}))
+	scr.OnSelect(scr.Entries[0])
+	if m.state.ui.screenManager.IsActive() {
+		t.Fatal("expected jumping to a worktree to close the timeline")
+	}
+}
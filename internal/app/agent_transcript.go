@@ -0,0 +1,118 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type agentTranscriptMsg struct {
+	title   string
+	agent   models.AgentKind
+	entries []models.AgentTranscriptEntry
+	err     error
+}
+
+// showAgentTranscript reads the whole conversation of the selected session
+// and opens it in the transcript viewer.
+func (m *Model) showAgentTranscript() tea.Cmd {
+	session := m.selectedAgentSession()
+	if session == nil {
+		return nil
+	}
+	title := m.agentSessionTitle(session)
+	snapshot := *session
+	return func() tea.Msg {
+		entries, err := services.LoadAgentTranscript(&snapshot)
+		return agentTranscriptMsg{title: title, agent: snapshot.Agent, entries: entries, err: err}
+	}
+}
+
+func (m *Model) handleAgentTranscript(msg agentTranscriptMsg) {
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Cannot read the transcript of %s: %v", msg.title, msg.err), nil)
+		return
+	}
+	scr := appscreen.NewAgentTranscriptScreen(
+		msg.title,
+		m.agentTranscriptBlocks(msg.agent, msg.entries),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	m.state.ui.screenManager.Push(scr)
+}
+
+// agentTranscriptBlocks renders messages and reasoning as markdown, and tool
+// arguments and output verbatim.
+func (m *Model) agentTranscriptBlocks(agent models.AgentKind, entries []models.AgentTranscriptEntry) []appscreen.TranscriptBlock {
+	valueStyle := lipgloss.NewStyle().Foreground(m.theme.TextFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.MutedFg)
+	verbatim := func(text string) []string {
+		var lines []string
+		for line := range strings.SplitSeq(strings.TrimRight(text, "\n"), "\n") {
+			lines = append(lines, mutedStyle.Render(strings.ReplaceAll(line, "\t", "    ")))
+		}
+		return lines
+	}
+
+	blocks := make([]appscreen.TranscriptBlock, 0, len(entries))
+	for _, entry := range entries {
+		stamp := ""
+		if !entry.Timestamp.IsZero() {
+			stamp = " · " + entry.Timestamp.Local().Format("Jan 2 15:04")
+		}
+		block := appscreen.TranscriptBlock{IsError: entry.IsError}
+		switch entry.Kind {
+		case models.AgentTranscriptUser:
+			block.Kind = appscreen.TranscriptPrompt
+			block.Title = "You" + stamp
+			block.Lines = m.renderMarkdownNoteLines(entry.Text, valueStyle)
+		case models.AgentTranscriptAssistant:
+			block.Kind = appscreen.TranscriptReply
+			block.Title = agentKindLabel(agent) + stamp
+			block.Lines = m.renderMarkdownNoteLines(entry.Text, valueStyle)
+		case models.AgentTranscriptThinking:
+			block.Kind = appscreen.TranscriptThinking
+			block.Title = "Thinking"
+			block.Lines = m.renderMarkdownNoteLines(entry.Text, mutedStyle)
+		case models.AgentTranscriptToolCall:
+			block.Kind = appscreen.TranscriptToolCall
+			block.Title = entry.ToolName + stamp
+			if entry.ToolInput != "" {
+				block.Lines = verbatim(entry.ToolInput)
+			}
+		case models.AgentTranscriptToolResult:
+			block.Kind = appscreen.TranscriptToolResult
+			block.Title = strings.TrimSpace(entry.ToolName + " result")
+			if entry.IsError {
+				block.Title = strings.TrimSpace(entry.ToolName + " error")
+			}
+			if entry.Text != "" {
+				block.Lines = verbatim(entry.Text)
+			}
+		default:
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func agentKindLabel(kind models.AgentKind) string {
+	for _, launcher := range agentLaunchers {
+		if launcher.kind == kind {
+			return launcher.label
+		}
+	}
+	if kind == models.AgentKindAider {
+		return "Aider"
+	}
+	return string(kind)
+}
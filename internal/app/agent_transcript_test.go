@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestAgentTranscriptBlocks(t *testing.T) {
+	m, _ := setupAgentLaunchTestModel(t)
+
+	blocks := m.agentTranscriptBlocks(models.AgentKindCodex, []models.AgentTranscriptEntry{
+		{Kind: models.AgentTranscriptUser, Text: "Fix **it**"},
+		{Kind: models.AgentTranscriptToolCall, ToolName: "Bash", ToolInput: "{\n  \"command\": \"make\"\n}"},
+		{Kind: models.AgentTranscriptToolResult, ToolName: "Bash", Text: "boom", IsError: true},
+		{Kind: models.AgentTranscriptAssistant, Text: "Done."},
+	})
+	if len(blocks) != 4 {
+		t.Fatalf("expected 4 blocks, got %d", len(blocks))
+	}
+	if blocks[0].Kind != appscreen.TranscriptPrompt || blocks[0].Title != "You" {
+		t.Fatalf("unexpected prompt block %#v", blocks[0])
+	}
+	if len(blocks[1].Lines) != 3 {
+		t.Fatalf("expected the tool arguments line by line, got %#v", blocks[1].Lines)
+	}
+	if blocks[2].Title != "Bash error" || !blocks[2].IsError {
+		t.Fatalf("unexpected result block %#v", blocks[2])
+	}
+	if blocks[3].Title != "Codex CLI" {
+		t.Fatalf("expected the reply to be labelled with the agent, got %q", blocks[3].Title)
+	}
+}
+
+func TestShowAgentTranscriptWithoutTranscript(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	m.state.data.agentSessions = []*models.AgentSession{{ID: "copilot-1", Agent: models.AgentKindCopilot, CWD: wt.Path, Title: "Review"}}
+	m.state.data.agentSessionIndex = 0
+
+	cmd := m.showAgentTranscript()
+	if cmd == nil {
+		t.Fatal("expected a command loading the transcript")
+	}
+	msg, ok := cmd().(agentTranscriptMsg)
+	if !ok || msg.err == nil {
+		t.Fatalf("expected an error for a session without a transcript, got %#v", msg)
+	}
+	m.handleAgentTranscript(msg)
+	if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+		t.Fatalf("expected an info message, got %v", m.state.ui.screenManager.Type())
+	}
+}
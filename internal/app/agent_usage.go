@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// agentUsageByWorktree totals the token usage of every known session per
+// worktree path.
+func (m *Model) agentUsageByWorktree() map[string]services.AgentUsageSummary {
+	byPath := make(map[string][]*models.AgentSession)
+	for _, session := range m.state.data.agentSessionsSnapshot {
+		if session == nil || session.Usage.Total() == 0 {
+			continue
+		}
+		if path := m.worktreePathForAgentCWD(session.CWD); path != "" {
+			byPath[path] = append(byPath[path], session)
+		}
+	}
+	usage := make(map[string]services.AgentUsageSummary, len(byPath))
+	for path, sessions := range byPath {
+		usage[path] = services.SummariseAgentUsage(sessions, m.config.AgentPrices)
+	}
+	return usage
+}
+
+// noteAgentUsage reveals the cost column the first time a session reports
+// token usage. It stays visible afterwards so rows never outgrow columns.
+func (m *Model) noteAgentUsage(sessions []*models.AgentSession) {
+	if m.loading.agentUsageSeen || !m.agentSessionsEnabled() {
+		return
+	}
+	for _, session := range sessions {
+		if session != nil && session.Usage.Total() > 0 {
+			m.loading.agentUsageSeen = true
+			m.updateTableColumns(m.state.ui.worktreeTable.Width())
+			return
+		}
+	}
+}
+
+// formatAgentUsage shows the estimated cost, or the token count when no
+// model involved has a price. A trailing "+" marks a cost that leaves out
+// unpriced usage.
+func formatAgentUsage(summary services.AgentUsageSummary) string {
+	switch {
+	case summary.Usage.Total() == 0:
+		return ""
+	case !summary.Unpriced:
+		return fmt.Sprintf("$%.2f", summary.Cost)
+	case summary.Cost > 0:
+		return fmt.Sprintf("$%.2f+", summary.Cost)
+	default:
+		return formatTokenCount(summary.Usage.Total()) + " tok"
+	}
+}
+
+func formatTokenCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
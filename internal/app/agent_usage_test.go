@@ -0,0 +1,60 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestFormatAgentUsage(t *testing.T) {
+	usage := models.AgentTokenUsage{Input: 1500, Output: 300}
+	tests := []struct {
+		summary services.AgentUsageSummary
+		want    string
+	}{
+		{services.AgentUsageSummary{}, ""},
+		{services.AgentUsageSummary{Usage: usage, Cost: 0.0421}, "$0.04"},
+		{services.AgentUsageSummary{Usage: usage, Cost: 1.5, Unpriced: true}, "$1.50+"},
+		{services.AgentUsageSummary{Usage: usage, Unpriced: true}, "1.8k tok"},
+		{services.AgentUsageSummary{Usage: models.AgentTokenUsage{CacheRead: 2_500_000}, Unpriced: true}, "2.5M tok"},
+	}
+	for _, tt := range tests {
+		if got := formatAgentUsage(tt.summary); got != tt.want {
+			t.Errorf("formatAgentUsage(%+v) = %q, want %q", tt.summary, got, tt.want)
+		}
+	}
+}
+
+func TestAgentUsageShowsCostColumnAndCard(t *testing.T) {
+	m, wt := setupAgentLaunchTestModel(t)
+	m.updateTable()
+	m.updateTableColumns(100)
+	if got := len(m.state.ui.worktreeTable.Columns()); got != 3 {
+		t.Fatalf("expected no cost column before any usage, got %d columns", got)
+	}
+
+	session := &models.AgentSession{
+		ID:            "s1",
+		Agent:         models.AgentKindClaude,
+		CWD:           wt.Path,
+		Model:         "claude-sonnet-4-5",
+		Title:         "Fix the build",
+		LivenessState: models.AgentSessionLivenessActive,
+		Usage:         models.AgentTokenUsage{Input: 1_000_000, Output: 100_000},
+	}
+	_, _ = m.Update(agentSessionsUpdatedMsg{sessions: []*models.AgentSession{session}})
+
+	columns := m.state.ui.worktreeTable.Columns()
+	if len(columns) != 4 || columns[3].Title != "Cost" {
+		t.Fatalf("expected a cost column after usage was reported, got %+v", columns)
+	}
+	if row := m.state.ui.worktreeTable.Rows()[0]; row[3] != "$4.50" {
+		t.Fatalf("expected the worktree cost, got %q", row[3])
+	}
+	card := strings.Join(m.renderAgentSessionCard(session, 80, false), "\n")
+	if !strings.Contains(card, "$4.50") {
+		t.Fatalf("expected the session card to show its cost, got %q", card)
+	}
+}
@@ -88,6 +88,7 @@ type (
 		statusFiles []StatusFile
 		log         []commitLogEntry
 		path        string
+		rebasing    bool
 	}
 	refreshCompleteMsg      struct{}
 	fetchRemotesCompleteMsg struct{}
@@ -121,6 +122,7 @@ type (
 		failed          int
 		orphansDeleted  int
 		branchesDeleted int
+		lockedSkipped   int
 	}
 	absorbMergeResultMsg struct {
 		path   string
@@ -137,6 +139,12 @@ type (
 		checks []*models.CICheck
 		err    error
 	}
+	ciFailuresLoadedMsg struct {
+		branch   string
+		link     string
+		failures []models.CIFailure
+		err      error
+	}
 	avatarLoadedMsg struct {
 		url   string
 		image *services.AvatarImage
@@ -154,6 +162,38 @@ type (
 		prs []*models.PRInfo
 		err error
 	}
+	prCreatedMsg struct {
+		worktreePath string
+		pr           *models.PRInfo
+		err          error
+	}
+	prMergedMsg struct {
+		worktreePath string
+		number       int
+		auto         bool
+		err          error
+	}
+	prInboxLoadedMsg struct {
+		inbox *git.PRInbox
+		err   error
+	}
+	ciLogLoadedMsg struct {
+		worktreePath string
+		check        *models.CICheck
+		log          *git.CILog
+		err          error
+		// viewer is the log viewer being refreshed, nil for the first load
+		viewer   *screen.CILogScreen
+		interval time.Duration
+	}
+	prReviewThreadsLoadedMsg struct {
+		worktreePath string
+		number       int
+		threads      []*models.PRReviewThread
+		preferredID  string
+		err          error
+		actionErr    error
+	}
 	pushResultMsg struct {
 		output string
 		err    error
@@ -191,6 +231,18 @@ type (
 		worktrees []*models.WorktreeInfo
 		err       error
 	}
+	worktreeLockResultMsg struct {
+		path      string
+		status    string
+		worktrees []*models.WorktreeInfo
+		err       error
+	}
+	worktreesRepairedMsg struct {
+		relocated []models.WorktreeRelocation
+		missing   []string
+		worktrees []*models.WorktreeInfo
+		err       error
+	}
 	createFromChangesReadyMsg struct {
 		worktree      *models.WorktreeInfo
 		currentBranch string
@@ -208,6 +260,34 @@ type (
 		targetWorktree *models.WorktreeInfo
 		err            error
 	}
+	stashesLoadedMsg struct {
+		worktreePath string
+		stashes      []*models.StashEntry
+		status       string
+		changed      bool // A stash was applied or dropped, so worktrees need a refresh
+		actionErr    error
+	}
+	stashPreviewMsg struct {
+		hash string
+		diff string
+	}
+	rebaseCommitsLoadedMsg struct {
+		worktreePath string
+		base         string
+		onto         string
+		commits      []*models.RebaseCommit
+		err          error
+	}
+	rebaseResultMsg struct {
+		worktree  *models.WorktreeInfo
+		operation string // "rebase", "continue" or "abort"
+		err       error
+	}
+	moveChangesResultMsg struct {
+		source *models.WorktreeInfo
+		target *models.WorktreeInfo
+		err    error
+	}
 	aiBranchNameGeneratedMsg struct {
 		name string
 		err  error
@@ -235,6 +315,7 @@ type (
 	}
 	ciRerunResultMsg struct {
 		runURL string
+		cancel bool
 		err    error
 	}
 	openNoteEditorMsg struct {
@@ -316,9 +397,11 @@ type dataState struct {
 	statusFiles           []StatusFile     // parsed list of files from git status (kept for compatibility)
 	statusFilesAll        []StatusFile     // full list of files from git status
 	statusFileIndex       int              // currently selected file index in status pane
+	rebaseInProgress      bool             // selected worktree is in the middle of a rebase
 	agentSessions         []*models.AgentSession
 	agentSessionsSnapshot []*models.AgentSession // last full refresh result, for change detection
 	agentSessionIndex     int
+	statusAgentEdits      map[string]models.AgentKind // status file -> agent that last edited it
 	logEntries            []commitLogEntry
 	logEntriesAll         []commitLogEntry
 }
@@ -332,7 +415,9 @@ type servicesState struct {
 	agentSessions  *services.AgentSessionService
 	agentProcesses *services.AgentProcessService
 	agentHooks     *services.AgentHookService
+	agentTimeline  *services.AgentTranscriptCache
 	agentWatch     *services.AgentWatchService
+	agentNotifier  *services.AgentNotifier
 	filter         *services.FilterService
 }
 
@@ -369,7 +454,8 @@ type Model struct {
 		dataCache       map[string]any
 		divergenceCache map[string]string
 		notifiedErrors  map[string]bool
-		ciCache         services.CICheckCache // branch -> CI checks cache
+		ciCache         services.CICheckCache         // branch -> CI checks cache
+		ciFailures      map[string][]models.CIFailure // check link -> failure summary
 		detailsCache    map[string]*detailsCacheEntry
 		detailsCacheMu  sync.RWMutex
 	}
@@ -471,6 +557,8 @@ func NewModel(cfg *config.AppConfig, initialFilter string) *Model {
 	gitService.SetGitPager(cfg.GitPager)
 	gitService.SetGitPagerArgs(cfg.GitPagerArgs)
 	gitService.SetCIRemote(cfg.CIRemote)
+	gitService.SetGiteaConfig(cfg.GiteaToken, cfg.GiteaHosts)
+	gitService.SetForgeClient(cfg.ForgeClient)
 	trustManager := security.NewTrustManager()
 
 	columns := []table.Column{
@@ -585,6 +673,7 @@ func NewModel(cfg *config.AppConfig, initialFilter string) *Model {
 	m.cache.divergenceCache = make(map[string]string)
 	m.cache.notifiedErrors = make(map[string]bool)
 	m.cache.ciCache = services.NewCICheckCache()
+	m.cache.ciFailures = make(map[string][]models.CIFailure)
 	m.cache.detailsCache = make(map[string]*detailsCacheEntry)
 
 	m.state.ui.worktreeTable = t
@@ -602,11 +691,16 @@ func NewModel(cfg *config.AppConfig, initialFilter string) *Model {
 	m.state.services.worktree = services.NewWorktreeService(gitService)
 	m.state.services.statusTree = services.NewStatusService()
 	m.state.services.watch = services.NewGitWatchService(gitService, m.debugf)
-	m.state.services.agentSessions = services.NewAgentSessionServiceFromConfig(
-		cfg.AgentSessionClaudeRoot, cfg.AgentSessionPiRoot, m.debugf,
-	)
+	m.state.services.agentSessions = services.NewAgentSessionServiceFromConfig(services.AgentSessionRoots{
+		Claude:   cfg.AgentSessionClaudeRoot,
+		Pi:       cfg.AgentSessionPiRoot,
+		Codex:    cfg.AgentSessionCodexRoot,
+		Gemini:   cfg.AgentSessionGeminiRoot,
+		OpenCode: cfg.AgentSessionOpenCodeRoot,
+	}, m.debugf)
 	m.state.services.agentHooks = services.NewAgentHookService(services.AgentHookSpoolDir(), m.debugf)
 	m.state.services.agentSessions.SetHookService(m.state.services.agentHooks)
+	m.state.services.agentTimeline = services.NewAgentTranscriptCache()
 	// The ps/lsof process scan is deprecated and opt-in; hook events installed
 	// via `lazyworktree setup-hooks` are the default liveness source.
 	if cfg.AgentProcessScan {
@@ -618,8 +712,11 @@ func NewModel(cfg *config.AppConfig, initialFilter string) *Model {
 		time.Duration(cfg.AgentRefreshDebounceMs)*time.Millisecond,
 		m.debugf,
 	)
-	agentWatch.SpoolRoots = []string{spoolDir}
+	agentWatch.SpoolRoots = append(m.state.services.agentSessions.JSONWatchRoots(), spoolDir)
 	m.state.services.agentWatch = agentWatch
+	if len(cfg.AgentNotifyChannels) > 0 {
+		m.state.services.agentNotifier = services.NewAgentNotifier(cfg.AgentNotifyEvents, time.Duration(cfg.AgentNotifyRateLimit)*time.Second)
+	}
 	m.state.services.filter = services.NewFilterService(initialFilter)
 
 	gitService.SetCommandRunner(func(ctx context.Context, name string, args ...string) *exec.Cmd {
@@ -752,6 +849,12 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err == nil && !agentSessionsEqual(m.state.data.agentSessionsSnapshot, msg.sessions) {
 			m.state.data.agentSessionsSnapshot = msg.sessions
 			m.refreshSelectedWorktreeAgentSessionsPane()
+			m.refreshStatusAgentEdits()
+			m.noteAgentUsage(msg.sessions)
+			if m.loading.agentUsageSeen {
+				m.updateTable()
+			}
+			return m, m.notifyAgentTransitions(msg.sessions)
 		}
 		return m, nil
 
@@ -804,6 +907,9 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.deleteWorktreeNote(msg.path)
+		if msg.branch == "" {
+			return m, m.refreshWorktrees()
+		}
 
 		// Worktree deleted successfully, show branch deletion prompt
 		confirmScreen := screen.NewConfirmScreenWithDefault(
@@ -875,6 +981,12 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 			err:       nil,
 		})
 
+	case worktreeLockResultMsg:
+		return m.handleWorktreeLockResult(msg)
+
+	case worktreesRepairedMsg:
+		return m.handleWorktreesRepaired(msg)
+
 	case openNoteEditorMsg:
 		return m, m.showWorktreeNoteEditor(msg.worktreePath)
 
@@ -966,7 +1078,7 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return m, nil
 
-	case prDataLoadedMsg, singlePRLoadedMsg, ciStatusLoadedMsg:
+	case prDataLoadedMsg, singlePRLoadedMsg, ciStatusLoadedMsg, ciFailuresLoadedMsg:
 		return m.handlePRMessages(msg)
 
 	case avatarLoadedMsg:
@@ -979,6 +1091,7 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.info != "" {
 			m.infoContent = msg.info
 		}
+		m.state.data.rebaseInProgress = msg.rebasing
 		m.setStatusFiles(msg.statusFiles)
 		m.updateWorktreeStatus(msg.path, msg.statusFiles)
 		if msg.log != nil {
@@ -1023,6 +1136,20 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.showInfo(message, nil)
 		return m, nil
 
+	case agentPaneMsg:
+		return m, m.handleAgentPane(msg)
+
+	case agentTranscriptMsg:
+		m.handleAgentTranscript(msg)
+		return m, nil
+
+	case agentTimelineMsg:
+		m.handleAgentTimeline(msg)
+		return m, nil
+
+	case agentLaunchPromptMsg:
+		return m, m.showAgentPromptEditor(msg.launcher, msg.worktreePath, msg.prompt)
+
 	case zellijPaneCreatedMsg:
 		m.showInfo(fmt.Sprintf("Pane added to session %q (%s).", msg.sessionName, msg.direction), nil)
 		return m, nil
@@ -1064,6 +1191,21 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusContent = "Push completed"
 		return m, m.updateDetailsView()
 
+	case prCreatedMsg:
+		return m.handlePRCreated(msg)
+
+	case prMergedMsg:
+		return m.handlePRMerged(msg)
+
+	case prInboxLoadedMsg:
+		return m.handlePRInboxLoaded(msg)
+
+	case ciLogLoadedMsg:
+		return m.handleCILogLoaded(msg)
+
+	case prReviewThreadsLoadedMsg:
+		return m.handlePRReviewThreadsLoaded(msg)
+
 	case syncResultMsg:
 		m.loading.active = false
 		m.loading.operation = ""
@@ -1109,8 +1251,8 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if cmd := m.refreshAgentSessions(); cmd != nil {
 			cmds = append(cmds, cmd)
 		}
-		// Periodically refresh CI status (GitHub only, requires ci_auto_refresh)
-		if m.config.CIAutoRefresh && m.state.services.git.IsGitHub(m.ctx) && m.shouldRefreshCI() {
+		// Periodically refresh CI status (forges with commit CI, requires ci_auto_refresh)
+		if m.config.CIAutoRefresh && m.state.services.git.SupportsCommitCI(m.ctx) && m.shouldRefreshCI() {
 			if cmd := m.maybeFetchCIStatus(); cmd != nil {
 				cmds = append(cmds, cmd)
 			}
@@ -1131,6 +1273,21 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case cherryPickResultMsg:
 		return m, m.handleCherryPickResult(msg)
 
+	case stashesLoadedMsg:
+		return m.handleStashesLoaded(msg)
+
+	case stashPreviewMsg:
+		return m, m.handleStashPreview(msg)
+
+	case moveChangesResultMsg:
+		return m, m.handleMoveChangesResult(msg)
+
+	case rebaseCommitsLoadedMsg:
+		return m.handleRebaseCommitsLoaded(msg)
+
+	case rebaseResultMsg:
+		return m, m.handleRebaseResult(msg)
+
 	case commitFilesLoadedMsg:
 		if msg.err != nil {
 			m.showInfo(fmt.Sprintf("Failed to load commit files: %v", msg.err), nil)
@@ -1180,9 +1337,23 @@ func (m *Model) updateModel(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ciRerunResultMsg:
-		m.loading.active = false
-		m.loading.operation = ""
-		m.clearLoadingScreen()
+		operation := "rerun"
+		if msg.cancel {
+			operation = "cancel"
+		}
+		if m.loading.operation == operation {
+			m.loading.active = false
+			m.loading.operation = ""
+			m.clearLoadingScreen()
+		}
+		if msg.cancel {
+			if msg.err != nil {
+				m.showInfo(fmt.Sprintf("Failed to cancel CI: %v", msg.err), nil)
+				return m, nil
+			}
+			m.showInfo("CI job cancelled successfully", nil)
+			return m, nil
+		}
 		if msg.err != nil {
 			m.showInfo(fmt.Sprintf("Failed to restart CI: %v", msg.err), nil)
 			return m, nil
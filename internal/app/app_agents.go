@@ -2,6 +2,8 @@ package app
 
 import (
 	"image/color"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -45,7 +47,7 @@ func agentSessionsEqual(a, b []*models.AgentSession) bool {
 			}
 			continue
 		}
-		if *a[i] != *b[i] {
+		if !reflect.DeepEqual(*a[i], *b[i]) {
 			return false
 		}
 	}
@@ -94,6 +96,25 @@ func (m *Model) refreshAgentSessions() tea.Cmd {
 	}
 }
 
+// syncAgentSessionWorktrees hands the loaded worktree paths to the session
+// service, which needs them to find agents that keep their history per
+// project (Aider, Gemini CLI). A refresh follows when the set changed.
+func (m *Model) syncAgentSessionWorktrees() tea.Cmd {
+	service := m.state.services.agentSessions
+	if service == nil || !m.agentSessionsEnabled() {
+		return nil
+	}
+	paths := make([]string, 0, len(m.state.data.worktrees))
+	for _, wt := range m.state.data.worktrees {
+		paths = append(paths, wt.Path)
+	}
+	if slices.Equal(paths, service.WorktreePaths()) {
+		return nil
+	}
+	service.SetWorktreePaths(paths)
+	return m.refreshAgentSessions()
+}
+
 func (m *Model) startAgentWatcher() tea.Cmd {
 	if !m.agentSessionsEnabled() {
 		return nil
@@ -303,19 +324,32 @@ func (m *Model) renderAgentSessionSeparator(width int) string {
 }
 
 func (m *Model) renderAgentSessionMarker(session *models.AgentSession) string {
-	letter := "C"
-	fg := m.theme.Accent
+	var kind models.AgentKind
+	if session != nil {
+		kind = session.Agent
+	}
+	letter, fg := m.agentMarker(kind)
+	return lipgloss.NewStyle().Foreground(fg).Bold(true).Render(letter)
+}
+
+// agentMarker returns the letter and colour identifying an agent.
+func (m *Model) agentMarker(kind models.AgentKind) (string, color.Color) {
 	switch {
-	case session != nil && session.Agent == models.AgentKindPi:
-		letter = "P"
-		fg = m.theme.Cyan
-	case session != nil && session.Agent == models.AgentKindCodex:
-		letter = "X"
-		fg = m.theme.SuccessFg
+	case kind == models.AgentKindPi:
+		return "P", m.theme.Cyan
+	case kind == models.AgentKindCodex:
+		return "X", m.theme.SuccessFg
+	case kind == models.AgentKindGemini:
+		return "G", m.theme.WarnFg
+	case kind == models.AgentKindOpenCode:
+		return "O", m.theme.TextFg
+	case kind == models.AgentKindAider:
+		return "A", m.theme.AccentDim
 	case m.config != nil && strings.EqualFold(strings.TrimSpace(m.config.IconSet), "nerd-font-v3"):
-		letter = "✻"
+		return "✻", m.theme.Accent
+	default:
+		return "C", m.theme.Accent
 	}
-	return lipgloss.NewStyle().Foreground(fg).Bold(true).Render(letter)
 }
 
 func (m *Model) renderAgentSessionRight(session *models.AgentSession) string {
@@ -324,6 +358,11 @@ func (m *Model) renderAgentSessionRight(session *models.AgentSession) string {
 	}
 	styles := m.agentRenderStyles()
 	parts := []string{m.renderAgentSessionActivityBadge(session)}
+	if session.Usage.Total() > 0 {
+		cost, ok := services.EstimateAgentCost(session, m.config.AgentPrices)
+		usage := formatAgentUsage(services.AgentUsageSummary{Usage: session.Usage, Cost: cost, Unpriced: !ok})
+		parts = append(parts, styles.muted.Render(usage))
+	}
 	parts = append(parts, styles.muted.Render(formatRelativeTime(session.LastActivity)))
 	return strings.Join(parts, " ")
 }
@@ -380,6 +419,15 @@ func (m *Model) agentSessionTitle(session *models.AgentSession) string {
 	if session.Agent == models.AgentKindCodex {
 		return "Codex session"
 	}
+	if session.Agent == models.AgentKindGemini {
+		return "Gemini session"
+	}
+	if session.Agent == models.AgentKindOpenCode {
+		return "OpenCode session"
+	}
+	if session.Agent == models.AgentKindAider {
+		return "Aider session"
+	}
 	return "Claude session"
 }
 
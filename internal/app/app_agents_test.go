@@ -255,6 +255,37 @@ func TestRenderAgentSessionMarkerUsesTextGlyphForClaude(t *testing.T) {
 	}
 }
 
+func TestRenderAgentSessionMarkerPerAgent(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+
+	for agent, want := range map[models.AgentKind]string{
+		models.AgentKindGemini:   "G",
+		models.AgentKindOpenCode: "O",
+		models.AgentKindAider:    "A",
+	} {
+		if marker := ansi.Strip(m.renderAgentSessionMarker(&models.AgentSession{Agent: agent})); marker != want {
+			t.Fatalf("expected %q marker for %s, got %q", want, agent, marker)
+		}
+	}
+}
+
+func TestSyncAgentSessionWorktreesRefreshesOnChange(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{{Path: "/repo/main"}, {Path: "/repo/feature"}}
+
+	if cmd := m.syncAgentSessionWorktrees(); cmd == nil {
+		t.Fatal("expected a refresh when the worktree set changes")
+	}
+	if paths := m.state.services.agentSessions.WorktreePaths(); len(paths) != 2 || paths[1] != "/repo/feature" {
+		t.Fatalf("unexpected worktree paths %#v", paths)
+	}
+	if cmd := m.syncAgentSessionWorktrees(); cmd != nil {
+		t.Fatal("expected no refresh when the worktree set is unchanged")
+	}
+}
+
 func TestAgentSessionsEqual(t *testing.T) {
 	now := time.Now()
 	a := &models.AgentSession{ID: "one", CWD: "/tmp/wt", Status: models.AgentSessionStatusWaitingForUser, LastActivity: now}
@@ -101,6 +101,8 @@ func (m *Model) openBranchInBrowser(branch string) tea.Cmd {
 	switch host {
 	case "gitlab":
 		webURL += "/-/tree/" + branch
+	case "gitea":
+		webURL += "/src/branch/" + branch
 	default:
 		webURL += "/tree/" + branch
 	}
@@ -133,8 +133,8 @@ func (m *Model) refreshCurrentWorktreePR() tea.Cmd {
 		return nil
 	}
 
-	// Only for GitHub/GitLab repos
-	if !m.state.services.git.IsGitHubOrGitLab(m.ctx) {
+	// Only for repos hosted on a supported forge
+	if !m.state.services.git.HasForge(m.ctx) {
 		return nil
 	}
 
@@ -13,6 +13,7 @@ import (
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/models"
 	"github.com/chmouel/lazyworktree/internal/worktreecolor"
 )
@@ -179,6 +180,10 @@ func (m *Model) updateTable() {
 
 	// Update table rows
 	showIcons := m.config.IconsEnabled()
+	var agentUsage map[string]services.AgentUsageSummary
+	if m.loading.agentUsageSeen {
+		agentUsage = m.agentUsageByWorktree()
+	}
 	rows := make([]table.Row, 0, len(m.state.data.filteredWts))
 	for idx, wt := range m.state.data.filteredWts {
 		name := filepath.Base(wt.Path)
@@ -217,6 +222,13 @@ func (m *Model) updateTable() {
 			}
 		}
 
+		if ref := wt.HeadRef(); ref != "" {
+			name = name + " @" + ref
+		}
+		if wt.Locked {
+			name = name + " " + lockedIndicator(showIcons)
+		}
+
 		// Append tag pills after truncation and colour styling
 		// so truncation never corrupts ANSI sequences
 		if hasNote && len(note.Tags) > 0 {
@@ -234,6 +246,14 @@ func (m *Model) updateTable() {
 			wt.LastActive,
 		}
 
+		if m.loading.agentUsageSeen {
+			costStr := formatAgentUsage(agentUsage[filepath.Clean(wt.Path)])
+			if costStr == "" {
+				costStr = "-"
+			}
+			row = append(row, costStr)
+		}
+
 		// Only include PR column if PR data has been loaded and PR is not disabled
 		if m.loading.prDataLoaded && !m.config.DisablePR {
 			prStr := "-"
@@ -404,6 +424,7 @@ func (m *Model) updateDetailsView() tea.Cmd {
 			statusFiles: parseStatusFiles(statusRaw),
 			log:         logEntries,
 			path:        wt.Path,
+			rebasing:    m.state.services.git.RebaseInProgress(wt.Path),
 		}
 	}
 }
@@ -154,6 +154,10 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 		BrowseTags:        m.showBrowseWorktreeTags,
 		Absorb:            m.showAbsorbWorktree,
 		Prune:             m.showPruneMerged,
+		ToggleLock:        m.showToggleWorktreeLock,
+		Repair:            m.repairMovedWorktrees,
+		StartAgent:        m.showStartAgent,
+		AgentTimeline:     m.showAgentTimeline,
 		CreateFromCurrent: m.showCreateFromCurrent,
 		CreateFromBranch: func() tea.Cmd {
 			defaultBase := m.state.services.git.GetMainBranch(m.ctx)
@@ -190,9 +194,15 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 			return m.openCICheckSelection()
 		},
 		CIChecksAvailable: func() bool {
-			return m.state.services.git != nil && m.state.services.git.IsGitHub(m.ctx)
+			return m.state.services.git != nil && m.state.services.git.HasForge(m.ctx)
 		},
 		OpenPR:      m.openPR,
+		CreatePR:    m.showCreatePR,
+		MergePR:     m.showMergePR,
+		PRReview:    m.showPRReviewThreads,
+		PRInbox:     m.showPRInbox,
+		Stashes:     m.showStashes,
+		MoveChanges: m.showMoveChanges,
 		OpenLazyGit: m.openLazyGit,
 		RunCommand:  m.showRunCommand,
 	})
@@ -223,8 +233,14 @@ func (m *Model) registerPaletteActions(registry *commands.Registry) {
 	})
 
 	commands.RegisterLogPaneActions(registry, commands.LogHandlers{
-		CherryPick: m.showCherryPick,
-		CommitView: m.openCommitView,
+		CherryPick:        m.showCherryPick,
+		CommitView:        m.openCommitView,
+		InteractiveRebase: m.showInteractiveRebase,
+		ContinueRebase:    m.continueRebase,
+		AbortRebase:       m.showAbortRebase,
+		RebaseInProgress: func() bool {
+			return m.state.data.rebaseInProgress
+		},
 	})
 
 	commands.RegisterNavigationActions(registry, commands.NavigationHandlers{
@@ -432,9 +432,9 @@ func TestShowCommandPaletteHasAllActions(t *testing.T) {
 		"worktree-create", "worktree-delete", "worktree-rename", "worktree-annotate", "worktree-browse-tags", "worktree-absorb", "worktree-prune",
 		"worktree-create-from-current", "worktree-create-from-branch", "worktree-create-from-commit",
 		"worktree-create-from-pr", "worktree-create-from-issue", "worktree-create-freeform",
-		"git-diff", "git-refresh", "git-fetch", "git-push", "git-sync", "git-fetch-pr-data", "git-pr", "git-lazygit", "git-run-command",
+		"git-diff", "git-refresh", "git-fetch", "git-push", "git-sync", "git-fetch-pr-data", "git-pr", "git-create-pr", "git-merge-pr", "git-pr-review", "git-pr-inbox", "git-stashes", "git-move-changes", "git-lazygit", "git-run-command",
 		"status-stage-file", "status-commit-staged", "status-commit-all", "status-edit-file", "status-delete-file",
-		"log-cherry-pick", "log-commit-view",
+		"log-cherry-pick", "log-commit-view", "log-rebase-interactive",
 		"nav-zoom-toggle", "nav-filter", "nav-search", "nav-focus-worktrees", "nav-focus-status", "nav-focus-log", "nav-sort-cycle",
 		"settings-theme", "settings-taskboard", "settings-help",
 	}
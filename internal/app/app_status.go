@@ -58,7 +58,7 @@ func parseStatusFiles(statusRaw string) []StatusFile {
 		}
 
 		var status, filename string
-		var isUntracked bool
+		var isUntracked, isConflicted bool
 
 		switch fields[0] {
 		case "1": // Ordinary changed entry: 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
@@ -77,14 +77,22 @@ func parseStatusFiles(statusRaw string) []StatusFile {
 			}
 			status = fields[1]
 			filename = fields[9]
+		case "u": // Unmerged: u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			if len(fields) < 11 {
+				continue
+			}
+			status = fields[1]
+			filename = fields[10]
+			isConflicted = true
 		default:
 			continue // Skip unhandled entry types
 		}
 
 		parsedFiles = append(parsedFiles, StatusFile{
-			Filename:    filename,
-			Status:      status,
-			IsUntracked: isUntracked,
+			Filename:     filename,
+			Status:       status,
+			IsUntracked:  isUntracked,
+			IsConflicted: isConflicted,
 		})
 	}
 
@@ -97,6 +105,10 @@ func statusCounts(files []StatusFile) (staged, modified, untracked int) {
 			untracked++
 			continue
 		}
+		if file.IsConflicted {
+			modified++
+			continue
+		}
 		if file.Status != "" {
 			first := file.Status[0]
 			if first != '.' && first != ' ' {
@@ -136,14 +148,20 @@ func (m *Model) setStatusFiles(files []StatusFile) {
 
 func (m *Model) applyStatusFilter() {
 	query := strings.ToLower(strings.TrimSpace(m.state.services.filter.StatusFilterQuery))
-	filtered := m.state.data.statusFilesAll
-	if query != "" {
-		filtered = make([]StatusFile, 0, len(m.state.data.statusFilesAll))
-		for _, sf := range m.state.data.statusFilesAll {
+	agentOnly := m.state.services.filter.StatusAgentOnly
+	m.state.data.statusAgentEdits = m.agentEditsForSelectedWorktree()
+	filtered := services.MarkAgentEdits(m.state.data.statusFilesAll, m.state.data.statusAgentEdits)
+	if query != "" || agentOnly {
+		matched := make([]StatusFile, 0, len(filtered))
+		for _, sf := range filtered {
+			if agentOnly && sf.Agent == "" {
+				continue
+			}
 			if strings.Contains(strings.ToLower(sf.Filename), query) {
-				filtered = append(filtered, sf)
+				matched = append(matched, sf)
 			}
 		}
+		filtered = matched
 	}
 
 	// Remember current selection (by path)
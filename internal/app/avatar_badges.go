@@ -173,14 +173,7 @@ func kittyRegisterAvatar(image *services.AvatarImage) string {
 			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, data[start:end])
 		}
 	}
-	return wrapKittyGraphicsSequence(b.String())
-}
-
-func wrapKittyGraphicsSequence(seq string) string {
-	if seq == "" || strings.TrimSpace(os.Getenv("TMUX")) == "" {
-		return seq
-	}
-	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	return wrapTmuxPassthrough(b.String())
 }
 
 func kittyAvatarPlaceholder(imageID uint32) string {
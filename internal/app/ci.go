@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,10 +13,18 @@ import (
 	tea "charm.land/bubbletea/v2"
 	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
 	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/git"
 	"github.com/chmouel/lazyworktree/internal/models"
 	"github.com/chmouel/lazyworktree/internal/utils"
 )
 
+const (
+	// ciLogPollInterval is how often the log of a running CI job is refreshed.
+	ciLogPollInterval = 3 * time.Second
+	// ciLogMaxPollInterval caps the back-off applied while the log is idle.
+	ciLogMaxPollInterval = 30 * time.Second
+)
+
 // ciDataSvc is the package-level CI data service instance.
 var ciDataSvc = services.NewCIDataService()
 
@@ -62,7 +71,7 @@ func (m *Model) openCICheckSelection() tea.Cmd {
 		"",
 		m.theme,
 	)
-	ciScreen.FooterHint = "Enter open • Ctrl+v view logs • Ctrl+r restart"
+	ciScreen.FooterHint = "Enter open • Ctrl+v view logs • Ctrl+r restart • Ctrl+x cancel"
 
 	ciScreen.OnEnter = func(item appscreen.SelectionItem) tea.Cmd {
 		var idx int
@@ -88,6 +97,14 @@ func (m *Model) openCICheckSelection() tea.Cmd {
 		return m.rerunCICheck(checks[idx])
 	}
 
+	ciScreen.OnCtrlX = func(item appscreen.SelectionItem) tea.Cmd {
+		var idx int
+		if _, err := fmt.Sscanf(item.ID, "%d", &idx); err != nil || idx < 0 || idx >= len(checks) {
+			return nil
+		}
+		return m.cancelCICheck(checks[idx])
+	}
+
 	ciScreen.OnCancel = func() tea.Cmd {
 		return nil
 	}
@@ -96,18 +113,19 @@ func (m *Model) openCICheckSelection() tea.Cmd {
 	return textinput.Blink
 }
 
-// showCICheckLog opens the CI check log in a pager using gh run view.
-// For external CI systems (non-GitHub Actions), it opens the check link in the browser.
+// showCICheckLog opens the CI check log in the built-in viewer, or in the
+// ci_script_pager for finished jobs when one is configured. For checks without
+// a log command (external CI systems), it opens the check link in the browser.
 func (m *Model) showCICheckLog(check *models.CICheck) tea.Cmd {
 	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
 		return nil
 	}
 	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
 
-	// Extract run ID from the check link
-	runID := extractRunIDFromLink(check.Link)
-	if runID == "" {
-		// Not a GitHub Actions URL - open in browser instead
+	// Use --log-failed style output for failed checks
+	logArgs := m.state.services.git.CICheckLogCommand(m.ctx, check, check.Conclusion == iconFailure)
+	if len(logArgs) == 0 {
+		// No CLI log access for this check - open in browser instead
 		if check.Link == "" {
 			m.showInfo("No link available for this check.", nil)
 			return nil
@@ -115,24 +133,39 @@ func (m *Model) showCICheckLog(check *models.CICheck) tea.Cmd {
 		return m.openURLInBrowser(check.Link)
 	}
 
+	// Logs open in the built-in viewer, which folds steps and jumps to
+	// errors, and follows jobs still running. A configured ci_script_pager
+	// takes over for finished jobs.
+	running := check.Conclusion == "pending" || check.Conclusion == ""
+	if running || m.config == nil || strings.TrimSpace(m.config.CIScriptPager) == "" {
+		return m.openCILogViewer(wt, check)
+	}
+	return m.pageCICheckLog(wt, check, logArgs)
+}
+
+// pageCICheckLog pipes the output of the forge log command into the pager.
+func (m *Model) pageCICheckLog(wt *models.WorktreeInfo, check *models.CICheck, logArgs []string) tea.Cmd {
 	// Build environment variables
 	env := m.buildCommandEnvForWorktree(wt)
 
 	// Add CI-specific environment variables
 	env["LW_CI_JOB_NAME"] = check.Name
 	env["LW_CI_JOB_NAME_CLEAN"] = utils.SanitizeBranchName(check.Name, 0)
-	env["LW_CI_RUN_ID"] = runID
+	if runID := extractRunIDFromLink(check.Link); runID != "" {
+		env["LW_CI_RUN_ID"] = runID
+	}
 	if !check.StartedAt.IsZero() {
 		env["LW_CI_STARTED_AT"] = check.StartedAt.Format(time.RFC3339)
 	}
 
 	envVars := services.AppendCommandEnv(os.Environ(), env)
 
-	// Use --log-failed for failed checks, --log for others
-	logFlag := "--log"
-	if check.Conclusion == iconFailure {
-		logFlag = "--log-failed"
+	// Quote every argument: job links and project paths come from the forge
+	quotedArgs := make([]string, len(logArgs))
+	for i, arg := range logArgs {
+		quotedArgs[i] = shellQuote(arg)
 	}
+	logCmd := strings.Join(quotedArgs, " ")
 
 	// Get CI-specific pager configuration
 	pager, isInteractive := m.ciScriptPagerCommand()
@@ -140,7 +173,7 @@ func (m *Model) showCICheckLog(check *models.CICheck) tea.Cmd {
 	var cmdStr string
 	if isInteractive {
 		// Interactive pager - direct terminal control
-		cmdStr = fmt.Sprintf("gh run view %s %s 2>&1 | %s", runID, logFlag, pager)
+		cmdStr = fmt.Sprintf("%s 2>&1 | %s", logCmd, pager)
 	} else {
 		// Non-interactive pager - use pager environment settings
 		pagerEnv := m.pagerEnv(pager)
@@ -148,7 +181,7 @@ func (m *Model) showCICheckLog(check *models.CICheck) tea.Cmd {
 		if pagerEnv != "" {
 			pagerCmd = fmt.Sprintf("%s %s", pagerEnv, pager)
 		}
-		cmdStr = fmt.Sprintf("set -o pipefail; gh run view %s %s 2>&1 | %s", runID, logFlag, pagerCmd)
+		cmdStr = fmt.Sprintf("set -o pipefail; %s 2>&1 | %s", logCmd, pagerCmd)
 	}
 
 	// Create command
@@ -169,6 +202,101 @@ func (m *Model) showCICheckLog(check *models.CICheck) tea.Cmd {
 	})
 }
 
+// openCILogViewer loads the log of a CI job into the built-in viewer, which
+// keeps polling it while the job runs.
+func (m *Model) openCILogViewer(wt *models.WorktreeInfo, check *models.CICheck) tea.Cmd {
+	m.loading.active = true
+	m.loading.operation = "ci-log"
+	m.setLoadingScreen("Loading CI log...")
+	return m.fetchCILogCmd(wt.Path, check, nil, 0)
+}
+
+// fetchCILogCmd fetches the log of a CI job after delay. When refreshing a
+// viewer only the output past what it already shows is asked for.
+func (m *Model) fetchCILogCmd(worktreePath string, check *models.CICheck, scr *appscreen.CILogScreen, delay time.Duration) tea.Cmd {
+	offset := 0
+	if scr != nil {
+		offset = scr.Size()
+	}
+	fetch := func() tea.Msg {
+		ciLog, err := m.state.services.git.FetchCICheckLog(m.ctx, check, worktreePath, offset)
+		return ciLogLoadedMsg{worktreePath: worktreePath, check: check, log: ciLog, err: err, viewer: scr, interval: delay}
+	}
+	if delay == 0 {
+		return fetch
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return fetch() })
+}
+
+// handleCILogLoaded opens or refreshes the CI log viewer and schedules the
+// next poll while the job runs. Polling continues while the viewer is
+// anywhere in the screen stack, backing off while the log does not change,
+// and stops once the viewer is closed.
+func (m *Model) handleCILogLoaded(msg ciLogLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.loading.operation == "ci-log" {
+		m.loading.active = false
+		m.loading.operation = ""
+		m.clearLoadingScreen()
+	}
+
+	scr := msg.viewer
+	if scr != nil && !m.state.ui.screenManager.Contains(scr) {
+		return m, nil
+	}
+	if msg.err != nil {
+		if scr != nil {
+			scr.StatusMessage = "Failed to refresh log: " + msg.err.Error()
+			return m, m.fetchCILogCmd(msg.worktreePath, msg.check, scr, nextCILogPollInterval(msg.interval, false))
+		}
+		if errors.Is(msg.err, git.ErrCILogUnsupported) {
+			// The forge prints logs it cannot fetch, e.g. of a whole run.
+			wt := m.worktreeByPath(msg.worktreePath)
+			if logArgs := m.state.services.git.CICheckLogCommand(m.ctx, msg.check, msg.check.Conclusion == iconFailure); wt != nil && len(logArgs) > 0 {
+				return m, m.pageCICheckLog(wt, msg.check, logArgs)
+			}
+			return m, m.openURLInBrowser(msg.check.Link)
+		}
+		if errors.Is(msg.err, git.ErrCILiveLogUnsupported) {
+			m.showInfo(fmt.Sprintf("Live tail is not available for %q: its log is published once the job finishes.\n\n%s", msg.check.Name, msg.check.Link), nil)
+			return m, nil
+		}
+		m.showInfo(fmt.Sprintf("Failed to load the CI log.\n\n%s", truncateToHeightFromEnd(msg.err.Error(), 5)), nil)
+		return m, nil
+	}
+
+	if scr == nil {
+		scr = appscreen.NewCILogScreen(
+			msg.check.Name,
+			msg.check.Link,
+			m.state.view.WindowWidth,
+			m.state.view.WindowHeight,
+			m.theme,
+			m.config.IconsEnabled(),
+		)
+		scr.Follow = msg.log.Running
+		scr.OnOpenURL = func() tea.Cmd {
+			return m.openURLInBrowser(msg.check.Link)
+		}
+		m.state.ui.screenManager.Push(scr)
+	}
+	changed := scr.AppendContent(msg.log.Offset, msg.log.Content, msg.log.Running)
+
+	if !msg.log.Running {
+		return m, nil
+	}
+	return m, m.fetchCILogCmd(msg.worktreePath, msg.check, scr, nextCILogPollInterval(msg.interval, changed))
+}
+
+// nextCILogPollInterval returns the delay before the next poll of a running
+// job log: the base interval when the log moved, doubled up to a cap while it
+// stays idle.
+func nextCILogPollInterval(previous time.Duration, changed bool) time.Duration {
+	if changed || previous < ciLogPollInterval {
+		return ciLogPollInterval
+	}
+	return min(previous*2, ciLogMaxPollInterval)
+}
+
 // extractRunIDFromLink extracts the run ID from a GitHub Actions URL.
 // Example URL: https://github.com/owner/repo/actions/runs/12345678/job/98765432
 func extractRunIDFromLink(link string) string {
@@ -194,20 +322,6 @@ func (m *Model) rerunCICheck(check *models.CICheck) tea.Cmd {
 	}
 	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
 
-	// Extract run ID and job ID from the check link
-	runID := extractRunIDFromLink(check.Link)
-	if runID == "" {
-		m.showInfo("Cannot restart: not a GitHub Actions job.", nil)
-		return nil
-	}
-
-	jobID := extractJobIDFromLink(check.Link)
-	repo := extractRepoFromLink(check.Link)
-	if repo == "" {
-		m.showInfo("Cannot restart: unable to determine repository from link.", nil)
-		return nil
-	}
-
 	// Show loading screen
 	m.loading.active = true
 	m.loading.operation = "rerun"
@@ -217,21 +331,34 @@ func (m *Model) rerunCICheck(check *models.CICheck) tea.Cmd {
 		ctx, cancel := context.WithTimeout(m.ctx, 30*time.Second)
 		defer cancel()
 
-		// Build the gh run rerun command
-		args := []string{"run", "rerun", runID}
-		if jobID != "" {
-			args = append(args, "--job", jobID)
+		runURL, err := m.state.services.git.RerunCICheck(ctx, check, wt.Path)
+		if err != nil {
+			return ciRerunResultMsg{err: err}
 		}
-		args = append(args, "-R", repo)
+		return ciRerunResultMsg{runURL: runURL}
+	}
+}
+
+// cancelCICheck cancels a running CI job and returns the run URL.
+func (m *Model) cancelCICheck(check *models.CICheck) tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
 
-		// gh run rerun produces no stdout on success, so we can't check output.
-		// RunGit with silent=false sends a notification on failure.
-		m.state.services.git.RunGit(ctx, append([]string{"gh"}, args...), wt.Path, []int{0}, true, false)
+	m.loading.active = true
+	m.loading.operation = "cancel"
+	m.setLoadingScreen("Cancelling CI job...")
 
-		// Construct the run URL
-		runURL := fmt.Sprintf("https://github.com/%s/actions/runs/%s", repo, runID)
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 30*time.Second)
+		defer cancel()
 
-		return ciRerunResultMsg{runURL: runURL}
+		runURL, err := m.state.services.git.CancelCICheck(ctx, check, wt.Path)
+		if err != nil {
+			return ciRerunResultMsg{cancel: true, err: err}
+		}
+		return ciRerunResultMsg{cancel: true, runURL: runURL}
 	}
 }
 
@@ -277,6 +404,31 @@ func (m *Model) fetchCIStatusByCommit(worktreePath, branch string) tea.Cmd {
 	}
 }
 
+// fetchCIFailures summarises the failed checks of a branch that have not
+// been summarised yet. Finished jobs never change, so summaries are cached by
+// check link for the whole session.
+func (m *Model) fetchCIFailures(branch string, checks []*models.CICheck) tea.Cmd {
+	wt := m.getWorktreeForBranch(branch)
+	if wt == nil {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, check := range checks {
+		if check.Conclusion != iconFailure || check.Link == "" {
+			continue
+		}
+		if _, ok := m.cache.ciFailures[check.Link]; ok {
+			continue
+		}
+		m.cache.ciFailures[check.Link] = nil
+		cmds = append(cmds, func() tea.Msg {
+			failures, err := m.state.services.git.FetchCIFailures(m.ctx, check, wt.Path)
+			return ciFailuresLoadedMsg{branch: branch, link: check.Link, failures: failures, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
 // maybeFetchCIStatus triggers CI fetch for current worktree if it has a PR or commit and cache is stale.
 func (m *Model) maybeFetchCIStatus() tea.Cmd {
 	if m.config.DisablePR {
@@ -298,8 +450,8 @@ func (m *Model) maybeFetchCIStatus() tea.Cmd {
 		return m.fetchCIStatus(wt.PR.Number, wt.Branch)
 	}
 
-	// For non-PR branches on GitHub, use commit-based CI fetch
-	if m.state.services.git.IsGitHub(m.ctx) {
+	// For non-PR branches, use commit-based CI fetch where the forge supports it
+	if m.state.services.git.SupportsCommitCI(m.ctx) {
 		return m.fetchCIStatusByCommit(wt.Path, wt.Branch)
 	}
 
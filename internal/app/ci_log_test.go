@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowCICheckLogOpensViewerForRunningJob(t *testing.T) {
+	withRemoteRepo(t, "https://github.com/o/r.git")
+	m, _ := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Conclusion: "pending", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	cmd := m.showCICheckLog(check)
+	require.NotNil(t, cmd)
+	assert.Equal(t, "ci-log", m.loading.operation)
+	assert.Equal(t, appscreen.TypeLoading, m.state.ui.screenManager.Type())
+}
+
+func TestShowCICheckLogOpensViewerForFinishedJob(t *testing.T) {
+	withRemoteRepo(t, "https://github.com/o/r.git")
+	m, _ := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Conclusion: "failure", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	cmd := m.showCICheckLog(check)
+	require.NotNil(t, cmd)
+	assert.Equal(t, "ci-log", m.loading.operation)
+	assert.Equal(t, appscreen.TypeLoading, m.state.ui.screenManager.Type())
+}
+
+func TestHandleCILogLoadedPagesLogsTheViewerCannotFetch(t *testing.T) {
+	withRemoteRepo(t, "https://github.com/o/r.git")
+	m, wt := newMergePRTestModel(t, nil)
+	var captured *exec.Cmd
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		captured = exec.CommandContext(ctx, name, args...) //#nosec G204,G702 -- test mock with controlled args
+		return captured
+	}
+	m.startCommand = func(*exec.Cmd) error { return nil }
+	// A run link has no job, so its log is only printed with gh run view.
+	check := &models.CICheck{Name: "build", Conclusion: "success", Link: "https://github.com/o/r/actions/runs/1"}
+
+	_, cmd := m.handleCILogLoaded(ciLogLoadedMsg{worktreePath: wt.Path, check: check, err: git.ErrCILogUnsupported})
+	require.NotNil(t, cmd)
+	require.NotNil(t, captured)
+	assert.Contains(t, captured.Args[len(captured.Args)-1], "'gh' 'run' 'view' '1'")
+}
+
+func TestHandleCILogLoadedPollsRunningJob(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	_, cmd := m.handleCILogLoaded(ciLogLoadedMsg{
+		worktreePath: wt.Path,
+		check:        check,
+		log:          &git.CILog{Content: "##[group]Run make\nstep 1\n", Running: true},
+	})
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.CILogScreen)
+	require.True(t, ok)
+	assert.Equal(t, "build", scr.Title)
+	assert.True(t, scr.Follow)
+	assert.NotNil(t, cmd, "expected the next poll to be scheduled")
+
+	_, cmd = m.handleCILogLoaded(ciLogLoadedMsg{
+		worktreePath: wt.Path,
+		check:        check,
+		log:          &git.CILog{Content: "##[group]Run make\nstep 1\nstep 2\n"},
+		viewer:       scr,
+		interval:     ciLogPollInterval,
+	})
+	assert.Same(t, scr, m.state.ui.screenManager.Current())
+	assert.False(t, scr.Running)
+	assert.Nil(t, cmd, "expected polling to stop once the job finished")
+
+	m.state.ui.screenManager.Pop()
+	_, cmd = m.handleCILogLoaded(ciLogLoadedMsg{
+		worktreePath: wt.Path,
+		check:        check,
+		log:          &git.CILog{Running: true},
+		viewer:       scr,
+		interval:     ciLogPollInterval,
+	})
+	assert.Nil(t, cmd, "expected polling to stop once the viewer is closed")
+	assert.False(t, m.state.ui.screenManager.IsActive())
+}
+
+func TestHandleCILogLoadedKeepsPollingBelowOtherScreens(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	m.handleCILogLoaded(ciLogLoadedMsg{
+		worktreePath: wt.Path,
+		check:        check,
+		log:          &git.CILog{Content: "step 1\n", Running: true},
+	})
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.CILogScreen)
+	require.True(t, ok)
+	m.showInfo("help", nil)
+
+	_, cmd := m.handleCILogLoaded(ciLogLoadedMsg{
+		worktreePath: wt.Path,
+		check:        check,
+		log:          &git.CILog{Content: "step 1\nstep 2\n", Running: true},
+		viewer:       scr,
+		interval:     ciLogPollInterval,
+	})
+	assert.NotNil(t, cmd, "expected polling to continue under another screen")
+	assert.Equal(t, appscreen.TypeInfo, m.state.ui.screenManager.Type())
+	assert.Contains(t, ansi.Strip(scr.View()), "step 2")
+}
+
+func TestNextCILogPollInterval(t *testing.T) {
+	assert.Equal(t, ciLogPollInterval, nextCILogPollInterval(0, false))
+	assert.Equal(t, 2*ciLogPollInterval, nextCILogPollInterval(ciLogPollInterval, false))
+	assert.Equal(t, ciLogMaxPollInterval, nextCILogPollInterval(ciLogMaxPollInterval, false))
+	assert.Equal(t, ciLogPollInterval, nextCILogPollInterval(ciLogMaxPollInterval, true))
+}
+
+func TestHandleCILogLoadedLiveTailUnavailable(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	_, cmd := m.handleCILogLoaded(ciLogLoadedMsg{worktreePath: wt.Path, check: check, err: git.ErrCILiveLogUnsupported})
+	assert.Nil(t, cmd, "expected no polling")
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, infoScreen.Message, "Live tail is not available")
+}
+
+func TestHandleCILogLoadedError(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	check := &models.CICheck{Name: "build", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+
+	m.handleCILogLoaded(ciLogLoadedMsg{worktreePath: wt.Path, check: check, err: errors.New("rate limited")})
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, infoScreen.Message, "rate limited")
+}
+
+func TestHandleCIStatusLoadedFetchesFailures(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+	failed := &models.CICheck{Name: "test", Conclusion: "failure", Link: "https://github.com/o/r/actions/runs/1/job/2"}
+	checks := []*models.CICheck{{Name: "lint", Conclusion: "success", Link: "https://github.com/o/r/actions/runs/1/job/3"}, failed}
+
+	_, cmd := m.handleCIStatusLoaded(ciStatusLoadedMsg{branch: "feature", checks: checks})
+	require.NotNil(t, cmd, "expected the failed check to be summarised")
+	_, seen := m.cache.ciFailures[failed.Link]
+	assert.True(t, seen, "expected the fetch to be marked in flight")
+
+	_, cmd = m.handleCIStatusLoaded(ciStatusLoadedMsg{branch: "feature", checks: checks})
+	assert.Nil(t, cmd, "expected no second fetch for the same check")
+
+	m.handleCIFailuresLoaded(ciFailuresLoadedMsg{
+		branch: "feature",
+		link:   failed.Link,
+		failures: []models.CIFailure{
+			{Check: "test", Test: "TestParse", File: "parse_test.go", Line: 42, Message: "expected 1, got 2"},
+		},
+	})
+	assert.Contains(t, ansi.Strip(m.infoContent), "↳ parse_test.go:42 TestParse expected 1, got 2")
+}
+
+func TestHandleCIFailuresLoadedErrorRetries(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+	link := "https://github.com/o/r/actions/runs/1/job/2"
+	m.cache.ciFailures[link] = nil
+
+	m.handleCIFailuresLoaded(ciFailuresLoadedMsg{branch: "feature", link: link, err: errors.New("rate limited")})
+	_, seen := m.cache.ciFailures[link]
+	assert.False(t, seen, "expected a failed fetch to be retried on the next refresh")
+
+	m.cache.ciFailures[link] = nil
+	m.handleCIFailuresLoaded(ciFailuresLoadedMsg{branch: "feature", link: link, err: git.ErrCILogUnsupported})
+	_, seen = m.cache.ciFailures[link]
+	assert.True(t, seen, "expected unsupported checks not to be retried")
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
 	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
 	"github.com/chmouel/lazyworktree/internal/models"
 	"github.com/chmouel/lazyworktree/internal/theme"
 )
@@ -959,6 +961,51 @@ func TestOpenCICheckSelectionWithChecks(t *testing.T) {
 	}
 }
 
+func TestCICheckSelectionCtrlXCancelsCheck(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+	m.state.data.filteredWts = []*models.WorktreeInfo{
+		{Path: testWorktreePath, Branch: "feat"},
+	}
+	m.state.data.selectedIndex = 0
+	m.setWindowSize(120, 40)
+	m.cache.ciCache.Set("feat", []*models.CICheck{
+		{Name: "test", Conclusion: "pending", Link: "https://tekton.dev/runs/456"},
+	})
+
+	m.openCICheckSelection()
+	listScreen := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	if !strings.Contains(listScreen.FooterHint, "Ctrl+x cancel") {
+		t.Fatalf("expected footer to mention cancel, got %q", listScreen.FooterHint)
+	}
+
+	_, cmd := listScreen.Update(tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl})
+	if cmd == nil {
+		t.Fatal("expected ctrl+x to return a cancel command")
+	}
+	if m.loading.operation != "cancel" {
+		t.Fatalf("expected cancel loading operation, got %q", m.loading.operation)
+	}
+	msg, ok := cmd().(ciRerunResultMsg)
+	if !ok || !msg.cancel {
+		t.Fatalf("expected cancel result message, got %#v", msg)
+	}
+	if !errors.Is(msg.err, git.ErrCICancelUnsupported) {
+		t.Fatalf("expected unsupported error for external check, got %v", msg.err)
+	}
+
+	m.Update(msg)
+	infoScr, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !ok || !strings.Contains(infoScr.Message, "Failed to cancel CI") {
+		t.Fatalf("expected cancel failure info screen, got %#v", m.state.ui.screenManager.Current())
+	}
+	if m.loading.active || m.loading.operation != "" {
+		t.Fatalf("expected cancel loading state to be cleared, got %+v", m.loading)
+	}
+}
+
 func TestCICheckSelectionColouredIcons(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
@@ -125,6 +125,10 @@ type WorktreeHandlers struct {
 	BrowseTags        func() tea.Cmd
 	Absorb            func() tea.Cmd
 	Prune             func() tea.Cmd
+	ToggleLock        func() tea.Cmd
+	Repair            func() tea.Cmd
+	StartAgent        func() tea.Cmd
+	AgentTimeline     func() tea.Cmd
 	CreateFromCurrent func() tea.Cmd
 	CreateFromBranch  func() tea.Cmd
 	CreateFromCommit  func() tea.Cmd
@@ -185,6 +189,10 @@ func RegisterWorktreeActions(r *Registry, h WorktreeHandlers) {
 		wtAction("worktree-browse-tags", "Browse by worktree tags", "Browse worktrees by existing tags and apply an exact tag filter", "", h.BrowseTags),
 		wtAction("worktree-absorb", "Absorb worktree", "Merge branch into main and remove worktree", "A", h.Absorb),
 		wtAction("worktree-prune", "Prune merged", "Remove merged PR worktrees", "X", h.Prune),
+		wtAction("worktree-lock", "Lock/unlock worktree", "Lock the selected worktree with an optional reason, or unlock it", "K", h.ToggleLock),
+		wtAction("worktree-repair", "Repair moved worktrees", "Reconnect worktrees whose directories were moved and migrate their notes", "", h.Repair),
+		wtAction("worktree-start-agent", "Start agent", "Launch a coding agent in the selected worktree with an initial prompt", "a", h.StartAgent),
+		wtAction("worktree-agent-timeline", "Agent timeline", "Recent agent activity across every worktree, filterable by agent, worktree and type", "t", h.AgentTimeline),
 	)
 
 	r.Register(
@@ -208,6 +216,12 @@ type GitHandlers struct {
 	ViewCIChecks      func() tea.Cmd
 	CIChecksAvailable func() bool
 	OpenPR            func() tea.Cmd
+	CreatePR          func() tea.Cmd
+	MergePR           func() tea.Cmd
+	PRReview          func() tea.Cmd
+	PRInbox           func() tea.Cmd
+	Stashes           func() tea.Cmd
+	MoveChanges       func() tea.Cmd
 	OpenLazyGit       func() tea.Cmd
 	RunCommand        func() tea.Cmd
 }
@@ -223,6 +237,12 @@ func RegisterGitOperations(r *Registry, h GitHandlers) {
 		CommandAction{ID: "git-fetch-pr-data", Label: "Fetch PR data", Description: "Fetch PR/MR status from GitHub/GitLab", Section: sectionGitOperations, Shortcut: "p", Icon: IconGit, Handler: h.FetchPRData},
 		CommandAction{ID: "git-ci-checks", Label: "View CI checks", Description: "View CI check logs for current worktree", Section: sectionGitOperations, Shortcut: "v", Icon: IconGit, Handler: h.ViewCIChecks, Available: h.CIChecksAvailable},
 		CommandAction{ID: "git-pr", Label: "Open in browser", Description: "Open PR, branch, or repo in browser", Section: sectionGitOperations, Shortcut: "o", Icon: IconGit, Handler: h.OpenPR},
+		CommandAction{ID: "git-create-pr", Label: "Create PR/MR", Description: "Push the branch and open a PR/MR for it", Section: sectionGitOperations, Icon: IconGit, Handler: h.CreatePR},
+		CommandAction{ID: "git-merge-pr", Label: "Merge PR/MR", Description: "Squash, rebase, or merge the PR/MR, optionally once checks pass", Section: sectionGitOperations, Icon: IconGit, Handler: h.MergePR},
+		CommandAction{ID: "git-pr-review", Label: "PR/MR review threads", Description: "Browse, reply to, and resolve review comments", Section: sectionGitOperations, Icon: IconGit, Handler: h.PRReview},
+		CommandAction{ID: "git-pr-inbox", Label: "PR/MR inbox", Description: "PRs/MRs awaiting your review, assigned to you, or needing your attention", Section: sectionGitOperations, Icon: IconGit, Handler: h.PRInbox},
+		CommandAction{ID: "git-stashes", Label: "Stash browser", Description: "Browse every stash and apply, pop, or drop it in the selected worktree", Section: sectionGitOperations, Shortcut: "Z", Icon: IconGit, Handler: h.Stashes},
+		CommandAction{ID: "git-move-changes", Label: "Move changes to another worktree", Description: "Stash uncommitted changes and apply them to another worktree", Section: sectionGitOperations, Icon: IconGit, Handler: h.MoveChanges},
 		CommandAction{ID: "git-lazygit", Label: "Open LazyGit", Description: "Open LazyGit in selected worktree", Section: sectionGitOperations, Shortcut: "g", Icon: IconGit, Handler: h.OpenLazyGit},
 		CommandAction{ID: "git-run-command", Label: "Run command", Description: "Run arbitrary shell command in worktree", Section: sectionGitOperations, Shortcut: "!", Icon: IconGit, Handler: h.RunCommand},
 	)
@@ -250,8 +270,12 @@ func RegisterStatusPaneActions(r *Registry, h StatusHandlers) {
 
 // LogHandlers holds callbacks for log pane actions.
 type LogHandlers struct {
-	CherryPick func() tea.Cmd
-	CommitView func() tea.Cmd
+	CherryPick        func() tea.Cmd
+	CommitView        func() tea.Cmd
+	InteractiveRebase func() tea.Cmd
+	ContinueRebase    func() tea.Cmd
+	AbortRebase       func() tea.Cmd
+	RebaseInProgress  func() bool
 }
 
 // RegisterLogPaneActions registers log pane actions.
@@ -259,6 +283,9 @@ func RegisterLogPaneActions(r *Registry, h LogHandlers) {
 	r.Register(
 		CommandAction{ID: "log-cherry-pick", Label: "Cherry-pick commit", Description: "Cherry-pick commit to another worktree", Section: sectionLogPane, Shortcut: "C", Icon: IconLog, Handler: h.CherryPick},
 		CommandAction{ID: "log-commit-view", Label: "Browse commit files", Description: "Browse files changed in selected commit", Section: sectionLogPane, Icon: IconLog, Handler: h.CommitView},
+		CommandAction{ID: "log-rebase-interactive", Label: "Interactive rebase", Description: "Reorder, squash, fixup, reword or drop the commits since the base branch", Section: sectionLogPane, Shortcut: "i", Icon: IconLog, Handler: h.InteractiveRebase},
+		CommandAction{ID: "log-rebase-continue", Label: "Continue rebase", Description: "git rebase --continue once conflicts are staged", Section: sectionLogPane, Icon: IconLog, Handler: h.ContinueRebase, Available: h.RebaseInProgress},
+		CommandAction{ID: "log-rebase-abort", Label: "Abort rebase", Description: "git rebase --abort, restoring the branch", Section: sectionLogPane, Icon: IconLog, Handler: h.AbortRebase, Available: h.RebaseInProgress},
 	)
 }
 
@@ -85,6 +85,7 @@ const (
 	UIIconPRStateMerged
 	UIIconPRStateClosed
 	UIIconPRStateUnknown
+	UIIconLocked
 )
 
 var nerdFontGlyphs = map[UIIcon]string{
@@ -134,6 +135,7 @@ var nerdFontGlyphs = map[UIIcon]string{
 	UIIconPRStateMerged:       "◆",
 	UIIconPRStateClosed:       "✕",
 	UIIconPRStateUnknown:      "?",
+	UIIconLocked:              "",
 }
 
 var textGlyphs = map[UIIcon]string{
@@ -183,6 +185,7 @@ var textGlyphs = map[UIIcon]string{
 	UIIconPRStateMerged:       "◆",
 	UIIconPRStateClosed:       "✕",
 	UIIconPRStateUnknown:      "?",
+	UIIconLocked:              "L",
 }
 
 // NerdFontV3Provider implements IconProvider for Nerd Font v3.
@@ -423,6 +426,8 @@ func emojiUIIcon(icon UIIcon, prIcon, issueIcon string) string {
 		return "❌"
 	case UIIconPRStateUnknown:
 		return "❓"
+	case UIIconLocked:
+		return "🔒"
 	default:
 		return ""
 	}
@@ -513,6 +518,13 @@ func behindIndicator(showIcons bool) string {
 	return "↓"
 }
 
+func lockedIndicator(showIcons bool) string {
+	if showIcons {
+		return uiIcon(UIIconLocked)
+	}
+	return "[locked]"
+}
+
 func disclosureIndicator(collapsed, showIcons bool) string {
 	if !showIcons {
 		if collapsed {
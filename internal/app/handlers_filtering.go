@@ -52,6 +52,7 @@ func (m *Model) clearCurrentPaneFilter() (tea.Model, tea.Cmd) {
 		m.updateTable()
 	case paneGitStatus:
 		m.state.services.filter.StatusFilterQuery = ""
+		m.state.services.filter.StatusAgentOnly = false
 		m.state.ui.filterInput.SetValue("")
 		m.applyStatusFilter()
 	case paneCommit:
@@ -65,7 +65,7 @@ func (m *Model) handleOperationKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, boo
 		m.loading.active = true
 		m.setLoadingScreen(loadingRefreshWorktrees)
 		cmds := []tea.Cmd{m.refreshWorktrees()}
-		if !m.config.DisablePR && m.state.services.git.IsGitHubOrGitLab(m.ctx) {
+		if !m.config.DisablePR && m.state.services.git.HasForge(m.ctx) {
 			m.cache.ciCache.Clear()
 			if cmd := m.refreshCurrentWorktreePR(); cmd != nil {
 				cmds = append(cmds, cmd)
@@ -109,6 +109,9 @@ func (m *Model) handleOperationKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, boo
 		}
 		return m, nil, true
 	case "v":
+		if m.state.view.FocusedPane == paneAgentSessions {
+			return m, m.showAgentTranscript(), true
+		}
 		return m, m.openCICheckSelection(), true
 	case "ctrl+v":
 		if m.state.view.FocusedPane == paneInfo {
@@ -128,7 +131,12 @@ func (m *Model) handleOperationKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, boo
 		return m, m.pushToUpstream(), true
 	case "S":
 		return m, m.syncWithUpstream(), true
+	case "Z":
+		return m, m.showStashes(), true
 	case "R":
+		if m.state.view.FocusedPane == paneAgentSessions {
+			return m, m.resumeSelectedAgentSession(), true
+		}
 		m.loading.active = true
 		m.statusContent = "Fetching remotes..."
 		m.setLoadingScreen("Fetching remotes...")
@@ -168,6 +176,9 @@ func (m *Model) handleOperationKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, boo
 		if m.state.view.FocusedPane == paneNotes {
 			return m, m.showAnnotateWorktree(), true
 		}
+		if m.state.view.FocusedPane == paneCommit {
+			return m, m.showInteractiveRebase(), true
+		}
 		return m, nil, true
 	case "T":
 		return m, m.showTaskboard(), true
@@ -177,9 +188,19 @@ func (m *Model) handleOperationKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd, boo
 			m.refreshSelectedWorktreeAgentSessionsPane()
 			return m, nil, true
 		}
+		if m.state.view.FocusedPane == paneGitStatus {
+			m.toggleStatusAgentFilter()
+			return m, nil, true
+		}
 		return m, m.showAbsorbWorktree(), true
+	case "a":
+		return m, m.showStartAgent(), true
+	case "t":
+		return m, m.showAgentTimeline(), true
 	case "X":
 		return m, m.showPruneMerged(), true
+	case "K":
+		return m, m.showToggleWorktreeLock(), true
 	case "!":
 		return m, m.showRunCommand(), true
 	case "C":
@@ -228,6 +249,8 @@ func (m *Model) handleEnterKey() (tea.Model, tea.Cmd) {
 		}
 	case paneCommit:
 		return m, m.openCommitView()
+	case paneAgentSessions:
+		return m, m.attachSelectedAgentSession()
 	}
 	return m, nil
 }
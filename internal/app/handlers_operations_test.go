@@ -930,8 +930,10 @@ func TestCICheckEnterOpensURL(t *testing.T) {
 }
 
 func TestCICheckCtrlVShowsLogs(t *testing.T) {
+	withRemoteRepo(t, "https://github.com/owner/repo.git")
 	cfg := &config.AppConfig{
-		WorktreeDir: t.TempDir(),
+		WorktreeDir:   t.TempDir(),
+		CIScriptPager: "less -R",
 	}
 	m := NewModel(cfg, "")
 	m.state.view.FocusedPane = 1
@@ -974,7 +976,7 @@ func TestCICheckCtrlVShowsLogs(t *testing.T) {
 	if capturedCmd.Args[0] != "bash" {
 		t.Fatalf("expected bash command, got %s", capturedCmd.Args[0])
 	}
-	if !strings.Contains(capturedCmd.Args[2], "gh run view") {
+	if !strings.Contains(capturedCmd.Args[2], "'gh' 'run' 'view'") {
 		t.Fatalf("expected gh run view in command, got %s", capturedCmd.Args[2])
 	}
 }
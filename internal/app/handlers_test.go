@@ -33,8 +33,7 @@ func seedClaudeAgentSessions(t *testing.T, m *Model, worktreePath string, openSt
 	t.Helper()
 	root := t.TempDir()
 	m.state.services.agentSessions = services.NewAgentSessionServiceWithStore(
-		root,
-		"",
+		services.AgentSessionRoots{Claude: root},
 		services.NewTestSessionRegistryStore(filepath.Join(root, "registry.json")),
 		nil,
 	)
@@ -666,20 +666,33 @@ func (m *Model) updateTableColumns(totalWidth int) {
 		pr = 12
 	}
 
+	showCostColumn := m.loading.agentUsageSeen
+	cost := 0
+	if showCostColumn {
+		cost = 8
+	}
+
 	// The table library handles separators internally (3 spaces per separator)
 	// So we need to account for them: (numColumns - 1) * 3
 	numColumns := 3
 	if showPRColumn {
-		numColumns = 4
+		numColumns++
+	}
+	if showCostColumn {
+		numColumns++
 	}
 	separatorSpace := (numColumns - 1) * 3
 
-	worktree := max(12, totalWidth-status-last-pr-separatorSpace)
-	excess := worktree + status + pr + last + separatorSpace - totalWidth
+	worktree := max(12, totalWidth-status-last-pr-cost-separatorSpace)
+	excess := worktree + status + pr + cost + last + separatorSpace - totalWidth
 	for excess > 0 && last > 10 {
 		last--
 		excess--
 	}
+	for excess > 0 && cost > 6 {
+		cost--
+		excess--
+	}
 	if showPRColumn {
 		for excess > 0 && pr > 8 {
 			pr--
@@ -699,7 +712,7 @@ func (m *Model) updateTableColumns(totalWidth int) {
 	}
 
 	// Final adjustment: ensure column widths + separators sum exactly to totalWidth
-	actualTotal := worktree + status + last + pr + separatorSpace
+	actualTotal := worktree + status + last + pr + cost + separatorSpace
 	if actualTotal < totalWidth {
 		// Distribute remaining space to the worktree column
 		worktree += (totalWidth - actualTotal)
@@ -714,6 +727,9 @@ func (m *Model) updateTableColumns(totalWidth int) {
 		{Title: "Last Active", Width: last},
 	}
 
+	if showCostColumn {
+		columns = append(columns, table.Column{Title: "Cost", Width: cost})
+	}
 	if showPRColumn {
 		columns = append(columns, table.Column{Title: m.changeRequestColumnTitle(), Width: pr})
 	}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"github.com/chmouel/lazyworktree/internal/app/screen"
 	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/git"
 	log "github.com/chmouel/lazyworktree/internal/log"
 	"github.com/chmouel/lazyworktree/internal/models"
 	"github.com/chmouel/lazyworktree/internal/utils"
@@ -141,6 +143,9 @@ func (m *Model) handleWorktreesLoaded(msg worktreesLoadedMsg) (tea.Model, tea.Cm
 	if cmd := m.startGitWatcher(); cmd != nil {
 		cmds = append(cmds, cmd)
 	}
+	if cmd := m.syncAgentSessionWorktrees(); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 	return m, tea.Batch(cmds...)
 }
 
@@ -218,6 +223,9 @@ func (m *Model) handlePruneResult(msg pruneResultMsg) (tea.Model, tea.Cmd) {
 	if msg.branchesDeleted > 0 {
 		parts = append(parts, fmt.Sprintf("deleted %d stale branches", msg.branchesDeleted))
 	}
+	if msg.lockedSkipped > 0 {
+		parts = append(parts, fmt.Sprintf("skipped %d locked worktrees", msg.lockedSkipped))
+	}
 	if msg.failed > 0 {
 		parts = append(parts, fmt.Sprintf("%d failed", msg.failed))
 	}
@@ -250,6 +258,8 @@ func (m *Model) handlePRMessages(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleSinglePRLoaded(msg)
 	case ciStatusLoadedMsg:
 		return m.handleCIStatusLoaded(msg)
+	case ciFailuresLoadedMsg:
+		return m.handleCIFailuresLoaded(msg)
 	default:
 		return m, nil
 	}
@@ -348,6 +358,24 @@ func (m *Model) handleCIStatusLoaded(msg ciStatusLoadedMsg) (tea.Model, tea.Cmd)
 				m.infoContent = m.buildInfoContent(wt)
 			}
 		}
+		return m, m.fetchCIFailures(msg.branch, msg.checks)
+	}
+	return m, nil
+}
+
+// handleCIFailuresLoaded stores the failure summary of a failed CI check.
+func (m *Model) handleCIFailuresLoaded(msg ciFailuresLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil && !errors.Is(msg.err, git.ErrCILogUnsupported) {
+		// Forget the attempt so the next CI refresh retries it.
+		delete(m.cache.ciFailures, msg.link)
+		return m, nil
+	}
+	m.cache.ciFailures[msg.link] = msg.failures
+	if m.state.data.selectedIndex >= 0 && m.state.data.selectedIndex < len(m.state.data.filteredWts) {
+		wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+		if wt.Branch == msg.branch {
+			m.infoContent = m.buildInfoContent(wt)
+		}
 	}
 	return m, nil
 }
@@ -404,111 +432,118 @@ func (m *Model) handleOpenPRsLoaded(msg openPRsLoadedMsg) tea.Cmd {
 		return nil
 	}
 
-	// Build map of branches already attached to worktrees
-	attachedBranches := make(map[string]string)
+	// Show PR selection screen
+	prScr := screen.NewPRSelectionScreen(msg.prs, m.state.view.WindowWidth, m.state.view.WindowHeight, m.theme, m.config.IconsEnabled())
+	prScr.AttachedBranches = m.attachedBranches()
+	prScr.OnSelectPR = m.createWorktreeFromPR
+	prScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(prScr)
+	return textinput.Blink
+}
+
+// attachedBranches maps branch names to the worktrees they are checked out in.
+func (m *Model) attachedBranches() map[string]string {
+	attached := make(map[string]string)
 	for _, wt := range m.state.data.worktrees {
 		if wt.Branch != "" {
-			attachedBranches[wt.Branch] = filepath.Base(wt.Path)
+			attached[wt.Branch] = filepath.Base(wt.Path)
 		}
 	}
+	return attached
+}
 
-	// Show PR selection screen
-	prScr := screen.NewPRSelectionScreen(msg.prs, m.state.view.WindowWidth, m.state.view.WindowHeight, m.theme, m.config.IconsEnabled())
-	prScr.AttachedBranches = attachedBranches
-	prScr.OnSelectPR = func(pr *models.PRInfo) tea.Cmd {
-		remoteBranch := strings.TrimSpace(pr.Branch)
-		if remoteBranch == "" {
-			m.showInfo(errPRBranchMissing, nil)
-			return nil
-		}
+// createWorktreeFromPR creates a worktree for the branch of a PR/MR, or jumps
+// to the worktree that already has it checked out.
+func (m *Model) createWorktreeFromPR(pr *models.PRInfo) tea.Cmd {
+	remoteBranch := strings.TrimSpace(pr.Branch)
+	if remoteBranch == "" {
+		m.showInfo(errPRBranchMissing, nil)
+		return nil
+	}
 
-		template := strings.TrimSpace(m.config.PRBranchNameTemplate)
-		if template == "" {
-			template = "pr-{number}-{title}"
-		}
-		generatedTitle := ""
-		if m.config.BranchNameScript != "" {
-			prContent := fmt.Sprintf("%s\n\n%s", pr.Title, pr.Body)
-			suggestedName := utils.GeneratePRWorktreeName(pr, template, "")
-			aiTitle, scriptErr := runBranchNameScript(
-				m.ctx,
-				m.config.BranchNameScript,
-				prContent,
-				"pr",
-				fmt.Sprintf("%d", pr.Number),
-				template,
-				suggestedName,
-			)
-			if scriptErr != nil {
-				log.Printf("branch_name_script failed for PR #%d: %v", pr.Number, scriptErr)
-			} else if aiTitle != "" {
-				generatedTitle = aiTitle
-			}
-		}
-		worktreeName := strings.TrimSpace(utils.GeneratePRWorktreeName(pr, template, generatedTitle))
-		if worktreeName == "" {
-			worktreeName = fmt.Sprintf("pr-%d", pr.Number)
-		}
+	template := strings.TrimSpace(m.config.PRBranchNameTemplate)
+	if template == "" {
+		template = "pr-{number}-{title}"
+	}
+	generatedTitle := ""
+	if m.config.BranchNameScript != "" {
+		prContent := fmt.Sprintf("%s\n\n%s", pr.Title, pr.Body)
 		suggestedName := utils.GeneratePRWorktreeName(pr, template, "")
-		lazyCtx := services.LazyWorktreeContextFromPR(pr, template, suggestedName)
-
-		localBranch := remoteBranch
-		if wt := m.getWorktreeForBranch(localBranch); wt != nil {
-			m.state.ui.screenManager.Clear()
-			m.selectWorktreeByPath(wt.Path)
-			m.showInfo(fmt.Sprintf("Branch %q is already checked out in worktree %q", localBranch, filepath.Base(wt.Path)), nil)
-			return nil
+		aiTitle, scriptErr := runBranchNameScript(
+			m.ctx,
+			m.config.BranchNameScript,
+			prContent,
+			"pr",
+			fmt.Sprintf("%d", pr.Number),
+			template,
+			suggestedName,
+		)
+		if scriptErr != nil {
+			log.Printf("branch_name_script failed for PR #%d: %v", pr.Number, scriptErr)
+		} else if aiTitle != "" {
+			generatedTitle = aiTitle
 		}
+	}
+	worktreeName := strings.TrimSpace(utils.GeneratePRWorktreeName(pr, template, generatedTitle))
+	if worktreeName == "" {
+		worktreeName = fmt.Sprintf("pr-%d", pr.Number)
+	}
+	suggestedName := utils.GeneratePRWorktreeName(pr, template, "")
+	lazyCtx := services.LazyWorktreeContextFromPR(pr, template, suggestedName)
 
-		targetPath := filepath.Join(m.getRepoWorktreeDir(), worktreeName)
-		if m.worktreePathExists(targetPath) {
-			m.showInfo(fmt.Sprintf("Path already exists: %s", targetPath), nil)
-			return nil
-		}
+	localBranch := remoteBranch
+	if wt := m.getWorktreeForBranch(localBranch); wt != nil {
+		m.state.ui.screenManager.Clear()
+		m.selectWorktreeByPath(wt.Path)
+		m.showInfo(fmt.Sprintf("Branch %q is already checked out in worktree %q", localBranch, filepath.Base(wt.Path)), nil)
+		return nil
+	}
 
-		if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
-			return func() tea.Msg { return errMsg{err: err} }
-		}
+	targetPath := filepath.Join(m.getRepoWorktreeDir(), worktreeName)
+	if m.worktreePathExists(targetPath) {
+		m.showInfo(fmt.Sprintf("Path already exists: %s", targetPath), nil)
+		return nil
+	}
 
-		label := changeRequestLabel(pr)
-
-		// Create worktree from PR/MR branch (can take time, so do it async with a loading pulse)
-		m.loading.active = true
-		m.statusContent = fmt.Sprintf("Creating worktree from %s #%d...", label, pr.Number)
-		m.state.ui.screenManager.Clear() // Clear all stacked screens before loading
-		m.setLoadingScreen(m.statusContent)
-		m.pendingOp.selectPath = targetPath
-		return func() tea.Msg {
-			ok := m.state.services.git.CreateWorktreeFromPR(m.ctx, pr.Number, remoteBranch, localBranch, targetPath)
-			if !ok {
-				return createFromPRResultMsg{
-					prNumber:   pr.Number,
-					branch:     localBranch,
-					targetPath: targetPath,
-					lazyCtx:    lazyCtx,
-					pr:         pr,
-					err:        fmt.Errorf("create worktree from %s branch %q", label, remoteBranch),
-				}
-			}
-			noteText, err := m.generateWorktreeNote("pr", pr.Number, pr.Title, pr.Body, pr.URL)
-			if err != nil {
-				m.debugf("worktree note script error for %s #%d: %v", label, pr.Number, err)
-			}
+	if err := m.ensureWorktreeDir(m.getRepoWorktreeDir()); err != nil {
+		return func() tea.Msg { return errMsg{err: err} }
+	}
+
+	label := changeRequestLabel(pr)
+
+	// Create worktree from PR/MR branch (can take time, so do it async with a loading pulse)
+	m.loading.active = true
+	m.statusContent = fmt.Sprintf("Creating worktree from %s #%d...", label, pr.Number)
+	m.state.ui.screenManager.Clear() // Clear all stacked screens before loading
+	m.setLoadingScreen(m.statusContent)
+	m.pendingOp.selectPath = targetPath
+	return func() tea.Msg {
+		ok := m.state.services.git.CreateWorktreeFromPR(m.ctx, pr.Number, remoteBranch, localBranch, targetPath)
+		if !ok {
 			return createFromPRResultMsg{
 				prNumber:   pr.Number,
 				branch:     localBranch,
 				targetPath: targetPath,
-				note:       noteText,
 				lazyCtx:    lazyCtx,
 				pr:         pr,
+				err:        fmt.Errorf("create worktree from %s branch %q", label, remoteBranch),
 			}
 		}
+		noteText, err := m.generateWorktreeNote("pr", pr.Number, pr.Title, pr.Body, pr.URL)
+		if err != nil {
+			m.debugf("worktree note script error for %s #%d: %v", label, pr.Number, err)
+		}
+		return createFromPRResultMsg{
+			prNumber:   pr.Number,
+			branch:     localBranch,
+			targetPath: targetPath,
+			note:       noteText,
+			lazyCtx:    lazyCtx,
+			pr:         pr,
+		}
 	}
-	prScr.OnCancel = func() tea.Cmd {
-		return nil
-	}
-	m.state.ui.screenManager.Push(prScr)
-	return textinput.Blink
 }
 
 // handleOpenIssuesLoaded handles the result of fetching open issues.
@@ -10,9 +10,10 @@ import (
 // loadingState groups all loading/operation-tracking flags on the Model.
 type loadingState struct {
 	active             bool
-	operation          string // tracks which operation is loading ("push", "sync", "rerun", etc.)
+	operation          string // tracks which operation is loading ("push", "sync", "rerun", "cancel", etc.)
 	prDataLoaded       bool
 	checkMergedAfterPR bool // trigger merged check after PR data refresh
+	agentUsageSeen     bool // show the agent cost column once a session reported token usage
 }
 
 // detailsState groups fields related to debounced detail pane updates and click detection.
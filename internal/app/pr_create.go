@@ -0,0 +1,191 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showCreatePR starts the PR/MR creation wizard for the selected worktree:
+// base branch, title and draft flag, body, reviewers, then labels.
+func (m *Model) showCreatePR() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if strings.TrimSpace(wt.Branch) == "" {
+		m.showInfo("Cannot open a PR/MR from a detached worktree.", nil)
+		return nil
+	}
+	if wt.PR != nil && wt.PR.State == prStateOpen {
+		m.showInfo(fmt.Sprintf("Branch %q already has %s #%d open.\n\n%s", wt.Branch, changeRequestLabel(wt.PR), wt.PR.Number, wt.PR.URL), nil)
+		return nil
+	}
+
+	return m.showBranchSelectionWithItems(
+		fmt.Sprintf("Base branch for '%s'", wt.Branch),
+		"Filter branches...",
+		"No branches found.",
+		m.state.services.git.GetMainBranch(m.ctx),
+		m.prBaseSelectionItems(wt.Branch),
+		func(base string) tea.Cmd {
+			commits := m.state.services.git.CommitSubjects(m.ctx, wt.Path, base)
+			note, _ := m.getWorktreeNote(wt.Path)
+			title, body := services.DraftPR(note, wt.Branch, commits)
+			opts := &git.CreatePROptions{Base: base, Title: title, Body: body}
+			return m.showCreatePRTitleInput(wt, opts)
+		},
+	)
+}
+
+// prBaseSelectionItems lists local and remote branches by name, without the
+// remote prefix, excluding the branch the PR/MR is opened from.
+func (m *Model) prBaseSelectionItems(headBranch string) []selectionItem {
+	seen := map[string]struct{}{headBranch: {}}
+	var items []selectionItem
+	for _, item := range m.branchSelectionItemsForRefs([]string{"refs/heads", "refs/remotes"}) {
+		name := item.id
+		if item.description == "remote" {
+			name = stripRemotePrefix(name)
+		}
+		if _, ok := seen[name]; ok || name == "HEAD" {
+			continue
+		}
+		seen[name] = struct{}{}
+		items = append(items, selectionItem{id: name, label: name})
+	}
+	return items
+}
+
+func (m *Model) showCreatePRTitleInput(wt *models.WorktreeInfo, opts *git.CreatePROptions) tea.Cmd {
+	inputScr := appscreen.NewInputScreen(
+		fmt.Sprintf("PR/MR title (%s → %s)", wt.Branch, opts.Base),
+		"Describe the change",
+		opts.Title,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	inputScr.SetCheckbox("Open as draft", opts.Draft)
+	inputScr.OnSubmit = func(value string, checked bool) tea.Cmd {
+		title := strings.TrimSpace(value)
+		if title == "" {
+			inputScr.ErrorMsg = "Title cannot be empty."
+			return nil
+		}
+		opts.Title = title
+		opts.Draft = checked
+		return m.showCreatePRBodyInput(wt, opts)
+	}
+	inputScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(inputScr)
+	return textinput.Blink
+}
+
+func (m *Model) showCreatePRBodyInput(wt *models.WorktreeInfo, opts *git.CreatePROptions) tea.Cmd {
+	textareaScr := appscreen.NewTextareaScreen(
+		"PR/MR description",
+		"Explain what this change does...",
+		opts.Body,
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	textareaScr.OnSubmit = func(value string) tea.Cmd {
+		opts.Body = strings.TrimSpace(value)
+		return m.showCreatePRListInput(
+			"Reviewers (comma separated, optional)",
+			"octocat, org/team",
+			func(reviewers []string) tea.Cmd {
+				opts.Reviewers = reviewers
+				return m.showCreatePRListInput(
+					"Labels (comma separated, optional)",
+					"bug, enhancement",
+					func(labels []string) tea.Cmd {
+						opts.Labels = labels
+						return m.beginCreatePR(wt, *opts)
+					},
+				)
+			},
+		)
+	}
+	textareaScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(textareaScr)
+	return textarea.Blink
+}
+
+// showCreatePRListInput prompts for an optional comma separated list.
+func (m *Model) showCreatePRListInput(prompt, placeholder string, onSubmit func([]string) tea.Cmd) tea.Cmd {
+	inputScr := appscreen.NewInputScreen(prompt, placeholder, "", m.theme, m.config.IconsEnabled())
+	inputScr.OnSubmit = func(value string, _ bool) tea.Cmd {
+		return onSubmit(services.SplitList(value))
+	}
+	inputScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(inputScr)
+	return textinput.Blink
+}
+
+// beginCreatePR pushes the branch when needed and opens the PR/MR.
+func (m *Model) beginCreatePR(wt *models.WorktreeInfo, opts git.CreatePROptions) tea.Cmd {
+	m.loading.active = true
+	m.loading.operation = "create-pr"
+	m.statusContent = "Creating PR/MR..."
+	m.setLoadingScreen("Pushing and creating PR/MR...")
+
+	// Clear cache so status pane refreshes with the new upstream
+	m.deleteDetailsCache(wt.Path)
+
+	worktreePath := wt.Path
+	branch := wt.Branch
+	return func() tea.Msg {
+		head, _, err := m.state.services.git.PushBranchForPR(m.ctx, worktreePath, branch, map[string]string{"GIT_TERMINAL_PROMPT": "0"})
+		if err != nil {
+			return prCreatedMsg{worktreePath: worktreePath, err: err}
+		}
+		opts.Head = head
+		pr, err := m.state.services.git.CreatePR(m.ctx, worktreePath, opts)
+		return prCreatedMsg{worktreePath: worktreePath, pr: pr, err: err}
+	}
+}
+
+// handlePRCreated attaches a newly created PR/MR to its worktree.
+func (m *Model) handlePRCreated(msg prCreatedMsg) (tea.Model, tea.Cmd) {
+	m.loading.active = false
+	m.loading.operation = ""
+	m.clearLoadingScreen()
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Failed to create PR/MR.\n\n%s", truncateToHeightFromEnd(msg.err.Error(), 5)), nil)
+		return m, nil
+	}
+
+	_, cmd := m.handleSinglePRLoaded(singlePRLoadedMsg{worktreePath: msg.worktreePath, pr: msg.pr})
+	created := createdPRReference(msg.pr)
+	m.statusContent = "Created " + created
+	m.showInfo(fmt.Sprintf("Created %s.\n\n%s", created, msg.pr.URL), m.updateDetailsView())
+	return m, cmd
+}
+
+// createdPRReference names a created PR/MR, e.g. "MR #7", leaving the number
+// out when the forge output did not include one.
+func createdPRReference(pr *models.PRInfo) string {
+	label := changeRequestLabel(pr)
+	if pr.Number <= 0 {
+		return label
+	}
+	return fmt.Sprintf("%s #%d", label, pr.Number)
+}
@@ -0,0 +1,15 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestCreatedPRReference(t *testing.T) {
+	assert.Equal(t, "PR #8", createdPRReference(&models.PRInfo{Number: 8, URL: "https://github.com/org/repo/pull/8"}))
+	assert.Equal(t, "MR #4", createdPRReference(&models.PRInfo{Number: 4, URL: "https://gitlab.com/group/repo/-/merge_requests/4"}))
+	assert.Equal(t, "MR", createdPRReference(&models.PRInfo{URL: "https://gitlab.example.com/group/repo/-/merge_requests/new"}))
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showPRInbox fetches the PRs/MRs waiting on the authenticated user.
+func (m *Model) showPRInbox() tea.Cmd {
+	if m.config.DisablePR {
+		m.showInfo("PR/MR display is disabled in configuration", nil)
+		return nil
+	}
+	m.loading.active = true
+	m.loading.operation = "pr-inbox"
+	m.setLoadingScreen("Loading PR/MR inbox...")
+	return m.loadPRInboxCmd()
+}
+
+func (m *Model) loadPRInboxCmd() tea.Cmd {
+	return func() tea.Msg {
+		inbox, err := m.state.services.git.FetchPRInbox(m.ctx)
+		return prInboxLoadedMsg{inbox: inbox, err: err}
+	}
+}
+
+// prInboxSections orders the inbox groups for display.
+func prInboxSections(inbox *git.PRInbox) []appscreen.PRInboxSection {
+	return []appscreen.PRInboxSection{
+		{Title: "Review requested", PRs: inbox.ReviewRequested},
+		{Title: "Assigned to me", PRs: inbox.Assigned},
+		{Title: "My PRs with failing CI", PRs: inbox.FailingCI},
+		{Title: "My PRs with new comments", PRs: inbox.NewComments},
+	}
+}
+
+// handlePRInboxLoaded opens the inbox screen, or refreshes it when it is
+// already displayed.
+func (m *Model) handlePRInboxLoaded(msg prInboxLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.loading.operation == "pr-inbox" {
+		m.loading.active = false
+		m.loading.operation = ""
+		m.clearLoadingScreen()
+	}
+
+	scr, _ := m.state.ui.screenManager.Current().(*appscreen.PRInboxScreen)
+	if msg.err != nil {
+		if scr != nil {
+			scr.StatusMessage = "Failed to refresh inbox: " + msg.err.Error()
+			return m, nil
+		}
+		m.showInfo(fmt.Sprintf("Failed to load the PR/MR inbox.\n\n%s", truncateToHeightFromEnd(msg.err.Error(), 5)), nil)
+		return m, nil
+	}
+	if scr != nil {
+		scr.StatusMessage = ""
+		scr.AttachedBranches = m.attachedBranches()
+		scr.SetSections(prInboxSections(msg.inbox))
+		return m, nil
+	}
+
+	scr = appscreen.NewPRInboxScreen(
+		prInboxSections(msg.inbox),
+		m.attachedBranches(),
+		fmt.Sprintf("PR/MR inbox for @%s", msg.inbox.Username),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnSelect = m.openPRFromInbox
+	scr.OnOpenURL = func(pr *models.PRInfo) tea.Cmd {
+		prURL, err := sanitizePRURL(pr.URL)
+		if err != nil {
+			scr.StatusMessage = err.Error()
+			return nil
+		}
+		return m.openURLInBrowser(prURL)
+	}
+	scr.OnRefresh = m.loadPRInboxCmd
+	m.state.ui.screenManager.Push(scr)
+	return m, nil
+}
+
+// openPRFromInbox jumps to the worktree of a PR/MR, creating it when the
+// branch is not checked out yet.
+func (m *Model) openPRFromInbox(pr *models.PRInfo) tea.Cmd {
+	if wt := m.getWorktreeForBranch(pr.Branch); wt != nil {
+		m.state.ui.screenManager.Clear()
+		m.selectWorktreeByPath(wt.Path)
+		m.statusContent = fmt.Sprintf("Switched to %s", filepath.Base(wt.Path))
+		return m.updateDetailsView()
+	}
+	return m.createWorktreeFromPR(pr)
+}
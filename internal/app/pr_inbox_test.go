@@ -0,0 +1,59 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func testPRInbox() *git.PRInbox {
+	return &git.PRInbox{
+		Username:        "me",
+		ReviewRequested: []*models.PRInfo{{Number: 1, Branch: "feature", Title: "Feature"}},
+		FailingCI:       []*models.PRInfo{{Number: 2, Branch: "other", Title: "Other"}},
+	}
+}
+
+func TestHandlePRInboxLoadedShowsScreen(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+
+	m.handlePRInboxLoaded(prInboxLoadedMsg{inbox: testPRInbox()})
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.PRInboxScreen)
+	require.True(t, ok)
+	assert.Equal(t, "PR/MR inbox for @me", scr.Title)
+	require.Len(t, scr.Sections, 4)
+	assert.Equal(t, "feature", scr.AttachedBranches["feature"])
+
+	m.handlePRInboxLoaded(prInboxLoadedMsg{err: errors.New("offline")})
+	assert.Same(t, scr, m.state.ui.screenManager.Current())
+	assert.Contains(t, scr.StatusMessage, "offline")
+}
+
+func TestHandlePRInboxLoadedError(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+
+	m.handlePRInboxLoaded(prInboxLoadedMsg{err: errors.New("no auth")})
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, infoScreen.Message, "no auth")
+}
+
+func TestOpenPRFromInboxJumpsToExistingWorktree(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	other := &models.WorktreeInfo{Path: "/tmp/wt/main", Branch: "main", IsMain: true}
+	m.state.data.worktrees = []*models.WorktreeInfo{other, wt}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+	m.handlePRInboxLoaded(prInboxLoadedMsg{inbox: testPRInbox()})
+
+	m.openPRFromInbox(&models.PRInfo{Number: 1, Branch: "feature"})
+	assert.False(t, m.state.ui.screenManager.IsActive())
+	assert.Same(t, wt, m.state.data.filteredWts[m.state.data.selectedIndex])
+	assert.Equal(t, "Switched to feature", m.statusContent)
+}
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// prMergeAutoPrefix marks merge method choices that enable auto-merge.
+const prMergeAutoPrefix = "auto:"
+
+var prMergeMethodLabels = map[string]string{
+	git.MergeMethodSquash: "Squash and merge",
+	git.MergeMethodRebase: "Rebase and merge",
+	git.MergeMethodMerge:  "Create a merge commit",
+}
+
+// showMergePR asks how to merge the open PR/MR of the selected worktree.
+func (m *Model) showMergePR() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if wt.PR == nil || wt.PR.State != prStateOpen {
+		m.showInfo(fmt.Sprintf("No open PR/MR for %q.", wt.Branch), nil)
+		return nil
+	}
+
+	// merge_method only knows rebase and merge; it picks the default choice.
+	method := git.MergeMethodRebase
+	if strings.TrimSpace(m.config.MergeMethod) == git.MergeMethodMerge {
+		method = git.MergeMethodMerge
+	}
+	preferred := method
+	if wt.PR.CIStatus == "pending" {
+		preferred = prMergeAutoPrefix + method
+	}
+
+	methods := []string{git.MergeMethodSquash, git.MergeMethodRebase, git.MergeMethodMerge}
+	items := make([]selectionItem, 0, len(methods)*2)
+	for _, method := range methods {
+		items = append(items, selectionItem{
+			id:          method,
+			label:       prMergeMethodLabels[method],
+			description: "Merge now",
+		})
+	}
+	for _, method := range methods {
+		items = append(items, selectionItem{
+			id:          prMergeAutoPrefix + method,
+			label:       prMergeMethodLabels[method] + " when checks pass",
+			description: "Enable auto-merge",
+		})
+	}
+
+	return m.showBranchSelectionWithItems(
+		fmt.Sprintf("Merge PR #%d into %s", wt.PR.Number, wt.PR.BaseBranch),
+		"Filter merge methods...",
+		"No merge methods match.",
+		preferred,
+		items,
+		func(choice string) tea.Cmd {
+			method, auto := strings.CutPrefix(choice, prMergeAutoPrefix)
+			return m.showMergePRConfirm(wt, git.MergePROptions{Method: method, Auto: auto})
+		},
+	)
+}
+
+func (m *Model) showMergePRConfirm(wt *models.WorktreeInfo, opts git.MergePROptions) tea.Cmd {
+	var message string
+	if opts.Auto {
+		message = fmt.Sprintf("Enable auto-merge for PR #%d (%s)?\n\n%s\n\nIt will be merged into %s once the required checks pass.", wt.PR.Number, opts.Method, wt.PR.Title, wt.PR.BaseBranch)
+	} else {
+		message = fmt.Sprintf("Merge PR #%d into %s (%s)?\n\n%s", wt.PR.Number, wt.PR.BaseBranch, opts.Method, wt.PR.Title)
+		if wt.PR.CIStatus == "failure" {
+			message += "\n\nWarning: CI checks are failing."
+		}
+	}
+
+	confirmScreen := appscreen.NewConfirmScreen(message, m.theme)
+	confirmScreen.OnConfirm = func() tea.Cmd {
+		return m.beginMergePR(wt, opts)
+	}
+	m.state.ui.screenManager.Push(confirmScreen)
+	return nil
+}
+
+// beginMergePR merges the PR/MR, or enables auto-merge for it.
+func (m *Model) beginMergePR(wt *models.WorktreeInfo, opts git.MergePROptions) tea.Cmd {
+	m.loading.active = true
+	m.loading.operation = "merge-pr"
+	m.statusContent = fmt.Sprintf("Merging PR #%d...", wt.PR.Number)
+	m.setLoadingScreen(fmt.Sprintf("Merging PR #%d...", wt.PR.Number))
+
+	worktreePath := wt.Path
+	number := wt.PR.Number
+	return func() tea.Msg {
+		err := m.state.services.git.MergePR(m.ctx, worktreePath, number, opts)
+		return prMergedMsg{worktreePath: worktreePath, number: number, auto: opts.Auto, err: err}
+	}
+}
+
+// handlePRMerged marks the worktree PR/MR as merged and offers to delete the
+// worktree and its branch.
+func (m *Model) handlePRMerged(msg prMergedMsg) (tea.Model, tea.Cmd) {
+	m.loading.active = false
+	m.loading.operation = ""
+	m.clearLoadingScreen()
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Failed to merge PR #%d.\n\n%s", msg.number, truncateToHeightFromEnd(msg.err.Error(), 5)), nil)
+		return m, nil
+	}
+	if msg.auto {
+		m.statusContent = fmt.Sprintf("Auto-merge enabled for PR #%d", msg.number)
+		m.showInfo(fmt.Sprintf("Auto-merge enabled for PR #%d.\n\nIt will be merged once the required checks pass.", msg.number), nil)
+		return m, nil
+	}
+
+	var wt *models.WorktreeInfo
+	for _, candidate := range m.state.data.worktrees {
+		if candidate.Path == msg.worktreePath {
+			wt = candidate
+			break
+		}
+	}
+	m.statusContent = fmt.Sprintf("Merged PR #%d", msg.number)
+	if wt == nil || wt.PR == nil {
+		return m, nil
+	}
+
+	merged := *wt.PR
+	merged.State = prStateMerged
+	m.deleteDetailsCache(wt.Path)
+	_, cmd := m.handleSinglePRLoaded(singlePRLoadedMsg{worktreePath: wt.Path, pr: &merged})
+	if wt.IsMain {
+		m.showInfo(fmt.Sprintf("Merged PR #%d.", msg.number), nil)
+		return m, cmd
+	}
+	if wt.Locked {
+		m.showInfo(fmt.Sprintf("Merged PR #%d.\n\n%s", msg.number, lockedWorktreeMessage("delete", wt)), nil)
+		return m, cmd
+	}
+
+	message := fmt.Sprintf("Merged PR #%d.\n\nDelete the worktree and its branch?\n\nPath: %s\nBranch: %s", msg.number, wt.Path, wt.Branch)
+	defaultButton := 0
+	if wt.Dirty {
+		message += "\n\nWarning: the worktree has uncommitted changes."
+		defaultButton = 1
+	}
+	confirmScreen := appscreen.NewConfirmScreenWithDefault(message, defaultButton, m.theme)
+	confirmScreen.OnConfirm = m.deleteWorktreeCmd(wt)
+	m.state.ui.screenManager.Push(confirmScreen)
+	return m, cmd
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func newMergePRTestModel(t *testing.T, pr *models.PRInfo) (*Model, *models.WorktreeInfo) {
+	t.Helper()
+	m := newTestModel(t)
+	wt := &models.WorktreeInfo{Path: "/tmp/wt/feature", Branch: "feature", PR: pr}
+	m.state.data.worktrees = []*models.WorktreeInfo{wt}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+	return m, wt
+}
+
+func TestShowMergePRWithoutOpenPR(t *testing.T) {
+	m, _ := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateMerged})
+
+	assert.Nil(t, m.showMergePR())
+	assert.Equal(t, appscreen.TypeInfo, m.state.ui.screenManager.Type())
+}
+
+func TestShowMergePRPrefersConfiguredMethod(t *testing.T) {
+	tests := []struct {
+		name        string
+		mergeMethod string
+		ciStatus    string
+		want        string
+	}{
+		{name: "default rebase", want: "rebase", ciStatus: "success"},
+		{name: "merge method", mergeMethod: "merge", ciStatus: "success", want: "merge"},
+		{name: "pending checks", mergeMethod: "merge", ciStatus: "pending", want: "auto:merge"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _ := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateOpen, BaseBranch: "main", CIStatus: tt.ciStatus})
+			m.config.MergeMethod = tt.mergeMethod
+
+			m.showMergePR()
+			listScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+			require.True(t, ok)
+			require.Len(t, listScreen.Items, 6)
+			assert.Equal(t, tt.want, listScreen.Filtered[listScreen.Cursor].ID)
+		})
+	}
+}
+
+func TestHandlePRMergedOffersDeletion(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateOpen, BaseBranch: "main"})
+
+	m.handlePRMerged(prMergedMsg{worktreePath: wt.Path, number: 3})
+	require.NotNil(t, wt.PR)
+	assert.Equal(t, prStateMerged, wt.PR.State)
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	require.True(t, ok)
+	assert.Contains(t, confirmScreen.Message, "Delete the worktree and its branch?")
+	assert.Equal(t, 0, confirmScreen.SelectedButton)
+	assert.NotNil(t, confirmScreen.OnConfirm)
+}
+
+func TestHandlePRMergedDirtyWorktreeDefaultsToCancel(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateOpen})
+	wt.Dirty = true
+
+	m.handlePRMerged(prMergedMsg{worktreePath: wt.Path, number: 3})
+	confirmScreen, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	require.True(t, ok)
+	assert.Contains(t, confirmScreen.Message, "uncommitted changes")
+	assert.Equal(t, 1, confirmScreen.SelectedButton)
+}
+
+func TestHandlePRMergedLockedWorktreeSkipsDeletion(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateOpen})
+	wt.Locked = true
+	wt.LockReason = "on a USB drive"
+
+	m.handlePRMerged(prMergedMsg{worktreePath: wt.Path, number: 3})
+	require.NotNil(t, wt.PR)
+	assert.Equal(t, prStateMerged, wt.PR.State)
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok, "expected no deletion prompt for a locked worktree")
+	assert.Contains(t, infoScreen.Message, "Merged PR #3.")
+	assert.Contains(t, infoScreen.Message, "Cannot delete: worktree is locked (on a USB drive).")
+}
+
+func TestHandlePRMergedAutoAndErrors(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 3, State: prStateOpen})
+
+	m.handlePRMerged(prMergedMsg{worktreePath: wt.Path, number: 3, auto: true})
+	assert.Equal(t, prStateOpen, wt.PR.State)
+	assert.Equal(t, appscreen.TypeInfo, m.state.ui.screenManager.Type())
+	assert.Equal(t, "Auto-merge enabled for PR #3", m.statusContent)
+
+	m.state.ui.screenManager.Clear()
+	m.handlePRMerged(prMergedMsg{worktreePath: wt.Path, number: 3, err: errors.New("not mergeable")})
+	assert.Equal(t, prStateOpen, wt.PR.State)
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, infoScreen.Message, "not mergeable")
+}
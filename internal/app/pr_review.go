@@ -0,0 +1,226 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showPRReviewThreads opens the review threads of the selected worktree PR/MR.
+func (m *Model) showPRReviewThreads() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if wt.PR == nil || wt.PR.Number == 0 {
+		m.showInfo(fmt.Sprintf("No PR/MR for %q.", wt.Branch), nil)
+		return nil
+	}
+
+	m.loading.active = true
+	m.loading.operation = "pr-review"
+	m.setLoadingScreen(fmt.Sprintf("Loading review threads for PR #%d...", wt.PR.Number))
+	return m.loadPRReviewThreadsCmd(wt.Path, wt.PR.Number)
+}
+
+func (m *Model) loadPRReviewThreadsCmd(worktreePath string, number int) tea.Cmd {
+	return func() tea.Msg {
+		threads, err := m.state.services.git.FetchReviewThreads(m.ctx, number)
+		return prReviewThreadsLoadedMsg{
+			worktreePath: worktreePath,
+			number:       number,
+			threads:      threads,
+			err:          err,
+		}
+	}
+}
+
+// prReviewScreen returns the review threads screen when it is on top.
+func (m *Model) prReviewScreen() *appscreen.PRReviewScreen {
+	if m.state.ui.screenManager.Type() != appscreen.TypePRReview {
+		return nil
+	}
+	scr, _ := m.state.ui.screenManager.Current().(*appscreen.PRReviewScreen)
+	return scr
+}
+
+// handlePRReviewThreadsLoaded opens the review threads screen, or refreshes
+// it when it is already displayed.
+func (m *Model) handlePRReviewThreadsLoaded(msg prReviewThreadsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.loading.operation == "pr-review" {
+		m.loading.active = false
+		m.loading.operation = ""
+		m.clearLoadingScreen()
+	}
+
+	if scr := m.prReviewScreen(); scr != nil {
+		if msg.actionErr != nil {
+			scr.StatusMessage = reviewThreadActionMessage(msg.actionErr)
+			return m, nil
+		}
+		if msg.err != nil {
+			scr.StatusMessage = "Failed to refresh review threads: " + msg.err.Error()
+			return m, nil
+		}
+		scr.SetThreads(msg.threads, msg.preferredID)
+		return m, nil
+	}
+	if msg.actionErr != nil {
+		m.showInfo(reviewThreadActionMessage(msg.actionErr), nil)
+		return m, nil
+	}
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Failed to load review threads for PR #%d.\n\n%s", msg.number, truncateToHeightFromEnd(msg.err.Error(), 5)), nil)
+		return m, nil
+	}
+
+	var wt *models.WorktreeInfo
+	for _, candidate := range m.state.data.worktrees {
+		if candidate.Path == msg.worktreePath {
+			wt = candidate
+			break
+		}
+	}
+	if wt == nil {
+		return m, nil
+	}
+
+	scr := appscreen.NewPRReviewScreen(
+		msg.threads,
+		fmt.Sprintf("Review threads for PR #%d", msg.number),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnOpenFile = func(thread *models.PRReviewThread) tea.Cmd {
+		return m.openReviewThreadInEditor(scr, wt, thread)
+	}
+	scr.OnReply = func(thread *models.PRReviewThread) tea.Cmd {
+		return m.showReplyToReviewThread(wt, msg.number, thread)
+	}
+	scr.OnToggleResolved = func(thread *models.PRReviewThread) tea.Cmd {
+		scr.StatusMessage = "Updating thread..."
+		return m.reviewThreadActionCmd(wt.Path, msg.number, thread.ID, func() error {
+			return m.state.services.git.SetReviewThreadResolved(m.ctx, msg.number, thread.ID, !thread.IsResolved)
+		})
+	}
+	scr.OnOpenURL = func(thread *models.PRReviewThread) tea.Cmd {
+		target := wt.PR.URL
+		for _, comment := range thread.Comments {
+			if comment.URL != "" {
+				target = comment.URL
+				break
+			}
+		}
+		safeURL, err := sanitizePRURL(target)
+		if err != nil {
+			scr.StatusMessage = err.Error()
+			return nil
+		}
+		return m.openURLInBrowser(safeURL)
+	}
+	m.state.ui.screenManager.Push(scr)
+	return m, nil
+}
+
+// openReviewThreadInEditor opens the file of a review thread at its line.
+func (m *Model) openReviewThreadInEditor(scr *appscreen.PRReviewScreen, wt *models.WorktreeInfo, thread *models.PRReviewThread) tea.Cmd {
+	editor := m.editorCommand()
+	if strings.TrimSpace(editor) == "" {
+		scr.StatusMessage = "No editor configured. Set editor in config or $EDITOR."
+		return nil
+	}
+	if thread.Path == "" {
+		scr.StatusMessage = "This thread is not attached to a file."
+		return nil
+	}
+	if _, err := os.Stat(filepath.Join(wt.Path, thread.Path)); err != nil {
+		scr.StatusMessage = fmt.Sprintf("Cannot open %s: %v", thread.Path, err)
+		return nil
+	}
+
+	env := m.buildCommandEnvForWorktree(wt)
+	envVars := services.AppendCommandEnv(os.Environ(), env)
+
+	cmdStr := fmt.Sprintf("%s %s", editor, shellQuote(thread.Path))
+	if thread.Line > 0 {
+		cmdStr = fmt.Sprintf("%s +%d %s", editor, thread.Line, shellQuote(thread.Path))
+	}
+	// #nosec G204 -- command is constructed from user config and controlled inputs
+	c := m.commandRunner(m.ctx, "bash", "-c", cmdStr)
+	c.Dir = wt.Path
+	c.Env = envVars
+
+	return m.execProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return nil
+	})
+}
+
+func (m *Model) showReplyToReviewThread(wt *models.WorktreeInfo, number int, thread *models.PRReviewThread) tea.Cmd {
+	location := thread.Path
+	if thread.Line > 0 {
+		location = fmt.Sprintf("%s:%d", thread.Path, thread.Line)
+	}
+	textareaScr := appscreen.NewTextareaScreen(
+		"Reply to "+location,
+		"Write a reply...",
+		"",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	textareaScr.OnSubmit = func(value string) tea.Cmd {
+		body := strings.TrimSpace(value)
+		if body == "" {
+			return nil
+		}
+		return m.reviewThreadActionCmd(wt.Path, number, thread.ID, func() error {
+			return m.state.services.git.ReplyToReviewThread(m.ctx, number, thread.ID, body)
+		})
+	}
+	textareaScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(textareaScr)
+	return textarea.Blink
+}
+
+// reviewThreadActionCmd runs a thread update and reloads the threads.
+func (m *Model) reviewThreadActionCmd(worktreePath string, number int, threadID string, action func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := action(); err != nil {
+			return prReviewThreadsLoadedMsg{worktreePath: worktreePath, number: number, actionErr: err}
+		}
+		threads, err := m.state.services.git.FetchReviewThreads(m.ctx, number)
+		return prReviewThreadsLoadedMsg{
+			worktreePath: worktreePath,
+			number:       number,
+			threads:      threads,
+			preferredID:  threadID,
+			err:          err,
+		}
+	}
+}
+
+// reviewThreadActionMessage describes a failed reply or resolve.
+func reviewThreadActionMessage(err error) string {
+	if errors.Is(err, git.ErrReviewThreadUnsupported) {
+		return "Replying to and resolving threads is not supported by this forge."
+	}
+	return "Failed to update thread: " + err.Error()
+}
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowPRReviewThreadsWithoutPR(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+
+	assert.Nil(t, m.showPRReviewThreads())
+	assert.Equal(t, appscreen.TypeInfo, m.state.ui.screenManager.Type())
+}
+
+func TestHandlePRReviewThreadsLoadedOpensAndRefreshes(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 7, State: prStateOpen})
+	threads := []*models.PRReviewThread{
+		{ID: "a", Path: "main.go", Line: 1},
+		{ID: "b", Path: "main.go", Line: 9},
+	}
+
+	m.handlePRReviewThreadsLoaded(prReviewThreadsLoadedMsg{worktreePath: wt.Path, number: 7, threads: threads})
+	scr := m.prReviewScreen()
+	require.NotNil(t, scr)
+	assert.Equal(t, "Review threads for PR #7", scr.Title)
+	assert.Equal(t, "a", scr.Selected().ID)
+
+	m.handlePRReviewThreadsLoaded(prReviewThreadsLoadedMsg{worktreePath: wt.Path, number: 7, threads: threads, preferredID: "b"})
+	assert.Same(t, scr, m.prReviewScreen())
+	assert.Equal(t, "b", scr.Selected().ID)
+
+	m.handlePRReviewThreadsLoaded(prReviewThreadsLoadedMsg{worktreePath: wt.Path, number: 7, actionErr: git.ErrReviewThreadUnsupported})
+	assert.Contains(t, scr.StatusMessage, "not supported")
+	assert.Len(t, scr.Threads, 2)
+}
+
+func TestHandlePRReviewThreadsLoadedError(t *testing.T) {
+	m, wt := newMergePRTestModel(t, &models.PRInfo{Number: 7, State: prStateOpen})
+
+	m.handlePRReviewThreadsLoaded(prReviewThreadsLoadedMsg{worktreePath: wt.Path, number: 7, err: errors.New("boom")})
+	infoScreen, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, infoScreen.Message, "Failed to load review threads for PR #7")
+	assert.Contains(t, infoScreen.Message, "boom")
+}
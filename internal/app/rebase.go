@@ -0,0 +1,195 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showInteractiveRebase loads the commits of the selected worktree since its
+// base branch into the interactive rebase editor.
+func (m *Model) showInteractiveRebase() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if m.state.services.git.RebaseInProgress(wt.Path) {
+		m.showInfo(fmt.Sprintf("A rebase is already in progress in %s.\n\nRun \"Continue rebase\" or \"Abort rebase\" from the command palette.", worktreeDisplayName(wt)), nil)
+		return nil
+	}
+	if wt.Dirty {
+		m.showInfo(fmt.Sprintf("%s has uncommitted changes.\n\nCommit or stash them before rebasing.", worktreeDisplayName(wt)), nil)
+		return nil
+	}
+
+	base := ""
+	if wt.PR != nil {
+		base = wt.PR.BaseBranch
+	}
+	m.loading.active = true
+	m.loading.operation = "rebase"
+	m.setLoadingScreen("Loading commits...")
+	return func() tea.Msg {
+		if base == "" {
+			base = m.state.services.git.GetMainBranch(m.ctx)
+		}
+		commits, onto, err := m.state.services.git.RebaseCommits(m.ctx, wt.Path, base)
+		return rebaseCommitsLoadedMsg{
+			worktreePath: wt.Path,
+			base:         base,
+			onto:         onto,
+			commits:      commits,
+			err:          err,
+		}
+	}
+}
+
+// handleRebaseCommitsLoaded opens the interactive rebase editor.
+func (m *Model) handleRebaseCommitsLoaded(msg rebaseCommitsLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.loading.operation == "rebase" {
+		m.loading.active = false
+		m.loading.operation = ""
+		m.clearLoadingScreen()
+	}
+
+	var wt *models.WorktreeInfo
+	for _, candidate := range m.state.data.worktrees {
+		if candidate.Path == msg.worktreePath {
+			wt = candidate
+			break
+		}
+	}
+	if wt == nil {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Cannot rebase %s.\n\n%s", worktreeDisplayName(wt), msg.err), nil)
+		return m, nil
+	}
+	if len(msg.commits) == 0 {
+		m.showInfo(fmt.Sprintf("No commits on %s since it forked from %s.", wt.BranchLabel(), msg.base), nil)
+		return m, nil
+	}
+
+	scr := appscreen.NewRebaseScreen(
+		msg.commits,
+		fmt.Sprintf("Interactive rebase of %s onto %s (oldest first)", wt.BranchLabel(), msg.base),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnReword = func(commit *models.RebaseCommit) tea.Cmd {
+		textareaScr := appscreen.NewTextareaScreen(
+			fmt.Sprintf("Reword %s", commit.ShortHash()),
+			"Commit message",
+			commit.Message,
+			m.state.view.WindowWidth,
+			m.state.view.WindowHeight,
+			m.theme,
+			m.config.IconsEnabled(),
+		)
+		textareaScr.OnSubmit = func(value string) tea.Cmd {
+			scr.SetMessage(commit, value)
+			return nil
+		}
+		textareaScr.OnCancel = func() tea.Cmd {
+			return nil
+		}
+		m.state.ui.screenManager.Push(textareaScr)
+		return textarea.Blink
+	}
+	scr.OnStart = func(commits []*models.RebaseCommit) tea.Cmd {
+		m.loading.active = true
+		m.loading.operation = "rebase"
+		m.setLoadingScreen(fmt.Sprintf("Rebasing %s...", wt.BranchLabel()))
+		return func() tea.Msg {
+			err := m.state.services.git.InteractiveRebase(m.ctx, wt.Path, msg.onto, commits)
+			return rebaseResultMsg{worktree: wt, operation: "rebase", err: err}
+		}
+	}
+	m.state.ui.screenManager.Push(scr)
+	return m, nil
+}
+
+// continueRebase resumes the rebase stopped in the selected worktree.
+func (m *Model) continueRebase() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if !m.state.services.git.RebaseInProgress(wt.Path) {
+		m.showInfo(fmt.Sprintf("No rebase in progress in %s.", worktreeDisplayName(wt)), nil)
+		return nil
+	}
+	for _, file := range m.state.data.statusFilesAll {
+		if file.IsConflicted {
+			m.showInfo(fmt.Sprintf("%s still has conflicts.\n\nResolve them, stage the files with s, then continue the rebase.", worktreeDisplayName(wt)), nil)
+			return nil
+		}
+	}
+
+	m.loading.active = true
+	m.loading.operation = "rebase"
+	m.setLoadingScreen("Continuing rebase...")
+	return func() tea.Msg {
+		err := m.state.services.git.ContinueRebase(m.ctx, wt.Path)
+		return rebaseResultMsg{worktree: wt, operation: "continue", err: err}
+	}
+}
+
+// showAbortRebase asks before abandoning the rebase of the selected worktree.
+func (m *Model) showAbortRebase() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if !m.state.services.git.RebaseInProgress(wt.Path) {
+		m.showInfo(fmt.Sprintf("No rebase in progress in %s.", worktreeDisplayName(wt)), nil)
+		return nil
+	}
+
+	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Abort the rebase in %s?\n\nThe branch returns to where it was before the rebase started.", worktreeDisplayName(wt)), m.theme)
+	confirmScreen.OnConfirm = func() tea.Cmd {
+		m.loading.active = true
+		m.loading.operation = "rebase"
+		m.setLoadingScreen("Aborting rebase...")
+		return func() tea.Msg {
+			err := m.state.services.git.AbortRebase(m.ctx, wt.Path)
+			return rebaseResultMsg{worktree: wt, operation: "abort", err: err}
+		}
+	}
+	m.state.ui.screenManager.Push(confirmScreen)
+	return nil
+}
+
+func (m *Model) handleRebaseResult(msg rebaseResultMsg) tea.Cmd {
+	m.loading.active = false
+	m.loading.operation = ""
+	m.clearLoadingScreen()
+	m.deleteDetailsCache(msg.worktree.Path)
+	name := worktreeDisplayName(msg.worktree)
+
+	switch {
+	case errors.Is(msg.err, git.ErrRebaseStopped):
+		detail := strings.TrimPrefix(msg.err.Error(), git.ErrRebaseStopped.Error()+": ")
+		m.showInfo(fmt.Sprintf("Rebase stopped in %s\n\nResolve the conflicts listed in the Git Status pane and stage them with s, then run \"Continue rebase\" from the command palette, or \"Abort rebase\" to undo it.\n\n%s",
+			name, truncateToHeightFromEnd(detail, 8)), m.refreshWorktrees())
+	case msg.err != nil:
+		m.showInfo(fmt.Sprintf("Rebase failed in %s\n\n%s", name, truncateToHeightFromEnd(msg.err.Error(), 8)), m.refreshWorktrees())
+	case msg.operation == "abort":
+		m.showInfo(fmt.Sprintf("Rebase aborted in %s.", name), m.refreshWorktrees())
+	default:
+		m.showInfo(fmt.Sprintf("Rebase of %s complete.", msg.worktree.BranchLabel()), m.refreshWorktrees())
+	}
+	return nil
+}
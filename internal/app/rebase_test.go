@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestParseStatusFilesConflicts(t *testing.T) {
+	statusRaw := "u UU N... 100644 100644 100644 100644 aaaa bbbb cccc main.go\n" +
+		"1 .M N... 100644 100644 100644 aaaa bbbb util.go\n"
+
+	files := parseStatusFiles(statusRaw)
+	require.Len(t, files, 2)
+	assert.Equal(t, "main.go", files[0].Filename)
+	assert.Equal(t, "UU", files[0].Status)
+	assert.True(t, files[0].IsConflicted)
+	assert.False(t, files[1].IsConflicted)
+
+	staged, modified, untracked := statusCounts(files)
+	assert.Equal(t, 0, staged)
+	assert.Equal(t, 2, modified)
+	assert.Equal(t, 0, untracked)
+}
+
+func TestGitStatusTitleShowsRebase(t *testing.T) {
+	m := newTestModel(t)
+	assert.Equal(t, "Git Status", m.gitStatusTitle())
+
+	m.state.data.rebaseInProgress = true
+	assert.Equal(t, "Git Status · Rebasing", m.gitStatusTitle())
+
+	m.setStatusFiles(parseStatusFiles("u UU N... 100644 100644 100644 100644 aaaa bbbb cccc main.go\n" +
+		"u AA N... 000000 100644 100644 100644 0000 bbbb cccc new.go\n"))
+	assert.Equal(t, "Git Status · Rebasing, 2 conflicts", m.gitStatusTitle())
+}
+
+func TestShowInteractiveRebaseRefusesDirtyWorktree(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	wt.Dirty = true
+
+	assert.Nil(t, m.showInteractiveRebase())
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "uncommitted changes")
+}
+
+func TestHandleRebaseCommitsLoadedRewords(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	commits := []*models.RebaseCommit{
+		{Hash: "aaaaaaaaaa", Subject: "first", Message: "first"},
+		{Hash: "bbbbbbbbbb", Subject: "second", Message: "second"},
+	}
+
+	m.handleRebaseCommitsLoaded(rebaseCommitsLoadedMsg{worktreePath: wt.Path, base: "main", onto: "cccc", commits: commits})
+	scr, ok := m.state.ui.screenManager.Current().(*appscreen.RebaseScreen)
+	require.True(t, ok)
+	assert.Equal(t, "Interactive rebase of feature onto main (oldest first)", scr.Title)
+
+	scr.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	textareaScr, ok := m.state.ui.screenManager.Current().(*appscreen.TextareaScreen)
+	require.True(t, ok)
+	textareaScr.OnSubmit("better first\n\nbody")
+	assert.Equal(t, models.RebaseActionReword, commits[0].Action)
+	assert.Equal(t, "better first", commits[0].Subject)
+}
+
+func TestHandleRebaseCommitsLoadedWithoutCommits(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+
+	m.handleRebaseCommitsLoaded(rebaseCommitsLoadedMsg{worktreePath: wt.Path, base: "main"})
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "No commits on feature since it forked from main")
+}
+
+func TestHandleRebaseResultStopped(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+
+	m.handleRebaseResult(rebaseResultMsg{
+		worktree:  wt,
+		operation: "rebase",
+		err:       fmt.Errorf("%w: CONFLICT (content): Merge conflict in main.go", git.ErrRebaseStopped),
+	})
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "Rebase stopped in feature")
+	assert.Contains(t, info.Message, "Merge conflict in main.go")
+	assert.NotContains(t, info.Message, git.ErrRebaseStopped.Error()+":")
+}
+
+func TestContinueRebaseRequiresResolvedConflicts(t *testing.T) {
+	m := newTestModel(t)
+	wtPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(wtPath, ".git", "rebase-merge"), 0o750))
+	m.state.data.worktrees = []*models.WorktreeInfo{{Path: wtPath, Branch: "feature"}}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+	m.setStatusFiles(parseStatusFiles("u UU N... 100644 100644 100644 100644 aaaa bbbb cccc main.go\n"))
+
+	assert.Nil(t, m.continueRebase())
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "still has conflicts")
+
+	m.setStatusFiles(nil)
+	assert.NotNil(t, m.continueRebase())
+	assert.Equal(t, appscreen.TypeLoading, m.state.ui.screenManager.Type())
+	assert.Equal(t, "rebase", m.loading.operation)
+}
+
+func TestShowAbortRebaseShowsLoading(t *testing.T) {
+	m := newTestModel(t)
+	wtPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(wtPath, ".git", "rebase-merge"), 0o750))
+	wt := &models.WorktreeInfo{Path: wtPath, Branch: "feature"}
+	m.state.data.worktrees = []*models.WorktreeInfo{wt}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	assert.Nil(t, m.showAbortRebase())
+	require.Equal(t, appscreen.TypeConfirm, m.state.ui.screenManager.Type())
+	_, cmd := m.handleScreenKey(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, appscreen.TypeLoading, m.state.ui.screenManager.Type())
+	assert.True(t, m.loading.active)
+	assert.Equal(t, "rebase", m.loading.operation)
+
+	m.handleRebaseResult(rebaseResultMsg{worktree: wt, operation: "abort"})
+	assert.False(t, m.loading.active)
+	assert.Empty(t, m.loading.operation)
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "Rebase aborted")
+}
+
+func TestShowAbortRebaseWithoutRebase(t *testing.T) {
+	m := newTestModel(t)
+	m.state.data.worktrees = []*models.WorktreeInfo{{Path: t.TempDir(), Branch: "feature"}}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	assert.Nil(t, m.showAbortRebase())
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "No rebase in progress")
+}
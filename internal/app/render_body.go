@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"strings"
 
 	"charm.land/lipgloss/v2"
@@ -127,6 +128,28 @@ func (m *Model) renderInfoBox(width, height int) string {
 		Render(boxContent)
 }
 
+// gitStatusTitle names the git status pane, flagging a rebase stopped in the
+// selected worktree and the conflicts left to resolve.
+func (m *Model) gitStatusTitle() string {
+	if !m.state.data.rebaseInProgress {
+		return "Git Status"
+	}
+	conflicts := 0
+	for _, file := range m.state.data.statusFilesAll {
+		if file.IsConflicted {
+			conflicts++
+		}
+	}
+	switch conflicts {
+	case 0:
+		return "Git Status · Rebasing"
+	case 1:
+		return "Git Status · Rebasing, 1 conflict"
+	default:
+		return fmt.Sprintf("Git Status · Rebasing, %d conflicts", conflicts)
+	}
+}
+
 // renderRightMiddlePane renders the right middle pane (git status file tree).
 func (m *Model) renderRightMiddlePane(layout layoutDims) string {
 	focused := m.state.view.FocusedPane == paneGitStatus
@@ -142,7 +165,7 @@ func (m *Model) renderRightMiddlePane(layout layoutDims) string {
 		Height(layout.rightMiddleInnerHeight).
 		Render(m.state.ui.statusViewport.View())
 
-	return m.renderPaneBlock(3, "Git Status", focused, layout.rightWidth, layout.rightMiddleHeight, statusBox)
+	return m.renderPaneBlock(3, m.gitStatusTitle(), focused, layout.rightWidth, layout.rightMiddleHeight, statusBox)
 }
 
 // renderRightBottomPane renders the right bottom pane (commit log table).
@@ -217,7 +240,7 @@ func (m *Model) renderBottomMiddlePane(layout layoutDims) string {
 		Height(layout.bottomMiddleInnerHeight).
 		Render(m.state.ui.statusViewport.View())
 
-	return m.renderPaneBlock(3, "Git Status", focused, layout.bottomMiddleWidth, layout.bottomHeight, statusBox)
+	return m.renderPaneBlock(3, m.gitStatusTitle(), focused, layout.bottomMiddleWidth, layout.bottomHeight, statusBox)
 }
 
 // renderBottomRightPane renders the commit pane in the bottom right of the top layout.
@@ -250,7 +273,7 @@ func (m *Model) renderZoomedRightMiddlePane(layout layoutDims) string {
 		Height(layout.rightMiddleInnerHeight).
 		Render(m.state.ui.statusViewport.View())
 
-	return m.renderPaneBlock(3, "Git Status", true, layout.rightWidth, layout.bodyHeight, statusBox)
+	return m.renderPaneBlock(3, m.gitStatusTitle(), true, layout.rightWidth, layout.bodyHeight, statusBox)
 }
 
 // renderZoomedRightBottomPane renders the zoomed right bottom pane (commit log).
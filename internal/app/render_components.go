@@ -94,7 +94,7 @@ func (m *Model) renderFooter(layout layoutDims) string {
 	case paneCommit: // Commit pane
 		if len(m.state.data.logEntries) > 0 {
 			groups = [][]string{
-				{m.renderKeyHint("Enter", "View Commit"), m.renderKeyHint("C", "Cherry-pick"), m.renderKeyHint("j/k", "Navigate")},
+				{m.renderKeyHint("Enter", "View Commit"), m.renderKeyHint("C", "Cherry-pick"), m.renderKeyHint("i", "Rebase"), m.renderKeyHint("j/k", "Navigate")},
 				{m.renderKeyHint("f", "Filter"), m.renderKeyHint("/", "Search"), m.renderKeyHint("r", "Refresh")},
 				{m.renderKeyHint("Tab", "Switch Pane"), m.renderKeyHint("q", "Quit"), m.renderKeyHint("?", "Help")},
 			}
@@ -105,7 +105,10 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 
 	infoLines := make([]string, 0, 32)
 	infoLines = addField(infoLines, "Path:", valueStyle.Render(shortenHomePath(wt.Path)))
-	infoLines = addField(infoLines, "Branch:", valueStyle.Render(wt.Branch))
+	infoLines = addField(infoLines, "Branch:", valueStyle.Render(wt.BranchLabel()))
+	if wt.Detached && wt.Head != "" {
+		infoLines = addField(infoLines, "Commit:", valueStyle.Render(wt.ShortHead()))
+	}
 	if note, ok := m.getWorktreeNote(wt.Path); ok {
 		if note.Description != "" {
 			infoLines = addField(infoLines, "Description:", valueStyle.Render(note.Description))
@@ -132,6 +135,21 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 		}
 		infoLines = addField(infoLines, "Divergence:", strings.Join(parts, " "))
 	}
+	if wt.Locked {
+		lockText := "yes"
+		if wt.LockReason != "" {
+			lockText = wt.LockReason
+		}
+		lockText = iconPrefix(UIIconLocked, m.config.IconsEnabled()) + lockText
+		infoLines = addField(infoLines, "Locked:", lipgloss.NewStyle().Foreground(m.theme.WarnFg).Render(lockText))
+	}
+	if wt.Prunable {
+		prunableText := "yes"
+		if wt.PrunableReason != "" {
+			prunableText = wt.PrunableReason
+		}
+		infoLines = addField(infoLines, "Prunable:", lipgloss.NewStyle().Foreground(m.theme.ErrorFg).Render(prunableText))
+	}
 	hidePRDetails := wt.PR != nil && wt.IsMain && (wt.PR.State == prStateMerged || wt.PR.State == prStateClosed)
 	if wt.PR != nil && !hidePRDetails && !m.config.DisablePR {
 		authorText := wt.PR.Author
@@ -244,6 +262,9 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 					line = fmt.Sprintf("  %s %s", iconStyle.Render(symbol), check.Name)
 				}
 				infoLines = append(infoLines, line)
+				if check.Conclusion == iconFailure {
+					infoLines = append(infoLines, m.ciFailureLines(m.cache.ciFailures[check.Link])...)
+				}
 			}
 		}
 	}
@@ -251,6 +272,37 @@ func (m *Model) buildInfoContent(wt *models.WorktreeInfo) string {
 	return strings.Join(infoLines, "\n")
 }
 
+// ciFailureLines renders the first failures of a failed CI check below it.
+func (m *Model) ciFailureLines(failures []models.CIFailure) []string {
+	const maxShown = 3
+	errorStyle := lipgloss.NewStyle().Foreground(m.theme.ErrorFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.theme.MutedFg)
+
+	var lines []string
+	for i, failure := range failures {
+		if i == maxShown {
+			lines = append(lines, mutedStyle.Render(fmt.Sprintf("      … %d more", len(failures)-maxShown)))
+			break
+		}
+		var parts []string
+		if failure.File != "" {
+			location := failure.File
+			if failure.Line > 0 {
+				location = fmt.Sprintf("%s:%d", location, failure.Line)
+			}
+			parts = append(parts, mutedStyle.Render(location))
+		}
+		if failure.Test != "" {
+			parts = append(parts, errorStyle.Render(failure.Test))
+		}
+		if failure.Message != "" {
+			parts = append(parts, failure.Message)
+		}
+		lines = append(lines, "    ↳ "+strings.Join(parts, " "))
+	}
+	return lines
+}
+
 // aggregateCIConclusion computes the overall CI status from a slice of checks.
 // Priority: failure > pending > success > skipped/cancelled.
 func aggregateCIConclusion(checks []*models.CICheck) string {
@@ -286,6 +338,9 @@ func (m *Model) renderStatusFiles() string {
 				fmt.Sprintf("No files match %q", strings.TrimSpace(m.state.services.filter.StatusFilterQuery)),
 			)
 		}
+		if m.state.services.filter.StatusAgentOnly {
+			return lipgloss.NewStyle().Foreground(m.theme.MutedFg).Render("No files changed by an agent")
+		}
 		return lipgloss.NewStyle().Foreground(m.theme.MutedFg).Render("No files to display")
 	}
 
@@ -294,6 +349,7 @@ func (m *Model) renderStatusFiles() string {
 	deletedStyle := lipgloss.NewStyle().Foreground(m.theme.ErrorFg)
 	untrackedStyle := lipgloss.NewStyle().Foreground(m.theme.WarnFg)
 	stagedStyle := lipgloss.NewStyle().Foreground(m.theme.Cyan)
+	conflictStyle := lipgloss.NewStyle().Foreground(m.theme.ErrorFg).Bold(true)
 	dirStyle := lipgloss.NewStyle().Foreground(m.theme.MutedFg)
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(m.theme.AccentFg).
@@ -309,6 +365,13 @@ func (m *Model) renderStatusFiles() string {
 
 		var lineContent string
 		var fileIcon string
+		// agentBadge marks files edited by a coding agent.
+		var agentBadge, agentBadgeText string
+		if node.File != nil && node.File.Agent != "" {
+			letter, fg := m.agentMarker(node.File.Agent)
+			agentBadgeText = " " + letter
+			agentBadge = " " + lipgloss.NewStyle().Foreground(fg).Bold(true).Render(letter)
+		}
 		if node.IsDir() {
 			// Directory line: "  ▼ dirname" or "  ▶ dirname"
 			expandIcon := disclosureIndicator(m.state.services.statusTree.CollapsedDirs[node.Path], showIcons)
@@ -324,7 +387,7 @@ func (m *Model) renderStatusFiles() string {
 			if showIcons {
 				fileIcon = iconWithSpace(deviconForName(node.Name(), false))
 			}
-			lineContent = fmt.Sprintf("%s  %s %s%s", indent, displayStatus, fileIcon, node.Name())
+			lineContent = fmt.Sprintf("%s  %s %s%s%s", indent, displayStatus, fileIcon, node.Name(), agentBadgeText)
 		}
 
 		// Apply styling based on selection and node type
@@ -344,10 +407,17 @@ func (m *Model) renderStatusFiles() string {
 				continue
 			}
 
+			// Conflicted files stand out until they are resolved and staged
+			if node.File.IsConflicted {
+				formatted := fmt.Sprintf("%s  %s %s%s%s", indent, conflictStyle.Render(formatStatusDisplay(status)), fileIcon, conflictStyle.Render(node.Name()), agentBadge)
+				lines = append(lines, formatted)
+				continue
+			}
+
 			// Special case for untracked files
 			if status == " ?" {
 				displayStatus := formatStatusDisplay(status)
-				formatted := fmt.Sprintf("%s  %s %s%s", indent, untrackedStyle.Render(displayStatus), fileIcon, node.Name())
+				formatted := fmt.Sprintf("%s  %s %s%s%s", indent, untrackedStyle.Render(displayStatus), fileIcon, node.Name(), agentBadge)
 				lines = append(lines, formatted)
 				continue
 			}
@@ -396,7 +466,7 @@ func (m *Model) renderStatusFiles() string {
 				}
 				statusRendered.WriteString(style.Render(string(char)))
 			}
-			formatted := fmt.Sprintf("%s  %s %s%s", indent, statusRendered.String(), fileIcon, node.Name())
+			formatted := fmt.Sprintf("%s  %s %s%s%s", indent, statusRendered.String(), fileIcon, node.Name(), agentBadge)
 			lines = append(lines, formatted)
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/charmbracelet/x/ansi"
 	"github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/config"
 	"github.com/chmouel/lazyworktree/internal/models"
@@ -459,3 +460,32 @@ func TestRenderStatusFiles_LinesJoinedByNewline(t *testing.T) {
 	// Multiple files must produce multiple lines
 	assert.Contains(t, result, "\n", "expected newline between file entries")
 }
+
+func TestStatusFilesChangedByAgent(t *testing.T) {
+	t.Parallel()
+	m := newModelForRenderTest(t)
+	wt := &models.WorktreeInfo{Path: t.TempDir(), Branch: "feature"}
+	m.state.data.worktrees = []*models.WorktreeInfo{wt}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+	m.setStatusFiles([]StatusFile{
+		{Status: ".M", Filename: "agent.go"},
+		{Status: ".M", Filename: "mine.go"},
+	})
+
+	m.state.data.agentSessionsSnapshot = []*models.AgentSession{
+		{Agent: models.AgentKindCodex, CWD: wt.Path, EditedPaths: []string{"agent.go"}},
+	}
+	m.refreshStatusAgentEdits()
+	assert.Equal(t, models.AgentKindCodex, m.state.data.statusFiles[0].Agent)
+	assert.Contains(t, ansi.Strip(m.renderStatusFiles()), "agent.go X")
+
+	m.toggleStatusAgentFilter()
+	assert.True(t, m.hasActiveFilterForPane(paneGitStatus))
+	assert.Len(t, m.state.data.statusFiles, 1)
+	assert.Equal(t, "agent.go", m.state.data.statusFiles[0].Filename)
+
+	m.state.data.agentSessionsSnapshot = nil
+	m.refreshStatusAgentEdits()
+	assert.Contains(t, m.renderStatusFiles(), "No files changed by an agent")
+}
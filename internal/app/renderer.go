@@ -89,6 +89,48 @@ func (m *Model) View() tea.View {
 			}
 			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
 			return v
+		case screen.TypePRReview:
+			if rs, ok := scr.(*screen.PRReviewScreen); ok {
+				rs.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypeStash:
+			if ss, ok := scr.(*screen.StashScreen); ok {
+				ss.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypeRebase:
+			if rs, ok := scr.(*screen.RebaseScreen); ok {
+				rs.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypePRInbox:
+			if is, ok := scr.(*screen.PRInboxScreen); ok {
+				is.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypeCILog:
+			if ls, ok := scr.(*screen.CILogScreen); ok {
+				ls.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypeAgentTimeline:
+			if ts, ok := scr.(*screen.AgentTimelineScreen); ok {
+				ts.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
+		case screen.TypeAgentTranscript:
+			if ts, ok := scr.(*screen.AgentTranscriptScreen); ok {
+				ts.Resize(m.state.view.WindowWidth, m.state.view.WindowHeight)
+			}
+			v.SetContent(m.overlayPopup(baseView, scr.View(), 2))
+			return v
 		case screen.TypeHelp:
 			if hs, ok := scr.(*screen.HelpScreen); ok {
 				hs.SetSize(m.state.view.WindowWidth, m.state.view.WindowHeight)
@@ -0,0 +1,293 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// TimelineEntry is one row of the agent activity timeline.
+type TimelineEntry struct {
+	When         string
+	Agent        string
+	Worktree     string
+	WorktreePath string
+	Kind         string
+	Text         string
+}
+
+// AgentTimelineScreen shows recent agent activity across every worktree,
+// newest first, with filters by agent, worktree and activity type.
+type AgentTimelineScreen struct {
+	Entries      []TimelineEntry
+	Cursor       int
+	ScrollOffset int
+	Width        int
+	Height       int
+	Title        string
+	Thm          *theme.Theme
+
+	// Active filters; empty means no filtering on that field.
+	AgentFilter    string
+	WorktreeFilter string
+	KindFilter     string
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnSelect  func(TimelineEntry) tea.Cmd
+	OnRefresh func() tea.Cmd
+	OnClose   func() tea.Cmd
+
+	visible []int
+}
+
+// NewAgentTimelineScreen creates the agent activity timeline modal.
+func NewAgentTimelineScreen(entries []TimelineEntry, title string, maxWidth, maxHeight int, thm *theme.Theme) *AgentTimelineScreen {
+	s := &AgentTimelineScreen{Title: title, Thm: thm}
+	s.Resize(maxWidth, maxHeight)
+	s.SetEntries(entries)
+	return s
+}
+
+// Type returns the screen type.
+func (s *AgentTimelineScreen) Type() Type {
+	return TypeAgentTimeline
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *AgentTimelineScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 96
+	s.Height = 28
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.8), 64, 140)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.8), 14, 48)
+	}
+	s.ensureCursorVisible()
+}
+
+// SetEntries replaces the timeline content and reapplies the filters. The
+// cursor returns to the newest entry.
+func (s *AgentTimelineScreen) SetEntries(entries []TimelineEntry) {
+	s.Entries = entries
+	s.applyFilters()
+}
+
+// Selected returns the entry under the cursor.
+func (s *AgentTimelineScreen) Selected() (TimelineEntry, bool) {
+	if s.Cursor < 0 || s.Cursor >= len(s.visible) {
+		return TimelineEntry{}, false
+	}
+	return s.Entries[s.visible[s.Cursor]], true
+}
+
+// Update handles keyboard input.
+func (s *AgentTimelineScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "up", "k", keyCtrlK:
+		s.moveCursor(-1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(1)
+	case "ctrl+u", "pgup":
+		s.moveCursor(-s.listHeight() / 2)
+	case "ctrl+d", "pgdown":
+		s.moveCursor(s.listHeight() / 2)
+	case "g":
+		s.moveCursor(-len(s.visible))
+	case "G":
+		s.moveCursor(len(s.visible))
+	case "a":
+		s.AgentFilter = s.nextFilterValue(s.AgentFilter, func(e TimelineEntry) string { return e.Agent })
+		s.applyFilters()
+	case "w":
+		s.WorktreeFilter = s.nextFilterValue(s.WorktreeFilter, func(e TimelineEntry) string { return e.Worktree })
+		s.applyFilters()
+	case "t":
+		s.KindFilter = s.nextFilterValue(s.KindFilter, func(e TimelineEntry) string { return e.Kind })
+		s.applyFilters()
+	case "x":
+		s.AgentFilter, s.WorktreeFilter, s.KindFilter = "", "", ""
+		s.applyFilters()
+	case keyEnter:
+		if entry, ok := s.Selected(); ok && entry.WorktreePath != "" && s.OnSelect != nil {
+			return s, s.OnSelect(entry)
+		}
+	case "r":
+		if s.OnRefresh != nil {
+			s.StatusMessage = "Refreshing..."
+			return s, s.OnRefresh()
+		}
+	}
+	return s, nil
+}
+
+// nextFilterValue cycles a filter through the values present in the
+// timeline, in order of first appearance, then back to no filter.
+func (s *AgentTimelineScreen) nextFilterValue(current string, field func(TimelineEntry) string) string {
+	var values []string
+	seen := make(map[string]bool)
+	for _, entry := range s.Entries {
+		if v := field(entry); v != "" && !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	if current == "" {
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	for i, v := range values {
+		if v == current && i+1 < len(values) {
+			return values[i+1]
+		}
+	}
+	return ""
+}
+
+func (s *AgentTimelineScreen) applyFilters() {
+	s.visible = s.visible[:0]
+	for i, entry := range s.Entries {
+		if (s.AgentFilter == "" || entry.Agent == s.AgentFilter) &&
+			(s.WorktreeFilter == "" || entry.Worktree == s.WorktreeFilter) &&
+			(s.KindFilter == "" || entry.Kind == s.KindFilter) {
+			s.visible = append(s.visible, i)
+		}
+	}
+	s.Cursor = 0
+	s.ScrollOffset = 0
+	s.StatusMessage = ""
+}
+
+// View renders the timeline modal.
+func (s *AgentTimelineScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	textStyle := lipgloss.NewStyle().Foreground(s.Thm.TextFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	agentStyle := lipgloss.NewStyle().Foreground(s.Thm.Cyan)
+	worktreeStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	statusStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+
+	whenWidth, agentWidth, worktreeWidth := 0, 0, 0
+	for _, i := range s.visible {
+		whenWidth = max(whenWidth, ansi.StringWidth(s.Entries[i].When))
+		agentWidth = max(agentWidth, ansi.StringWidth(s.Entries[i].Agent))
+		worktreeWidth = max(worktreeWidth, ansi.StringWidth(s.Entries[i].Worktree))
+	}
+	worktreeWidth = min(worktreeWidth, max(8, contentWidth/4))
+
+	filters := s.filterSummary()
+	listHeight := s.listHeight()
+	lines := make([]string, 0, listHeight)
+	end := min(len(s.visible), s.ScrollOffset+listHeight)
+	for row := s.ScrollOffset; row < end; row++ {
+		entry := s.Entries[s.visible[row]]
+		when := fmt.Sprintf(" %-*s ", whenWidth, entry.When)
+		agent := fmt.Sprintf("%-*s ", agentWidth, entry.Agent)
+		worktree := fmt.Sprintf("%-*s ", worktreeWidth, ansi.Truncate(entry.Worktree, worktreeWidth, "…"))
+		available := max(1, contentWidth-ansi.StringWidth(when+agent+worktree))
+		text := ansi.Truncate(entry.Text, available, "…")
+
+		if row == s.Cursor {
+			lines = append(lines, selectedStyle.Width(contentWidth).Render(when+agent+worktree+text))
+			continue
+		}
+		lines = append(lines, mutedStyle.Render(when)+agentStyle.Render(agent)+worktreeStyle.Render(worktree)+textStyle.Render(text))
+	}
+	if len(s.visible) == 0 {
+		message := " No agent activity in this period."
+		if filters != "" {
+			message = " No agent activity matches the filters."
+		}
+		lines = append(lines, mutedStyle.Render(message))
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	info := fmt.Sprintf("%d events", len(s.visible))
+	if filters != "" {
+		info += " · " + filters
+	}
+	infoLine := mutedStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(info, s.Width-4, "…"))
+
+	footer := "Enter jump to worktree • a agent • w worktree • t type • x clear filters • r refresh • q close"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	if s.StatusMessage != "" {
+		footerLine = statusStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), infoLine, footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+func (s *AgentTimelineScreen) filterSummary() string {
+	var parts []string
+	if s.AgentFilter != "" {
+		parts = append(parts, "agent: "+s.AgentFilter)
+	}
+	if s.WorktreeFilter != "" {
+		parts = append(parts, "worktree: "+s.WorktreeFilter)
+	}
+	if s.KindFilter != "" {
+		parts = append(parts, "type: "+s.KindFilter)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (s *AgentTimelineScreen) listHeight() int {
+	return max(3, s.Height-5)
+}
+
+func (s *AgentTimelineScreen) moveCursor(delta int) {
+	if len(s.visible) == 0 {
+		return
+	}
+	s.Cursor = clampInt(s.Cursor+delta, 0, len(s.visible)-1)
+	s.StatusMessage = ""
+	s.ensureCursorVisible()
+}
+
+func (s *AgentTimelineScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = s.Cursor
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+	s.ScrollOffset = max(0, s.ScrollOffset)
+}
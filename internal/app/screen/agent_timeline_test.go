@@ -0,0 +1,77 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testTimelineEntries() []TimelineEntry {
+	return []TimelineEntry{
+		{When: "just now", Agent: "claude", Worktree: "feat-x", WorktreePath: "/wt/feat-x", Kind: "run", Text: "ran `go test`"},
+		{When: "2 minutes ago", Agent: "codex", Worktree: "fix-y", WorktreePath: "/wt/fix-y", Kind: "waiting", Text: "waiting for input"},
+		{When: "5 minutes ago", Agent: "claude", Worktree: "fix-y", WorktreePath: "/wt/fix-y", Kind: "edit", Text: "edited main.go"},
+	}
+}
+
+func TestAgentTimelineScreenFilters(t *testing.T) {
+	s := NewAgentTimelineScreen(testTimelineEntries(), "Timeline", 120, 40, theme.Dracula())
+	if s.Type() != TypeAgentTimeline {
+		t.Fatalf("expected TypeAgentTimeline, got %v", s.Type())
+	}
+	view := s.View()
+	if !strings.Contains(view, "ran `go test`") || !strings.Contains(view, "3 events") {
+		t.Fatalf("expected every event listed, got:\n%s", view)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if s.AgentFilter != "claude" || len(s.visible) != 2 {
+		t.Fatalf("expected the claude filter, got %q with %d events", s.AgentFilter, len(s.visible))
+	}
+	s.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	s.Update(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	if entry, ok := s.Selected(); !ok || s.WorktreeFilter != "fix-y" || len(s.visible) != 1 || entry.Text != "edited main.go" {
+		t.Fatalf("expected claude in fix-y only, got %q with %d events", s.WorktreeFilter, len(s.visible))
+	}
+	s.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	if s.KindFilter != "run" || len(s.visible) != 0 {
+		t.Fatalf("expected no run event in fix-y, got %q with %d events", s.KindFilter, len(s.visible))
+	}
+	if view := s.View(); !strings.Contains(view, "matches the filters") {
+		t.Fatalf("expected the empty filter message, got:\n%s", view)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	s.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	if s.AgentFilter != "" {
+		t.Fatalf("expected the agent filter to cycle back to all, got %q", s.AgentFilter)
+	}
+	s.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if len(s.visible) != 3 || s.WorktreeFilter != "" || s.KindFilter != "" {
+		t.Fatalf("expected x to clear every filter, got %d events", len(s.visible))
+	}
+}
+
+func TestAgentTimelineScreenSelect(t *testing.T) {
+	s := NewAgentTimelineScreen(testTimelineEntries(), "Timeline", 120, 40, theme.Dracula())
+	var selected string
+	s.OnSelect = func(entry TimelineEntry) tea.Cmd {
+		selected = entry.WorktreePath
+		return nil
+	}
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if selected != "/wt/fix-y" {
+		t.Fatalf("expected Enter to select the second event's worktree, got %q", selected)
+	}
+
+	s.SetEntries(nil)
+	if _, ok := s.Selected(); ok {
+		t.Fatal("expected nothing selected in an empty timeline")
+	}
+	if next, _ := s.Update(tea.KeyPressMsg{Code: 'q', Text: "q"}); next != nil {
+		t.Fatal("expected q to close the timeline")
+	}
+}
@@ -0,0 +1,495 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/theme"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// TranscriptBlockKind identifies what a transcript block holds.
+type TranscriptBlockKind int
+
+const (
+	// TranscriptPrompt is a message written by the user.
+	TranscriptPrompt TranscriptBlockKind = iota
+	// TranscriptReply is a message written by the agent.
+	TranscriptReply
+	// TranscriptThinking is the agent reasoning, hidden by default.
+	TranscriptThinking
+	// TranscriptToolCall is a tool invocation and its arguments.
+	TranscriptToolCall
+	// TranscriptToolResult is a tool output, folded by default.
+	TranscriptToolResult
+)
+
+// TranscriptBlock is one entry of an agent conversation.
+type TranscriptBlock struct {
+	Kind  TranscriptBlockKind
+	Title string
+	// Lines is the rendered body; it may hold ANSI styling.
+	Lines   []string
+	IsError bool
+}
+
+type transcriptRow struct {
+	block int
+	line  int // index into the wrapped body, -1 for the block header
+}
+
+// AgentTranscriptScreen shows the whole conversation of an agent session.
+type AgentTranscriptScreen struct {
+	Title        string
+	Blocks       []TranscriptBlock
+	ShowThinking bool
+	Cursor       int
+	ScrollOffset int
+	Width        int
+	Height       int
+	Thm          *theme.Theme
+	ShowIcons    bool
+
+	SearchInput textinput.Model
+	Searching   bool
+	SearchQuery string
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnClose func() tea.Cmd
+
+	wrapped   [][]string // block bodies wrapped to the content width
+	plain     [][]string // wrapped bodies without ANSI sequences, for search
+	wrapWidth int
+	toggled   map[int]bool // fold state of blocks toggled by the user
+	rows      []transcriptRow
+}
+
+// NewAgentTranscriptScreen creates the transcript viewer modal.
+func NewAgentTranscriptScreen(title string, blocks []TranscriptBlock, maxWidth, maxHeight int, thm *theme.Theme, showIcons bool) *AgentTranscriptScreen {
+	ti := textinput.New()
+	ti.Placeholder = "Search transcript..."
+	ti.CharLimit = 128
+	ti.Prompt = "/ "
+	ti.Blur()
+
+	s := &AgentTranscriptScreen{
+		Title:       title,
+		Blocks:      blocks,
+		Thm:         thm,
+		ShowIcons:   showIcons,
+		SearchInput: ti,
+		toggled:     make(map[int]bool),
+	}
+	s.Resize(maxWidth, maxHeight)
+	// Start on the latest exchange, like a chat.
+	s.Cursor = len(s.rows) - 1
+	s.ensureCursorVisible()
+	return s
+}
+
+// Type returns the screen type.
+func (s *AgentTranscriptScreen) Type() Type {
+	return TypeAgentTranscript
+}
+
+// Resize updates modal dimensions from terminal size, rewrapping the
+// transcript when the width changes.
+func (s *AgentTranscriptScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 110
+	s.Height = 32
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.9), 70, 200)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.9), 14, 60)
+	}
+	s.SearchInput.SetWidth(max(20, s.Width-10))
+	if width := s.Width - 9; width != s.wrapWidth {
+		s.rewrap(width)
+	}
+	s.ensureCursorVisible()
+}
+
+func (s *AgentTranscriptScreen) rewrap(width int) {
+	current := s.cursorRow()
+	s.wrapWidth = width
+	s.wrapped = make([][]string, len(s.Blocks))
+	s.plain = make([][]string, len(s.Blocks))
+	for i, block := range s.Blocks {
+		for _, line := range block.Lines {
+			for wrapped := range strings.SplitSeq(utils.WrapANSIContent(line, width), "\n") {
+				s.wrapped[i] = append(s.wrapped[i], wrapped)
+				s.plain[i] = append(s.plain[i], ansi.Strip(wrapped))
+			}
+		}
+	}
+	s.buildRows()
+	s.moveToRow(transcriptRow{block: current.block, line: -1})
+}
+
+// collapsed reports whether a block body is folded. Tool results are folded
+// by default.
+func (s *AgentTranscriptScreen) collapsed(block int) bool {
+	if v, ok := s.toggled[block]; ok {
+		return v
+	}
+	return s.Blocks[block].Kind == TranscriptToolResult
+}
+
+func (s *AgentTranscriptScreen) foldable(block int) bool {
+	switch s.Blocks[block].Kind {
+	case TranscriptThinking, TranscriptToolCall, TranscriptToolResult:
+		return len(s.wrapped[block]) > 0
+	}
+	return false
+}
+
+func (s *AgentTranscriptScreen) buildRows() {
+	s.rows = s.rows[:0]
+	for i, block := range s.Blocks {
+		if block.Kind == TranscriptThinking && !s.ShowThinking {
+			continue
+		}
+		s.rows = append(s.rows, transcriptRow{block: i, line: -1})
+		if s.foldable(i) && s.collapsed(i) {
+			continue
+		}
+		for j := range s.wrapped[i] {
+			s.rows = append(s.rows, transcriptRow{block: i, line: j})
+		}
+	}
+}
+
+func (s *AgentTranscriptScreen) cursorRow() transcriptRow {
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		return transcriptRow{line: -1}
+	}
+	return s.rows[s.Cursor]
+}
+
+// moveToRow puts the cursor on a row, or on the nearest visible row above it.
+func (s *AgentTranscriptScreen) moveToRow(target transcriptRow) {
+	s.Cursor = 0
+	for i, row := range s.rows {
+		if row.block > target.block || (row.block == target.block && row.line > target.line) {
+			break
+		}
+		s.Cursor = i
+	}
+	s.ensureCursorVisible()
+}
+
+// Update handles keyboard input.
+func (s *AgentTranscriptScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	key := msg.String()
+	if s.Searching {
+		switch key {
+		case keyEnter:
+			s.Searching = false
+			s.SearchInput.Blur()
+			s.SearchQuery = strings.TrimSpace(s.SearchInput.Value())
+			s.findMatch(1, true)
+			return s, nil
+		case keyEsc, keyEscRaw, keyCtrlC:
+			s.Searching = false
+			s.SearchInput.Blur()
+			return s, nil
+		}
+		var cmd tea.Cmd
+		s.SearchInput, cmd = s.SearchInput.Update(msg)
+		return s, cmd
+	}
+
+	switch key {
+	case keyEsc, keyEscRaw:
+		if s.SearchQuery != "" {
+			s.SearchQuery = ""
+			s.SearchInput.SetValue("")
+			return s, nil
+		}
+		return s.close()
+	case keyQ, keyCtrlC:
+		return s.close()
+	case "/":
+		s.Searching = true
+		s.SearchInput.Focus()
+		return s, textinput.Blink
+	case "n":
+		s.findMatch(1, false)
+	case "N":
+		s.findMatch(-1, false)
+	case "up", "k", keyCtrlK:
+		s.moveCursor(-1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(1)
+	case "ctrl+u", "pgup":
+		s.moveCursor(-s.listHeight() / 2)
+	case "ctrl+d", "pgdown":
+		s.moveCursor(s.listHeight() / 2)
+	case "g", "home":
+		s.moveCursor(-len(s.rows))
+	case "G", "end":
+		s.moveCursor(len(s.rows))
+	case "[":
+		s.jumpToPrompt(-1)
+	case "]":
+		s.jumpToPrompt(1)
+	case keyEnter, "space":
+		s.toggleBlock()
+	case "z":
+		s.toggleAllTools()
+	case "t":
+		current := s.cursorRow()
+		s.ShowThinking = !s.ShowThinking
+		s.buildRows()
+		s.moveToRow(current)
+	}
+	return s, nil
+}
+
+func (s *AgentTranscriptScreen) close() (Screen, tea.Cmd) {
+	if s.OnClose != nil {
+		return nil, s.OnClose()
+	}
+	return nil, nil
+}
+
+func (s *AgentTranscriptScreen) moveCursor(delta int) {
+	if len(s.rows) == 0 {
+		return
+	}
+	s.Cursor = max(0, min(s.Cursor+delta, len(s.rows)-1))
+	s.StatusMessage = ""
+	s.ensureCursorVisible()
+}
+
+// toggleBlock folds or unfolds the block under the cursor.
+func (s *AgentTranscriptScreen) toggleBlock() {
+	row := s.cursorRow()
+	if len(s.rows) == 0 || !s.foldable(row.block) {
+		return
+	}
+	s.toggled[row.block] = !s.collapsed(row.block)
+	s.buildRows()
+	s.moveToRow(transcriptRow{block: row.block, line: -1})
+}
+
+// toggleAllTools expands every tool call and result when one is folded, and
+// folds them all otherwise.
+func (s *AgentTranscriptScreen) toggleAllTools() {
+	fold := true
+	for i, block := range s.Blocks {
+		if (block.Kind == TranscriptToolCall || block.Kind == TranscriptToolResult) && s.foldable(i) && s.collapsed(i) {
+			fold = false
+			break
+		}
+	}
+	current := s.cursorRow()
+	for i, block := range s.Blocks {
+		if block.Kind == TranscriptToolCall || block.Kind == TranscriptToolResult {
+			s.toggled[i] = fold
+		}
+	}
+	s.buildRows()
+	s.moveToRow(transcriptRow{block: current.block, line: -1})
+}
+
+// jumpToPrompt moves to the next (delta 1) or previous (delta -1) prompt.
+func (s *AgentTranscriptScreen) jumpToPrompt(delta int) {
+	for i := s.Cursor + delta; i >= 0 && i < len(s.rows); i += delta {
+		row := s.rows[i]
+		if row.line < 0 && s.Blocks[row.block].Kind == TranscriptPrompt {
+			s.Cursor = i
+			s.StatusMessage = ""
+			s.ensureCursorVisible()
+			return
+		}
+	}
+}
+
+// findMatch moves to the next (delta 1) or previous (delta -1) line matching
+// the search query, unfolding its block and showing thinking when needed.
+func (s *AgentTranscriptScreen) findMatch(delta int, inclusive bool) {
+	if s.SearchQuery == "" || len(s.Blocks) == 0 {
+		return
+	}
+	query := strings.ToLower(s.SearchQuery)
+
+	var all []transcriptRow
+	for i := range s.Blocks {
+		all = append(all, transcriptRow{block: i, line: -1})
+		for j := range s.wrapped[i] {
+			all = append(all, transcriptRow{block: i, line: j})
+		}
+	}
+	start := 0
+	current := s.cursorRow()
+	for i, row := range all {
+		if row == current {
+			start = i
+			break
+		}
+	}
+	if !inclusive {
+		start += delta
+	}
+	for n := range len(all) {
+		row := all[((start+delta*n)%len(all)+len(all))%len(all)]
+		if !strings.Contains(strings.ToLower(s.rowText(row)), query) {
+			continue
+		}
+		if s.Blocks[row.block].Kind == TranscriptThinking {
+			s.ShowThinking = true
+		}
+		if row.line >= 0 {
+			s.toggled[row.block] = false
+		}
+		s.buildRows()
+		s.moveToRow(row)
+		return
+	}
+	s.StatusMessage = fmt.Sprintf("No match for %q", s.SearchQuery)
+}
+
+func (s *AgentTranscriptScreen) rowText(row transcriptRow) string {
+	if row.line < 0 {
+		return s.Blocks[row.block].Title
+	}
+	return s.plain[row.block][row.line]
+}
+
+// View renders the transcript viewer modal.
+func (s *AgentTranscriptScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	warnStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+	matchStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg).Bold(true)
+	headerStyles := map[TranscriptBlockKind]lipgloss.Style{
+		TranscriptPrompt:     lipgloss.NewStyle().Foreground(s.Thm.Accent).Bold(true),
+		TranscriptReply:      lipgloss.NewStyle().Foreground(s.Thm.SuccessFg).Bold(true),
+		TranscriptThinking:   mutedStyle.Italic(true),
+		TranscriptToolCall:   lipgloss.NewStyle().Foreground(s.Thm.Cyan),
+		TranscriptToolResult: mutedStyle,
+	}
+	errorStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+
+	query := strings.ToLower(s.SearchQuery)
+	listHeight := s.listHeight()
+	lines := make([]string, 0, listHeight)
+	end := min(len(s.rows), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		row := s.rows[i]
+		block := s.Blocks[row.block]
+
+		gutter := " "
+		if query != "" && strings.Contains(strings.ToLower(s.rowText(row)), query) {
+			gutter = matchStyle.Render("▌")
+		}
+
+		var text string
+		if row.line < 0 {
+			text = block.Title
+			if s.foldable(row.block) {
+				text = fmt.Sprintf("%s %s", disclosureIndicator(s.collapsed(row.block), s.ShowIcons), text)
+				if s.collapsed(row.block) {
+					text += fmt.Sprintf(" (%d lines)", len(s.wrapped[row.block]))
+				}
+			}
+			style := headerStyles[block.Kind]
+			if block.IsError {
+				style = errorStyle
+			}
+			text = style.Render(text)
+		} else {
+			text = "  " + s.wrapped[row.block][row.line]
+		}
+		text = ansi.Truncate(text, contentWidth-1, "…")
+
+		if i == s.Cursor {
+			lines = append(lines, gutter+selectedStyle.Width(contentWidth-1).Render(ansi.Strip(text)))
+			continue
+		}
+		lines = append(lines, gutter+text+"\x1b[0m")
+	}
+	if len(s.rows) == 0 {
+		lines = append(lines, mutedStyle.Render(" The transcript is empty."))
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	var prompts, tools, thinking int
+	for _, block := range s.Blocks {
+		switch block.Kind {
+		case TranscriptPrompt:
+			prompts++
+		case TranscriptToolCall:
+			tools++
+		case TranscriptThinking:
+			thinking++
+		}
+	}
+	info := fmt.Sprintf("%d prompts • %d tool calls", prompts, tools)
+	if thinking > 0 {
+		state := "hidden"
+		if s.ShowThinking {
+			state = "shown"
+		}
+		info += fmt.Sprintf(" • %d thinking blocks %s", thinking, state)
+	}
+	infoLine := mutedStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(info)
+
+	footer := "/ search • n/N next/prev • [/] prev/next prompt • Enter fold • z fold tools • t thinking • q close"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	switch {
+	case s.Searching:
+		footerLine = s.SearchInput.View()
+	case s.StatusMessage != "":
+		footerLine = warnStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), infoLine, footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+func (s *AgentTranscriptScreen) listHeight() int {
+	return max(3, s.Height-5)
+}
+
+func (s *AgentTranscriptScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = max(0, s.Cursor)
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+	s.ScrollOffset = max(0, min(s.ScrollOffset, len(s.rows)-listHeight))
+}
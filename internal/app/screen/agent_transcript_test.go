@@ -0,0 +1,86 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testTranscriptBlocks() []TranscriptBlock {
+	return []TranscriptBlock{
+		{Kind: TranscriptPrompt, Title: "You", Lines: []string{"  Run the tests"}},
+		{Kind: TranscriptThinking, Title: "Thinking", Lines: []string{"  The user wants a secret plan"}},
+		{Kind: TranscriptToolCall, Title: "Bash", Lines: []string{"{", `  "command": "go test ./..."`, "}"}},
+		{Kind: TranscriptToolResult, Title: "Bash error", Lines: []string{"--- FAIL: TestParser", "FAIL"}, IsError: true},
+		{Kind: TranscriptReply, Title: "Claude Code", Lines: []string{"  One test fails."}},
+	}
+}
+
+func TestAgentTranscriptScreenFolding(t *testing.T) {
+	s := NewAgentTranscriptScreen("Fix the parser", testTranscriptBlocks(), 120, 40, theme.Dracula(), false)
+
+	// Thinking is hidden and tool results are folded.
+	if len(s.rows) != 9 {
+		t.Fatalf("expected 9 visible rows, got %d", len(s.rows))
+	}
+	if row := s.cursorRow(); row.block != 4 || row.line != 0 {
+		t.Fatalf("expected the cursor on the latest reply, got %+v", row)
+	}
+	view := s.View()
+	if strings.Contains(view, "secret plan") || strings.Contains(view, "TestParser") {
+		t.Fatal("expected thinking and tool output to be hidden")
+	}
+	if !strings.Contains(view, "Bash error (2 lines)") {
+		t.Fatal("expected the folded result to show its size")
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	if len(s.rows) != 11 {
+		t.Fatalf("expected t to show thinking, got %d rows", len(s.rows))
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	s.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(s.rows) != 13 || s.cursorRow().block != 3 {
+		t.Fatalf("expected enter to unfold the result, got %d rows at %+v", len(s.rows), s.cursorRow())
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if len(s.rows) != 8 {
+		t.Fatalf("expected z to fold every tool block, got %d rows", len(s.rows))
+	}
+
+	s.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	if row := s.cursorRow(); row.block != 0 || row.line != -1 {
+		t.Fatalf("expected [ to jump to the prompt, got %+v", row)
+	}
+}
+
+func TestAgentTranscriptScreenSearchRevealsHiddenBlocks(t *testing.T) {
+	s := NewAgentTranscriptScreen("Fix the parser", testTranscriptBlocks(), 120, 40, theme.Dracula(), false)
+
+	s.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "secret" {
+		s.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !s.ShowThinking || s.cursorRow().block != 1 {
+		t.Fatalf("expected search to reveal the thinking block, got %+v", s.cursorRow())
+	}
+
+	s.SearchQuery = "testparser"
+	s.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if row := s.cursorRow(); row.block != 3 || row.line != 0 {
+		t.Fatalf("expected n to unfold the matching result, got %+v", row)
+	}
+
+	s.SearchQuery = "missing"
+	s.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if s.StatusMessage == "" {
+		t.Fatal("expected a status message when nothing matches")
+	}
+}
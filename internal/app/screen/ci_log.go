@@ -0,0 +1,620 @@
+package screen
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// githubLogTimestamp matches the timestamp GitHub Actions prefixes to every log line.
+var githubLogTimestamp = regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?Z ?`)
+
+type ciLogLineKind int
+
+const (
+	ciLogText ciLogLineKind = iota
+	ciLogHeader
+	ciLogCommand
+	ciLogWarning
+	ciLogError
+)
+
+type ciLogLine struct {
+	text  string // display text, ANSI sequences preserved
+	plain string // text without ANSI sequences, used for search
+	kind  ciLogLineKind
+	group int // innermost enclosing group, -1 at top level
+}
+
+type ciLogGroup struct {
+	title    string
+	parent   int
+	header   int // index of the header line
+	size     int // number of lines inside the group
+	hasError bool
+}
+
+// parseCILog splits a GitHub Actions or GitLab CI log into lines and
+// collapsible groups (`##[group]`/`::group::` markers and GitLab sections).
+func parseCILog(content string) ([]ciLogLine, []ciLogGroup) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil, nil
+	}
+
+	var (
+		lines  []ciLogLine
+		groups []ciLogGroup
+		stack  []int
+	)
+	current := func() int {
+		if len(stack) == 0 {
+			return -1
+		}
+		return stack[len(stack)-1]
+	}
+	openGroup := func(title string) {
+		groups = append(groups, ciLogGroup{title: title, parent: current(), header: len(lines)})
+		lines = append(lines, ciLogLine{text: title, plain: ansi.Strip(title), kind: ciLogHeader, group: current()})
+		stack = append(stack, len(groups)-1)
+	}
+	closeGroup := func() {
+		if len(stack) > 0 {
+			stack = stack[:len(stack)-1]
+		}
+	}
+	addLine := func(text string, kind ciLogLineKind) {
+		group := current()
+		lines = append(lines, ciLogLine{text: text, plain: ansi.Strip(text), kind: kind, group: group})
+		for g := group; g >= 0; g = groups[g].parent {
+			groups[g].size++
+			if kind == ciLogError {
+				groups[g].hasError = true
+			}
+		}
+	}
+
+	for raw := range strings.SplitSeq(content, "\n") {
+		raw = githubLogTimestamp.ReplaceAllString(raw, "")
+
+		// GitLab sections and progress output use carriage returns: keep
+		// the markers and the last visible segment.
+		var text, section string
+		for segment := range strings.SplitSeq(raw, "\r") {
+			segment = strings.TrimPrefix(segment, "\x1b[0K")
+			plain := ansi.Strip(segment)
+			switch {
+			case strings.HasPrefix(plain, "section_start:"):
+				fields := strings.SplitN(plain, ":", 3)
+				section = fields[len(fields)-1]
+				section, _, _ = strings.Cut(section, "[")
+			case strings.HasPrefix(plain, "section_end:"):
+				closeGroup()
+			case segment != "":
+				text = segment
+			}
+		}
+		if section != "" {
+			openGroup(cmp.Or(strings.TrimSpace(text), section))
+			continue
+		}
+
+		plain := ansi.Strip(text)
+		switch {
+		case strings.HasPrefix(plain, "##[group]"), strings.HasPrefix(plain, "::group::"):
+			openGroup(strings.TrimSpace(plain[len("##[group]"):]))
+		case strings.HasPrefix(plain, "##[endgroup]"), strings.HasPrefix(plain, "::endgroup::"):
+			closeGroup()
+		case strings.HasPrefix(plain, "##[error]"):
+			addLine("Error: "+plain[len("##[error]"):], ciLogError)
+		case strings.HasPrefix(plain, "##[warning]"):
+			addLine("Warning: "+plain[len("##[warning]"):], ciLogWarning)
+		case strings.HasPrefix(plain, "##[command]"):
+			addLine(plain[len("##[command]"):], ciLogCommand)
+		case strings.HasPrefix(plain, "ERROR: "):
+			addLine(text, ciLogError)
+		case text == "" && strings.Contains(raw, "section_end:"):
+			// Line holding only section markers.
+		default:
+			addLine(text, ciLogText)
+		}
+	}
+	return lines, groups
+}
+
+// CILogScreen shows a CI job log, following it while the job runs.
+type CILogScreen struct {
+	Title   string
+	Link    string
+	Running bool
+	// Follow keeps the cursor on the last line as new output arrives.
+	Follow       bool
+	Cursor       int
+	ScrollOffset int
+	Width        int
+	Height       int
+	Thm          *theme.Theme
+	ShowIcons    bool
+
+	SearchInput textinput.Model
+	Searching   bool
+	SearchQuery string
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnOpenURL func() tea.Cmd
+	OnClose   func() tea.Cmd
+
+	content string // raw log, to skip re-parsing unchanged polls
+	loaded  bool
+	lines   []ciLogLine
+	groups  []ciLogGroup
+	toggled map[int]bool // collapse state of groups toggled by the user
+	rows    []int        // indices of visible lines
+}
+
+// NewCILogScreen creates the CI log viewer modal.
+func NewCILogScreen(title, link string, maxWidth, maxHeight int, thm *theme.Theme, showIcons bool) *CILogScreen {
+	ti := textinput.New()
+	ti.Placeholder = "Search log..."
+	ti.CharLimit = 128
+	ti.Prompt = "/ "
+	ti.Blur()
+
+	s := &CILogScreen{
+		Title:       title,
+		Link:        link,
+		Thm:         thm,
+		ShowIcons:   showIcons,
+		SearchInput: ti,
+		toggled:     make(map[int]bool),
+	}
+	s.Resize(maxWidth, maxHeight)
+	return s
+}
+
+// Type returns the screen type.
+func (s *CILogScreen) Type() Type {
+	return TypeCILog
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *CILogScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 110
+	s.Height = 32
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.9), 70, 200)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.9), 14, 60)
+	}
+	s.SearchInput.SetWidth(max(20, s.Width-10))
+	s.ensureCursorVisible()
+}
+
+// SetContent replaces the log, keeping the collapse state of the groups and
+// the cursor position, or moving to the end when following. It reports
+// whether the log text changed.
+func (s *CILogScreen) SetContent(content string, running bool) bool {
+	s.Running = running
+	if s.loaded && content == s.content {
+		return false
+	}
+	cursorLine := s.cursorLine()
+	s.content, s.loaded = content, true
+	s.lines, s.groups = parseCILog(content)
+	s.buildRows()
+
+	if s.Follow {
+		s.Cursor = len(s.rows) - 1
+	} else {
+		s.moveToLine(cursorLine)
+	}
+	s.Cursor = max(0, min(s.Cursor, len(s.rows)-1))
+	s.ensureCursorVisible()
+	return true
+}
+
+// AppendContent replaces the log from byte offset on with content, as
+// returned by a fetch of the output printed since offset.
+func (s *CILogScreen) AppendContent(offset int, content string, running bool) bool {
+	if offset > 0 && offset <= len(s.content) {
+		content = s.content[:offset] + content
+	}
+	return s.SetContent(content, running)
+}
+
+// Size returns the length in bytes of the log shown, the offset from which
+// the next poll needs new output.
+func (s *CILogScreen) Size() int {
+	return len(s.content)
+}
+
+// collapsed reports whether a group is folded. Groups are folded by default,
+// except those with errors and the step still running.
+func (s *CILogScreen) collapsed(group int) bool {
+	if v, ok := s.toggled[group]; ok {
+		return v
+	}
+	if s.groups[group].hasError {
+		return false
+	}
+	return !s.Running || !s.isLastGroup(group)
+}
+
+func (s *CILogScreen) isLastGroup(group int) bool {
+	for g := len(s.groups) - 1; g >= 0; g = s.groups[g].parent {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// visible reports whether every group enclosing a line is expanded.
+func (s *CILogScreen) visible(line ciLogLine) bool {
+	for g := line.group; g >= 0; g = s.groups[g].parent {
+		if s.collapsed(g) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *CILogScreen) buildRows() {
+	s.rows = s.rows[:0]
+	for i, line := range s.lines {
+		if s.visible(line) {
+			s.rows = append(s.rows, i)
+		}
+	}
+}
+
+func (s *CILogScreen) cursorLine() int {
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		return -1
+	}
+	return s.rows[s.Cursor]
+}
+
+// moveToLine puts the cursor on a line, or on the nearest visible line above it.
+func (s *CILogScreen) moveToLine(line int) {
+	s.Cursor = 0
+	for i, idx := range s.rows {
+		if idx > line {
+			break
+		}
+		s.Cursor = i
+	}
+	s.ensureCursorVisible()
+}
+
+// revealLine expands the groups enclosing a line and moves the cursor to it.
+func (s *CILogScreen) revealLine(line int) {
+	for g := s.lines[line].group; g >= 0; g = s.groups[g].parent {
+		s.toggled[g] = false
+	}
+	s.buildRows()
+	s.moveToLine(line)
+}
+
+// ErrorCount returns the number of error lines in the log.
+func (s *CILogScreen) ErrorCount() int {
+	count := 0
+	for _, line := range s.lines {
+		if line.kind == ciLogError {
+			count++
+		}
+	}
+	return count
+}
+
+// Update handles keyboard input.
+func (s *CILogScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	key := msg.String()
+	if s.Searching {
+		switch key {
+		case keyEnter:
+			s.Searching = false
+			s.SearchInput.Blur()
+			s.SearchQuery = strings.TrimSpace(s.SearchInput.Value())
+			s.findMatch(1, true)
+			return s, nil
+		case keyEsc, keyEscRaw, keyCtrlC:
+			s.Searching = false
+			s.SearchInput.Blur()
+			return s, nil
+		}
+		var cmd tea.Cmd
+		s.SearchInput, cmd = s.SearchInput.Update(msg)
+		return s, cmd
+	}
+
+	switch key {
+	case keyEsc, keyEscRaw:
+		if s.SearchQuery != "" {
+			s.SearchQuery = ""
+			s.SearchInput.SetValue("")
+			return s, nil
+		}
+		return s.close()
+	case keyQ, keyCtrlC:
+		return s.close()
+	case "/":
+		s.Searching = true
+		s.SearchInput.Focus()
+		return s, textinput.Blink
+	case "n":
+		s.findMatch(1, false)
+	case "N":
+		s.findMatch(-1, false)
+	case "up", "k", keyCtrlK:
+		s.moveCursor(-1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(1)
+	case "ctrl+u", "pgup":
+		s.moveCursor(-s.listHeight() / 2)
+	case "ctrl+d", "pgdown":
+		s.moveCursor(s.listHeight() / 2)
+	case "g", "home":
+		s.moveCursor(-len(s.rows))
+	case "G", "end":
+		s.moveCursor(len(s.rows))
+		s.Follow = s.Running
+	case "f":
+		s.Follow = !s.Follow
+		if s.Follow {
+			s.moveCursor(len(s.rows))
+			s.Follow = true
+		}
+	case keyEnter, "space":
+		s.toggleGroup()
+	case "z":
+		s.toggleAllGroups()
+	case "e":
+		s.jumpToFirstError()
+	case "o":
+		if s.OnOpenURL != nil {
+			return s, s.OnOpenURL()
+		}
+	}
+	return s, nil
+}
+
+func (s *CILogScreen) close() (Screen, tea.Cmd) {
+	if s.OnClose != nil {
+		return nil, s.OnClose()
+	}
+	return nil, nil
+}
+
+func (s *CILogScreen) moveCursor(delta int) {
+	if len(s.rows) == 0 {
+		return
+	}
+	s.Cursor = max(0, min(s.Cursor+delta, len(s.rows)-1))
+	s.StatusMessage = ""
+	// Scrolling away from the end stops following.
+	s.Follow = s.Follow && s.Cursor == len(s.rows)-1
+	s.ensureCursorVisible()
+}
+
+// toggleGroup folds or unfolds the group under the cursor. On a line inside
+// a group, it folds the enclosing group.
+func (s *CILogScreen) toggleGroup() {
+	line := s.cursorLine()
+	if line < 0 {
+		return
+	}
+	group := s.lines[line].group
+	if s.lines[line].kind == ciLogHeader {
+		group = s.groupOfHeader(line)
+	}
+	if group < 0 {
+		return
+	}
+	s.toggled[group] = !s.collapsed(group)
+	s.buildRows()
+	s.moveToLine(s.groups[group].header)
+}
+
+func (s *CILogScreen) groupOfHeader(line int) int {
+	for g, group := range s.groups {
+		if group.header == line {
+			return g
+		}
+	}
+	return -1
+}
+
+// toggleAllGroups expands every group when one is folded, and folds them
+// all otherwise.
+func (s *CILogScreen) toggleAllGroups() {
+	fold := true
+	for g := range s.groups {
+		if s.collapsed(g) {
+			fold = false
+			break
+		}
+	}
+	line := s.cursorLine()
+	for g := range s.groups {
+		s.toggled[g] = fold
+	}
+	s.buildRows()
+	s.moveToLine(line)
+}
+
+func (s *CILogScreen) jumpToFirstError() {
+	for i, line := range s.lines {
+		if line.kind == ciLogError {
+			s.Follow = false
+			s.revealLine(i)
+			return
+		}
+	}
+	s.StatusMessage = "No errors in the log"
+}
+
+// findMatch moves to the next (delta 1) or previous (delta -1) line matching
+// the search query, starting from the cursor line itself when inclusive.
+func (s *CILogScreen) findMatch(delta int, inclusive bool) {
+	if s.SearchQuery == "" || len(s.lines) == 0 {
+		return
+	}
+	query := strings.ToLower(s.SearchQuery)
+	start := max(0, s.cursorLine())
+	if !inclusive {
+		start += delta
+	}
+	for n := range len(s.lines) {
+		i := ((start+delta*n)%len(s.lines) + len(s.lines)) % len(s.lines)
+		if strings.Contains(strings.ToLower(s.lines[i].plain), query) {
+			s.Follow = false
+			s.revealLine(i)
+			return
+		}
+	}
+	s.StatusMessage = fmt.Sprintf("No match for %q", s.SearchQuery)
+}
+
+// View renders the log viewer modal.
+func (s *CILogScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	headerStyle := lipgloss.NewStyle().Foreground(s.Thm.Accent).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	errorStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+	warnStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+	commandStyle := lipgloss.NewStyle().Foreground(s.Thm.Cyan)
+	matchStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg).Bold(true)
+
+	query := strings.ToLower(s.SearchQuery)
+	listHeight := s.listHeight()
+	lines := make([]string, 0, listHeight)
+	end := min(len(s.rows), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		line := s.lines[s.rows[i]]
+		indent := strings.Repeat("  ", s.depth(line))
+
+		gutter := " "
+		if query != "" && strings.Contains(strings.ToLower(line.plain), query) {
+			gutter = matchStyle.Render("▌")
+		}
+
+		var text string
+		switch line.kind {
+		case ciLogHeader:
+			group := s.groupOfHeader(s.rows[i])
+			text = fmt.Sprintf("%s %s", disclosureIndicator(s.collapsed(group), s.ShowIcons), line.plain)
+			if s.collapsed(group) {
+				text += mutedStyle.Render(fmt.Sprintf(" (%d lines)", s.groups[group].size))
+			}
+			text = headerStyle.Render(text)
+		case ciLogError:
+			text = errorStyle.Render(line.plain)
+		case ciLogWarning:
+			text = warnStyle.Render(line.plain)
+		case ciLogCommand:
+			text = commandStyle.Render(line.plain)
+		default:
+			text = line.text
+		}
+		text = ansi.Truncate(indent+text, contentWidth-1, "…")
+
+		if i == s.Cursor {
+			plainText := ansi.Strip(text)
+			lines = append(lines, gutter+selectedStyle.Width(contentWidth-1).Render(plainText))
+			continue
+		}
+		lines = append(lines, gutter+text+"\x1b[0m")
+	}
+	if len(s.rows) == 0 {
+		waiting := "The log is empty."
+		if s.Running {
+			waiting = "Waiting for the job to print output..."
+		}
+		lines = append(lines, mutedStyle.Render(" "+waiting))
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	state := "finished"
+	if s.Running {
+		state = "running"
+		if s.Follow {
+			state = "running, following"
+		}
+	}
+	info := fmt.Sprintf("%s • %d lines", state, len(s.lines))
+	if errors := s.ErrorCount(); errors > 0 {
+		info += fmt.Sprintf(" • %d errors", errors)
+	}
+	infoLine := mutedStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(info)
+
+	footer := "/ search • n/N next/prev • e first error • Enter fold • z fold all • f follow • o browser • q close"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	switch {
+	case s.Searching:
+		footerLine = s.SearchInput.View()
+	case s.StatusMessage != "":
+		footerLine = warnStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), infoLine, footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+func (s *CILogScreen) depth(line ciLogLine) int {
+	depth := 0
+	for g := line.group; g >= 0; g = s.groups[g].parent {
+		depth++
+	}
+	return depth
+}
+
+func (s *CILogScreen) listHeight() int {
+	return max(3, s.Height-5)
+}
+
+func (s *CILogScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = max(0, s.Cursor)
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+	s.ScrollOffset = max(0, min(s.ScrollOffset, len(s.rows)-listHeight))
+}
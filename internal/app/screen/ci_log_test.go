@@ -0,0 +1,189 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+const testGitHubLog = "\ufeff2024-05-01T10:00:00.0000000Z ##[group]Set up job\n" +
+	"2024-05-01T10:00:00.1000000Z Runner version 2.316\n" +
+	"2024-05-01T10:00:00.2000000Z ##[endgroup]\n" +
+	"2024-05-01T10:00:01.0000000Z ##[group]Run make test\n" +
+	"2024-05-01T10:00:01.1000000Z ##[command]make test\n" +
+	"2024-05-01T10:00:02.0000000Z \x1b[32mok\x1b[0m  pkg/a\n" +
+	"2024-05-01T10:00:03.0000000Z --- FAIL: TestB\n" +
+	"2024-05-01T10:00:03.1000000Z ##[endgroup]\n" +
+	"2024-05-01T10:00:04.0000000Z ##[error]Process completed with exit code 2.\n"
+
+func TestParseCILogGitHubGroups(t *testing.T) {
+	lines, groups := parseCILog(testGitHubLog)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if groups[0].title != "Set up job" || groups[0].size != 1 {
+		t.Fatalf("unexpected first group %+v", groups[0])
+	}
+	if groups[1].size != 3 {
+		t.Fatalf("expected 3 lines in the second group, got %d", groups[1].size)
+	}
+	last := lines[len(lines)-1]
+	if last.kind != ciLogError || last.group != -1 || last.plain != "Error: Process completed with exit code 2." {
+		t.Fatalf("unexpected error line %+v", last)
+	}
+	if !strings.Contains(lines[4].text, "\x1b[32m") {
+		t.Fatalf("expected ANSI colours to be preserved, got %q", lines[4].text)
+	}
+	if lines[4].plain != "ok  pkg/a" {
+		t.Fatalf("expected timestamp and ANSI to be stripped from plain text, got %q", lines[4].plain)
+	}
+}
+
+func TestParseCILogGitLabSections(t *testing.T) {
+	log := "\x1b[0KRunning with gitlab-runner 16.0\n" +
+		"section_start:1700000000:prepare_script[collapsed=true]\r\x1b[0K\x1b[0K\x1b[36;1mPreparing environment\x1b[0;m\n" +
+		"Running on runner-1\n" +
+		"section_end:1700000001:prepare_script\r\x1b[0Ksection_start:1700000001:step_script\r\x1b[0K\x1b[0K\x1b[36;1mExecuting step script\x1b[0;m\n" +
+		"Downloading 10%\rDownloading 100%\n" +
+		"section_end:1700000002:step_script\r\x1b[0K\n" +
+		"\x1b[31;1mERROR: Job failed: exit code 1\x1b[0;m\n"
+
+	lines, groups := parseCILog(log)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 sections, got %d", len(groups))
+	}
+	if !strings.Contains(ansi.Strip(groups[0].title), "Preparing environment") {
+		t.Fatalf("unexpected section title %q", groups[0].title)
+	}
+	if groups[1].parent != -1 {
+		t.Fatalf("expected the second section at top level, got parent %d", groups[1].parent)
+	}
+	var progress, failed bool
+	for _, line := range lines {
+		if line.plain == "Downloading 100%" {
+			progress = true
+		}
+		if line.kind == ciLogError && line.group == -1 {
+			failed = true
+		}
+	}
+	if !progress || !failed {
+		t.Fatalf("expected overwritten progress and top-level error, got %+v", lines)
+	}
+}
+
+func TestCILogScreenFoldingAndErrors(t *testing.T) {
+	s := NewCILogScreen("build", "", 120, 40, theme.Dracula(), false)
+	s.SetContent(testGitHubLog, false)
+
+	// Finished jobs fold every group, leaving the headers and the error.
+	if len(s.rows) != 3 {
+		t.Fatalf("expected 3 visible rows, got %d", len(s.rows))
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'e', Text: "e"})
+	if got := s.lines[s.cursorLine()]; got.kind != ciLogError {
+		t.Fatalf("expected e to jump to the error, got %+v", got)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(s.rows) != 4 {
+		t.Fatalf("expected enter to unfold the first group, got %d rows", len(s.rows))
+	}
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(s.rows) != 3 || s.cursorLine() != 0 {
+		t.Fatalf("expected enter inside a group to fold it, got %d rows at line %d", len(s.rows), s.cursorLine())
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if len(s.rows) != len(s.lines) {
+		t.Fatalf("expected z to unfold everything, got %d of %d rows", len(s.rows), len(s.lines))
+	}
+}
+
+func TestCILogScreenSearch(t *testing.T) {
+	s := NewCILogScreen("build", "", 120, 40, theme.Dracula(), false)
+	s.SetContent(testGitHubLog, false)
+
+	s.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range "fail" {
+		s.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if got := s.lines[s.cursorLine()].plain; got != "--- FAIL: TestB" {
+		t.Fatalf("expected search to reveal the matching line, got %q", got)
+	}
+	if !strings.Contains(s.View(), "--- FAIL: TestB") {
+		t.Fatal("expected the match to be rendered")
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if got := s.lines[s.cursorLine()].plain; got != "--- FAIL: TestB" {
+		t.Fatalf("expected n to wrap to the only match, got %q", got)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if s.SearchQuery != "" {
+		t.Fatal("expected esc to clear the search")
+	}
+	next, _ := s.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if next != nil {
+		t.Fatal("expected esc to close the viewer")
+	}
+}
+
+func TestCILogScreenFollowsRunningJob(t *testing.T) {
+	s := NewCILogScreen("build", "", 120, 40, theme.Dracula(), false)
+	s.Follow = true
+	s.SetContent("##[group]Run make\nstep 1\n", true)
+	if got := s.lines[s.cursorLine()].plain; got != "step 1" {
+		t.Fatalf("expected the running step to be unfolded and followed, got %q", got)
+	}
+
+	s.SetContent("##[group]Run make\nstep 1\nstep 2\n", true)
+	if got := s.lines[s.cursorLine()].plain; got != "step 2" {
+		t.Fatalf("expected the cursor to follow new output, got %q", got)
+	}
+
+	if s.SetContent("##[group]Run make\nstep 1\nstep 2\n", true) {
+		t.Fatal("expected an identical log to be reported as unchanged")
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if s.Follow {
+		t.Fatal("expected moving up to stop following")
+	}
+	s.SetContent("##[group]Run make\nstep 1\nstep 2\nstep 3\n", true)
+	if got := s.lines[s.cursorLine()].plain; got != "step 1" {
+		t.Fatalf("expected the cursor to stay in place, got %q", got)
+	}
+	if !strings.Contains(s.View(), "running") {
+		t.Fatal("expected the view to show the job is running")
+	}
+}
+
+func TestCILogScreenAppendContent(t *testing.T) {
+	s := NewCILogScreen("build", "", 120, 40, theme.Dracula(), false)
+	s.Follow = true
+	s.SetContent("step 1\n", true)
+
+	if !s.AppendContent(s.Size(), "step 2\n", true) {
+		t.Fatal("expected new output to be reported as a change")
+	}
+	if got := s.lines[s.cursorLine()].plain; got != "step 2" {
+		t.Fatalf("expected the appended output to be followed, got %q", got)
+	}
+	if s.AppendContent(s.Size(), "", true) {
+		t.Fatal("expected an empty poll to be reported as unchanged")
+	}
+	s.AppendContent(0, "restarted\n", true)
+	if s.Size() != len("restarted\n") {
+		t.Fatalf("expected a zero offset to replace the log, got %d bytes", s.Size())
+	}
+}
@@ -70,7 +70,7 @@ func NewHelpScreen(maxWidth, maxHeight int, customCommands config.CustomCommands
 - Enter: Open selected CI check URL in browser
 - PR number in the info panel is clickable in terminals that support OSC-8 hyperlinks
 - PR/MR author avatars can appear in the info panel on Kitty-compatible terminals when avatar_badges is enabled
-- Ctrl+v: View selected CI check logs in pager (when CI check is selected)
+- Ctrl+v: View selected CI check logs (when CI check is selected)
 
 **Notes Pane (pane 5, visible when worktree has a note)**
 - j / k: Scroll notes content
@@ -81,14 +81,17 @@ func NewHelpScreen(maxWidth, maxHeight int, customCommands config.CustomCommands
 - Tab reaches pane 5 before the final agent sessions pane when both are visible
 
 **Agent Sessions Pane (pane 6, visible when sessions exist)**
-- j / k: Move between matching Claude / Codex / Copilot / pi sessions
+- j / k: Move between matching Claude / Codex / Copilot / Gemini / OpenCode / Aider / pi sessions
 - Ctrl+D / Ctrl+U: Half page down / up
 - g / G: Jump to top / bottom
 - A: Toggle between active sessions only and all matching sessions
+- Enter: Focus the tmux pane or zellij session running the selected session (resumes it when no longer running)
+- R: Resume the selected session in a new tmux window, zellij tab, or terminal tab
+- v: View the full transcript (Enter folds tool blocks, t shows thinking, / searches, [ / ] jump between prompts)
 - 6: Focus agent sessions pane (or toggle zoom if already focused)
 - When no active session is open, pressing 6 reveals recent and historical matching sessions
 - Tab includes pane 6 at the end of the cycle when visible
-- Run lazyworktree setup-hooks to install Claude Code, Codex CLI, and Copilot CLI lifecycle hooks for precise, low-cost status tracking, including questions awaiting your input
+- Run lazyworktree setup-hooks to install Claude Code, Codex CLI, Copilot CLI, and Gemini CLI lifecycle hooks for precise, low-cost status tracking, including questions awaiting your input
 
 **{{HELP_CI_CHECKS}}Git Status Pane (when focused)**
 - j / k: Navigate files and directories
@@ -101,6 +104,7 @@ func NewHelpScreen(maxWidth, maxHeight int, customCommands config.CustomCommands
 - C: Commit changes using git editor
 - Ctrl+{{ARROW_LEFT}} / {{ARROW_RIGHT}}: Jump to previous / next folder
 - f: Filter files
+- A: Show only files changed by an agent (files an agent edited carry its marker letter)
 - /: Search file or directory names
 - Ctrl+D / Space: Half page down
 - Ctrl+U: Half page up
@@ -112,6 +116,7 @@ func NewHelpScreen(maxWidth, maxHeight int, customCommands config.CustomCommands
 - Enter: Open commit file tree (browse changed files)
 - d: Show full commit diff in pager
 - C: Cherry-pick commit to another worktree
+- i: Interactive rebase of the commits since the base branch (p pick, r reword, s squash, f fixup, d drop, J/K move, Enter start)
 - /: Search commit titles
 
 Commit Status Indicators:
@@ -155,8 +160,11 @@ Commit Status Indicators:
 - Uppercase note tags such as TODO, FIXME, or WARNING: are highlighted with icons outside fenced code blocks; lowercase tags are left unchanged
 - Worktree filter: use tag:<name> for exact tag matches
 - m: Rename selected worktree
-- D: Delete selected worktree
+- D: Delete selected worktree (locked worktrees are refused)
+- K: Lock or unlock selected worktree with an optional reason (locked worktrees show a lock badge and are skipped by prune)
 - A: Absorb worktree into main (merge or rebase based on configuration, then delete)
+- a: Start a coding agent in the selected worktree (prompt prefilled from notes or the linked issue)
+- t: Agent activity timeline across all worktrees (a/w/t filter by agent, worktree or type, Enter jumps to the worktree)
 - X: Prune merged worktrees and stale branches (auto-refreshes PR data; enable prune_stale_branches to include merged branches without worktrees)
 - !: Run arbitrary shell command in selected worktree
 
@@ -185,11 +193,14 @@ Supported: Letters (a-z, A-Z), numbers (0-9), and hyphens (-). See help for full
 - r: Refresh worktree list (also refreshes PR/MR/CI for current worktree on GitHub/GitLab)
 - R: Fetch all remotes
 - S: Synchronise with upstream (git pull, then git push, current branch only, requires a clean worktree, honours merge_method)
+- Z: Stash browser (every stash of the repository with its branch/worktree and diff; Enter/a apply, p pop, D drop into the selected worktree)
 - P: Push to upstream branch (current branch only, requires a clean worktree, prompts to set upstream when missing)
 - v: View CI checks (opens selection screen)
 - Enter: Open selected CI job in browser (within CI check selection screen)
-- Ctrl+v: View selected CI check logs in pager (within CI check selection screen, or in status pane when CI check is selected)
-- Ctrl+r: Restart selected CI job (GitHub Actions only, within CI check selection screen)
+- Ctrl+v: View selected CI check logs in the built-in log viewer, or in ci_script_pager for finished jobs when set (within CI check selection screen, or in status pane when CI check is selected)
+- CI log viewer: / search, n/N next/previous match, e first error, Enter fold section, z fold all, f follow, o open in browser
+- Ctrl+r: Restart selected CI job (GitHub Actions and GitLab CI, within CI check selection screen)
+- Ctrl+x: Cancel selected CI job (GitHub Actions and GitLab CI, within CI check selection screen)
 - s: Cycle sort (Path / Last Active / Last Switched)
 
 **{{HELP_BACKGROUND_REFRESH}}Background Refresh**
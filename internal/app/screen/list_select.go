@@ -45,6 +45,7 @@ type ListSelectionScreen struct {
 	// Special key handlers (for CI checks)
 	OnCtrlV func(SelectionItem) tea.Cmd // Ctrl+V handler (e.g., view logs)
 	OnCtrlR func(SelectionItem) tea.Cmd // Ctrl+R handler (e.g., restart)
+	OnCtrlX func(SelectionItem) tea.Cmd // Ctrl+X handler (e.g., cancel)
 	OnEnter func(SelectionItem) tea.Cmd // Enter handler (overrides OnSelect if set)
 
 	// Optional additional hint for footer (e.g., "Ctrl+r to restart")
@@ -152,6 +153,13 @@ func (s *ListSelectionScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
 				}
 			}
 			return s, nil
+		case "ctrl+x":
+			if s.OnCtrlX != nil {
+				if item, ok := s.Selected(); ok {
+					return s, s.OnCtrlX(item)
+				}
+			}
+			return s, nil
 		case "enter":
 			if s.OnEnter != nil {
 				if item, ok := s.Selected(); ok {
@@ -221,6 +229,13 @@ func (s *ListSelectionScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
 			}
 		}
 		return s, nil
+	case "ctrl+x":
+		if s.OnCtrlX != nil {
+			if item, ok := s.Selected(); ok {
+				return s, s.OnCtrlX(item)
+			}
+		}
+		return s, nil
 	case "enter":
 		if s.OnEnter != nil {
 			if item, ok := s.Selected(); ok {
@@ -45,6 +45,8 @@ const (
 	TipOperationPush TipOperation = "push"
 	// TipOperationRerun is used during CI rerun operations.
 	TipOperationRerun TipOperation = "rerun"
+	// TipOperationCancel is used during CI cancel operations.
+	TipOperationCancel TipOperation = "cancel"
 	// TipOperationCommand is used during command execution operations.
 	TipOperationCommand TipOperation = "command"
 )
@@ -75,7 +77,7 @@ var LoadingTips = []Tip{
 	{ID: "push", Text: "Use 'P' to push the current branch to its upstream; set upstream when prompted.", Category: TipCategoryRepo, Operations: []TipOperation{TipOperationGeneral, TipOperationPush}, Priority: 2, ShowInHelp: false},
 	{ID: "fetch", Text: "Press 'R' to fetch all remotes and refresh upstream tracking information.", Category: TipCategoryRepo, Operations: []TipOperation{TipOperationGeneral, TipOperationFetch}, Priority: 2, ShowInHelp: false},
 	{ID: "refresh", Text: "Press 'r' to refresh worktrees and, on GitHub/GitLab, refresh PR and CI data.", Category: TipCategoryRepo, Operations: []TipOperation{TipOperationGeneral, TipOperationRefresh}, Priority: 2, ShowInHelp: false},
-	{ID: "ci", Text: "Press 'v' to open CI checks, Enter to open a job, and Ctrl+v to view logs in the pager.", Category: TipCategoryRepo, Operations: []TipOperation{TipOperationGeneral, TipOperationRefresh, TipOperationRerun}, Priority: 2, ShowInHelp: true},
+	{ID: "ci", Text: "Press 'v' to open CI checks, Enter to open a job, and Ctrl+v to view logs in the pager.", Category: TipCategoryRepo, Operations: []TipOperation{TipOperationGeneral, TipOperationRefresh, TipOperationRerun, TipOperationCancel}, Priority: 2, ShowInHelp: true},
 	{ID: "status-jump", Text: "In the Status pane, use Ctrl+Left and Ctrl+Right to jump between folders.", Category: TipCategoryNavigation, Operations: []TipOperation{TipOperationGeneral, TipOperationRefresh}, Priority: 1, ShowInHelp: true},
 	{ID: "run", Text: "Press '!' to run a command in the selected worktree with command history support.", Category: TipCategoryTools, Operations: []TipOperation{TipOperationGeneral, TipOperationCommand}, Priority: 1, ShowInHelp: false},
 	{ID: "lazygit", Text: "Press 'g' to open LazyGit in the selected worktree.", Category: TipCategoryTools, Operations: []TipOperation{TipOperationGeneral}, Priority: 1, ShowInHelp: false},
@@ -93,6 +95,8 @@ func TipOperationFromContext(loadingOperation, message string) TipOperation {
 		return TipOperationSync
 	case string(TipOperationRerun):
 		return TipOperationRerun
+	case string(TipOperationCancel):
+		return TipOperationCancel
 	}
 
 	lower := strings.ToLower(message)
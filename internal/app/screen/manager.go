@@ -1,5 +1,7 @@
 package screen
 
+import "slices"
+
 // Manager handles screen state and provides a stack-based interface for modal overlays.
 type Manager struct {
 	current Screen
@@ -55,6 +57,18 @@ func (m *Manager) Type() Type {
 	return m.current.Type()
 }
 
+// Contains reports whether s is the current screen or anywhere below it in
+// the stack.
+func (m *Manager) Contains(s Screen) bool {
+	if s == nil {
+		return false
+	}
+	if m.current == s {
+		return true
+	}
+	return slices.Contains(m.stack, s)
+}
+
 // Clear removes all screens from the stack.
 func (m *Manager) Clear() {
 	m.current = nil
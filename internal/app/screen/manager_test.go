@@ -68,6 +68,27 @@ func TestManagerPushPop(t *testing.T) {
 	}
 }
 
+func TestManagerContains(t *testing.T) {
+	m := NewManager()
+	thm := theme.Dracula()
+	confirm := NewConfirmScreen("test", thm)
+	info := NewInfoScreen("info", thm)
+
+	m.Push(confirm)
+	m.Push(info)
+	if !m.Contains(confirm) || !m.Contains(info) {
+		t.Error("expected both stacked screens to be found")
+	}
+
+	m.Pop()
+	if m.Contains(info) {
+		t.Error("expected popped screen not to be found")
+	}
+	if m.Contains(nil) {
+		t.Error("expected nil never to be found")
+	}
+}
+
 func TestManagerClear(t *testing.T) {
 	m := NewManager()
 	thm := theme.Dracula()
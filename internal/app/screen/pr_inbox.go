@@ -0,0 +1,284 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// PRInboxSection is a titled group of PRs/MRs in the inbox.
+type PRInboxSection struct {
+	Title string
+	PRs   []*models.PRInfo
+}
+
+type prInboxRow struct {
+	section string
+	count   int
+	pr      *models.PRInfo
+}
+
+// PRInboxScreen lists the PRs/MRs waiting on the user, grouped by reason.
+type PRInboxScreen struct {
+	Sections     []PRInboxSection
+	Cursor       int
+	ScrollOffset int
+	Width        int
+	Height       int
+	Title        string
+	Thm          *theme.Theme
+
+	// AttachedBranches maps branch names to worktree names for branches already checked out
+	AttachedBranches map[string]string
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnSelect  func(*models.PRInfo) tea.Cmd
+	OnOpenURL func(*models.PRInfo) tea.Cmd
+	OnRefresh func() tea.Cmd
+	OnClose   func() tea.Cmd
+
+	rows []prInboxRow
+}
+
+// NewPRInboxScreen creates the PR/MR inbox modal.
+func NewPRInboxScreen(sections []PRInboxSection, attached map[string]string, title string, maxWidth, maxHeight int, thm *theme.Theme) *PRInboxScreen {
+	s := &PRInboxScreen{Title: title, Thm: thm, AttachedBranches: attached}
+	s.Resize(maxWidth, maxHeight)
+	s.SetSections(sections)
+	return s
+}
+
+// Type returns the screen type.
+func (s *PRInboxScreen) Type() Type {
+	return TypePRInbox
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *PRInboxScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 96
+	s.Height = 28
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.8), 64, 130)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.8), 14, 44)
+	}
+	s.ensureCursorVisible()
+}
+
+// SetSections replaces the inbox content, keeping the cursor on the same
+// PR/MR when it is still listed.
+func (s *PRInboxScreen) SetSections(sections []PRInboxSection) {
+	preferred := 0
+	if pr := s.Selected(); pr != nil {
+		preferred = pr.Number
+	}
+
+	s.Sections = sections
+	s.rows = s.rows[:0]
+	for _, section := range sections {
+		s.rows = append(s.rows, prInboxRow{section: section.Title, count: len(section.PRs)})
+		for _, pr := range section.PRs {
+			s.rows = append(s.rows, prInboxRow{pr: pr})
+		}
+	}
+
+	s.Cursor = -1
+	for i, row := range s.rows {
+		if row.pr != nil && row.pr.Number == preferred {
+			s.Cursor = i
+			break
+		}
+	}
+	if s.Cursor < 0 {
+		s.moveCursor(1)
+	}
+	s.ensureCursorVisible()
+}
+
+// Selected returns the PR/MR under the cursor, or nil.
+func (s *PRInboxScreen) Selected() *models.PRInfo {
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		return nil
+	}
+	return s.rows[s.Cursor].pr
+}
+
+// Update handles keyboard input.
+func (s *PRInboxScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "up", "k", keyCtrlK:
+		s.moveCursor(-1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(1)
+	case "g":
+		s.Cursor = -1
+		s.moveCursor(1)
+	case "G":
+		s.Cursor = len(s.rows)
+		s.moveCursor(-1)
+	case keyTab:
+		s.jumpSection(1)
+	case keyShiftTab:
+		s.jumpSection(-1)
+	case keyEnter:
+		if pr := s.Selected(); pr != nil && s.OnSelect != nil {
+			return s, s.OnSelect(pr)
+		}
+	case "o":
+		if pr := s.Selected(); pr != nil && s.OnOpenURL != nil {
+			return s, s.OnOpenURL(pr)
+		}
+	case "r":
+		if s.OnRefresh != nil {
+			s.StatusMessage = "Refreshing..."
+			return s, s.OnRefresh()
+		}
+	}
+	return s, nil
+}
+
+// View renders the inbox modal.
+func (s *PRInboxScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Background(s.Thm.AccentDim).
+		Bold(true)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	prStyle := lipgloss.NewStyle().Foreground(s.Thm.TextFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	worktreeStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	statusStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+
+	listHeight := s.listHeight()
+	lines := make([]string, 0, listHeight)
+	end := min(len(s.rows), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		row := s.rows[i]
+		if row.pr == nil {
+			header := fmt.Sprintf(" %s (%d)", row.section, row.count)
+			lines = append(lines, sectionStyle.Width(contentWidth).Render(ansi.Truncate(header, contentWidth, "")))
+			continue
+		}
+
+		worktree := s.AttachedBranches[row.pr.Branch]
+		marker := "  "
+		if worktree != "" {
+			marker = "● "
+		}
+		text := fmt.Sprintf("%s#%-5d %s", marker, row.pr.Number, row.pr.Title)
+		if row.pr.Author != "" {
+			text += " @" + row.pr.Author
+		}
+		suffix := "no worktree"
+		if worktree != "" {
+			suffix = worktree
+		}
+		available := max(1, contentWidth-ansi.StringWidth(suffix)-1)
+		text = ansi.Truncate(text, available, "…")
+		padding := strings.Repeat(" ", max(1, contentWidth-ansi.StringWidth(text)-ansi.StringWidth(suffix)))
+
+		switch {
+		case i == s.Cursor:
+			lines = append(lines, selectedStyle.Width(contentWidth).Render(text+padding+suffix))
+		case worktree != "":
+			lines = append(lines, prStyle.Render(text)+padding+worktreeStyle.Render(suffix))
+		default:
+			lines = append(lines, prStyle.Render(text)+padding+mutedStyle.Render(suffix))
+		}
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	footer := "Enter jump to/create worktree • o browser • r refresh • Tab next group • q close"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	if s.StatusMessage != "" {
+		footerLine = statusStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+func (s *PRInboxScreen) listHeight() int {
+	return max(3, s.Height-4)
+}
+
+func (s *PRInboxScreen) moveCursor(delta int) {
+	for i := s.Cursor + delta; i >= 0 && i < len(s.rows); i += delta {
+		if s.rows[i].pr != nil {
+			s.Cursor = i
+			s.StatusMessage = ""
+			s.ensureCursorVisible()
+			return
+		}
+	}
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		s.Cursor = -1
+	}
+}
+
+// jumpSection moves the cursor to the first PR/MR of the next or previous
+// non-empty group.
+func (s *PRInboxScreen) jumpSection(delta int) {
+	current := s.Cursor
+	for current > 0 && s.rows[current].pr != nil {
+		current--
+	}
+	for i := current + delta; i >= 0 && i < len(s.rows); i += delta {
+		if s.rows[i].pr == nil && s.rows[i].count > 0 {
+			s.Cursor = i
+			s.moveCursor(1)
+			return
+		}
+	}
+}
+
+func (s *PRInboxScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < 0 {
+		s.ScrollOffset = 0
+		return
+	}
+	// Keep the group header of the first visible PR/MR on screen.
+	if s.Cursor-1 < s.ScrollOffset {
+		s.ScrollOffset = max(0, s.Cursor-1)
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+}
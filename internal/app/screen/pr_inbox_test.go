@@ -0,0 +1,80 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testInboxSections() []PRInboxSection {
+	return []PRInboxSection{
+		{Title: "Review requested", PRs: []*models.PRInfo{
+			{Number: 1, Title: "Add parser", Branch: "parser", Author: "alice"},
+			{Number: 2, Title: "Fix typo", Branch: "typo", Author: "bob"},
+		}},
+		{Title: "Assigned to me"},
+		{Title: "My PRs with failing CI", PRs: []*models.PRInfo{
+			{Number: 3, Title: "Refactor", Branch: "refactor", Author: "me"},
+		}},
+	}
+}
+
+func TestPRInboxScreenNavigation(t *testing.T) {
+	s := NewPRInboxScreen(testInboxSections(), nil, "Inbox", 120, 40, theme.Dracula())
+	if s.Type() != TypePRInbox {
+		t.Fatalf("expected TypePRInbox, got %v", s.Type())
+	}
+	if got := s.Selected(); got == nil || got.Number != 1 {
+		t.Fatalf("expected first PR selected, got %+v", got)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if got := s.Selected(); got.Number != 3 {
+		t.Fatalf("expected tab to skip the empty group, got #%d", got.Number)
+	}
+	s.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	if got := s.Selected(); got.Number != 1 {
+		t.Fatalf("expected shift+tab to return to the first group, got #%d", got.Number)
+	}
+	s.Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	if got := s.Selected(); got.Number != 3 {
+		t.Fatalf("expected G to select the last PR, got #%d", got.Number)
+	}
+
+	s.SetSections(testInboxSections()[:1])
+	if got := s.Selected(); got.Number != 1 {
+		t.Fatalf("expected cursor reset when the PR disappears, got #%d", got.Number)
+	}
+}
+
+func TestPRInboxScreenSelect(t *testing.T) {
+	s := NewPRInboxScreen(testInboxSections(), nil, "Inbox", 120, 40, theme.Dracula())
+	var selected int
+	s.OnSelect = func(pr *models.PRInfo) tea.Cmd {
+		selected = pr.Number
+		return nil
+	}
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if selected != 2 {
+		t.Fatalf("expected PR #2 to be selected, got #%d", selected)
+	}
+
+	next, _ := s.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if next != nil {
+		t.Fatal("expected esc to close the screen")
+	}
+}
+
+func TestPRInboxScreenViewShowsWorktrees(t *testing.T) {
+	s := NewPRInboxScreen(testInboxSections(), map[string]string{"typo": "wt-typo"}, "Inbox", 120, 40, theme.Dracula())
+	view := s.View()
+	for _, want := range []string{"Review requested (2)", "Assigned to me (0)", "#2", "wt-typo", "no worktree", "@alice"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected view to contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// prReviewRow is a file header or a thread in the review threads list.
+type prReviewRow struct {
+	header string
+	thread *models.PRReviewThread
+}
+
+// PRReviewScreen lists the review threads of a PR/MR grouped by file, with
+// the comments of the selected thread shown below the list.
+type PRReviewScreen struct {
+	Threads      []*models.PRReviewThread
+	HideResolved bool
+	Cursor       int
+	ScrollOffset int
+	DetailOffset int
+	Width        int
+	Height       int
+	Title        string
+	Thm          *theme.Theme
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnOpenFile       func(*models.PRReviewThread) tea.Cmd
+	OnReply          func(*models.PRReviewThread) tea.Cmd
+	OnToggleResolved func(*models.PRReviewThread) tea.Cmd
+	OnOpenURL        func(*models.PRReviewThread) tea.Cmd
+	OnClose          func() tea.Cmd
+
+	rows []prReviewRow
+}
+
+// NewPRReviewScreen creates the review threads modal.
+func NewPRReviewScreen(threads []*models.PRReviewThread, title string, maxWidth, maxHeight int, thm *theme.Theme) *PRReviewScreen {
+	s := &PRReviewScreen{Title: title, Thm: thm}
+	s.Resize(maxWidth, maxHeight)
+	s.SetThreads(threads, "")
+	return s
+}
+
+// Type returns the screen type.
+func (s *PRReviewScreen) Type() Type {
+	return TypePRReview
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *PRReviewScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 96
+	s.Height = 30
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.85), 72, 140)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.85), 20, 50)
+	}
+	s.ensureCursorVisible()
+}
+
+// SetThreads replaces the threads, keeping the cursor on preferredID when it
+// is still listed.
+func (s *PRReviewScreen) SetThreads(threads []*models.PRReviewThread, preferredID string) {
+	s.Threads = threads
+	s.rebuildRows(preferredID)
+}
+
+// Selected returns the thread under the cursor, or nil.
+func (s *PRReviewScreen) Selected() *models.PRReviewThread {
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		return nil
+	}
+	return s.rows[s.Cursor].thread
+}
+
+// Update handles keyboard input.
+func (s *PRReviewScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "up", "k", keyCtrlK:
+		s.moveCursor(-1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(1)
+	case "g":
+		s.Cursor = -1
+		s.moveCursor(1)
+	case "G":
+		s.Cursor = len(s.rows)
+		s.moveCursor(-1)
+	case keyCtrlD:
+		s.DetailOffset += max(1, s.detailHeight()/2)
+	case keyCtrlU:
+		s.DetailOffset = max(0, s.DetailOffset-max(1, s.detailHeight()/2))
+	case "h":
+		s.HideResolved = !s.HideResolved
+		selected := s.Selected()
+		preferred := ""
+		if selected != nil {
+			preferred = selected.ID
+		}
+		s.rebuildRows(preferred)
+	case keyEnter, "e":
+		return s, s.call(s.OnOpenFile)
+	case "r":
+		return s, s.call(s.OnReply)
+	case "x":
+		return s, s.call(s.OnToggleResolved)
+	case "o":
+		return s, s.call(s.OnOpenURL)
+	}
+	return s, nil
+}
+
+func (s *PRReviewScreen) call(handler func(*models.PRReviewThread) tea.Cmd) tea.Cmd {
+	thread := s.Selected()
+	if thread == nil || handler == nil {
+		return nil
+	}
+	return handler(thread)
+}
+
+// View renders the review threads modal.
+func (s *PRReviewScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Background(s.Thm.AccentDim).
+		Bold(true)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	openStyle := lipgloss.NewStyle().Foreground(s.Thm.TextFg)
+	resolvedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	authorStyle := lipgloss.NewStyle().Foreground(s.Thm.Cyan).Bold(true)
+	separatorStyle := lipgloss.NewStyle().Foreground(s.Thm.BorderDim)
+	statusStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+
+	listHeight := s.listHeight()
+	lines := make([]string, 0, s.Height)
+	if len(s.rows) == 0 {
+		message := "No review threads."
+		if s.HideResolved && len(s.Threads) > 0 {
+			message = "No unresolved review threads. Press h to show resolved threads."
+		}
+		lines = append(lines, mutedStyle.Render(message))
+	}
+	end := min(len(s.rows), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		row := s.rows[i]
+		if row.thread == nil {
+			lines = append(lines, sectionStyle.Width(contentWidth).Render(ansi.Truncate(" "+row.header, contentWidth, "")))
+			continue
+		}
+		line := ansi.Truncate(threadSummary(row.thread, i == s.Cursor), contentWidth, "…")
+		switch {
+		case i == s.Cursor:
+			line = selectedStyle.Width(contentWidth).Render(line)
+		case row.thread.IsResolved:
+			line = resolvedStyle.Render(line)
+		default:
+			line = openStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, separatorStyle.Render(strings.Repeat("─", contentWidth)))
+
+	var detail []string
+	if thread := s.Selected(); thread != nil {
+		for i, comment := range thread.Comments {
+			if i > 0 {
+				detail = append(detail, "")
+			}
+			author := comment.Author
+			if author == "" {
+				author = "ghost"
+			}
+			heading := authorStyle.Render(author)
+			if !comment.CreatedAt.IsZero() {
+				heading += mutedStyle.Render(" · " + comment.CreatedAt.Local().Format("2006-01-02 15:04"))
+			}
+			detail = append(detail, heading)
+			body := strings.TrimSpace(strings.ReplaceAll(comment.Body, "\r\n", "\n"))
+			detail = append(detail, strings.Split(utils.WrapANSIContent(body, contentWidth), "\n")...)
+		}
+	}
+	detailHeight := s.detailHeight()
+	s.DetailOffset = min(s.DetailOffset, max(0, len(detail)-detailHeight))
+	detail = detail[s.DetailOffset:]
+	if len(detail) > detailHeight {
+		detail = detail[:detailHeight]
+	}
+	lines = append(lines, detail...)
+	for len(lines) < listHeight+1+detailHeight {
+		lines = append(lines, "")
+	}
+
+	footer := "Enter/e open in editor • r reply • x resolve/unresolve • o browser • h hide resolved • Ctrl+D/U scroll • q close"
+	if s.HideResolved {
+		footer = strings.Replace(footer, "h hide resolved", "h show resolved", 1)
+	}
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	if s.StatusMessage != "" {
+		footerLine = statusStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+// threadSummary renders the list row of a thread.
+func threadSummary(thread *models.PRReviewThread, isCursor bool) string {
+	pointer := " "
+	if isCursor {
+		pointer = ">"
+	}
+	state := "●"
+	if thread.IsResolved {
+		state = "✓"
+	}
+	location := "file"
+	if thread.Line > 0 {
+		location = fmt.Sprintf("L%d", thread.Line)
+	}
+
+	summary := ""
+	if len(thread.Comments) > 0 {
+		first := thread.Comments[0]
+		text, _, _ := strings.Cut(strings.TrimSpace(first.Body), "\n")
+		summary = text
+		if first.Author != "" {
+			summary = first.Author + ": " + text
+		}
+		if replies := len(thread.Comments) - 1; replies > 0 {
+			summary += fmt.Sprintf(" (+%d)", replies)
+		}
+	}
+	if thread.IsOutdated {
+		summary += " [outdated]"
+	}
+	return fmt.Sprintf("%s %s %-6s %s", pointer, state, location, summary)
+}
+
+func (s *PRReviewScreen) listHeight() int {
+	return max(3, (s.Height-4)*2/5)
+}
+
+func (s *PRReviewScreen) detailHeight() int {
+	return max(3, s.Height-4-s.listHeight()-1)
+}
+
+func (s *PRReviewScreen) rebuildRows(preferredID string) {
+	s.rows = s.rows[:0]
+	lastPath := ""
+	for _, thread := range s.Threads {
+		if s.HideResolved && thread.IsResolved {
+			continue
+		}
+		if len(s.rows) == 0 || thread.Path != lastPath {
+			s.rows = append(s.rows, prReviewRow{header: thread.Path})
+			lastPath = thread.Path
+		}
+		s.rows = append(s.rows, prReviewRow{thread: thread})
+	}
+
+	s.Cursor = -1
+	for i, row := range s.rows {
+		if row.thread != nil && row.thread.ID == preferredID {
+			s.Cursor = i
+			break
+		}
+	}
+	if s.Cursor < 0 {
+		s.moveCursor(1)
+	}
+	s.ensureCursorVisible()
+}
+
+func (s *PRReviewScreen) moveCursor(delta int) {
+	for i := s.Cursor + delta; i >= 0 && i < len(s.rows); i += delta {
+		if s.rows[i].thread != nil {
+			s.Cursor = i
+			s.DetailOffset = 0
+			s.StatusMessage = ""
+			s.ensureCursorVisible()
+			return
+		}
+	}
+	if s.Cursor < 0 || s.Cursor >= len(s.rows) {
+		s.Cursor = -1
+	}
+}
+
+func (s *PRReviewScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < 0 {
+		s.ScrollOffset = 0
+		return
+	}
+	// Keep the file header of the first visible thread on screen.
+	if s.Cursor-1 < s.ScrollOffset {
+		s.ScrollOffset = max(0, s.Cursor-1)
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+}
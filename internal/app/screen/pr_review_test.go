@@ -0,0 +1,113 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testReviewThreads() []*models.PRReviewThread {
+	return []*models.PRReviewThread{
+		{ID: "t1", Path: "main.go", Line: 10, Comments: []models.PRReviewComment{
+			{Author: "alice", Body: "Handle the error here"},
+			{Author: "bob", Body: "Done"},
+		}},
+		{ID: "t2", Path: "main.go", Line: 42, IsResolved: true, Comments: []models.PRReviewComment{
+			{Author: "alice", Body: "Typo"},
+		}},
+		{ID: "t3", Path: "util.go", Line: 3, IsOutdated: true, Comments: []models.PRReviewComment{
+			{Author: "carol", Body: "Rename this"},
+		}},
+	}
+}
+
+func TestPRReviewScreenNavigationSkipsHeaders(t *testing.T) {
+	s := NewPRReviewScreen(testReviewThreads(), "Review threads", 120, 40, theme.Dracula())
+	if s.Type() != TypePRReview {
+		t.Fatalf("expected TypePRReview, got %v", s.Type())
+	}
+	if got := s.Selected(); got == nil || got.ID != "t1" {
+		t.Fatalf("expected first thread selected, got %+v", got)
+	}
+
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if got := s.Selected(); got == nil || got.ID != "t3" {
+		t.Fatalf("expected cursor to skip the util.go header, got %+v", got)
+	}
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if got := s.Selected(); got.ID != "t3" {
+		t.Fatalf("expected cursor to stay on the last thread, got %s", got.ID)
+	}
+	s.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	if got := s.Selected(); got.ID != "t1" {
+		t.Fatalf("expected g to jump to the first thread, got %s", got.ID)
+	}
+}
+
+func TestPRReviewScreenHideResolved(t *testing.T) {
+	s := NewPRReviewScreen(testReviewThreads(), "Review threads", 120, 40, theme.Dracula())
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	s.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if !s.HideResolved {
+		t.Fatal("expected resolved threads to be hidden")
+	}
+	if got := s.Selected(); got == nil || got.ID != "t1" {
+		t.Fatalf("expected cursor to move off the hidden thread, got %+v", got)
+	}
+	if strings.Contains(s.View(), "Typo") {
+		t.Fatal("expected resolved thread to be hidden from the view")
+	}
+
+	s.SetThreads(testReviewThreads(), "t3")
+	if got := s.Selected(); got == nil || got.ID != "t3" {
+		t.Fatalf("expected preferred thread to stay selected, got %+v", got)
+	}
+}
+
+func TestPRReviewScreenCallbacks(t *testing.T) {
+	s := NewPRReviewScreen(testReviewThreads(), "Review threads", 120, 40, theme.Dracula())
+	var opened, replied, toggled string
+	s.OnOpenFile = func(thread *models.PRReviewThread) tea.Cmd {
+		opened = thread.ID
+		return nil
+	}
+	s.OnReply = func(thread *models.PRReviewThread) tea.Cmd {
+		replied = thread.ID
+		return nil
+	}
+	s.OnToggleResolved = func(thread *models.PRReviewThread) tea.Cmd {
+		toggled = thread.ID
+		return nil
+	}
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	s.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	s.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	if opened != "t1" || replied != "t1" || toggled != "t1" {
+		t.Fatalf("unexpected callback targets: open=%q reply=%q toggle=%q", opened, replied, toggled)
+	}
+
+	next, _ := s.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if next != nil {
+		t.Fatal("expected q to close the screen")
+	}
+}
+
+func TestPRReviewScreenView(t *testing.T) {
+	s := NewPRReviewScreen(testReviewThreads(), "Review threads", 120, 40, theme.Dracula())
+	view := s.View()
+	for _, want := range []string{"Review threads", "main.go", "util.go", "L10", "alice: Handle the error here (+1)", "[outdated]", "bob"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected view to contain %q", want)
+		}
+	}
+
+	empty := NewPRReviewScreen(nil, "Review threads", 120, 40, theme.Dracula())
+	if !strings.Contains(empty.View(), "No review threads.") {
+		t.Fatal("expected empty state message")
+	}
+}
@@ -0,0 +1,317 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// RebaseScreen edits the todo list of an interactive rebase: commits are
+// listed oldest first, as git applies them, and can be reordered, squashed,
+// fixed up, reworded or dropped before the rebase starts.
+type RebaseScreen struct {
+	Commits      []*models.RebaseCommit
+	Cursor       int
+	ScrollOffset int
+	Width        int
+	Height       int
+	Title        string
+	Thm          *theme.Theme
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	// OnReword asks for the new message of a commit; the caller sets it with
+	// SetMessage.
+	OnReword func(*models.RebaseCommit) tea.Cmd
+	OnStart  func([]*models.RebaseCommit) tea.Cmd
+	OnClose  func() tea.Cmd
+
+	originalOrder    []string
+	originalMessages map[string]string
+}
+
+// NewRebaseScreen creates the interactive rebase modal.
+func NewRebaseScreen(commits []*models.RebaseCommit, title string, maxWidth, maxHeight int, thm *theme.Theme) *RebaseScreen {
+	s := &RebaseScreen{
+		Commits:          commits,
+		Title:            title,
+		Thm:              thm,
+		originalMessages: make(map[string]string, len(commits)),
+	}
+	for _, commit := range commits {
+		if commit.Action == "" {
+			commit.Action = models.RebaseActionPick
+		}
+		s.originalOrder = append(s.originalOrder, commit.Hash)
+		s.originalMessages[commit.Hash] = commit.Message
+	}
+	s.Resize(maxWidth, maxHeight)
+	return s
+}
+
+// Type returns the screen type.
+func (s *RebaseScreen) Type() Type {
+	return TypeRebase
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *RebaseScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 96
+	s.Height = 30
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.85), 72, 140)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.85), 20, 50)
+	}
+	s.ensureCursorVisible()
+}
+
+// Selected returns the commit under the cursor, or nil.
+func (s *RebaseScreen) Selected() *models.RebaseCommit {
+	if s.Cursor < 0 || s.Cursor >= len(s.Commits) {
+		return nil
+	}
+	return s.Commits[s.Cursor]
+}
+
+// SetMessage rewords a commit with a new message.
+func (s *RebaseScreen) SetMessage(commit *models.RebaseCommit, message string) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		s.StatusMessage = "The commit message cannot be empty."
+		return
+	}
+	commit.Message = message
+	commit.Subject, _, _ = strings.Cut(message, "\n")
+	commit.Action = models.RebaseActionReword
+	s.StatusMessage = ""
+}
+
+// Changed reports whether the todo list differs from a plain replay of the
+// commits.
+func (s *RebaseScreen) Changed() bool {
+	for i, commit := range s.Commits {
+		if commit.Action != models.RebaseActionPick || commit.Hash != s.originalOrder[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Update handles keyboard input.
+func (s *RebaseScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "up", "k", keyCtrlK:
+		s.moveCursor(s.Cursor - 1)
+	case "down", "j", keyCtrlJ:
+		s.moveCursor(s.Cursor + 1)
+	case "g":
+		s.moveCursor(0)
+	case "G":
+		s.moveCursor(len(s.Commits) - 1)
+	case "K", "shift+up":
+		s.moveCommit(-1)
+	case "J", "shift+down":
+		s.moveCommit(1)
+	case "p":
+		s.setAction(models.RebaseActionPick)
+	case "s":
+		s.setAction(models.RebaseActionSquash)
+	case "f":
+		s.setAction(models.RebaseActionFixup)
+	case "d":
+		s.setAction(models.RebaseActionDrop)
+	case "r":
+		if commit := s.Selected(); commit != nil && s.OnReword != nil {
+			return s, s.OnReword(commit)
+		}
+	case keyEnter:
+		if !s.Changed() {
+			s.StatusMessage = "Nothing to change: mark or move commits first."
+			return s, nil
+		}
+		if s.OnStart != nil {
+			return s, s.OnStart(s.Commits)
+		}
+	}
+	return s, nil
+}
+
+func (s *RebaseScreen) setAction(action string) {
+	commit := s.Selected()
+	if commit == nil {
+		return
+	}
+	if (action == models.RebaseActionSquash || action == models.RebaseActionFixup) && !s.hasPickBefore(s.Cursor) {
+		s.StatusMessage = fmt.Sprintf("Cannot %s the first commit: there is nothing to combine it with.", action)
+		return
+	}
+	if commit.Action == models.RebaseActionReword && action != models.RebaseActionReword {
+		commit.Message = s.originalMessages[commit.Hash]
+		commit.Subject, _, _ = strings.Cut(commit.Message, "\n")
+	}
+	commit.Action = action
+	s.StatusMessage = ""
+}
+
+// hasPickBefore reports whether a commit kept by the rebase precedes index,
+// which squash and fixup need to meld into.
+func (s *RebaseScreen) hasPickBefore(index int) bool {
+	for _, commit := range s.Commits[:index] {
+		if commit.Action != models.RebaseActionDrop {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *RebaseScreen) moveCommit(delta int) {
+	target := s.Cursor + delta
+	if s.Selected() == nil || target < 0 || target >= len(s.Commits) {
+		return
+	}
+	s.Commits[s.Cursor], s.Commits[target] = s.Commits[target], s.Commits[s.Cursor]
+	s.moveCursor(target)
+}
+
+// View renders the interactive rebase modal.
+func (s *RebaseScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	separatorStyle := lipgloss.NewStyle().Foreground(s.Thm.BorderDim)
+	statusStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+	actionStyles := map[string]lipgloss.Style{
+		models.RebaseActionPick:   lipgloss.NewStyle().Foreground(s.Thm.TextFg),
+		models.RebaseActionReword: lipgloss.NewStyle().Foreground(s.Thm.Cyan),
+		models.RebaseActionSquash: lipgloss.NewStyle().Foreground(s.Thm.WarnFg),
+		models.RebaseActionFixup:  lipgloss.NewStyle().Foreground(s.Thm.WarnFg),
+		models.RebaseActionDrop:   lipgloss.NewStyle().Foreground(s.Thm.MutedFg).Strikethrough(true),
+	}
+
+	listHeight := s.listHeight()
+	lines := make([]string, 0, s.Height)
+	if len(s.Commits) == 0 {
+		lines = append(lines, mutedStyle.Render("No commits to rebase."))
+	}
+	end := min(len(s.Commits), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		commit := s.Commits[i]
+		text := ansi.Truncate(rebaseCommitSummary(commit, i == s.Cursor), contentWidth, "…")
+		if i == s.Cursor {
+			lines = append(lines, selectedStyle.Width(contentWidth).Render(text))
+			continue
+		}
+		lines = append(lines, actionStyles[commit.Action].Render(text))
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, separatorStyle.Render(strings.Repeat("─", contentWidth)))
+
+	var detail []string
+	if commit := s.Selected(); commit != nil {
+		heading := fmt.Sprintf("%s by %s", commit.ShortHash(), commit.Author)
+		switch commit.Action {
+		case models.RebaseActionReword:
+			heading += " · new message"
+		case models.RebaseActionSquash:
+			heading += " · melded into the commit above, messages combined"
+		case models.RebaseActionFixup:
+			heading += " · melded into the commit above, message discarded"
+		case models.RebaseActionDrop:
+			heading += " · removed from the branch"
+		}
+		detail = append(detail, mutedStyle.Render(heading), "")
+		detail = append(detail, strings.Split(utils.WrapANSIContent(commit.Message, contentWidth), "\n")...)
+	}
+	detailHeight := s.detailHeight()
+	if len(detail) > detailHeight {
+		detail = detail[:detailHeight]
+	}
+	lines = append(lines, detail...)
+	for len(lines) < listHeight+1+detailHeight {
+		lines = append(lines, "")
+	}
+
+	footer := "p pick • r reword • s squash • f fixup • d drop • J/K move • Enter start rebase • q cancel"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	if s.StatusMessage != "" {
+		footerLine = statusStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+// rebaseCommitSummary renders the todo line of a commit.
+func rebaseCommitSummary(commit *models.RebaseCommit, isCursor bool) string {
+	pointer := " "
+	if isCursor {
+		pointer = ">"
+	}
+	subject := commit.Subject
+	if commit.Action == models.RebaseActionSquash || commit.Action == models.RebaseActionFixup {
+		subject = "↑ " + subject
+	}
+	return fmt.Sprintf("%s %-6s %s %s", pointer, commit.Action, commit.ShortHash(), subject)
+}
+
+func (s *RebaseScreen) listHeight() int {
+	return max(3, (s.Height-4)*3/5)
+}
+
+func (s *RebaseScreen) detailHeight() int {
+	return max(3, s.Height-4-s.listHeight()-1)
+}
+
+func (s *RebaseScreen) moveCursor(cursor int) {
+	s.Cursor = clampInt(cursor, 0, max(0, len(s.Commits)-1))
+	s.StatusMessage = ""
+	s.ensureCursorVisible()
+}
+
+func (s *RebaseScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = s.Cursor
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+	s.ScrollOffset = max(0, s.ScrollOffset)
+}
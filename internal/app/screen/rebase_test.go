@@ -0,0 +1,123 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testRebaseCommits() []*models.RebaseCommit {
+	return []*models.RebaseCommit{
+		{Hash: "aaaaaaaaaa", Author: "alice", Subject: "first", Message: "first\n\nbody"},
+		{Hash: "bbbbbbbbbb", Author: "bob", Subject: "second", Message: "second"},
+		{Hash: "cccccccccc", Author: "carol", Subject: "third", Message: "third"},
+	}
+}
+
+func rebaseKey(s *RebaseScreen, key string) tea.Cmd {
+	_, cmd := s.Update(tea.KeyPressMsg{Code: rune(key[0]), Text: key})
+	return cmd
+}
+
+func TestRebaseScreenReordersAndMarksCommits(t *testing.T) {
+	s := NewRebaseScreen(testRebaseCommits(), "Rebase", 120, 40, theme.Dracula())
+	if s.Type() != TypeRebase {
+		t.Fatalf("expected TypeRebase, got %v", s.Type())
+	}
+	if s.Changed() {
+		t.Fatal("expected an untouched todo list")
+	}
+
+	rebaseKey(s, "G")
+	rebaseKey(s, "K")
+	if got := s.Selected(); got.Hash != "cccccccccc" || s.Cursor != 1 {
+		t.Fatalf("expected the moved commit to stay selected at 1, got %s at %d", got.Hash, s.Cursor)
+	}
+	rebaseKey(s, "f")
+	rebaseKey(s, "j")
+	rebaseKey(s, "d")
+
+	var actions []string
+	for _, commit := range s.Commits {
+		actions = append(actions, commit.Action+" "+commit.Subject)
+	}
+	want := "pick first,fixup third,drop second"
+	if got := strings.Join(actions, ","); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if !s.Changed() {
+		t.Fatal("expected the todo list to be changed")
+	}
+}
+
+func TestRebaseScreenRefusesSquashingFirstCommit(t *testing.T) {
+	s := NewRebaseScreen(testRebaseCommits(), "Rebase", 120, 40, theme.Dracula())
+	rebaseKey(s, "s")
+	if s.Commits[0].Action != models.RebaseActionPick {
+		t.Fatalf("expected the first commit to stay picked, got %s", s.Commits[0].Action)
+	}
+	if !strings.Contains(s.StatusMessage, "Cannot squash") {
+		t.Fatalf("unexpected status %q", s.StatusMessage)
+	}
+
+	rebaseKey(s, "d")
+	rebaseKey(s, "j")
+	rebaseKey(s, "f")
+	if s.Commits[1].Action != models.RebaseActionPick {
+		t.Fatal("expected fixup to be refused after a dropped first commit")
+	}
+}
+
+func TestRebaseScreenRewordRestoresMessage(t *testing.T) {
+	s := NewRebaseScreen(testRebaseCommits(), "Rebase", 120, 40, theme.Dracula())
+	var reworded *models.RebaseCommit
+	s.OnReword = func(commit *models.RebaseCommit) tea.Cmd {
+		reworded = commit
+		return nil
+	}
+
+	rebaseKey(s, "r")
+	if reworded == nil || reworded.Hash != "aaaaaaaaaa" {
+		t.Fatalf("expected reword of the first commit, got %+v", reworded)
+	}
+	s.SetMessage(reworded, "  ")
+	if reworded.Action != models.RebaseActionPick {
+		t.Fatal("expected an empty message to be refused")
+	}
+	s.SetMessage(reworded, "better\n\nnew body")
+	if reworded.Action != models.RebaseActionReword || reworded.Subject != "better" {
+		t.Fatalf("unexpected reword %+v", reworded)
+	}
+	if !strings.Contains(s.View(), "new body") {
+		t.Fatal("expected the new message in the preview")
+	}
+
+	rebaseKey(s, "p")
+	if reworded.Message != "first\n\nbody" || reworded.Subject != "first" {
+		t.Fatalf("expected the original message back, got %q", reworded.Message)
+	}
+}
+
+func TestRebaseScreenStart(t *testing.T) {
+	s := NewRebaseScreen(testRebaseCommits(), "Rebase", 120, 40, theme.Dracula())
+	var started []*models.RebaseCommit
+	s.OnStart = func(commits []*models.RebaseCommit) tea.Cmd {
+		started = commits
+		return nil
+	}
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if started != nil || !strings.Contains(s.StatusMessage, "Nothing to change") {
+		t.Fatal("expected an unchanged todo list not to start a rebase")
+	}
+
+	rebaseKey(s, "j")
+	rebaseKey(s, "s")
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(started) != 3 || started[1].Action != models.RebaseActionSquash {
+		t.Fatalf("expected the rebase to start with the todo list, got %+v", started)
+	}
+}
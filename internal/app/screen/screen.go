@@ -43,6 +43,13 @@ const (
 	TypeTagEditor
 	TypeTaskboard
 	TypeCommitMessage
+	TypePRReview
+	TypePRInbox
+	TypeCILog
+	TypeAgentTranscript
+	TypeAgentTimeline
+	TypeStash
+	TypeRebase
 )
 
 // String returns a human-readable name for the screen type.
@@ -88,6 +95,20 @@ func (t Type) String() string {
 		return "taskboard"
 	case TypeCommitMessage:
 		return "commit-message"
+	case TypePRReview:
+		return "pr-review"
+	case TypePRInbox:
+		return "pr-inbox"
+	case TypeCILog:
+		return "ci-log"
+	case TypeAgentTranscript:
+		return "agent-transcript"
+	case TypeAgentTimeline:
+		return "agent-timeline"
+	case TypeStash:
+		return "stash"
+	case TypeRebase:
+		return "rebase"
 	default:
 		return "unknown"
 	}
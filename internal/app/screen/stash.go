@@ -0,0 +1,307 @@
+package screen
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+// StashScreen lists the stashes of the repository, with the patch of the
+// selected stash shown below the list.
+type StashScreen struct {
+	Stashes      []*models.StashEntry
+	Cursor       int
+	ScrollOffset int
+	DetailOffset int
+	Width        int
+	Height       int
+	Title        string
+	Thm          *theme.Theme
+
+	// Worktrees maps branch names to the worktree they are checked out in
+	Worktrees map[string]string
+	// Previews caches the patch of each stash by hash
+	Previews map[string]string
+
+	// StatusMessage shows temporary feedback (cleared on navigation)
+	StatusMessage string
+
+	OnPreview func(*models.StashEntry) tea.Cmd
+	OnApply   func(*models.StashEntry) tea.Cmd
+	OnPop     func(*models.StashEntry) tea.Cmd
+	OnDrop    func(*models.StashEntry) tea.Cmd
+	OnRefresh func() tea.Cmd
+	OnClose   func() tea.Cmd
+}
+
+// NewStashScreen creates the stash browser modal.
+func NewStashScreen(stashes []*models.StashEntry, worktrees map[string]string, title string, maxWidth, maxHeight int, thm *theme.Theme) *StashScreen {
+	s := &StashScreen{
+		Title:     title,
+		Thm:       thm,
+		Worktrees: worktrees,
+		Previews:  make(map[string]string),
+	}
+	s.Resize(maxWidth, maxHeight)
+	s.SetStashes(stashes)
+	return s
+}
+
+// Type returns the screen type.
+func (s *StashScreen) Type() Type {
+	return TypeStash
+}
+
+// Resize updates modal dimensions from terminal size.
+func (s *StashScreen) Resize(maxWidth, maxHeight int) {
+	s.Width = 96
+	s.Height = 30
+	if maxWidth > 0 {
+		s.Width = clampInt(int(float64(maxWidth)*0.85), 72, 140)
+	}
+	if maxHeight > 0 {
+		s.Height = clampInt(int(float64(maxHeight)*0.85), 20, 50)
+	}
+	s.ensureCursorVisible()
+}
+
+// SetStashes replaces the stashes, keeping the cursor on the same stash when
+// it is still listed.
+func (s *StashScreen) SetStashes(stashes []*models.StashEntry) {
+	preferred := ""
+	if selected := s.Selected(); selected != nil {
+		preferred = selected.Hash
+	}
+	s.Stashes = stashes
+	s.Cursor = 0
+	for i, stash := range stashes {
+		if stash.Hash == preferred {
+			s.Cursor = i
+			break
+		}
+	}
+	s.DetailOffset = 0
+	s.ensureCursorVisible()
+}
+
+// Selected returns the stash under the cursor, or nil.
+func (s *StashScreen) Selected() *models.StashEntry {
+	if s.Cursor < 0 || s.Cursor >= len(s.Stashes) {
+		return nil
+	}
+	return s.Stashes[s.Cursor]
+}
+
+// PreviewCmd requests the patch of the selected stash unless it is cached.
+func (s *StashScreen) PreviewCmd() tea.Cmd {
+	stash := s.Selected()
+	if stash == nil || s.OnPreview == nil {
+		return nil
+	}
+	if _, ok := s.Previews[stash.Hash]; ok {
+		return nil
+	}
+	return s.OnPreview(stash)
+}
+
+// Update handles keyboard input.
+func (s *StashScreen) Update(msg tea.KeyPressMsg) (Screen, tea.Cmd) {
+	switch msg.String() {
+	case keyEsc, keyEscRaw, keyQ, keyCtrlC:
+		if s.OnClose != nil {
+			return nil, s.OnClose()
+		}
+		return nil, nil
+	case "up", "k", keyCtrlK:
+		return s, s.moveCursor(s.Cursor - 1)
+	case "down", "j", keyCtrlJ:
+		return s, s.moveCursor(s.Cursor + 1)
+	case "g":
+		return s, s.moveCursor(0)
+	case "G":
+		return s, s.moveCursor(len(s.Stashes) - 1)
+	case keyCtrlD:
+		s.DetailOffset += max(1, s.detailHeight()/2)
+	case keyCtrlU:
+		s.DetailOffset = max(0, s.DetailOffset-max(1, s.detailHeight()/2))
+	case keyEnter, "a":
+		return s, s.call(s.OnApply)
+	case "p":
+		return s, s.call(s.OnPop)
+	case "D":
+		return s, s.call(s.OnDrop)
+	case "r":
+		if s.OnRefresh != nil {
+			return s, s.OnRefresh()
+		}
+	}
+	return s, nil
+}
+
+func (s *StashScreen) call(handler func(*models.StashEntry) tea.Cmd) tea.Cmd {
+	stash := s.Selected()
+	if stash == nil || handler == nil {
+		return nil
+	}
+	return handler(stash)
+}
+
+// View renders the stash browser modal.
+func (s *StashScreen) View() string {
+	contentWidth := s.Width - 6
+
+	titleStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.Accent).
+		Bold(true).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.AccentFg).
+		Background(s.Thm.Accent)
+	stashStyle := lipgloss.NewStyle().Foreground(s.Thm.TextFg)
+	mutedStyle := lipgloss.NewStyle().Foreground(s.Thm.MutedFg)
+	worktreeStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	separatorStyle := lipgloss.NewStyle().Foreground(s.Thm.BorderDim)
+	statusStyle := lipgloss.NewStyle().Foreground(s.Thm.WarnFg)
+	addedStyle := lipgloss.NewStyle().Foreground(s.Thm.SuccessFg)
+	removedStyle := lipgloss.NewStyle().Foreground(s.Thm.ErrorFg)
+	hunkStyle := lipgloss.NewStyle().Foreground(s.Thm.Cyan)
+
+	listHeight := s.listHeight()
+	lines := make([]string, 0, s.Height)
+	if len(s.Stashes) == 0 {
+		lines = append(lines, mutedStyle.Render("No stashes."))
+	}
+	end := min(len(s.Stashes), s.ScrollOffset+listHeight)
+	for i := s.ScrollOffset; i < end; i++ {
+		stash := s.Stashes[i]
+		text := fmt.Sprintf("%-10s %s", stash.Ref, stash.Message)
+		if stash.Timestamp > 0 {
+			text = fmt.Sprintf("%-10s %s  %s", stash.Ref, time.Unix(stash.Timestamp, 0).Local().Format("2006-01-02 15:04"), stash.Message)
+		}
+		suffix, attached := s.origin(stash)
+		available := max(1, contentWidth-ansi.StringWidth(suffix)-1)
+		text = ansi.Truncate(text, available, "…")
+		padding := strings.Repeat(" ", max(1, contentWidth-ansi.StringWidth(text)-ansi.StringWidth(suffix)))
+
+		switch {
+		case i == s.Cursor:
+			lines = append(lines, selectedStyle.Width(contentWidth).Render(text+padding+suffix))
+		case attached:
+			lines = append(lines, stashStyle.Render(text)+padding+worktreeStyle.Render(suffix))
+		default:
+			lines = append(lines, stashStyle.Render(text)+padding+mutedStyle.Render(suffix))
+		}
+	}
+	for len(lines) < listHeight {
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, separatorStyle.Render(strings.Repeat("─", contentWidth)))
+
+	var detail []string
+	if stash := s.Selected(); stash != nil {
+		preview, ok := s.Previews[stash.Hash]
+		switch {
+		case !ok:
+			detail = append(detail, mutedStyle.Render("Loading diff..."))
+		case strings.TrimSpace(preview) == "":
+			detail = append(detail, mutedStyle.Render("No changes to show."))
+		default:
+			for line := range strings.SplitSeq(strings.TrimRight(preview, "\n"), "\n") {
+				line = ansi.Truncate(strings.ReplaceAll(line, "\t", "    "), contentWidth, "…")
+				switch {
+				case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff "):
+					line = mutedStyle.Bold(true).Render(line)
+				case strings.HasPrefix(line, "+"):
+					line = addedStyle.Render(line)
+				case strings.HasPrefix(line, "-"):
+					line = removedStyle.Render(line)
+				case strings.HasPrefix(line, "@@"):
+					line = hunkStyle.Render(line)
+				}
+				detail = append(detail, line)
+			}
+		}
+	}
+	detailHeight := s.detailHeight()
+	s.DetailOffset = min(s.DetailOffset, max(0, len(detail)-detailHeight))
+	detail = detail[s.DetailOffset:]
+	if len(detail) > detailHeight {
+		detail = detail[:detailHeight]
+	}
+	lines = append(lines, detail...)
+	for len(lines) < listHeight+1+detailHeight {
+		lines = append(lines, "")
+	}
+
+	footer := "Enter/a apply • p pop • D drop • r refresh • Ctrl+D/U scroll • q close"
+	footerStyle := lipgloss.NewStyle().
+		Foreground(s.Thm.MutedFg).
+		Width(s.Width - 4).
+		Align(lipgloss.Center)
+	footerLine := footerStyle.Render(ansi.Truncate(footer, s.Width-4, "…"))
+	if s.StatusMessage != "" {
+		footerLine = statusStyle.Width(s.Width - 4).Align(lipgloss.Center).Render(ansi.Truncate(s.StatusMessage, s.Width-4, "…"))
+	}
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(s.Thm.Accent).
+		Padding(0, 1).
+		Width(s.Width).
+		Height(s.Height)
+
+	content := []string{titleStyle.Render(s.Title), strings.Join(lines, "\n"), footerLine}
+	return boxStyle.Render(strings.Join(content, "\n"))
+}
+
+// origin describes where a stash was made: the worktree its branch is checked
+// out in, or the branch alone once no worktree has it.
+func (s *StashScreen) origin(stash *models.StashEntry) (string, bool) {
+	if stash.Branch == "" {
+		return "detached HEAD", false
+	}
+	if worktree := s.Worktrees[stash.Branch]; worktree != "" {
+		return stash.Branch + " @ " + worktree, true
+	}
+	return stash.Branch + " (no worktree)", false
+}
+
+func (s *StashScreen) listHeight() int {
+	return max(3, (s.Height-4)*2/5)
+}
+
+func (s *StashScreen) detailHeight() int {
+	return max(3, s.Height-4-s.listHeight()-1)
+}
+
+func (s *StashScreen) moveCursor(cursor int) tea.Cmd {
+	cursor = clampInt(cursor, 0, max(0, len(s.Stashes)-1))
+	if cursor == s.Cursor {
+		return nil
+	}
+	s.Cursor = cursor
+	s.DetailOffset = 0
+	s.StatusMessage = ""
+	s.ensureCursorVisible()
+	return s.PreviewCmd()
+}
+
+func (s *StashScreen) ensureCursorVisible() {
+	listHeight := s.listHeight()
+	if s.Cursor < s.ScrollOffset {
+		s.ScrollOffset = s.Cursor
+	}
+	if s.Cursor >= s.ScrollOffset+listHeight {
+		s.ScrollOffset = s.Cursor - listHeight + 1
+	}
+	s.ScrollOffset = max(0, s.ScrollOffset)
+}
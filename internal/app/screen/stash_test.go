@@ -0,0 +1,105 @@
+package screen
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/theme"
+)
+
+func testStashes() []*models.StashEntry {
+	return []*models.StashEntry{
+		{Ref: "stash@{0}", Hash: "aaaa", Branch: "feature", Message: "half done"},
+		{Ref: "stash@{1}", Hash: "bbbb", Branch: "removed", Message: "old idea"},
+		{Ref: "stash@{2}", Hash: "cccc", Message: "WIP 1234567 bisect"},
+	}
+}
+
+func TestStashScreenNavigationRequestsPreviews(t *testing.T) {
+	s := NewStashScreen(testStashes(), map[string]string{"feature": "feature-wt"}, "Stashes", 120, 40, theme.Dracula())
+	if s.Type() != TypeStash {
+		t.Fatalf("expected TypeStash, got %v", s.Type())
+	}
+	var requested []string
+	s.OnPreview = func(stash *models.StashEntry) tea.Cmd {
+		requested = append(requested, stash.Hash)
+		return func() tea.Msg { return nil }
+	}
+	s.Previews["bbbb"] = "+cached"
+
+	if _, cmd := s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"}); cmd != nil {
+		t.Fatal("expected no preview request for a cached stash")
+	}
+	if _, cmd := s.Update(tea.KeyPressMsg{Code: 'G', Text: "G"}); cmd == nil {
+		t.Fatal("expected a preview request for an uncached stash")
+	}
+	if got := s.Selected(); got.Hash != "cccc" {
+		t.Fatalf("expected G to select the last stash, got %s", got.Hash)
+	}
+	if _, cmd := s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"}); cmd != nil {
+		t.Fatal("expected no preview request when the cursor does not move")
+	}
+	if strings.Join(requested, ",") != "cccc" {
+		t.Fatalf("unexpected preview requests: %v", requested)
+	}
+}
+
+func TestStashScreenKeepsSelectionOnRefresh(t *testing.T) {
+	s := NewStashScreen(testStashes(), nil, "Stashes", 120, 40, theme.Dracula())
+	s.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+
+	stashes := testStashes()[1:]
+	stashes[0].Ref = "stash@{0}"
+	s.SetStashes(stashes)
+	if got := s.Selected(); got == nil || got.Hash != "bbbb" {
+		t.Fatalf("expected the selected stash to survive a refresh, got %+v", got)
+	}
+
+	s.SetStashes(nil)
+	if s.Selected() != nil {
+		t.Fatal("expected no selection without stashes")
+	}
+	if !strings.Contains(s.View(), "No stashes.") {
+		t.Fatal("expected empty state to be rendered")
+	}
+}
+
+func TestStashScreenActions(t *testing.T) {
+	s := NewStashScreen(testStashes(), nil, "Stashes", 120, 40, theme.Dracula())
+	var calls []string
+	record := func(name string) func(*models.StashEntry) tea.Cmd {
+		return func(stash *models.StashEntry) tea.Cmd {
+			calls = append(calls, name+":"+stash.Ref)
+			return nil
+		}
+	}
+	s.OnApply = record("apply")
+	s.OnPop = record("pop")
+	s.OnDrop = record("drop")
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	s.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	s.Update(tea.KeyPressMsg{Code: 'D', Text: "D"})
+	want := "apply:stash@{0},pop:stash@{0},drop:stash@{0}"
+	if got := strings.Join(calls, ","); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+
+	if next, _ := s.Update(tea.KeyPressMsg{Code: 'q', Text: "q"}); next != nil {
+		t.Fatal("expected q to close the screen")
+	}
+}
+
+func TestStashScreenViewShowsOriginAndDiff(t *testing.T) {
+	s := NewStashScreen(testStashes(), map[string]string{"feature": "feature-wt"}, "Stashes", 120, 40, theme.Dracula())
+	s.Previews["aaaa"] = "diff --git a/main.go b/main.go\n@@ -1 +1 @@\n-old line\n+new line\n"
+
+	view := s.View()
+	for _, want := range []string{"feature @ feature-wt", "removed (no worktree)", "detached HEAD", "+new line", "-old line"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected view to contain %q", want)
+		}
+	}
+}
@@ -507,6 +507,9 @@ func TestTipOperationFromContext(t *testing.T) {
 	if got := appscreen.TipOperationFromContext("", "Fetching remotes..."); got != appscreen.TipOperationFetch {
 		t.Fatalf("expected fetch operation, got %q", got)
 	}
+	if got := appscreen.TipOperationFromContext("cancel", "Cancelling CI job..."); got != appscreen.TipOperationCancel {
+		t.Fatalf("expected cancel operation, got %q", got)
+	}
 }
 
 func TestHelpTips(t *testing.T) {
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// isAgentEditTool reports whether a normalised tool name writes files.
+func isAgentEditTool(name string) bool {
+	switch name {
+	case "Write", "Edit", "MultiEdit", "NotebookEdit":
+		return true
+	default:
+		return false
+	}
+}
+
+// noteEditedPath records a file written by the session at the given time,
+// which may be zero when the transcript does not say.
+func noteEditedPath(session *models.AgentSession, path string, at time.Time) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return
+	}
+	if !slices.Contains(session.EditedPaths, path) {
+		session.EditedPaths = append(session.EditedPaths, path)
+	}
+	if at.IsZero() {
+		return
+	}
+	if session.EditedAt == nil {
+		session.EditedAt = make(map[string]time.Time)
+	}
+	if at.After(session.EditedAt[path]) {
+		session.EditedAt[path] = at
+	}
+}
+
+// agentEditTime returns when the session last wrote path, falling back to
+// its last activity when the edit was not timestamped.
+func agentEditTime(session *models.AgentSession, path string) time.Time {
+	if at, ok := session.EditedAt[path]; ok {
+		return at
+	}
+	return session.LastActivity
+}
+
+// AgentEditedFiles maps the files under root that sessions wrote or edited,
+// as slash-separated paths relative to root, to the agent that touched them
+// last. Relative paths in transcripts are resolved against the session's
+// working directory. Edits made before since, usually the time of the HEAD
+// commit, are ignored: they were committed or discarded already. Each edit is
+// dated by its tool call, or by the last activity of its session for agents
+// that do not timestamp them, such as Aider. A zero since keeps every edit.
+func AgentEditedFiles(sessions []*models.AgentSession, root string, since time.Time) map[string]models.AgentKind {
+	root = filepath.Clean(root)
+	edited := make(map[string]models.AgentKind)
+	editedAt := make(map[string]time.Time)
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		for _, path := range session.EditedPaths {
+			at := agentEditTime(session, path)
+			if !since.IsZero() && !at.After(since) {
+				continue
+			}
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(session.CWD, path)
+			}
+			rel, err := filepath.Rel(root, filepath.Clean(path))
+			if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			rel = filepath.ToSlash(rel)
+			if last, ok := editedAt[rel]; ok && at.Before(last) {
+				continue
+			}
+			edited[rel], editedAt[rel] = session.Agent, at
+		}
+	}
+	return edited
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestParseClaudeSessionRecordsEditedPaths(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	toolUse := func(name string, input map[string]any) string {
+		return mustJSONLine(t, map[string]any{"type": "assistant", "timestamp": ts, "cwd": "/tmp/feature", "message": map[string]any{
+			"role":    "assistant",
+			"content": []map[string]any{{"type": "tool_use", "id": name, "name": name, "input": input}},
+		}})
+	}
+	writeJSONLLines(
+		t, path,
+		toolUse("Read", map[string]any{"file_path": "/tmp/feature/README.md"}),
+		toolUse("Edit", map[string]any{"file_path": "/tmp/feature/main.go"}),
+		toolUse("Write", map[string]any{"file_path": "/tmp/feature/new.go"}),
+		toolUse("MultiEdit", map[string]any{"file_path": "/tmp/feature/main.go"}),
+	)
+
+	session, err := parseClaudeSession(path, "")
+	if err != nil {
+		t.Fatalf("parseClaudeSession returned error: %v", err)
+	}
+	if len(session.EditedPaths) != 2 || session.EditedPaths[0] != "/tmp/feature/main.go" || session.EditedPaths[1] != "/tmp/feature/new.go" {
+		t.Fatalf("expected the edited files once each, got %v", session.EditedPaths)
+	}
+}
+
+func TestAgentEditedFiles(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "feature")
+	now := time.Now()
+	sessions := []*models.AgentSession{
+		{Agent: models.AgentKindCodex, CWD: root, LastActivity: now, EditedPaths: []string{"internal/parser.go", "../other/x.go"}},
+		{Agent: models.AgentKindClaude, CWD: filepath.Join(root, "internal"), LastActivity: now.Add(-time.Hour), EditedPaths: []string{
+			filepath.Join(root, "main.go"), "parser.go",
+		}},
+		{Agent: models.AgentKindGemini, CWD: "/elsewhere", LastActivity: now, EditedPaths: []string{"/elsewhere/main.go"}},
+		nil,
+	}
+
+	edited := AgentEditedFiles(sessions, root, time.Time{})
+	want := map[string]models.AgentKind{
+		"main.go":            models.AgentKindClaude,
+		"internal/parser.go": models.AgentKindCodex,
+	}
+	if len(edited) != len(want) {
+		t.Fatalf("expected %v, got %v", want, edited)
+	}
+	for file, agent := range want {
+		if edited[file] != agent {
+			t.Fatalf("expected %s edited by %s, got %v", file, agent, edited)
+		}
+	}
+
+	files := MarkAgentEdits([]StatusFile{{Filename: "main.go", Status: ".M"}, {Filename: "go.mod", Status: ".M"}}, edited)
+	if files[0].Agent != models.AgentKindClaude || files[1].Agent != "" {
+		t.Fatalf("unexpected marks %+v", files)
+	}
+}
+
+func TestAgentEditedFilesIgnoresSessionsBeforeHead(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "feature")
+	head := time.Now().Add(-time.Hour)
+	sessions := []*models.AgentSession{
+		{Agent: models.AgentKindClaude, CWD: root, LastActivity: head.Add(-24 * time.Hour), EditedPaths: []string{"main.go", "old.go"}},
+		{Agent: models.AgentKindCodex, CWD: root, LastActivity: head.Add(time.Minute), EditedPaths: []string{"main.go"}},
+	}
+
+	edited := AgentEditedFiles(sessions, root, head)
+	if len(edited) != 1 || edited["main.go"] != models.AgentKindCodex {
+		t.Fatalf("expected only the session active since HEAD to count, got %v", edited)
+	}
+}
+
+func TestAgentEditedFilesIgnoresEditsBeforeHead(t *testing.T) {
+	t.Parallel()
+
+	root := filepath.Join(t.TempDir(), "feature")
+	head := time.Now().Add(-time.Hour)
+	session := &models.AgentSession{Agent: models.AgentKindClaude, CWD: root, LastActivity: head.Add(time.Minute)}
+	noteEditedPath(session, "old.go", head.Add(-time.Minute))
+	noteEditedPath(session, "new.go", head.Add(time.Minute))
+	noteEditedPath(session, "both.go", head.Add(-time.Minute))
+	noteEditedPath(session, "both.go", head.Add(time.Minute))
+
+	edited := AgentEditedFiles([]*models.AgentSession{session}, root, head)
+	if len(edited) != 2 || edited["new.go"] != models.AgentKindClaude || edited["both.go"] != models.AgentKindClaude {
+		t.Fatalf("expected only the files edited since HEAD, got %v", edited)
+	}
+}
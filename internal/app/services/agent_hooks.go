@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -22,6 +23,11 @@ const (
 	agentHookStaleAfter = 24 * time.Hour
 	// agentHookSpoolFileStaleAfter prunes unconsumed spool files older than this.
 	agentHookSpoolFileStaleAfter = 24 * time.Hour
+	// agentHookHistoryLimit caps the drained events kept for the activity timeline.
+	agentHookHistoryLimit = 1000
+	// agentHookHistoryFile stores the drained events next to the spool, so the
+	// activity timeline survives a restart.
+	agentHookHistoryFile = "agent-events-history.jsonl"
 )
 
 // AgentHookSpoolDir returns the directory where hook shims spool events.
@@ -85,9 +91,14 @@ func normalizeAgentHookEventName(eventName, notificationType, toolName string) s
 		if toolName == "ask_user" || toolName == "AskUserQuestion" {
 			return models.AgentHookWaitingForUser
 		}
+	case "BeforeAgent":
+		// Gemini CLI names its turn boundaries after the agent loop.
+		return models.AgentHookUserPromptSubmit
+	case "AfterAgent":
+		return models.AgentHookStop
 	case "Notification":
 		switch notificationType {
-		case "elicitation_dialog", "agent_needs_input":
+		case "elicitation_dialog", "agent_needs_input", "ToolPermission":
 			return models.AgentHookWaitingForUser
 		case "elicitation_complete", "elicitation_response":
 			return models.AgentHookUserPromptSubmit
@@ -209,6 +220,14 @@ type AgentHookService struct {
 	states   map[string]*AgentHookState
 	pidAlive func(pid int) bool
 	logf     func(format string, args ...any)
+
+	// history holds the drained events, mirrored to historyPath so they are
+	// read back after a restart. historyLines counts the lines of that file,
+	// which is compacted once it holds twice the events kept.
+	history       []models.AgentHookEvent
+	historyPath   string
+	historyLoaded bool
+	historyLines  int
 }
 
 // NewAgentHookService creates a hook service reading events from dir.
@@ -217,10 +236,11 @@ func NewAgentHookService(dir string, logf func(format string, args ...any)) *Age
 		logf = func(string, ...any) {}
 	}
 	return &AgentHookService{
-		dir:      dir,
-		states:   map[string]*AgentHookState{},
-		pidAlive: agentHookPIDAlive,
-		logf:     logf,
+		dir:         dir,
+		states:      map[string]*AgentHookState{},
+		historyPath: filepath.Join(filepath.Dir(dir), agentHookHistoryFile),
+		pidAlive:    agentHookPIDAlive,
+		logf:        logf,
 	}
 }
 
@@ -261,6 +281,8 @@ func (s *AgentHookService) Drain() {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.loadHistoryLocked()
+	var drained []models.AgentHookEvent
 	for _, name := range names {
 		path := filepath.Join(s.dir, name)
 		data, err := os.ReadFile(path) //nolint:gosec // Spool path is app-controlled.
@@ -275,11 +297,113 @@ func (s *AgentHookService) Drain() {
 			continue
 		}
 		s.applyLocked(event)
+		drained = append(drained, event)
 		s.removeSpoolFile(path, name)
 	}
+	s.history = append(s.history, drained...)
+	if excess := len(s.history) - agentHookHistoryLimit; excess > 0 {
+		s.history = append(s.history[:0], s.history[excess:]...)
+	}
+	s.persistHistoryLocked(drained)
 	s.pruneLocked(time.Now())
 }
 
+// loadHistoryLocked reads back the events drained by earlier runs, dropping
+// those older than agentHookStaleAfter, once per service.
+func (s *AgentHookService) loadHistoryLocked() {
+	if s.historyLoaded {
+		return
+	}
+	s.historyLoaded = true
+	data, err := os.ReadFile(s.historyPath)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			s.logf("agent hooks: read history: %v", err)
+		}
+		return
+	}
+	cutoff := time.Now().Add(-agentHookStaleAfter)
+	var events []models.AgentHookEvent
+	for line := range bytes.SplitSeq(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		s.historyLines++
+		var event models.AgentHookEvent
+		if json.Unmarshal(line, &event) != nil || event.Timestamp.Before(cutoff) {
+			continue
+		}
+		events = append(events, event)
+	}
+	if excess := len(events) - agentHookHistoryLimit; excess > 0 {
+		events = events[excess:]
+	}
+	s.history = append(events, s.history...)
+	if s.historyLines > len(events) {
+		s.rewriteHistoryLocked()
+	}
+}
+
+// persistHistoryLocked appends newly drained events to the history file,
+// rewriting it with the kept events once it has grown too large.
+func (s *AgentHookService) persistHistoryLocked(events []models.AgentHookEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if s.historyLines+len(events) > 2*agentHookHistoryLimit {
+		s.rewriteHistoryLocked()
+		return
+	}
+	data, err := encodeAgentHookEvents(events)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(s.historyPath), 0o700)
+	}
+	if err == nil {
+		var f *os.File
+		f, err = os.OpenFile(s.historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, defaultFilePerms) //nolint:gosec // History path is app-controlled.
+		if err == nil {
+			_, err = f.Write(data)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+	}
+	if err != nil {
+		s.logf("agent hooks: write history: %v", err)
+		return
+	}
+	s.historyLines += len(events)
+}
+
+func (s *AgentHookService) rewriteHistoryLocked() {
+	data, err := encodeAgentHookEvents(s.history)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(s.historyPath), 0o700)
+	}
+	if err == nil {
+		err = writeAtomically(s.historyPath, data)
+	}
+	if err != nil {
+		s.logf("agent hooks: write history: %v", err)
+		return
+	}
+	s.historyLines = len(s.history)
+}
+
+// encodeAgentHookEvents encodes events as JSON lines.
+func encodeAgentHookEvents(events []models.AgentHookEvent) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
 func (s *AgentHookService) removeSpoolFile(path, name string) {
 	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
 		s.logf("agent hooks: remove %s: %v", name, err)
@@ -357,6 +481,16 @@ func (s *AgentHookService) States() []AgentHookState {
 	return out
 }
 
+// Events returns the drained hook events, including those of earlier runs
+// from the last agentHookStaleAfter, oldest first, up to the most recent
+// agentHookHistoryLimit.
+func (s *AgentHookService) Events() []models.AgentHookEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loadHistoryLocked()
+	return append([]models.AgentHookEvent(nil), s.history...)
+}
+
 // RestoreSessions rehydrates live hook state from the persisted session
 // registry after a restart. Pending hook events always take precedence.
 func (s *AgentHookService) RestoreSessions(previous map[string]*models.AgentSession) {
@@ -135,6 +135,54 @@ func TestAgentHookServiceDrainAppliesEvents(t *testing.T) {
 	if len(entries) != 0 {
 		t.Fatalf("expected spool drained, %d files remain", len(entries))
 	}
+	if events := svc.Events(); len(events) != 3 || events[1].HookEventName != models.AgentHookUserPromptSubmit {
+		t.Fatalf("expected the drained events to be kept for the timeline, got %+v", events)
+	}
+}
+
+func TestAgentHookServiceHistorySurvivesRestart(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "agent-events")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i, name := range []string{models.AgentHookSessionStart, models.AgentHookUserPromptSubmit} {
+		event := models.AgentHookEvent{
+			SchemaVersion: models.AgentHookEventSchemaVersion,
+			Agent:         models.AgentKindClaude, HookEventName: name,
+			SessionID: "s1", PID: 4242, Timestamp: now.Add(time.Duration(i) * time.Second),
+		}
+		if err := WriteAgentHookEvent(dir, event); err != nil {
+			t.Fatalf("write event: %v", err)
+		}
+	}
+	NewAgentHookService(dir, nil).Drain()
+
+	historyPath := filepath.Join(filepath.Dir(dir), agentHookHistoryFile)
+	stale, err := encodeAgentHookEvents([]models.AgentHookEvent{{
+		Agent: models.AgentKindClaude, HookEventName: models.AgentHookStop,
+		SessionID: "old", Timestamp: now.Add(-2 * agentHookStaleAfter),
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatalf("expected history file: %v", err)
+	}
+	if err := os.WriteFile(historyPath, append(stale, data...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	events := NewAgentHookService(dir, nil).Events()
+	if len(events) != 2 || events[0].HookEventName != models.AgentHookSessionStart ||
+		events[1].HookEventName != models.AgentHookUserPromptSubmit {
+		t.Fatalf("expected the previous run's events without the stale one, got %+v", events)
+	}
+	data, _ = os.ReadFile(historyPath)
+	if strings.Count(string(data), "\n") != 2 {
+		t.Fatalf("expected the history file compacted to 2 lines, got %q", data)
+	}
 }
 
 func TestAgentHookServiceSessionEndMarksEnded(t *testing.T) {
@@ -229,6 +277,30 @@ func TestParseAgentHookPayloadCopilot(t *testing.T) {
 	}
 }
 
+func TestParseAgentHookPayloadGemini(t *testing.T) {
+	tests := []struct {
+		event            string
+		notificationType string
+		want             string
+	}{
+		{event: "SessionStart", want: models.AgentHookSessionStart},
+		{event: "BeforeAgent", want: models.AgentHookUserPromptSubmit},
+		{event: "AfterAgent", want: models.AgentHookStop},
+		{event: "Notification", notificationType: "ToolPermission", want: models.AgentHookWaitingForUser},
+	}
+	for _, tt := range tests {
+		data := `{"hook_event_name":"` + tt.event + `","session_id":"gem-1","cwd":"/tmp/repo",` +
+			`"transcript_path":"/tmp/gemini/chats/session-1.json","notification_type":"` + tt.notificationType + `"}`
+		event, err := ParseAgentHookPayload(models.AgentKindGemini, []byte(data))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.event, err)
+		}
+		if event.HookEventName != tt.want || event.TranscriptPath != "/tmp/gemini/chats/session-1.json" {
+			t.Fatalf("%s: unexpected event: %+v", tt.event, event)
+		}
+	}
+}
+
 func TestParseAgentHookPayloadQuestionLifecycle(t *testing.T) {
 	tests := []struct {
 		name  string
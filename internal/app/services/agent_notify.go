@@ -0,0 +1,122 @@
+package services
+
+import (
+	"slices"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// AgentNotifyEvent names a session transition worth telling the user about.
+type AgentNotifyEvent string
+
+const (
+	// AgentNotifyWaiting fires when an agent finishes working and waits for input.
+	AgentNotifyWaiting AgentNotifyEvent = "waiting"
+	// AgentNotifyApproval fires when an agent asks for a tool approval.
+	AgentNotifyApproval AgentNotifyEvent = "approval"
+	// AgentNotifyIdle fires when a working agent goes quiet without asking for anything.
+	AgentNotifyIdle AgentNotifyEvent = "idle"
+)
+
+// AgentNotification is a single transition reported by AgentNotifier.
+type AgentNotification struct {
+	Event        AgentNotifyEvent
+	Session      *models.AgentSession
+	WorktreePath string
+}
+
+// AgentNotifier turns successive session snapshots into notifications. It
+// remembers the last activity of every session and rate limits per agent,
+// worktree and event, so a chatty agent cannot flood the desktop while an
+// approval request is never swallowed by an earlier waiting or idle one.
+type AgentNotifier struct {
+	events    []AgentNotifyEvent
+	rateLimit time.Duration
+	primed    bool
+	activity  map[string]models.AgentActivity
+	lastSent  map[string]time.Time
+}
+
+// NewAgentNotifier creates a notifier for the given event names. Unknown
+// names are ignored.
+func NewAgentNotifier(events []string, rateLimit time.Duration) *AgentNotifier {
+	n := &AgentNotifier{
+		rateLimit: rateLimit,
+		activity:  make(map[string]models.AgentActivity),
+		lastSent:  make(map[string]time.Time),
+	}
+	for _, event := range events {
+		switch e := AgentNotifyEvent(event); e {
+		case AgentNotifyWaiting, AgentNotifyApproval, AgentNotifyIdle:
+			n.events = append(n.events, e)
+		}
+	}
+	return n
+}
+
+// Observe records a new snapshot and returns the notifications it triggers.
+// The first snapshot only sets the baseline: sessions that were already
+// waiting when lazyworktree started are not reported. worktreeFor maps a
+// session working directory to its worktree path, or "" when unknown.
+func (n *AgentNotifier) Observe(sessions []*models.AgentSession, worktreeFor func(cwd string) string, now time.Time) []AgentNotification {
+	seen := make(map[string]models.AgentActivity, len(sessions))
+	var out []AgentNotification
+	for _, session := range sessions {
+		if session == nil {
+			continue
+		}
+		key := session.SessionKey
+		if key == "" {
+			key = string(session.Agent) + ":" + session.ID
+		}
+		seen[key] = session.Activity
+		if !n.primed || session.LivenessState == models.AgentSessionLivenessInactive {
+			continue
+		}
+		event, ok := agentNotifyTransition(n.activity[key], session.Activity)
+		if !ok || !slices.Contains(n.events, event) {
+			continue
+		}
+		worktree := ""
+		if worktreeFor != nil {
+			worktree = worktreeFor(session.CWD)
+		}
+		limitKey := string(session.Agent) + "\x00" + worktree + "\x00" + string(event)
+		if last, ok := n.lastSent[limitKey]; ok && now.Sub(last) < n.rateLimit {
+			continue
+		}
+		n.lastSent[limitKey] = now
+		out = append(out, AgentNotification{Event: event, Session: session, WorktreePath: worktree})
+	}
+	n.activity = seen
+	n.primed = true
+	return out
+}
+
+// agentNotifyTransition reports the event, if any, for a change of activity.
+// Approvals notify from any state; waiting and idle only after real work.
+func agentNotifyTransition(prev, cur models.AgentActivity) (AgentNotifyEvent, bool) {
+	if prev == cur {
+		return "", false
+	}
+	switch cur {
+	case models.AgentActivityApproval:
+		return AgentNotifyApproval, true
+	case models.AgentActivityWaiting:
+		return AgentNotifyWaiting, agentActivityWorking(prev)
+	case models.AgentActivityIdle:
+		return AgentNotifyIdle, agentActivityWorking(prev)
+	default:
+		return "", false
+	}
+}
+
+func agentActivityWorking(activity models.AgentActivity) bool {
+	switch activity {
+	case "", models.AgentActivityIdle, models.AgentActivityWaiting, models.AgentActivityApproval:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func notifySession(key string, agent models.AgentKind, cwd string, activity models.AgentActivity) *models.AgentSession {
+	return &models.AgentSession{
+		SessionKey:    key,
+		Agent:         agent,
+		CWD:           cwd,
+		Activity:      activity,
+		LivenessState: models.AgentSessionLivenessActive,
+	}
+}
+
+func TestAgentNotifierTransitions(t *testing.T) {
+	t.Parallel()
+
+	worktreeFor := func(cwd string) string { return cwd }
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	n := NewAgentNotifier([]string{"waiting", "approval", "idle", "bogus"}, 0)
+
+	// The first snapshot only primes the baseline.
+	got := n.Observe([]*models.AgentSession{
+		notifySession("a", models.AgentKindClaude, "/wt/a", models.AgentActivityWaiting),
+		notifySession("b", models.AgentKindCodex, "/wt/b", models.AgentActivityRunning),
+		notifySession("c", models.AgentKindPi, "/wt/c", models.AgentActivityThinking),
+	}, worktreeFor, now)
+	if len(got) != 0 {
+		t.Fatalf("expected no notifications on the first snapshot, got %+v", got)
+	}
+
+	got = n.Observe([]*models.AgentSession{
+		notifySession("a", models.AgentKindClaude, "/wt/a", models.AgentActivityIdle),
+		notifySession("b", models.AgentKindCodex, "/wt/b", models.AgentActivityApproval),
+		notifySession("c", models.AgentKindPi, "/wt/c", models.AgentActivityWaiting),
+	}, worktreeFor, now.Add(time.Second))
+	if len(got) != 2 {
+		t.Fatalf("expected approval and waiting notifications, got %+v", got)
+	}
+	if got[0].Event != AgentNotifyApproval || got[0].WorktreePath != "/wt/b" {
+		t.Fatalf("unexpected first notification %+v", got[0])
+	}
+	if got[1].Event != AgentNotifyWaiting || got[1].Session.SessionKey != "c" {
+		t.Fatalf("unexpected second notification %+v", got[1])
+	}
+
+	got = n.Observe([]*models.AgentSession{
+		notifySession("b", models.AgentKindCodex, "/wt/b", models.AgentActivityWriting),
+	}, worktreeFor, now.Add(2*time.Second))
+	got = append(got, n.Observe([]*models.AgentSession{
+		notifySession("b", models.AgentKindCodex, "/wt/b", models.AgentActivityIdle),
+	}, worktreeFor, now.Add(3*time.Second))...)
+	if len(got) != 1 || got[0].Event != AgentNotifyIdle {
+		t.Fatalf("expected an idle notification after work, got %+v", got)
+	}
+}
+
+func TestAgentNotifierRateLimitAndFilters(t *testing.T) {
+	t.Parallel()
+
+	worktreeFor := func(string) string { return "/wt/feature" }
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	n := NewAgentNotifier([]string{"approval"}, time.Minute)
+	observe := func(at time.Duration, sessions ...*models.AgentSession) []AgentNotification {
+		return n.Observe(sessions, worktreeFor, now.Add(at))
+	}
+
+	observe(0, notifySession("a", models.AgentKindClaude, "/wt/feature", models.AgentActivityRunning))
+	if got := observe(time.Second, notifySession("a", models.AgentKindClaude, "/wt/feature", models.AgentActivityWaiting)); len(got) != 0 {
+		t.Fatalf("expected waiting to be filtered out, got %+v", got)
+	}
+	if got := observe(2*time.Second, notifySession("a", models.AgentKindClaude, "/wt/feature", models.AgentActivityApproval)); len(got) != 1 {
+		t.Fatalf("expected an approval notification, got %+v", got)
+	}
+
+	// A second Claude session in the same worktree shares the rate limit,
+	// a different agent does not.
+	got := observe(30*time.Second,
+		notifySession("a", models.AgentKindClaude, "/wt/feature", models.AgentActivityApproval),
+		notifySession("b", models.AgentKindClaude, "/wt/feature", models.AgentActivityApproval),
+		notifySession("c", models.AgentKindCodex, "/wt/feature", models.AgentActivityApproval),
+	)
+	if len(got) != 1 || got[0].Session.SessionKey != "c" {
+		t.Fatalf("expected only the codex session to notify, got %+v", got)
+	}
+
+	inactive := notifySession("d", models.AgentKindPi, "/wt/feature", models.AgentActivityApproval)
+	inactive.LivenessState = models.AgentSessionLivenessInactive
+	if got := observe(2*time.Minute, inactive); len(got) != 0 {
+		t.Fatalf("expected inactive sessions to stay quiet, got %+v", got)
+	}
+}
+
+func TestAgentNotifierRateLimitPerEvent(t *testing.T) {
+	t.Parallel()
+
+	worktreeFor := func(string) string { return "/wt/feature" }
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	n := NewAgentNotifier([]string{"waiting", "approval"}, time.Minute)
+	observe := func(at time.Duration, activity models.AgentActivity) []AgentNotification {
+		return n.Observe([]*models.AgentSession{notifySession("a", models.AgentKindClaude, "/wt/feature", activity)}, worktreeFor, now.Add(at))
+	}
+
+	observe(0, models.AgentActivityRunning)
+	if got := observe(time.Second, models.AgentActivityWaiting); len(got) != 1 {
+		t.Fatalf("expected a waiting notification, got %+v", got)
+	}
+	// An approval right after a waiting notification is still reported.
+	if got := observe(2*time.Second, models.AgentActivityApproval); len(got) != 1 || got[0].Event != AgentNotifyApproval {
+		t.Fatalf("expected an approval notification, got %+v", got)
+	}
+	observe(3*time.Second, models.AgentActivityRunning)
+	if got := observe(4*time.Second, models.AgentActivityWaiting); len(got) != 0 {
+		t.Fatalf("expected a second waiting notification to be rate limited, got %+v", got)
+	}
+}
@@ -26,7 +26,7 @@ type AgentProcess struct {
 }
 
 // AgentProcessService snapshots live agent CLI processes (Claude, Codex,
-// Copilot, and pi). The ps/lsof process scan is deprecated and opt-in via
+// Copilot, Gemini CLI, OpenCode, Aider, and pi). The ps/lsof process scan is deprecated and opt-in via
 // the agent_sessions.process_scan configuration key; hook events installed
 // with `lazyworktree setup-hooks` are the default liveness source.
 type AgentProcessService struct {
@@ -53,7 +53,7 @@ func NewAgentProcessServiceWithRunner(runCmd agentProcessRunner, logf func(strin
 	}
 }
 
-// Refresh discovers live Claude, Codex, and pi processes.
+// Refresh discovers live agent processes.
 func (s *AgentProcessService) Refresh() ([]*AgentProcess, error) {
 	if runtime.GOOS == "windows" {
 		s.mu.Lock()
@@ -209,6 +209,12 @@ func classifyAgentProcess(command, args string) (models.AgentKind, string, bool)
 		return models.AgentKindCodex, "cli", true
 	case isCopilotCLIProcess(base, args):
 		return models.AgentKindCopilot, "cli", true
+	case isGeminiCLIProcess(base, args):
+		return models.AgentKindGemini, "cli", true
+	case isOpenCodeProcess(base, args):
+		return models.AgentKindOpenCode, "cli", true
+	case isAiderProcess(base, args):
+		return models.AgentKindAider, "cli", true
 	case strings.EqualFold(base, "pi"):
 		return models.AgentKindPi, "cli", true
 	default:
@@ -268,6 +274,98 @@ func isCopilotCLIProcess(commandBase, args string) bool {
 	return false
 }
 
+func isGeminiCLIProcess(commandBase, args string) bool {
+	base := strings.ToLower(filepath.Base(strings.TrimSpace(commandBase)))
+	if base == "gemini" {
+		return true
+	}
+
+	tokens := splitCommandTokens(args)
+	switch base {
+	case "node", "bun":
+		for _, token := range tokens {
+			normalized := strings.ReplaceAll(strings.ToLower(token), `\`, "/")
+			if strings.Contains(normalized, "/@google/gemini-cli/") ||
+				strings.EqualFold(filepath.Base(token), "gemini") {
+				return true
+			}
+		}
+	case "npm", "npx", "pnpm", "yarn":
+		for _, token := range tokens {
+			if strings.EqualFold(token, "@google/gemini-cli") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isOpenCodeProcess(commandBase, args string) bool {
+	base := strings.ToLower(filepath.Base(strings.TrimSpace(commandBase)))
+	if base == "opencode" {
+		return true
+	}
+
+	tokens := splitCommandTokens(args)
+	switch base {
+	case "node", "bun":
+		for _, token := range tokens {
+			normalized := strings.ReplaceAll(strings.ToLower(token), `\`, "/")
+			if strings.Contains(normalized, "/opencode-ai/") ||
+				strings.EqualFold(filepath.Base(token), "opencode") {
+				return true
+			}
+		}
+	case "npm", "npx", "pnpm", "yarn", "bunx":
+		for _, token := range tokens {
+			if strings.EqualFold(token, "opencode-ai") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isAiderProcess matches the aider entry point script as well as
+// `python -m aider`. Only the script or module python runs is considered, so
+// arguments passed to another program named aider do not match.
+func isAiderProcess(commandBase, args string) bool {
+	base := strings.ToLower(filepath.Base(strings.TrimSpace(commandBase)))
+	if base == "aider" {
+		return true
+	}
+	if !strings.HasPrefix(base, "python") {
+		return false
+	}
+
+	tokens := splitCommandTokens(args)
+	for i := 1; i < len(tokens); i++ {
+		token := tokens[i]
+		switch {
+		case token == "-m":
+			return i+1 < len(tokens) && tokens[i+1] == "aider"
+		case token == "-c":
+			return false
+		case pythonOptionTakesValue(token):
+			i++
+		case strings.HasPrefix(token, "-"):
+		default:
+			return strings.EqualFold(filepath.Base(token), "aider")
+		}
+	}
+	return false
+}
+
+// pythonOptionTakesValue reports whether a python interpreter option consumes
+// the next argument.
+func pythonOptionTakesValue(token string) bool {
+	switch token {
+	case "-W", "-X", "--check-hash-based-pycs":
+		return true
+	}
+	return false
+}
+
 func isClaudeCLIProcess(commandBase, args string) bool {
 	base := strings.ToLower(filepath.Base(strings.TrimSpace(commandBase)))
 	if base == "claude" || base == "claude-code" {
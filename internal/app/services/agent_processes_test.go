@@ -342,6 +342,39 @@ func TestClassifyAgentProcessCopilot(t *testing.T) {
 	}
 }
 
+func TestClassifyAgentProcessGeminiOpenCodeAider(t *testing.T) {
+	cases := []struct {
+		command string
+		args    string
+		want    models.AgentKind
+	}{
+		{"gemini", "gemini", models.AgentKindGemini},
+		{"node", "node /usr/local/bin/gemini --yolo", models.AgentKindGemini},
+		{"node", "node /usr/lib/node_modules/@google/gemini-cli/dist/index.js", models.AgentKindGemini},
+		{"npx", "npx @google/gemini-cli", models.AgentKindGemini},
+		{"opencode", "opencode", models.AgentKindOpenCode},
+		{"node", "node /usr/lib/node_modules/opencode-ai/bin/opencode", models.AgentKindOpenCode},
+		{"npx", "npx opencode-ai", models.AgentKindOpenCode},
+		{"aider", "/home/me/.local/bin/aider --model sonnet", models.AgentKindAider},
+		{"python3", "python3 /home/me/.local/bin/aider", models.AgentKindAider},
+		{"python3.12", "python3.12 -m aider --no-git", models.AgentKindAider},
+		{"python3", "python3 -u -X dev /opt/aider/bin/aider --yes", models.AgentKindAider},
+		{"python3", "python3 -m http.server", ""},
+		{"python3", "python3 manage.py aider", ""},
+		{"python3", "python3 -m pytest tests/aider", ""},
+		{"python3", "python3 -c import aider aider", ""},
+	}
+	for _, tc := range cases {
+		kind, source, ok := classifyAgentProcess(tc.command, tc.args)
+		if ok != (tc.want != "") {
+			t.Fatalf("classify(%q, %q) matched=%v, want %q", tc.command, tc.args, ok, tc.want)
+		}
+		if ok && (kind != tc.want || source != "cli") {
+			t.Fatalf("classify(%q, %q) = %v/%v, want %v/cli", tc.command, tc.args, kind, source, tc.want)
+		}
+	}
+}
+
 func TestClassifyAgentProcessWindowsCodexWrapper(t *testing.T) {
 	args := `node.exe C:\Users\me\AppData\Roaming\npm\node_modules\@openai\codex\bin\codex.js`
 	kind, source, ok := classifyAgentProcess("node.exe", args)
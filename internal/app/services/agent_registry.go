@@ -291,5 +291,62 @@ func deriveAgentSessionTitle(session *models.AgentSession) string {
 	if session.Agent == models.AgentKindCopilot {
 		return "Copilot session"
 	}
+	if session.Agent == models.AgentKindGemini {
+		return "Gemini session"
+	}
+	if session.Agent == models.AgentKindOpenCode {
+		return "OpenCode session"
+	}
+	if session.Agent == models.AgentKindAider {
+		return "Aider session"
+	}
 	return "Claude session"
 }
+
+// agentResumeHint returns the shell command that reopens the session in its
+// agent, to be run from the session working directory. It is empty when the
+// agent cannot resume the session.
+func agentResumeHint(session *models.AgentSession) string {
+	if session == nil || session.LivenessSource == models.AgentSessionLivenessSourceLaunch {
+		return ""
+	}
+	id := strings.TrimSpace(session.ID)
+	switch session.Agent {
+	case models.AgentKindPi:
+		if strings.TrimSpace(session.JSONLPath) != "" {
+			return "pi --session " + resumeArg(session.JSONLPath)
+		}
+		return ""
+	case models.AgentKindAider:
+		// Aider has no session ids; it replays the worktree chat history.
+		return "aider --restore-chat-history"
+	}
+	if id == "" {
+		return ""
+	}
+	switch session.Agent {
+	case models.AgentKindClaude:
+		return "claude --resume " + resumeArg(id)
+	case models.AgentKindCodex:
+		return "codex resume " + resumeArg(id)
+	case models.AgentKindCopilot:
+		return "copilot --resume " + resumeArg(id)
+	case models.AgentKindGemini:
+		return "gemini --resume " + resumeArg(id)
+	case models.AgentKindOpenCode:
+		return "opencode --session " + resumeArg(id)
+	}
+	return ""
+}
+
+// resumeArg shell-quotes arg unless it only holds characters that are safe
+// unquoted, which keeps the usual id-only hints readable.
+func resumeArg(arg string) string {
+	unsafe := strings.IndexFunc(arg, func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && !strings.ContainsRune("-_./@:", r)
+	})
+	if arg != "" && unsafe < 0 {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+}
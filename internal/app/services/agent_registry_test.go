@@ -74,8 +74,7 @@ func TestAgentSessionServiceFallsBackToRegistryWhenParsingFails(t *testing.T) {
 	)
 
 	service := NewAgentSessionServiceWithStore(
-		claudeRoot,
-		"",
+		AgentSessionRoots{Claude: claudeRoot},
 		NewTestSessionRegistryStore(filepath.Join(root, "registry.json")),
 		nil,
 	)
@@ -112,3 +111,31 @@ func TestAgentSessionServiceFallsBackToRegistryWhenParsingFails(t *testing.T) {
 		t.Fatalf("expected registry fallback source, got %q", second[0].LivenessSource)
 	}
 }
+
+func TestAgentResumeHint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		session models.AgentSession
+		want    string
+	}{
+		{name: "claude", session: models.AgentSession{Agent: models.AgentKindClaude, ID: "0f1e2d3c-aaaa"}, want: "claude --resume 0f1e2d3c-aaaa"},
+		{name: "codex", session: models.AgentSession{Agent: models.AgentKindCodex, ID: "019a-b"}, want: "codex resume 019a-b"},
+		{name: "copilot", session: models.AgentSession{Agent: models.AgentKindCopilot, ID: "cop-1"}, want: "copilot --resume cop-1"},
+		{name: "gemini", session: models.AgentSession{Agent: models.AgentKindGemini, ID: "gem-1"}, want: "gemini --resume gem-1"},
+		{name: "opencode", session: models.AgentSession{Agent: models.AgentKindOpenCode, ID: "ses_1"}, want: "opencode --session ses_1"},
+		{name: "pi quotes the transcript path", session: models.AgentSession{Agent: models.AgentKindPi, JSONLPath: "/home/me/pi sessions/a.jsonl"}, want: "pi --session '/home/me/pi sessions/a.jsonl'"},
+		{name: "aider", session: models.AgentSession{Agent: models.AgentKindAider, ID: "/repo@2026"}, want: "aider --restore-chat-history"},
+		{name: "missing id", session: models.AgentSession{Agent: models.AgentKindClaude}, want: ""},
+		{name: "launch placeholder", session: models.AgentSession{Agent: models.AgentKindClaude, ID: "launch-1", LivenessSource: models.AgentSessionLivenessSourceLaunch}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := agentResumeHint(&tt.session); got != tt.want {
+				t.Fatalf("agentResumeHint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package services
 
 import (
 	"bufio"
+	"cmp"
 	"encoding/json"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -36,6 +38,13 @@ type AgentAdapter interface {
 	) ([]*models.AgentSession, error)
 }
 
+// jsonTranscriptAdapter marks adapters whose transcripts are .json documents
+// rewritten in place rather than appended JSONL files.
+type jsonTranscriptAdapter interface {
+	AgentAdapter
+	jsonTranscripts()
+}
+
 type transcriptAgentAdapter struct {
 	name  string
 	root  string
@@ -66,59 +75,102 @@ func (a *transcriptAgentAdapter) Discover(
 	return discoverSessionsFromDir(a.root, seen, a.parse, cached)
 }
 
-// AgentSessionService discovers Claude and pi transcript sessions from disk.
+// AgentSessionRoots lists the transcript directories scanned per agent. An
+// empty root disables transcript discovery for that agent.
+type AgentSessionRoots struct {
+	Claude   string
+	Pi       string
+	Codex    string
+	Gemini   string
+	OpenCode string
+}
+
+// DefaultAgentSessionRoots returns the transcript locations each agent uses
+// out of the box.
+func DefaultAgentSessionRoots() AgentSessionRoots {
+	return AgentSessionRoots{
+		Claude:   claudeProjectsDir(),
+		Pi:       piSessionsDir(),
+		Codex:    codexSessionsDir(),
+		Gemini:   geminiTmpDir(),
+		OpenCode: openCodeStorageDir(),
+	}
+}
+
+// AgentSessionService discovers agent transcript sessions from disk.
 type AgentSessionService struct {
-	mu         sync.RWMutex
-	cache      map[string]agentSessionCacheEntry
-	sessions   []*models.AgentSession
-	claudeRoot string
-	piRoot     string
-	adapters   []AgentAdapter
-	store      SessionRegistryStore
-	hooks      *AgentHookService
-	logf       func(string, ...any)
+	mu            sync.RWMutex
+	cache         map[string]agentSessionCacheEntry
+	sessions      []*models.AgentSession
+	roots         AgentSessionRoots
+	worktreePaths []string
+	adapters      []AgentAdapter
+	launches      []agentLaunch
+	store         SessionRegistryStore
+	hooks         *AgentHookService
+	logf          func(string, ...any)
 }
 
 // NewAgentSessionService builds a service using the default agent transcript locations.
 func NewAgentSessionService(logf func(string, ...any)) *AgentSessionService {
-	return NewAgentSessionServiceWithStore(claudeProjectsDir(), piSessionsDir(), newFileSessionRegistryStore(), logf)
+	return NewAgentSessionServiceWithStore(DefaultAgentSessionRoots(), newFileSessionRegistryStore(), logf)
 }
 
 // NewAgentSessionServiceFromConfig builds a service using config values when non-empty,
 // falling back to the default agent transcript locations.
-func NewAgentSessionServiceFromConfig(claudeRoot, piRoot string, logf func(string, ...any)) *AgentSessionService {
-	if claudeRoot == "" {
-		claudeRoot = claudeProjectsDir()
-	}
-	if piRoot == "" {
-		piRoot = piSessionsDir()
-	}
-	return NewAgentSessionServiceWithStore(claudeRoot, piRoot, newFileSessionRegistryStore(), logf)
+func NewAgentSessionServiceFromConfig(roots AgentSessionRoots, logf func(string, ...any)) *AgentSessionService {
+	defaults := DefaultAgentSessionRoots()
+	roots.Claude = cmp.Or(roots.Claude, defaults.Claude)
+	roots.Pi = cmp.Or(roots.Pi, defaults.Pi)
+	roots.Codex = cmp.Or(roots.Codex, defaults.Codex)
+	roots.Gemini = cmp.Or(roots.Gemini, defaults.Gemini)
+	roots.OpenCode = cmp.Or(roots.OpenCode, defaults.OpenCode)
+	return NewAgentSessionServiceWithStore(roots, newFileSessionRegistryStore(), logf)
 }
 
 // NewAgentSessionServiceWithRoots builds a service with explicit roots for tests.
-func NewAgentSessionServiceWithRoots(claudeRoot, piRoot string, logf func(string, ...any)) *AgentSessionService {
-	return NewAgentSessionServiceWithStore(claudeRoot, piRoot, newFileSessionRegistryStore(), logf)
+func NewAgentSessionServiceWithRoots(roots AgentSessionRoots, logf func(string, ...any)) *AgentSessionService {
+	return NewAgentSessionServiceWithStore(roots, newFileSessionRegistryStore(), logf)
 }
 
 // NewAgentSessionServiceWithStore builds a service with explicit roots and registry storage.
-func NewAgentSessionServiceWithStore(claudeRoot, piRoot string, store SessionRegistryStore, logf func(string, ...any)) *AgentSessionService {
-	return &AgentSessionService{
-		cache:      make(map[string]agentSessionCacheEntry),
-		claudeRoot: claudeRoot,
-		piRoot:     piRoot,
-		adapters: []AgentAdapter{
-			&transcriptAgentAdapter{name: "claude", root: claudeRoot, parse: parseClaudeSession},
-			&transcriptAgentAdapter{name: "pi", root: piRoot, parse: parsePiSession},
-		},
+func NewAgentSessionServiceWithStore(roots AgentSessionRoots, store SessionRegistryStore, logf func(string, ...any)) *AgentSessionService {
+	s := &AgentSessionService{
+		cache: make(map[string]agentSessionCacheEntry),
+		roots: roots,
 		store: store,
 		logf:  logf,
 	}
+	s.adapters = []AgentAdapter{
+		&transcriptAgentAdapter{name: "claude", root: roots.Claude, parse: parseClaudeSession},
+		&transcriptAgentAdapter{name: "pi", root: roots.Pi, parse: parsePiSession},
+		&transcriptAgentAdapter{name: "codex", root: roots.Codex, parse: parseCodexSession},
+		&geminiAgentAdapter{root: roots.Gemini, worktreePaths: s.WorktreePaths},
+		&openCodeAgentAdapter{root: roots.OpenCode},
+		&aiderAgentAdapter{worktreePaths: s.WorktreePaths},
+	}
+	return s
+}
+
+// SetWorktreePaths records the worktrees of the current repository. Agents
+// that keep their history inside the project (Aider) or key it by a hash of
+// the project path (Gemini CLI) are discovered through these paths.
+func (s *AgentSessionService) SetWorktreePaths(paths []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.worktreePaths = slices.Clone(paths)
+}
+
+// WorktreePaths returns the paths recorded by SetWorktreePaths.
+func (s *AgentSessionService) WorktreePaths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.worktreePaths)
 }
 
 // WatchRoots returns the directories that should be watched for transcript changes.
 func (s *AgentSessionService) WatchRoots() []string {
-	roots := make([]string, 0, 2)
+	roots := make([]string, 0, len(s.adapters))
 	for _, adapter := range s.adapters {
 		if adapter == nil {
 			continue
@@ -132,6 +184,21 @@ func (s *AgentSessionService) WatchRoots() []string {
 	return roots
 }
 
+// JSONWatchRoots returns the watch roots whose transcripts are .json files
+// rather than JSONL, so the watcher can react to their changes.
+func (s *AgentSessionService) JSONWatchRoots() []string {
+	var roots []string
+	for _, adapter := range s.adapters {
+		if _, ok := adapter.(jsonTranscriptAdapter); !ok {
+			continue
+		}
+		if root := strings.TrimSpace(adapter.WatchRoot()); root != "" {
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
 // Refresh re-discovers all transcript sessions and updates the cache.
 func (s *AgentSessionService) Refresh() ([]*models.AgentSession, error) {
 	return s.RefreshWithProcesses(nil)
@@ -204,6 +271,7 @@ func (s *AgentSessionService) RefreshWithProcesses(processes []*AgentProcess) ([
 	}
 
 	s.mu.Lock()
+	sessions = s.withPendingLaunches(sessions, now)
 	s.sessions = sessions
 	out := cloneAgentSessions(s.sessions)
 	s.mu.Unlock()
@@ -337,8 +405,10 @@ func piSessionsDir() string {
 }
 
 type claudeJSONLMessage struct {
+	ID          string          `json:"id"`
 	Role        string          `json:"role"`
 	Model       string          `json:"model"`
+	Usage       *claudeUsage    `json:"usage"`
 	RawContent  json.RawMessage `json:"content"`
 	TextContent string          `json:"-"`
 	Content     []contentBlock  `json:"-"`
@@ -352,10 +422,14 @@ type contentBlock struct {
 	ToolUseID string          `json:"tool_use_id"`
 	Input     json.RawMessage `json:"input"`
 	Arguments json.RawMessage `json:"arguments"`
+	Thinking  string          `json:"thinking"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
 }
 
 type claudeEnvelope struct {
 	Type      string              `json:"type"`
+	IsMeta    bool                `json:"isMeta"`
 	CWD       string              `json:"cwd"`
 	GitBranch string              `json:"gitBranch"`
 	Timestamp string              `json:"timestamp"`
@@ -375,6 +449,24 @@ type claudeProgressMessage struct {
 	Message   *claudeJSONLMessage `json:"message"`
 }
 
+// claudeUsage is the token accounting of one assistant message. Claude Code
+// writes one line per content block, each repeating the message usage.
+type claudeUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+func (u *claudeUsage) tokenUsage() models.AgentTokenUsage {
+	return models.AgentTokenUsage{
+		Input:      u.InputTokens,
+		Output:     u.OutputTokens,
+		CacheWrite: u.CacheCreationInputTokens,
+		CacheRead:  u.CacheReadInputTokens,
+	}
+}
+
 type normalizedClaudeEntry struct {
 	Type              string
 	CWD               string
@@ -432,6 +524,7 @@ func parseClaudeSession(path, encodedDir string) (*models.AgentSession, error) {
 	var lastMeaningful *normalizedClaudeEntry
 	pendingTools := make(map[string]*pendingClaudeTool)
 	pendingToolOrder := 0
+	usageByMessage := make(map[string]models.AgentTokenUsage)
 	for scanner.Scan() {
 		var envelope claudeEnvelope
 		if err := json.Unmarshal(scanner.Bytes(), &envelope); err != nil {
@@ -469,6 +562,13 @@ func parseClaudeSession(path, encodedDir string) (*models.AgentSession, error) {
 			if entry.Message != nil && entry.Message.Model != "" {
 				session.Model = entry.Message.Model
 			}
+			if entry.Type == "assistant" && entry.Message != nil && entry.Message.Usage != nil {
+				if entry.Message.ID == "" {
+					session.Usage = session.Usage.Add(entry.Message.Usage.tokenUsage())
+				} else {
+					usageByMessage[entry.Message.ID] = entry.Message.Usage.tokenUsage()
+				}
+			}
 			if entry.Message != nil && !entry.FromAgentProgress {
 				switch entry.Type {
 				case "user":
@@ -498,6 +598,9 @@ func parseClaudeSession(path, encodedDir string) (*models.AgentSession, error) {
 							session.LastToolAt = entryTS
 							if path := extractTargetPath(block.Input); path != "" {
 								session.LastTargetPath = path
+								if isAgentEditTool(block.Name) {
+									noteEditedPath(session, path, session.LastToolAt)
+								}
 							}
 							if command := extractCommandText(block.Input); command != "" {
 								session.LastCommand = command
@@ -528,6 +631,9 @@ func parseClaudeSession(path, encodedDir string) (*models.AgentSession, error) {
 	if session.CWD == "" {
 		session.CWD = decodeClaudeProjectDir(encodedDir)
 	}
+	for _, usage := range usageByMessage {
+		session.Usage = session.Usage.Add(usage)
+	}
 	var role string
 	var hasToolUse, isToolResult bool
 	var toolName string
@@ -578,7 +684,7 @@ func parseClaudeSession(path, encodedDir string) (*models.AgentSession, error) {
 	session.TaskLabel = deriveAgentTaskLabel(session)
 	session.Title = deriveAgentSessionTitle(session)
 	session.SessionKey = agentSessionKey(session)
-	session.ResumeHint = session.JSONLPath
+	session.ResumeHint = agentResumeHint(session)
 	return session, nil
 }
 
@@ -701,9 +807,11 @@ type piEntry struct {
 }
 
 type piMessage struct {
-	Role    string          `json:"role"`
-	Model   string          `json:"model"`
-	Content json.RawMessage `json:"content"`
+	Role     string          `json:"role"`
+	Model    string          `json:"model"`
+	Content  json.RawMessage `json:"content"`
+	ToolName string          `json:"toolName"`
+	IsError  bool            `json:"isError"`
 }
 
 func parsePiSession(path, encodedDir string) (*models.AgentSession, error) {
@@ -785,6 +893,9 @@ func parsePiSession(path, encodedDir string) (*models.AgentSession, error) {
 				session.LastToolAt = ts
 				if path := extractTargetPath(block.Arguments); path != "" {
 					session.LastTargetPath = path
+					if isAgentEditTool(session.LastToolName) {
+						noteEditedPath(session, path, session.LastToolAt)
+					}
 				}
 				if command := extractCommandText(block.Arguments); command != "" {
 					session.LastCommand = command
@@ -822,7 +933,7 @@ func parsePiSession(path, encodedDir string) (*models.AgentSession, error) {
 	session.TaskLabel = deriveAgentTaskLabel(session)
 	session.Title = deriveAgentSessionTitle(session)
 	session.SessionKey = agentSessionKey(session)
-	session.ResumeHint = session.JSONLPath
+	session.ResumeHint = agentResumeHint(session)
 	return session, nil
 }
 
@@ -1149,7 +1260,7 @@ func (s *AgentSessionService) mergeWithRegistryFallbacks(
 			session.SessionKey = agentSessionKey(session)
 		}
 		session.Title = deriveAgentSessionTitle(session)
-		session.ResumeHint = session.JSONLPath
+		session.ResumeHint = agentResumeHint(session)
 		if prev := previous[session.SessionKey]; prev != nil {
 			if session.LastObservedAt.IsZero() {
 				session.LastObservedAt = prev.LastObservedAt
@@ -1189,7 +1300,7 @@ func (s *AgentSessionService) mergeWithRegistryFallbacks(
 		fallback.SessionKey = agentSessionKey(fallback)
 		fallback.Title = deriveAgentSessionTitle(fallback)
 		if strings.TrimSpace(fallback.ResumeHint) == "" {
-			fallback.ResumeHint = fallback.JSONLPath
+			fallback.ResumeHint = agentResumeHint(fallback)
 		}
 		merged = append(merged, fallback)
 	}
@@ -1214,7 +1325,7 @@ func (s *AgentSessionService) classifySessionLiveness(
 			session.Title = deriveAgentSessionTitle(session)
 		}
 		if strings.TrimSpace(session.ResumeHint) == "" {
-			session.ResumeHint = session.JSONLPath
+			session.ResumeHint = agentResumeHint(session)
 		}
 		if prev := previous[session.SessionKey]; prev != nil && session.LastObservedAt.Before(prev.LastObservedAt) {
 			session.LastObservedAt = prev.LastObservedAt
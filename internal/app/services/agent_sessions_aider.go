@@ -0,0 +1,136 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const (
+	aiderSessionSchema = "aider-md-v1"
+	aiderHistoryFile   = ".aider.chat.history.md"
+	aiderChatStarted   = "# aider chat started at "
+)
+
+// aiderAgentAdapter reads the chat history Aider appends to
+// .aider.chat.history.md at the root of the project it runs in. Each run
+// starts a new section, of which only the latest is reported.
+type aiderAgentAdapter struct {
+	worktreePaths func() []string
+}
+
+func (a *aiderAgentAdapter) Name() string { return "aider" }
+
+// WatchRoot is empty: the history lives inside worktrees, which are not
+// watched, so Aider sessions follow the regular refresh.
+func (a *aiderAgentAdapter) WatchRoot() string { return "" }
+
+func (a *aiderAgentAdapter) Discover(
+	seen map[string]struct{},
+	cached func(path string, parse func() (*models.AgentSession, error)) (*models.AgentSession, error),
+) ([]*models.AgentSession, error) {
+	if a.worktreePaths == nil {
+		return nil, nil
+	}
+	var sessions []*models.AgentSession
+	for _, worktreePath := range a.worktreePaths() {
+		path := filepath.Join(worktreePath, aiderHistoryFile)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		seen[path] = struct{}{}
+		session, err := cached(path, func() (*models.AgentSession, error) {
+			return parseAiderHistory(path, worktreePath)
+		})
+		if err == nil && session != nil {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func parseAiderHistory(path, projectRoot string) (*models.AgentSession, error) {
+	//nolint:gosec // History paths are built from the worktrees of the current repository.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+	idx := strings.LastIndex(content, aiderChatStarted)
+	if idx < 0 {
+		return nil, nil
+	}
+	content = content[idx:]
+
+	session := &models.AgentSession{
+		Agent:         models.AgentKindAider,
+		CWD:           projectRoot,
+		JSONLPath:     path,
+		LastActivity:  info.ModTime(),
+		SchemaVersion: aiderSessionSchema,
+	}
+
+	var role string
+	var reply []string
+	flushReply := func() {
+		if text := compactWhitespace(strings.Join(reply, " ")); text != "" {
+			session.LastReplyText = text
+			role = "assistant"
+		}
+		reply = reply[:0]
+	}
+	for line := range strings.SplitSeq(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, aiderChatStarted):
+			started := strings.TrimSpace(strings.TrimPrefix(line, aiderChatStarted))
+			// The start time tells runs in the same worktree apart.
+			session.ID = projectRoot + "@" + started
+		case strings.HasPrefix(line, "#### "):
+			flushReply()
+			if text := compactWhitespace(strings.TrimPrefix(line, "#### ")); text != "" {
+				session.LastPromptText = text
+			}
+			role = "user"
+		case strings.HasPrefix(line, "> "):
+			flushReply()
+			parseAiderNotice(session, strings.TrimPrefix(line, "> "))
+		case strings.TrimSpace(line) != "" && role != "":
+			reply = append(reply, line)
+		}
+	}
+	flushReply()
+
+	applyAgentStatus(session, role, false, "", false)
+	session.TaskLabel = deriveAgentTaskLabel(session)
+	session.Title = deriveAgentSessionTitle(session)
+	session.SessionKey = agentSessionKey(session)
+	session.ResumeHint = agentResumeHint(session)
+	return session, nil
+}
+
+// parseAiderNotice records the model and edits from the "> " tool output
+// lines Aider writes between messages.
+func parseAiderNotice(session *models.AgentSession, notice string) {
+	notice = strings.TrimSpace(notice)
+	for _, prefix := range []string{"Main model: ", "Model: "} {
+		if rest, ok := strings.CutPrefix(notice, prefix); ok {
+			if model, _, _ := strings.Cut(rest, " "); model != "" {
+				session.Model = model
+			}
+			return
+		}
+	}
+	if file, ok := strings.CutPrefix(notice, "Applied edit to "); ok {
+		session.LastToolName = "Edit"
+		session.LastTargetPath = strings.TrimSpace(file)
+		noteEditedPath(session, file, time.Time{})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const testAiderHistory = `
+# aider chat started at 2026-01-01 09:00:00
+
+#### An older run
+
+# aider chat started at 2026-01-02 10:30:00
+
+> /home/me/.local/bin/aider --model sonnet
+> Aider v0.86.0
+> Main model: anthropic/claude-sonnet-4 with diff edit format, infinite output
+> Git repo: .git with 42 files
+
+#### Add a   --verbose flag
+
+I will add the flag to the CLI.
+
+> Applied edit to cmd/main.go
+> Commit 1a2b3c4 feat: add verbose flag
+`
+
+func TestParseAiderHistory(t *testing.T) {
+	t.Parallel()
+
+	worktreePath := t.TempDir()
+	path := filepath.Join(worktreePath, aiderHistoryFile)
+	if err := os.WriteFile(path, []byte(testAiderHistory), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := parseAiderHistory(path, worktreePath)
+	if err != nil {
+		t.Fatalf("parseAiderHistory returned error: %v", err)
+	}
+	if session.Agent != models.AgentKindAider || session.ID != worktreePath+"@2026-01-02 10:30:00" {
+		t.Fatalf("unexpected identity %q/%q", session.Agent, session.ID)
+	}
+	if session.Model != "anthropic/claude-sonnet-4" || session.LastPromptText != "Add a --verbose flag" {
+		t.Fatalf("unexpected model %q or prompt %q", session.Model, session.LastPromptText)
+	}
+	if session.LastReplyText != "I will add the flag to the CLI." || session.LastTargetPath != "cmd/main.go" {
+		t.Fatalf("unexpected reply %q or target %q", session.LastReplyText, session.LastTargetPath)
+	}
+	if session.Status != models.AgentSessionStatusWaitingForUser {
+		t.Fatalf("expected a replied prompt to wait for the user, got %q", session.Status)
+	}
+}
+
+func TestAgentSessionServiceDiscoversAiderHistory(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	worktreePath := filepath.Join(root, "feature")
+	if err := os.MkdirAll(worktreePath, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	history := "# aider chat started at 2026-01-02 10:30:00\n\n#### Fix the build\n"
+	if err := os.WriteFile(filepath.Join(worktreePath, aiderHistoryFile), []byte(history), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	service.SetWorktreePaths([]string{worktreePath, filepath.Join(root, "other")})
+	if _, err := service.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	matching := service.SessionsForWorktree(worktreePath)
+	if len(matching) != 1 || matching[0].Agent != models.AgentKindAider {
+		t.Fatalf("expected one Aider session, got %#v", matching)
+	}
+	if matching[0].Status != models.AgentSessionStatusThinking {
+		t.Fatalf("expected an unanswered prompt to be thinking, got %q", matching[0].Status)
+	}
+	if roots := service.WatchRoots(); len(roots) != 0 {
+		t.Fatalf("expected Aider to add no watch roots, got %#v", roots)
+	}
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const codexSessionSchema = "codex-jsonl-v1"
+
+// codexRolloutLine is one line of a Codex rollout file. Current Codex
+// versions wrap every item in a typed envelope with a payload; older ones
+// wrote a bare session header followed by bare response items.
+type codexRolloutLine struct {
+	Timestamp string          `json:"timestamp"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type codexItem struct {
+	Type      string            `json:"type"`
+	ID        string            `json:"id"`
+	CWD       string            `json:"cwd"`
+	Model     string            `json:"model"`
+	Role      string            `json:"role"`
+	Content   []codexContent    `json:"content"`
+	Name      string            `json:"name"`
+	Arguments string            `json:"arguments"`
+	Input     string            `json:"input"`
+	CallID    string            `json:"call_id"`
+	Action    *codexShellAction `json:"action"`
+	Message   string            `json:"message"`
+	Git       *codexGitInfo     `json:"git"`
+	Output    json.RawMessage   `json:"output"`
+	Summary   []codexContent    `json:"summary"`
+	Info      *codexTokenInfo   `json:"info"`
+}
+
+// codexTokenInfo is the payload of a token_count event. The totals are
+// cumulative over the whole session.
+type codexTokenInfo struct {
+	Total struct {
+		InputTokens       int64 `json:"input_tokens"`
+		CachedInputTokens int64 `json:"cached_input_tokens"`
+		OutputTokens      int64 `json:"output_tokens"`
+	} `json:"total_token_usage"`
+}
+
+type codexContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type codexShellAction struct {
+	Command []string `json:"command"`
+}
+
+type codexGitInfo struct {
+	Branch string `json:"branch"`
+}
+
+type pendingCodexTool struct {
+	name    string
+	at      time.Time
+	path    string
+	command string
+	// edited lists the files written by the call.
+	edited []string
+}
+
+func codexSessionsDir() string {
+	if codexHome := strings.TrimSpace(os.Getenv("CODEX_HOME")); codexHome != "" {
+		return filepath.Join(codexHome, "sessions")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".codex", "sessions")
+}
+
+// parseCodexSession reads a Codex rollout file from
+// $CODEX_HOME/sessions/YYYY/MM/DD/rollout-<timestamp>-<id>.jsonl.
+func parseCodexSession(path, _ string) (*models.AgentSession, error) {
+	//nolint:gosec // Transcript paths come from local agent directories discovered by the application.
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.AgentSession{
+		ID:            codexSessionIDFromPath(path),
+		Agent:         models.AgentKindCodex,
+		JSONLPath:     path,
+		LastActivity:  info.ModTime(),
+		SchemaVersion: codexSessionSchema,
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+
+	var role string
+	var isToolResult bool
+	pending := make(map[string]pendingCodexTool)
+	var pendingOrder []string
+	for scanner.Scan() {
+		var line codexRolloutLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			continue
+		}
+		kind, raw := line.Type, line.Payload
+		if len(raw) == 0 {
+			// Legacy rollouts: an untyped session header, then bare items.
+			kind, raw = "response_item", scanner.Bytes()
+			if line.Type == "" {
+				kind = "session_meta"
+			}
+		}
+		var item codexItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			continue
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, line.Timestamp)
+		if !ts.IsZero() {
+			session.LastActivity = ts
+		}
+
+		switch kind {
+		case "session_meta":
+			if item.ID != "" {
+				session.ID = item.ID
+			}
+			if item.CWD != "" {
+				session.CWD = item.CWD
+			}
+			if item.Git != nil && item.Git.Branch != "" {
+				session.GitBranch = item.Git.Branch
+			}
+		case "turn_context":
+			if item.CWD != "" {
+				session.CWD = item.CWD
+			}
+			if item.Model != "" {
+				session.Model = item.Model
+			}
+		case "compacted":
+			session.LastSummaryAt = ts
+		case "event_msg":
+			switch item.Type {
+			case "user_message":
+				if text := compactWhitespace(item.Message); text != "" {
+					session.LastPromptText = text
+				}
+				role, isToolResult = "user", false
+			case "agent_message":
+				if text := compactWhitespace(item.Message); text != "" {
+					session.LastReplyText = text
+				}
+			case "token_count":
+				if item.Info != nil {
+					// Codex counts cached tokens as part of the input.
+					total := item.Info.Total
+					session.Usage = models.AgentTokenUsage{
+						Input:     total.InputTokens - total.CachedInputTokens,
+						Output:    total.OutputTokens,
+						CacheRead: total.CachedInputTokens,
+					}
+				}
+			case "task_complete", "turn_aborted":
+				// The turn is over: any unanswered call was abandoned.
+				role, isToolResult = "assistant", false
+				clear(pending)
+				pendingOrder = pendingOrder[:0]
+			}
+		case "response_item":
+			switch item.Type {
+			case "message":
+				text := firstCodexText(item.Content)
+				switch item.Role {
+				case "user":
+					if text == "" {
+						// Only environment and instruction preambles.
+						continue
+					}
+					session.LastPromptText = text
+					role, isToolResult = "user", false
+				case "assistant":
+					if text != "" {
+						session.LastReplyText = text
+					}
+					role, isToolResult = "assistant", false
+				}
+			case "reasoning":
+				role, isToolResult = "user", false
+			case "function_call", "custom_tool_call", "local_shell_call":
+				tool := codexToolCall(item)
+				tool.at = ts
+				session.LastToolName = tool.name
+				session.LastToolAt = ts
+				if tool.path != "" {
+					session.LastTargetPath = tool.path
+				}
+				if tool.command != "" {
+					session.LastCommand = tool.command
+				}
+				for _, path := range tool.edited {
+					noteEditedPath(session, path, session.LastToolAt)
+				}
+				if item.CallID != "" {
+					pending[item.CallID] = tool
+					pendingOrder = append(pendingOrder, item.CallID)
+				}
+				role, isToolResult = "assistant", false
+			case "function_call_output", "custom_tool_call_output", "local_shell_call_output":
+				delete(pending, item.CallID)
+				role, isToolResult = "user", true
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var current *pendingCodexTool
+	for i := len(pendingOrder) - 1; i >= 0; i-- {
+		if tool, ok := pending[pendingOrder[i]]; ok {
+			current = &tool
+			break
+		}
+	}
+	if current != nil {
+		session.LastToolName = current.name
+		session.LastToolAt = current.at
+		if current.path != "" {
+			session.LastTargetPath = current.path
+		}
+		if current.command != "" {
+			session.LastCommand = current.command
+		}
+		applyAgentStatus(session, "assistant", true, current.name, false)
+	} else {
+		applyAgentStatus(session, role, false, "", isToolResult)
+	}
+	session.TaskLabel = deriveAgentTaskLabel(session)
+	session.Title = deriveAgentSessionTitle(session)
+	session.SessionKey = agentSessionKey(session)
+	session.ResumeHint = agentResumeHint(session)
+	return session, nil
+}
+
+// codexSessionIDFromPath extracts the trailing UUID of a rollout file name,
+// used when the session header is missing.
+func codexSessionIDFromPath(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	// A UUID is 36 characters: 8-4-4-4-12.
+	if len(name) > 36 && name[len(name)-37] == '-' && strings.Count(name[len(name)-36:], "-") == 4 {
+		return name[len(name)-36:]
+	}
+	return name
+}
+
+// firstCodexText returns the first text block that is a real message rather
+// than the environment and instruction preambles Codex injects as user input.
+func firstCodexText(content []codexContent) string {
+	for _, block := range content {
+		text := strings.TrimSpace(block.Text)
+		if text == "" || strings.HasPrefix(text, "<environment_context>") ||
+			strings.HasPrefix(text, "<user_instructions>") || strings.HasPrefix(text, "# AGENTS.md") {
+			continue
+		}
+		return compactWhitespace(text)
+	}
+	return ""
+}
+
+func codexToolCall(item codexItem) pendingCodexTool {
+	tool := pendingCodexTool{name: normalizeCodexToolName(item.Name)}
+	var patch string
+	switch item.Type {
+	case "local_shell_call":
+		tool.name = "Bash"
+		if item.Action != nil {
+			tool.command = codexShellCommand(item.Action.Command)
+		}
+	case "custom_tool_call":
+		patch = item.Input
+		tool.path = codexPatchPath(patch)
+	default:
+		raw := json.RawMessage(item.Arguments)
+		var args struct {
+			Command json.RawMessage `json:"command"`
+			Input   string          `json:"input"`
+		}
+		if json.Unmarshal(raw, &args) == nil && len(args.Command) > 0 && args.Command[0] == '[' {
+			var argv []string
+			_ = json.Unmarshal(args.Command, &argv)
+			tool.command = codexShellCommand(argv)
+		} else {
+			tool.command = extractCommandText(raw)
+		}
+		patch = args.Input
+		tool.path = extractTargetPath(raw)
+		if tool.path == "" && patch != "" {
+			tool.path = codexPatchPath(patch)
+		}
+	}
+	if tool.name == "Edit" {
+		// apply_patch input is the patch, not a command to run.
+		tool.command = ""
+		tool.edited = codexPatchPaths(patch)
+		if len(tool.edited) == 0 && tool.path != "" {
+			tool.edited = []string{tool.path}
+		}
+	}
+	return tool
+}
+
+// codexShellCommand turns an exec argv into the command the user would type,
+// unwrapping the `bash -lc "<script>"` form Codex uses for most calls.
+func codexShellCommand(argv []string) string {
+	if len(argv) == 3 && (argv[1] == "-lc" || argv[1] == "-c") {
+		switch filepath.Base(argv[0]) {
+		case "bash", "sh", "zsh":
+			return compactWhitespace(argv[2])
+		}
+	}
+	return compactWhitespace(strings.Join(argv, " "))
+}
+
+// codexPatchPath returns the first file touched by an apply_patch body.
+func codexPatchPath(patch string) string {
+	if paths := codexPatchPaths(patch); len(paths) > 0 {
+		return paths[0]
+	}
+	return ""
+}
+
+// codexPatchPaths returns every file touched by an apply_patch body.
+func codexPatchPaths(patch string) []string {
+	var paths []string
+	for line := range strings.SplitSeq(patch, "\n") {
+		for _, prefix := range []string{"*** Update File: ", "*** Add File: ", "*** Delete File: ", "*** Move to: "} {
+			if path, ok := strings.CutPrefix(strings.TrimSpace(line), prefix); ok {
+				paths = append(paths, strings.TrimSpace(path))
+			}
+		}
+	}
+	return paths
+}
+
+func normalizeCodexToolName(name string) string {
+	switch name {
+	case "shell", "container.exec", "exec_command", "shell_command", "local_shell":
+		return "Bash"
+	case "apply_patch":
+		return "Edit"
+	case "read_file", "view_image":
+		return "Read"
+	case "list_dir", "grep_files":
+		return "Grep"
+	case "web_search":
+		return "WebSearch"
+	case "update_plan":
+		return "TodoWrite"
+	default:
+		if name == "" {
+			return ""
+		}
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+}
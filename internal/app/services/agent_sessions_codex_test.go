@@ -0,0 +1,204 @@
+package services
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const testCodexSessionID = "0199a213-81c0-7800-8aa1-bbab2a035a53"
+
+func codexLine(t *testing.T, ts time.Time, kind string, payload map[string]any) string {
+	t.Helper()
+	return mustJSONLine(t, map[string]any{
+		"timestamp": ts.Format(time.RFC3339Nano),
+		"type":      kind,
+		"payload":   payload,
+	})
+}
+
+func TestParseCodexSession(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	ts := time.Now().UTC()
+	args, err := json.Marshal(map[string]any{"command": []string{"bash", "-lc", "go test ./..."}, "workdir": worktreePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(root, "2025", "10", "01", "rollout-2025-10-01T10-00-00-"+testCodexSessionID+".jsonl")
+	writeJSONLLines(
+		t, path,
+		codexLine(t, ts, "session_meta", map[string]any{
+			"id":  testCodexSessionID,
+			"cwd": worktreePath,
+			"git": map[string]any{"branch": "feature"},
+		}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type": "message",
+			"role": "user",
+			"content": []map[string]any{
+				{"type": "input_text", "text": "<environment_context>\n  <cwd>" + worktreePath + "</cwd>\n</environment_context>"},
+			},
+		}),
+		codexLine(t, ts, "turn_context", map[string]any{"cwd": worktreePath, "model": "gpt-5-codex"}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "message",
+			"role":    "user",
+			"content": []map[string]any{{"type": "input_text", "text": "Fix the flaky   parser test"}},
+		}),
+		codexLine(t, ts, "event_msg", map[string]any{"type": "user_message", "message": "Fix the flaky   parser test"}),
+		codexLine(t, ts, "response_item", map[string]any{"type": "reasoning", "summary": []any{}}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "custom_tool_call",
+			"name":    "apply_patch",
+			"call_id": "call_1",
+			"input":   "*** Begin Patch\n*** Update File: internal/parser.go\n@@\n-a\n+b\n*** Add File: internal/parser_test.go\n+c\n*** End Patch",
+		}),
+		codexLine(t, ts, "response_item", map[string]any{"type": "custom_tool_call_output", "call_id": "call_1", "output": "Success"}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":      "function_call",
+			"name":      "shell",
+			"call_id":   "call_2",
+			"arguments": string(args),
+		}),
+	)
+
+	session, err := parseCodexSession(path, "2025")
+	if err != nil {
+		t.Fatalf("parseCodexSession returned error: %v", err)
+	}
+	if session.Agent != models.AgentKindCodex || session.ID != testCodexSessionID {
+		t.Fatalf("unexpected identity %q/%q", session.Agent, session.ID)
+	}
+	if session.CWD != worktreePath || session.GitBranch != "feature" || session.Model != "gpt-5-codex" {
+		t.Fatalf("unexpected metadata cwd=%q branch=%q model=%q", session.CWD, session.GitBranch, session.Model)
+	}
+	if session.LastPromptText != "Fix the flaky parser test" {
+		t.Fatalf("expected the prompt without the environment preamble, got %q", session.LastPromptText)
+	}
+	if session.LastTargetPath != "internal/parser.go" {
+		t.Fatalf("expected the patched file as target, got %q", session.LastTargetPath)
+	}
+	if !slices.Equal(session.EditedPaths, []string{"internal/parser.go", "internal/parser_test.go"}) {
+		t.Fatalf("expected every patched file recorded, got %v", session.EditedPaths)
+	}
+	if session.Status != models.AgentSessionStatusExecutingTool || session.CurrentTool != "Bash" {
+		t.Fatalf("expected a running shell call, got %q/%q", session.Status, session.CurrentTool)
+	}
+	if session.TaskLabel != "running go test ./..." {
+		t.Fatalf("expected the unwrapped shell command as task label, got %q", session.TaskLabel)
+	}
+	if session.Activity != models.AgentActivityRunning {
+		t.Fatalf("expected running activity, got %q", session.Activity)
+	}
+}
+
+func TestParseCodexSessionTurnComplete(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	ts := time.Now().UTC()
+	path := filepath.Join(root, "rollout-2025-10-01T10-00-00-"+testCodexSessionID+".jsonl")
+	writeJSONLLines(
+		t, path,
+		codexLine(t, ts, "session_meta", map[string]any{"id": testCodexSessionID, "cwd": root}),
+		codexLine(t, ts, "event_msg", map[string]any{"type": "user_message", "message": "Summarise the repo"}),
+		codexLine(t, ts, "response_item", map[string]any{"type": "function_call", "name": "shell", "call_id": "call_1", "arguments": `{"command":["ls"]}`}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "message",
+			"role":    "assistant",
+			"content": []map[string]any{{"type": "output_text", "text": "It is a Go TUI."}},
+		}),
+		codexLine(t, ts, "event_msg", map[string]any{"type": "task_complete"}),
+	)
+
+	session, err := parseCodexSession(path, "")
+	if err != nil {
+		t.Fatalf("parseCodexSession returned error: %v", err)
+	}
+	if session.Status != models.AgentSessionStatusWaitingForUser {
+		t.Fatalf("expected a completed turn to wait for the user, got %q", session.Status)
+	}
+	if session.CurrentTool != "" {
+		t.Fatalf("expected the abandoned call to be dropped, got %q", session.CurrentTool)
+	}
+	if session.LastReplyText != "It is a Go TUI." {
+		t.Fatalf("unexpected reply %q", session.LastReplyText)
+	}
+}
+
+func TestParseCodexSessionLegacyRollout(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	path := filepath.Join(root, "rollout-2025-05-07T17-24-21-"+testCodexSessionID+".jsonl")
+	writeJSONLLines(
+		t, path,
+		mustJSONLine(t, map[string]any{"id": testCodexSessionID, "timestamp": "2025-05-07T17:24:21.123Z", "instructions": ""}),
+		mustJSONLine(t, map[string]any{"record_type": "state"}),
+		mustJSONLine(t, map[string]any{
+			"type":    "message",
+			"role":    "user",
+			"content": []map[string]any{{"type": "input_text", "text": "Add a changelog"}},
+		}),
+	)
+
+	session, err := parseCodexSession(path, "")
+	if err != nil {
+		t.Fatalf("parseCodexSession returned error: %v", err)
+	}
+	if session.ID != testCodexSessionID || session.LastPromptText != "Add a changelog" {
+		t.Fatalf("unexpected legacy session %q/%q", session.ID, session.LastPromptText)
+	}
+	if session.Status != models.AgentSessionStatusThinking {
+		t.Fatalf("expected thinking after a prompt, got %q", session.Status)
+	}
+}
+
+func TestAgentSessionServiceDiscoversCodexRollouts(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	codexRoot := filepath.Join(root, "codex")
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	writeJSONLLines(
+		t, filepath.Join(codexRoot, "2025", "10", "01", "rollout-2025-10-01T10-00-00-"+testCodexSessionID+".jsonl"),
+		codexLine(t, time.Now().UTC(), "session_meta", map[string]any{"id": testCodexSessionID, "cwd": worktreePath}),
+	)
+
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{Codex: codexRoot}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	if _, err := service.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	matching := service.SessionsForWorktree(worktreePath)
+	if len(matching) != 1 || matching[0].Agent != models.AgentKindCodex {
+		t.Fatalf("expected one Codex session, got %#v", matching)
+	}
+	if roots := service.WatchRoots(); len(roots) != 1 || roots[0] != codexRoot {
+		t.Fatalf("expected the Codex root to be watched, got %#v", roots)
+	}
+}
+
+func TestCodexShellCommand(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		argv []string
+		want string
+	}{
+		{argv: []string{"bash", "-lc", "make  test"}, want: "make test"},
+		{argv: []string{"/bin/zsh", "-c", "ls"}, want: "ls"},
+		{argv: []string{"rg", "-n", "TODO"}, want: "rg -n TODO"},
+	} {
+		if got := codexShellCommand(tc.argv); got != tc.want {
+			t.Fatalf("codexShellCommand(%q) = %q, want %q", tc.argv, got, tc.want)
+		}
+	}
+}
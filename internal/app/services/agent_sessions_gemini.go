@@ -0,0 +1,259 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const geminiSessionSchema = "gemini-json-v1"
+
+// geminiAgentAdapter discovers Gemini CLI chats. The CLI stores them under
+// ~/.gemini/tmp/<sha256 of the project root>/chats/session-*.json without
+// recording the project path, so only the chats of known worktrees are read.
+type geminiAgentAdapter struct {
+	root          string
+	worktreePaths func() []string
+}
+
+func (a *geminiAgentAdapter) Name() string { return "gemini" }
+
+func (a *geminiAgentAdapter) WatchRoot() string { return a.root }
+
+func (a *geminiAgentAdapter) jsonTranscripts() {}
+
+func (a *geminiAgentAdapter) Discover(
+	seen map[string]struct{},
+	cached func(path string, parse func() (*models.AgentSession, error)) (*models.AgentSession, error),
+) ([]*models.AgentSession, error) {
+	if a.root == "" || a.worktreePaths == nil {
+		return nil, nil
+	}
+	var sessions []*models.AgentSession
+	for _, worktreePath := range a.worktreePaths() {
+		chats, err := filepath.Glob(filepath.Join(a.root, geminiProjectHash(worktreePath), "chats", "session-*.json"))
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range chats {
+			seen[path] = struct{}{}
+			session, err := cached(path, func() (*models.AgentSession, error) {
+				return parseGeminiSession(path, worktreePath)
+			})
+			if err == nil && session != nil {
+				sessions = append(sessions, session)
+			}
+		}
+	}
+	return sessions, nil
+}
+
+func geminiTmpDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gemini", "tmp")
+}
+
+// geminiProjectHash mirrors the Gemini CLI project directory naming.
+func geminiProjectHash(projectRoot string) string {
+	sum := sha256.Sum256([]byte(projectRoot))
+	return hex.EncodeToString(sum[:])
+}
+
+type geminiConversation struct {
+	SessionID   string          `json:"sessionId"`
+	LastUpdated string          `json:"lastUpdated"`
+	Summary     string          `json:"summary"`
+	Messages    []geminiMessage `json:"messages"`
+}
+
+type geminiMessage struct {
+	Type      string           `json:"type"`
+	Timestamp string           `json:"timestamp"`
+	Content   json.RawMessage  `json:"content"`
+	Model     string           `json:"model"`
+	Thoughts  []geminiThought  `json:"thoughts"`
+	ToolCalls []geminiToolCall `json:"toolCalls"`
+}
+
+type geminiThought struct {
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+type geminiToolCall struct {
+	Name          string          `json:"name"`
+	Args          json.RawMessage `json:"args"`
+	Status        string          `json:"status"`
+	Timestamp     string          `json:"timestamp"`
+	ResultDisplay json.RawMessage `json:"resultDisplay"`
+}
+
+func parseGeminiSession(path, projectRoot string) (*models.AgentSession, error) {
+	//nolint:gosec // Transcript paths come from local agent directories discovered by the application.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	var conversation geminiConversation
+	if err := json.Unmarshal(data, &conversation); err != nil {
+		return nil, err
+	}
+
+	session := &models.AgentSession{
+		ID:            firstNonEmpty(conversation.SessionID, strings.TrimSuffix(filepath.Base(path), ".json")),
+		Agent:         models.AgentKindGemini,
+		CWD:           projectRoot,
+		JSONLPath:     path,
+		DisplayName:   compactWhitespace(conversation.Summary),
+		LastActivity:  info.ModTime(),
+		SchemaVersion: geminiSessionSchema,
+	}
+	if ts, err := time.Parse(time.RFC3339Nano, conversation.LastUpdated); err == nil {
+		session.LastActivity = ts
+	}
+
+	var role string
+	var isToolResult bool
+	var pending *geminiToolCall
+	for i := range conversation.Messages {
+		message := &conversation.Messages[i]
+		text := geminiText(message.Content)
+		switch message.Type {
+		case "user":
+			if text != "" {
+				session.LastPromptText = text
+			}
+			role, isToolResult, pending = "user", false, nil
+		case "gemini":
+			if message.Model != "" {
+				session.Model = message.Model
+			}
+			if text != "" {
+				session.LastReplyText = text
+			}
+			role, isToolResult, pending = "assistant", false, nil
+			for j := range message.ToolCalls {
+				call := &message.ToolCalls[j]
+				session.LastToolName = normalizeGeminiToolName(call.Name)
+				session.LastToolAt, _ = time.Parse(time.RFC3339Nano, firstNonEmpty(call.Timestamp, message.Timestamp))
+				if path := extractGeminiTargetPath(call.Args); path != "" {
+					session.LastTargetPath = path
+					if isAgentEditTool(session.LastToolName) {
+						noteEditedPath(session, path, session.LastToolAt)
+					}
+				}
+				if command := extractCommandText(call.Args); command != "" {
+					session.LastCommand = command
+				}
+				switch call.Status {
+				case "success", "error", "cancelled":
+					// Finished calls leave the model digesting their results.
+					role, isToolResult = "user", true
+				default:
+					pending = call
+				}
+			}
+		}
+	}
+
+	switch {
+	case pending != nil && pending.Status == "awaiting_approval":
+		session.CurrentTool = normalizeGeminiToolName(pending.Name)
+		session.Status = models.AgentSessionStatusWaitingApproval
+		session.Activity = resolveAgentActivity(
+			session.LastSummaryAt,
+			session.LastToolAt,
+			session.LastToolName,
+			session.CurrentTool,
+			session.IsOpen,
+			session.Status,
+			session.LastActivity,
+			time.Now(),
+		)
+	case pending != nil:
+		applyAgentStatus(session, "assistant", true, normalizeGeminiToolName(pending.Name), false)
+	default:
+		applyAgentStatus(session, role, false, "", isToolResult)
+	}
+	session.TaskLabel = deriveAgentTaskLabel(session)
+	session.Title = deriveAgentSessionTitle(session)
+	session.SessionKey = agentSessionKey(session)
+	session.ResumeHint = agentResumeHint(session)
+	return session, nil
+}
+
+// geminiText returns the text of a message whose content is either a plain
+// string or a list of parts.
+func geminiText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	if raw[0] == '"' {
+		var text string
+		_ = json.Unmarshal(raw, &text)
+		return compactWhitespace(text)
+	}
+	var parts []struct {
+		Text string `json:"text"`
+	}
+	_ = json.Unmarshal(raw, &parts)
+	for _, part := range parts {
+		if text := compactWhitespace(part.Text); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+func extractGeminiTargetPath(raw json.RawMessage) string {
+	if path := extractTargetPath(raw); path != "" {
+		return path
+	}
+	var obj struct {
+		AbsolutePath string `json:"absolute_path"`
+		DirPath      string `json:"dir_path"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return ""
+	}
+	return firstNonEmpty(obj.AbsolutePath, obj.DirPath)
+}
+
+func normalizeGeminiToolName(name string) string {
+	switch name {
+	case "read_file", "read_many_files":
+		return "Read"
+	case "write_file":
+		return "Write"
+	case "replace":
+		return "Edit"
+	case "run_shell_command":
+		return "Bash"
+	case "glob", "list_directory":
+		return "Glob"
+	case "search_file_content":
+		return "Grep"
+	case "web_fetch":
+		return "WebFetch"
+	case "google_web_search":
+		return "WebSearch"
+	default:
+		if name == "" {
+			return ""
+		}
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+}
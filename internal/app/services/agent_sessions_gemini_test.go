@@ -0,0 +1,111 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func writeGeminiChat(t *testing.T, path string, messages ...map[string]any) {
+	t.Helper()
+	writeJSONLLines(t, path, mustJSONLine(t, map[string]any{
+		"sessionId":   "gem-1",
+		"startTime":   "2026-01-01T10:00:00.000Z",
+		"lastUpdated": time.Now().UTC().Format(time.RFC3339Nano),
+		"messages":    messages,
+	}))
+}
+
+func TestParseGeminiSession(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	path := filepath.Join(root, "chats", "session-2026-01-01T10-00-gem1.json")
+	writeGeminiChat(
+		t, path,
+		map[string]any{"type": "user", "content": "Rename the   config loader"},
+		map[string]any{
+			"type":    "gemini",
+			"model":   "gemini-2.5-pro",
+			"content": "Reading the loader first.",
+			"toolCalls": []map[string]any{
+				{"name": "read_file", "status": "success", "args": map[string]any{"absolute_path": "/repo/config.go"}},
+				{"name": "replace", "status": "awaiting_approval", "args": map[string]any{"file_path": "/repo/config.go"}},
+			},
+		},
+	)
+
+	session, err := parseGeminiSession(path, worktreePath)
+	if err != nil {
+		t.Fatalf("parseGeminiSession returned error: %v", err)
+	}
+	if session.Agent != models.AgentKindGemini || session.ID != "gem-1" || session.CWD != worktreePath {
+		t.Fatalf("unexpected identity %q/%q/%q", session.Agent, session.ID, session.CWD)
+	}
+	if session.Model != "gemini-2.5-pro" || session.LastPromptText != "Rename the config loader" {
+		t.Fatalf("unexpected model %q or prompt %q", session.Model, session.LastPromptText)
+	}
+	if session.Status != models.AgentSessionStatusWaitingApproval || session.CurrentTool != "Edit" {
+		t.Fatalf("expected an edit awaiting approval, got %q/%q", session.Status, session.CurrentTool)
+	}
+	if session.LastTargetPath != "/repo/config.go" {
+		t.Fatalf("unexpected target %q", session.LastTargetPath)
+	}
+}
+
+func TestParseGeminiSessionFinishedTurn(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session-1.json")
+	writeGeminiChat(
+		t, path,
+		map[string]any{"type": "user", "content": []map[string]any{{"text": "Explain the cache"}}},
+		map[string]any{"type": "gemini", "content": "It is keyed by path."},
+	)
+
+	session, err := parseGeminiSession(path, "/repo")
+	if err != nil {
+		t.Fatalf("parseGeminiSession returned error: %v", err)
+	}
+	if session.LastPromptText != "Explain the cache" || session.LastReplyText != "It is keyed by path." {
+		t.Fatalf("unexpected texts %q/%q", session.LastPromptText, session.LastReplyText)
+	}
+	if session.Status != models.AgentSessionStatusWaitingForUser {
+		t.Fatalf("expected a finished turn to wait for the user, got %q", session.Status)
+	}
+}
+
+func TestAgentSessionServiceDiscoversGeminiChatsOfKnownWorktrees(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	geminiRoot := filepath.Join(root, "gemini")
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	writeGeminiChat(
+		t, filepath.Join(geminiRoot, geminiProjectHash(worktreePath), "chats", "session-1.json"),
+		map[string]any{"type": "user", "content": "Hello"},
+	)
+	writeGeminiChat(
+		t, filepath.Join(geminiRoot, geminiProjectHash("/elsewhere"), "chats", "session-2.json"),
+		map[string]any{"type": "user", "content": "Unrelated"},
+	)
+
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{Gemini: geminiRoot}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	if sessions, _ := service.Refresh(); len(sessions) != 0 {
+		t.Fatalf("expected no sessions before worktrees are known, got %d", len(sessions))
+	}
+	service.SetWorktreePaths([]string{worktreePath})
+	sessions, err := service.Refresh()
+	if err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].CWD != worktreePath {
+		t.Fatalf("expected the feature worktree chat only, got %#v", sessions)
+	}
+	if roots := service.JSONWatchRoots(); len(roots) != 1 || roots[0] != geminiRoot {
+		t.Fatalf("expected the Gemini root to accept .json events, got %#v", roots)
+	}
+}
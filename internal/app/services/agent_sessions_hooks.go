@@ -34,7 +34,7 @@ func hookStateMatchesSession(state *AgentHookState, session *models.AgentSession
 }
 
 // applyHookSessions synthesises sessions for hook-tracked agents that have no
-// transcript-backed session yet (e.g. Codex, whose transcript format is unstable).
+// transcript-backed session yet (e.g. Copilot, whose transcripts are not read).
 func (s *AgentSessionService) applyHookSessions(sessions []*models.AgentSession, states []AgentHookState, now time.Time) []*models.AgentSession {
 	for i := range states {
 		state := &states[i]
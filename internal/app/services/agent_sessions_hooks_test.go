@@ -12,7 +12,7 @@ func newHookTestServices(t *testing.T) (*AgentSessionService, *AgentHookService)
 	t.Helper()
 	root := t.TempDir()
 	service := NewAgentSessionServiceWithStore(
-		filepath.Join(root, "claude"), filepath.Join(root, "pi"),
+		AgentSessionRoots{Claude: filepath.Join(root, "claude"), Pi: filepath.Join(root, "pi"), Codex: filepath.Join(root, "codex")},
 		NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil,
 	)
 	hooks := NewAgentHookService(filepath.Join(root, "spool"), nil)
@@ -67,7 +67,7 @@ func TestRefreshRestoresHookSessionFromRegistry(t *testing.T) {
 	registryPath := filepath.Join(root, "registry.json")
 	newService := func(spool string) (*AgentSessionService, *AgentHookService) {
 		service := NewAgentSessionServiceWithStore(
-			filepath.Join(root, "claude"), filepath.Join(root, "pi"),
+			AgentSessionRoots{Claude: filepath.Join(root, "claude"), Pi: filepath.Join(root, "pi"), Codex: filepath.Join(root, "codex")},
 			NewTestSessionRegistryStore(registryPath), nil,
 		)
 		hooks := NewAgentHookService(spool, nil)
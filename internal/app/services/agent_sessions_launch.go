@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const (
+	// agentLaunchTimeout bounds how long a launched agent is shown before its
+	// own session appears; agents that never start drop off after this.
+	agentLaunchTimeout = 2 * time.Minute
+	// agentLaunchClockSkew tolerates transcripts stamped slightly before the
+	// launch was recorded.
+	agentLaunchClockSkew = 5 * time.Second
+)
+
+// agentLaunch is an agent started from lazyworktree whose transcript has not
+// been discovered yet.
+type agentLaunch struct {
+	agent  models.AgentKind
+	cwd    string
+	prompt string
+	at     time.Time
+}
+
+// RegisterLaunch records an agent started in cwd and returns the placeholder
+// session shown until the agent writes its own transcript. The placeholder is
+// visible straight away, without waiting for the next refresh.
+func (s *AgentSessionService) RegisterLaunch(agent models.AgentKind, cwd, prompt string, at time.Time) *models.AgentSession {
+	launch := agentLaunch{
+		agent:  agent,
+		cwd:    filepath.Clean(strings.TrimSpace(cwd)),
+		prompt: compactWhitespace(prompt),
+		at:     at,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.launches = append(s.launches, launch)
+	session := launch.session()
+	s.sessions = append([]*models.AgentSession{session}, s.sessions...)
+	return cloneAgentSession(session)
+}
+
+// withPendingLaunches drops launches whose session has been discovered or
+// that timed out, and prepends placeholders for the rest. Callers hold s.mu.
+func (s *AgentSessionService) withPendingLaunches(sessions []*models.AgentSession, now time.Time) []*models.AgentSession {
+	if len(s.launches) == 0 {
+		return sessions
+	}
+	pending := s.launches[:0]
+	placeholders := make([]*models.AgentSession, 0, len(s.launches))
+	for _, launch := range s.launches {
+		if now.Sub(launch.at) > agentLaunchTimeout || launch.resolvedBy(sessions) {
+			continue
+		}
+		pending = append(pending, launch)
+		placeholders = append(placeholders, launch.session())
+	}
+	s.launches = pending
+	return append(placeholders, sessions...)
+}
+
+// resolvedBy reports whether the launched agent has written a session of its
+// own in the launch directory since it was started.
+func (l agentLaunch) resolvedBy(sessions []*models.AgentSession) bool {
+	for _, session := range sessions {
+		if session == nil || session.Agent != l.agent {
+			continue
+		}
+		cwd := filepath.Clean(strings.TrimSpace(session.CWD))
+		if cwd != l.cwd && !strings.HasPrefix(cwd, l.cwd+string(filepath.Separator)) {
+			continue
+		}
+		if !sessionObservationTime(session).Before(l.at.Add(-agentLaunchClockSkew)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l agentLaunch) session() *models.AgentSession {
+	session := &models.AgentSession{
+		ID:             fmt.Sprintf("launch-%d", l.at.UnixNano()),
+		Agent:          l.agent,
+		CWD:            l.cwd,
+		LastPromptText: l.prompt,
+		Status:         models.AgentSessionStatusWaitingForUser,
+		Activity:       models.AgentActivityWaiting,
+		LastActivity:   l.at,
+		LastObservedAt: l.at,
+		LivenessState:  models.AgentSessionLivenessActive,
+		LivenessSource: models.AgentSessionLivenessSourceLaunch,
+		OpenConfidence: models.AgentOpenConfidenceNone,
+	}
+	if l.prompt != "" {
+		session.Status = models.AgentSessionStatusThinking
+		session.Activity = models.AgentActivityThinking
+	}
+	session.TaskLabel = deriveAgentTaskLabel(session)
+	session.Title = deriveAgentSessionTitle(session)
+	session.SessionKey = agentSessionKey(session)
+	return session
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestRegisterLaunchShowsPlaceholderUntilSessionAppears(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	storage := filepath.Join(root, "opencode")
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{OpenCode: storage}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+
+	launchedAt := time.Now()
+	placeholder := service.RegisterLaunch(models.AgentKindOpenCode, worktreePath, "Fix the   importer", launchedAt)
+	if placeholder.LivenessSource != models.AgentSessionLivenessSourceLaunch || placeholder.LastPromptText != "Fix the importer" {
+		t.Fatalf("unexpected placeholder %#v", placeholder)
+	}
+	matching := service.SessionsForWorktree(worktreePath)
+	if len(matching) != 1 || matching[0].Status != models.AgentSessionStatusThinking {
+		t.Fatalf("expected the launch to be associated with the worktree at once, got %#v", matching)
+	}
+
+	// The placeholder survives refreshes until the agent writes its session.
+	if _, err := service.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if matching := service.SessionsForWorktree(worktreePath); len(matching) != 1 || matching[0].LivenessSource != models.AgentSessionLivenessSourceLaunch {
+		t.Fatalf("expected the placeholder to remain, got %#v", matching)
+	}
+
+	writeOpenCodeJSON(t, filepath.Join(storage, "session", "proj1", "ses_1.json"), map[string]any{
+		"id":        "ses_1",
+		"directory": worktreePath,
+		"time":      map[string]any{"updated": launchedAt.Add(time.Second).UnixMilli()},
+	})
+	if _, err := service.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	matching = service.SessionsForWorktree(worktreePath)
+	if len(matching) != 1 || matching[0].ID != "ses_1" {
+		t.Fatalf("expected the real session to replace the placeholder, got %#v", matching)
+	}
+}
+
+func TestPendingLaunchesExpire(t *testing.T) {
+	t.Parallel()
+
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{}, NewTestSessionRegistryStore(filepath.Join(t.TempDir(), "registry.json")), nil)
+	service.RegisterLaunch(models.AgentKindClaude, "/repo", "", time.Now().Add(-agentLaunchTimeout-time.Second))
+	sessions, err := service.Refresh()
+	if err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected a stale launch to be dropped, got %#v", sessions)
+	}
+}
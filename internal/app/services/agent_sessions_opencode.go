@@ -0,0 +1,286 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const openCodeSessionSchema = "opencode-json-v1"
+
+// openCodeAgentAdapter discovers OpenCode sessions. OpenCode keeps one JSON
+// document per session, message and message part:
+//
+//	storage/session/<project>/<session>.json
+//	storage/message/<session>/<message>.json
+//	storage/part/<message>/<part>.json
+//
+// OpenCode bumps the session document on every message, so it doubles as the
+// cache key for the whole conversation.
+type openCodeAgentAdapter struct {
+	root string
+}
+
+func (a *openCodeAgentAdapter) Name() string { return "opencode" }
+
+func (a *openCodeAgentAdapter) WatchRoot() string { return a.root }
+
+func (a *openCodeAgentAdapter) jsonTranscripts() {}
+
+func (a *openCodeAgentAdapter) Discover(
+	seen map[string]struct{},
+	cached func(path string, parse func() (*models.AgentSession, error)) (*models.AgentSession, error),
+) ([]*models.AgentSession, error) {
+	if a.root == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(a.root, "session", "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*models.AgentSession, 0, len(paths))
+	for _, path := range paths {
+		seen[path] = struct{}{}
+		session, err := cached(path, func() (*models.AgentSession, error) {
+			return parseOpenCodeSession(a.root, path)
+		})
+		if err == nil && session != nil {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func openCodeStorageDir() string {
+	if dataHome := strings.TrimSpace(os.Getenv("XDG_DATA_HOME")); dataHome != "" {
+		return filepath.Join(dataHome, "opencode", "storage")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "opencode", "storage")
+}
+
+type openCodeTime struct {
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+	Completed int64 `json:"completed"`
+}
+
+type openCodeSessionInfo struct {
+	ID        string       `json:"id"`
+	Directory string       `json:"directory"`
+	Title     string       `json:"title"`
+	Time      openCodeTime `json:"time"`
+}
+
+type openCodeMessage struct {
+	ID      string       `json:"id"`
+	Role    string       `json:"role"`
+	ModelID string       `json:"modelID"`
+	Time    openCodeTime `json:"time"`
+}
+
+type openCodePart struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Text      string `json:"text"`
+	Synthetic bool   `json:"synthetic"`
+	Tool      string `json:"tool"`
+	State     struct {
+		Status string          `json:"status"`
+		Input  json.RawMessage `json:"input"`
+		Output string          `json:"output"`
+		Error  string          `json:"error"`
+		Time   openCodeTime    `json:"time"`
+	} `json:"state"`
+}
+
+func parseOpenCodeSession(root, path string) (*models.AgentSession, error) {
+	var info openCodeSessionInfo
+	if err := readOpenCodeJSON(path, &info); err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.AgentSession{
+		ID:            firstNonEmpty(info.ID, strings.TrimSuffix(filepath.Base(path), ".json")),
+		Agent:         models.AgentKindOpenCode,
+		CWD:           info.Directory,
+		JSONLPath:     path,
+		DisplayName:   compactWhitespace(info.Title),
+		LastActivity:  stat.ModTime(),
+		SchemaVersion: openCodeSessionSchema,
+	}
+	if info.Time.Updated > 0 {
+		session.LastActivity = time.UnixMilli(info.Time.Updated)
+	}
+
+	var messages []openCodeMessage
+	readOpenCodeDir(filepath.Join(root, "message", session.ID), func(path string) {
+		var message openCodeMessage
+		if readOpenCodeJSON(path, &message) == nil && message.ID != "" {
+			messages = append(messages, message)
+		}
+	})
+	// Identifiers are time ordered.
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	var role string
+	var isToolResult bool
+	var pending *openCodePart
+	for _, message := range messages {
+		parts := openCodeMessageParts(root, message.ID)
+		text := openCodeText(parts)
+		switch message.Role {
+		case "user":
+			if text != "" {
+				session.LastPromptText = text
+			}
+			role, isToolResult, pending = "user", false, nil
+		case "assistant":
+			if message.ModelID != "" {
+				session.Model = message.ModelID
+			}
+			if text != "" {
+				session.LastReplyText = text
+			}
+			role, isToolResult, pending = "assistant", false, nil
+			for i := range parts {
+				part := &parts[i]
+				if part.Type != "tool" {
+					continue
+				}
+				session.LastToolName = normalizeOpenCodeToolName(part.Tool)
+				session.LastToolAt = time.UnixMilli(part.State.Time.Created)
+				if path := extractOpenCodeTargetPath(part.State.Input); path != "" {
+					session.LastTargetPath = path
+					if isAgentEditTool(session.LastToolName) {
+						noteEditedPath(session, path, session.LastToolAt)
+					}
+				}
+				if command := extractCommandText(part.State.Input); command != "" {
+					session.LastCommand = command
+				}
+				switch part.State.Status {
+				case "completed", "error":
+					role, isToolResult = "user", true
+				default:
+					pending = part
+				}
+			}
+			switch {
+			case pending != nil:
+			case message.Time.Completed > 0:
+				role, isToolResult = "assistant", false
+			case !isToolResult:
+				// The model is still streaming its answer.
+				role = "user"
+			}
+		}
+	}
+
+	if pending != nil {
+		applyAgentStatus(session, "assistant", true, normalizeOpenCodeToolName(pending.Tool), false)
+	} else {
+		applyAgentStatus(session, role, false, "", isToolResult)
+	}
+	session.TaskLabel = deriveAgentTaskLabel(session)
+	session.Title = deriveAgentSessionTitle(session)
+	session.SessionKey = agentSessionKey(session)
+	session.ResumeHint = agentResumeHint(session)
+	return session, nil
+}
+
+func openCodeMessageParts(root, messageID string) []openCodePart {
+	var parts []openCodePart
+	readOpenCodeDir(filepath.Join(root, "part", messageID), func(path string) {
+		var part openCodePart
+		if readOpenCodeJSON(path, &part) == nil {
+			parts = append(parts, part)
+		}
+	})
+	sort.Slice(parts, func(i, j int) bool { return parts[i].ID < parts[j].ID })
+	return parts
+}
+
+func readOpenCodeDir(dir string, visit func(path string)) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && filepath.Ext(entry.Name()) == ".json" {
+			visit(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
+
+func readOpenCodeJSON(path string, out any) error {
+	//nolint:gosec // Transcript paths come from local agent directories discovered by the application.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+// openCodeText returns the last non-empty text part, skipping reasoning.
+func openCodeText(parts []openCodePart) string {
+	for i := len(parts) - 1; i >= 0; i-- {
+		if parts[i].Type != "text" {
+			continue
+		}
+		if text := compactWhitespace(parts[i].Text); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+func extractOpenCodeTargetPath(raw json.RawMessage) string {
+	var obj struct {
+		FilePath string `json:"filePath"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil && strings.TrimSpace(obj.FilePath) != "" {
+		return obj.FilePath
+	}
+	return extractTargetPath(raw)
+}
+
+func normalizeOpenCodeToolName(name string) string {
+	switch name {
+	case "bash":
+		return "Bash"
+	case "read":
+		return "Read"
+	case "write":
+		return "Write"
+	case "edit", "patch", "multiedit":
+		return "Edit"
+	case "glob", "list":
+		return "Glob"
+	case "grep":
+		return "Grep"
+	case "webfetch":
+		return "WebFetch"
+	case "todowrite":
+		return "TodoWrite"
+	case "task":
+		return "Task"
+	default:
+		if name == "" {
+			return ""
+		}
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+}
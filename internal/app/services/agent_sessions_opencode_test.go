@@ -0,0 +1,92 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func writeOpenCodeJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	writeJSONLLines(t, path, mustJSONLine(t, v))
+}
+
+func TestAgentSessionServiceDiscoversOpenCodeSessions(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	storage := filepath.Join(root, "opencode", "storage")
+	worktreePath := filepath.Join(root, "worktrees", "feature")
+	now := time.Now().UnixMilli()
+
+	writeOpenCodeJSON(t, filepath.Join(storage, "session", "proj1", "ses_1.json"), map[string]any{
+		"id":        "ses_1",
+		"projectID": "proj1",
+		"directory": worktreePath,
+		"title":     "Tidy the importer",
+		"time":      map[string]any{"created": now, "updated": now},
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "message", "ses_1", "msg_1.json"), map[string]any{
+		"id": "msg_1", "role": "user", "time": map[string]any{"created": now},
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "part", "msg_1", "prt_1.json"), map[string]any{
+		"id": "prt_1", "type": "text", "text": "Tidy the   importer",
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "message", "ses_1", "msg_2.json"), map[string]any{
+		"id": "msg_2", "role": "assistant", "modelID": "claude-sonnet-4", "time": map[string]any{"created": now},
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "part", "msg_2", "prt_2.json"), map[string]any{
+		"id": "prt_2", "type": "tool", "tool": "read",
+		"state": map[string]any{"status": "completed", "input": map[string]any{"filePath": "/repo/a.go"}},
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "part", "msg_2", "prt_3.json"), map[string]any{
+		"id": "prt_3", "type": "tool", "tool": "bash",
+		"state": map[string]any{"status": "running", "input": map[string]any{"command": "go test ./..."}},
+	})
+
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{OpenCode: storage}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	if _, err := service.Refresh(); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	matching := service.SessionsForWorktree(worktreePath)
+	if len(matching) != 1 {
+		t.Fatalf("expected one OpenCode session, got %#v", matching)
+	}
+	session := matching[0]
+	if session.Agent != models.AgentKindOpenCode || session.ID != "ses_1" || session.Model != "claude-sonnet-4" {
+		t.Fatalf("unexpected session %q/%q/%q", session.Agent, session.ID, session.Model)
+	}
+	if session.LastPromptText != "Tidy the importer" || session.DisplayName != "Tidy the importer" {
+		t.Fatalf("unexpected prompt %q or title %q", session.LastPromptText, session.DisplayName)
+	}
+	if session.Status != models.AgentSessionStatusExecutingTool || session.CurrentTool != "Bash" {
+		t.Fatalf("expected a running shell tool, got %q/%q", session.Status, session.CurrentTool)
+	}
+	if session.LastTargetPath != "/repo/a.go" || session.LastCommand != "go test ./..." {
+		t.Fatalf("unexpected target %q or command %q", session.LastTargetPath, session.LastCommand)
+	}
+}
+
+func TestParseOpenCodeSessionCompletedReply(t *testing.T) {
+	t.Parallel()
+
+	storage := t.TempDir()
+	path := filepath.Join(storage, "session", "proj1", "ses_2.json")
+	writeOpenCodeJSON(t, path, map[string]any{"id": "ses_2", "directory": "/repo"})
+	writeOpenCodeJSON(t, filepath.Join(storage, "message", "ses_2", "msg_1.json"), map[string]any{
+		"id": "msg_1", "role": "assistant", "time": map[string]any{"created": 1, "completed": 2},
+	})
+	writeOpenCodeJSON(t, filepath.Join(storage, "part", "msg_1", "prt_1.json"), map[string]any{
+		"id": "prt_1", "type": "text", "text": "Done.",
+	})
+
+	session, err := parseOpenCodeSession(storage, path)
+	if err != nil {
+		t.Fatalf("parseOpenCodeSession returned error: %v", err)
+	}
+	if session.Status != models.AgentSessionStatusWaitingForUser || session.LastReplyText != "Done." {
+		t.Fatalf("expected a completed reply waiting for the user, got %q/%q", session.Status, session.LastReplyText)
+	}
+}
@@ -166,12 +166,18 @@ func TestParsePiSession(t *testing.T) {
 func TestNewAgentSessionServiceWithStorePreservesExplicitEmptyRoots(t *testing.T) {
 	t.Parallel()
 
-	service := NewAgentSessionServiceWithStore("", "", NewTestSessionRegistryStore(filepath.Join(t.TempDir(), "registry.json")), nil)
-	if service.claudeRoot != "" {
-		t.Fatalf("expected explicit empty Claude root to stay empty, got %q", service.claudeRoot)
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{}, NewTestSessionRegistryStore(filepath.Join(t.TempDir(), "registry.json")), nil)
+	if service.roots.Claude != "" {
+		t.Fatalf("expected explicit empty Claude root to stay empty, got %q", service.roots.Claude)
 	}
-	if service.piRoot != "" {
-		t.Fatalf("expected explicit empty pi root to stay empty, got %q", service.piRoot)
+	if service.roots.Pi != "" {
+		t.Fatalf("expected explicit empty pi root to stay empty, got %q", service.roots.Pi)
+	}
+	if service.roots.Codex != "" {
+		t.Fatalf("expected explicit empty Codex root to stay empty, got %q", service.roots.Codex)
+	}
+	if service.roots.Gemini != "" || service.roots.OpenCode != "" {
+		t.Fatalf("expected explicit empty Gemini and OpenCode roots to stay empty, got %#v", service.roots)
 	}
 	if roots := service.WatchRoots(); len(roots) != 0 {
 		t.Fatalf("expected no watch roots when explicit roots are empty, got %#v", roots)
@@ -212,7 +218,7 @@ func TestAgentSessionServiceSessionsForWorktree(t *testing.T) {
 		}),
 	)
 
-	service := NewAgentSessionServiceWithStore(claudeRoot, piRoot, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{Claude: claudeRoot, Pi: piRoot}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
 	if _, err := service.Refresh(); err != nil {
 		t.Fatalf("Refresh returned error: %v", err)
 	}
@@ -251,7 +257,7 @@ func TestAgentSessionServiceRefreshInvalidatesCache(t *testing.T) {
 		}),
 	)
 
-	service := NewAgentSessionServiceWithStore(claudeRoot, "", NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{Claude: claudeRoot}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
 	first, err := service.Refresh()
 	if err != nil {
 		t.Fatalf("first Refresh returned error: %v", err)
@@ -343,7 +349,7 @@ func TestAgentSessionServiceRefreshFindsNestedClaudeJSONL(t *testing.T) {
 		}),
 	)
 
-	service := NewAgentSessionServiceWithStore(claudeRoot, "", NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
+	service := NewAgentSessionServiceWithStore(AgentSessionRoots{Claude: claudeRoot}, NewTestSessionRegistryStore(filepath.Join(root, "registry.json")), nil)
 	sessions, err := service.Refresh()
 	if err != nil {
 		t.Fatalf("Refresh returned error: %v", err)
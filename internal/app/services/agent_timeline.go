@@ -0,0 +1,271 @@
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// AgentTimelineKind groups timeline events for filtering.
+type AgentTimelineKind string
+
+const (
+	// AgentTimelinePrompt is a prompt sent to the agent.
+	AgentTimelinePrompt AgentTimelineKind = "prompt"
+	// AgentTimelineReply is a message written by the agent.
+	AgentTimelineReply AgentTimelineKind = "reply"
+	// AgentTimelineRead is a file read or a workspace search.
+	AgentTimelineRead AgentTimelineKind = "read"
+	// AgentTimelineEdit is a file written or edited by the agent.
+	AgentTimelineEdit AgentTimelineKind = "edit"
+	// AgentTimelineRun is a shell command run by the agent.
+	AgentTimelineRun AgentTimelineKind = "run"
+	// AgentTimelineTool is any other tool call.
+	AgentTimelineTool AgentTimelineKind = "tool"
+	// AgentTimelineWaiting is the agent stopping for input or approval.
+	AgentTimelineWaiting AgentTimelineKind = "waiting"
+	// AgentTimelineSession is a session starting or ending.
+	AgentTimelineSession AgentTimelineKind = "session"
+)
+
+// AgentTimelineKinds lists every kind in display order.
+var AgentTimelineKinds = []AgentTimelineKind{
+	AgentTimelinePrompt, AgentTimelineReply, AgentTimelineRead, AgentTimelineEdit,
+	AgentTimelineRun, AgentTimelineTool, AgentTimelineWaiting, AgentTimelineSession,
+}
+
+// AgentTimelineEvent is one thing an agent did, at a point in time.
+type AgentTimelineEvent struct {
+	At        time.Time
+	Agent     models.AgentKind
+	SessionID string
+	CWD       string
+	Kind      AgentTimelineKind
+	Text      string
+}
+
+// AgentTranscriptCache keeps parsed transcripts between timeline refreshes,
+// re-reading a transcript only when its size or modification time changes.
+type AgentTranscriptCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedAgentTranscript
+}
+
+type cachedAgentTranscript struct {
+	modTime time.Time
+	size    int64
+	entries []models.AgentTranscriptEntry
+}
+
+// NewAgentTranscriptCache creates an empty transcript cache.
+func NewAgentTranscriptCache() *AgentTranscriptCache {
+	return &AgentTranscriptCache{entries: map[string]cachedAgentTranscript{}}
+}
+
+// load returns the transcript entries of session, parsing the transcript
+// only when it changed since the last call. OpenCode transcripts span a
+// directory of message files and are always re-read. A nil cache reads
+// through.
+func (c *AgentTranscriptCache) load(session *models.AgentSession, seen map[string]bool) ([]models.AgentTranscriptEntry, error) {
+	if c == nil || session.Agent == models.AgentKindOpenCode {
+		return LoadAgentTranscript(session)
+	}
+	info, err := os.Stat(session.JSONLPath)
+	if err != nil {
+		return nil, err
+	}
+	key := string(session.Agent) + "\x00" + session.ID + "\x00" + session.JSONLPath
+	seen[key] = true
+
+	c.mu.Lock()
+	cached, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) {
+		return cached.entries, nil
+	}
+	entries, err := LoadAgentTranscript(session)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = cachedAgentTranscript{modTime: info.ModTime(), size: info.Size(), entries: entries}
+	c.mu.Unlock()
+	return entries, nil
+}
+
+// prune drops the transcripts not read by the last build.
+func (c *AgentTranscriptCache) prune(seen map[string]bool) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if !seen[key] {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// BuildAgentTimeline merges the transcripts of sessions active since the
+// given time with spooled hook events into one feed, newest first. Parsed
+// transcripts are reused from cache, which may be nil.
+func BuildAgentTimeline(sessions []*models.AgentSession, hookEvents []models.AgentHookEvent, since time.Time, cache *AgentTranscriptCache) []AgentTimelineEvent {
+	var events []AgentTimelineEvent
+	hooked := make(map[string]bool)
+	seen := make(map[string]bool)
+	for _, event := range AgentTimelineFromHooks(hookEvents) {
+		hooked[string(event.Agent)+":"+event.SessionID] = true
+		events = append(events, event)
+	}
+	for _, session := range sessions {
+		if session == nil || session.LastActivity.Before(since) {
+			continue
+		}
+		if entries, err := cache.load(session, seen); err == nil {
+			events = append(events, AgentTimelineFromTranscript(session, entries)...)
+		}
+		// Agents without hooks only reveal that they wait through their
+		// current status.
+		if hooked[string(session.Agent)+":"+session.ID] {
+			continue
+		}
+		switch session.Activity {
+		case models.AgentActivityWaiting:
+			events = append(events, timelineSessionEvent(session, session.LastActivity, AgentTimelineWaiting, "waiting for input"))
+		case models.AgentActivityApproval:
+			events = append(events, timelineSessionEvent(session, session.LastActivity, AgentTimelineWaiting, "waiting for approval"))
+		}
+	}
+
+	cache.prune(seen)
+
+	kept := events[:0]
+	for _, event := range events {
+		if !event.At.IsZero() && !event.At.Before(since) {
+			kept = append(kept, event)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].At.After(kept[j].At)
+	})
+	return kept
+}
+
+// AgentTimelineFromTranscript turns the prompts, replies and tool calls of a
+// transcript into timeline events. Entries without a timestamp are skipped.
+func AgentTimelineFromTranscript(session *models.AgentSession, entries []models.AgentTranscriptEntry) []AgentTimelineEvent {
+	events := make([]AgentTimelineEvent, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Timestamp.IsZero() {
+			continue
+		}
+		var kind AgentTimelineKind
+		var text string
+		switch entry.Kind {
+		case models.AgentTranscriptUser:
+			kind, text = AgentTimelinePrompt, summarizeText(entry.Text)
+		case models.AgentTranscriptAssistant:
+			kind, text = AgentTimelineReply, summarizeText(entry.Text)
+		case models.AgentTranscriptToolCall:
+			kind, text = timelineToolCall(entry.ToolName, entry.ToolInput)
+		default:
+			continue
+		}
+		if text != "" {
+			events = append(events, timelineSessionEvent(session, entry.Timestamp, kind, text))
+		}
+	}
+	return events
+}
+
+// AgentTimelineFromHooks reports session starts, ends and stops. Prompts
+// are left to the transcripts, which carry their text.
+func AgentTimelineFromHooks(hookEvents []models.AgentHookEvent) []AgentTimelineEvent {
+	events := make([]AgentTimelineEvent, 0, len(hookEvents))
+	for _, hook := range hookEvents {
+		var kind AgentTimelineKind
+		var text string
+		switch hook.HookEventName {
+		case models.AgentHookSessionStart:
+			kind, text = AgentTimelineSession, "started a session"
+		case models.AgentHookSessionEnd:
+			kind, text = AgentTimelineSession, "ended the session"
+		case models.AgentHookStop, models.AgentHookWaitingForUser:
+			kind, text = AgentTimelineWaiting, "waiting for input"
+		default:
+			continue
+		}
+		events = append(events, AgentTimelineEvent{
+			At:        hook.Timestamp,
+			Agent:     hook.Agent,
+			SessionID: hook.SessionID,
+			CWD:       hook.CWD,
+			Kind:      kind,
+			Text:      text,
+		})
+	}
+	return events
+}
+
+func timelineSessionEvent(session *models.AgentSession, at time.Time, kind AgentTimelineKind, text string) AgentTimelineEvent {
+	return AgentTimelineEvent{
+		At:        at,
+		Agent:     session.Agent,
+		SessionID: session.ID,
+		CWD:       session.CWD,
+		Kind:      kind,
+		Text:      text,
+	}
+}
+
+func timelineToolCall(name, input string) (AgentTimelineKind, string) {
+	path := summarizePath(extractTargetPath(json.RawMessage(input)))
+	switch name {
+	case "Bash", "shell", "exec_command", "run_shell_command":
+		if command := summarizeCommand(timelineCommand(input)); command != "" {
+			return AgentTimelineRun, "ran `" + command + "`"
+		}
+		return AgentTimelineRun, "ran a command"
+	case "Read", "read_file", "view":
+		return AgentTimelineRead, strings.TrimSpace("read " + path)
+	case "Glob", "Grep", "glob", "grep", "search_file_content":
+		return AgentTimelineRead, "searched the workspace"
+	case "Write", "Edit", "MultiEdit", "NotebookEdit", "write_file", "replace", "edit", "apply_patch":
+		if path == "" {
+			return AgentTimelineEdit, "edited files"
+		}
+		return AgentTimelineEdit, "edited " + path
+	default:
+		if name == "" {
+			return AgentTimelineTool, ""
+		}
+		return AgentTimelineTool, "used " + name
+	}
+}
+
+// timelineCommand extracts a shell command from tool arguments. Codex passes
+// an argv such as ["bash", "-lc", "go test ./..."]; other agents a string.
+func timelineCommand(input string) string {
+	if command := extractCommandText(json.RawMessage(input)); command != "" {
+		return command
+	}
+	var argv struct {
+		Command []string `json:"command"`
+	}
+	if err := json.Unmarshal([]byte(input), &argv); err == nil && len(argv.Command) > 0 {
+		if n := len(argv.Command); n >= 3 && (argv.Command[n-2] == "-lc" || argv.Command[n-2] == "-c") {
+			return argv.Command[n-1]
+		}
+		return strings.Join(argv.Command, " ")
+	}
+	if !json.Valid([]byte(input)) {
+		return input
+	}
+	return ""
+}
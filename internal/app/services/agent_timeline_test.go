@@ -0,0 +1,126 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestAgentTimelineFromTranscript(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	session := &models.AgentSession{ID: "s1", Agent: models.AgentKindCodex, CWD: "/wt/feat-x"}
+	events := AgentTimelineFromTranscript(session, []models.AgentTranscriptEntry{
+		{Kind: models.AgentTranscriptUser, Timestamp: ts, Text: "Please fix the\\nparser."},
+		{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: "hmm"},
+		{Kind: models.AgentTranscriptToolCall, Timestamp: ts, ToolName: "Bash", ToolInput: "{\n  \"command\": [\"bash\", \"-lc\", \"go test ./...\"]\n}"},
+		{Kind: models.AgentTranscriptToolCall, Timestamp: ts, ToolName: "Edit", ToolInput: `{"file_path": "/wt/feat-x/parser.go"}`},
+		{Kind: models.AgentTranscriptToolCall, Timestamp: ts, ToolName: "WebSearch", ToolInput: `{"query": "go"}`},
+		{Kind: models.AgentTranscriptToolResult, Timestamp: ts, ToolName: "Bash", Text: "ok"},
+		{Kind: models.AgentTranscriptAssistant, Text: "no timestamp"},
+	})
+
+	want := []struct {
+		kind AgentTimelineKind
+		text string
+	}{
+		{AgentTimelinePrompt, `fix the\nparser`},
+		{AgentTimelineRun, "ran `go test ./...`"},
+		{AgentTimelineEdit, "edited /wt/feat-x/parser.go"},
+		{AgentTimelineTool, "used WebSearch"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), events)
+	}
+	for i, w := range want {
+		if events[i].Kind != w.kind || events[i].Text != w.text || events[i].CWD != "/wt/feat-x" {
+			t.Fatalf("event %d: expected %s %q, got %+v", i, w.kind, w.text, events[i])
+		}
+	}
+}
+
+func TestBuildAgentTimelineMergesHooksAndTranscripts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	writeJSONLLines(
+		t, path,
+		mustJSONLine(t, map[string]any{"type": "user", "timestamp": now.Add(-3 * time.Minute).Format(time.RFC3339Nano), "message": map[string]any{"role": "user", "content": "Run the tests"}}),
+		mustJSONLine(t, map[string]any{"type": "user", "timestamp": now.Add(-48 * time.Hour).Format(time.RFC3339Nano), "message": map[string]any{"role": "user", "content": "Too old"}}),
+	)
+	claude := &models.AgentSession{ID: "s1", Agent: models.AgentKindClaude, CWD: "/wt/a", JSONLPath: path, LastActivity: now}
+	codex := &models.AgentSession{ID: "c1", Agent: models.AgentKindCodex, CWD: "/wt/b", Activity: models.AgentActivityApproval, LastActivity: now.Add(-time.Minute)}
+	stale := &models.AgentSession{ID: "old", Agent: models.AgentKindCodex, Activity: models.AgentActivityWaiting, LastActivity: now.Add(-72 * time.Hour)}
+	hooks := []models.AgentHookEvent{
+		{Agent: models.AgentKindClaude, SessionID: "s1", CWD: "/wt/a", HookEventName: models.AgentHookSessionStart, Timestamp: now.Add(-4 * time.Minute)},
+		{Agent: models.AgentKindClaude, SessionID: "s1", CWD: "/wt/a", HookEventName: models.AgentHookUserPromptSubmit, Timestamp: now.Add(-3 * time.Minute)},
+		{Agent: models.AgentKindClaude, SessionID: "s1", CWD: "/wt/a", HookEventName: models.AgentHookStop, Timestamp: now.Add(-2 * time.Minute)},
+	}
+
+	events := BuildAgentTimeline([]*models.AgentSession{claude, codex, stale}, hooks, now.Add(-24*time.Hour), nil)
+	got := make([]string, 0, len(events))
+	for _, event := range events {
+		got = append(got, string(event.Agent)+" "+event.Text)
+	}
+	want := []string{
+		"codex waiting for approval",
+		"claude waiting for input",
+		"claude Run the tests",
+		"claude started a session",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestAgentTranscriptCacheReparsesChangedTranscripts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	prompt := func(text string) string {
+		return mustJSONLine(t, map[string]any{"type": "user", "timestamp": now.Format(time.RFC3339Nano), "message": map[string]any{"role": "user", "content": text}})
+	}
+	writeJSONLLines(t, path, prompt("First"))
+	session := &models.AgentSession{ID: "s1", Agent: models.AgentKindClaude, JSONLPath: path, LastActivity: now}
+	cache := NewAgentTranscriptCache()
+
+	texts := func() []string {
+		var got []string
+		for _, event := range BuildAgentTimeline([]*models.AgentSession{session}, nil, now.Add(-time.Hour), cache) {
+			got = append(got, event.Text)
+		}
+		return got
+	}
+	if got := texts(); len(got) != 1 || got[0] != "First" {
+		t.Fatalf("expected the first prompt, got %v", got)
+	}
+
+	// Same size and modification time: the cached entries are reused.
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeJSONLLines(t, path, prompt("Other"))
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatal(err)
+	}
+	if got := texts(); len(got) != 1 || got[0] != "First" {
+		t.Fatalf("expected the cached prompt, got %v", got)
+	}
+
+	writeJSONLLines(t, path, prompt("First"), prompt("Second"))
+	if got := texts(); len(got) != 2 {
+		t.Fatalf("expected the rewritten transcript re-parsed, got %v", got)
+	}
+}
@@ -0,0 +1,488 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// LoadAgentTranscript reads the whole conversation of a session from its
+// transcript, oldest entry first.
+func LoadAgentTranscript(session *models.AgentSession) ([]models.AgentTranscriptEntry, error) {
+	if session == nil || strings.TrimSpace(session.JSONLPath) == "" {
+		return nil, fmt.Errorf("the session has no transcript")
+	}
+	path := session.JSONLPath
+	switch session.Agent {
+	case models.AgentKindClaude:
+		return readClaudeTranscript(path)
+	case models.AgentKindPi:
+		return readPiTranscript(path)
+	case models.AgentKindCodex:
+		return readCodexTranscript(path)
+	case models.AgentKindGemini:
+		return readGeminiTranscript(path)
+	case models.AgentKindOpenCode:
+		// storage/session/<project>/<session>.json
+		return readOpenCodeTranscript(filepath.Dir(filepath.Dir(filepath.Dir(path))), session.ID), nil
+	case models.AgentKindAider:
+		return readAiderTranscript(path, strings.TrimPrefix(session.ID, session.CWD+"@"))
+	}
+	return nil, fmt.Errorf("%s transcripts cannot be read", session.Agent)
+}
+
+// scanTranscriptLines calls visit for every line of a JSONL transcript.
+func scanTranscriptLines(path string, visit func(line []byte)) error {
+	//nolint:gosec // Transcript paths come from local agent directories discovered by the application.
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		visit(scanner.Bytes())
+	}
+	return scanner.Err()
+}
+
+func readClaudeTranscript(path string) ([]models.AgentTranscriptEntry, error) {
+	var entries []models.AgentTranscriptEntry
+	toolNames := make(map[string]string)
+	err := scanTranscriptLines(path, func(line []byte) {
+		var envelope claudeEnvelope
+		if json.Unmarshal(line, &envelope) != nil || envelope.IsMeta || envelope.Message == nil {
+			return
+		}
+		kind := models.AgentTranscriptAssistant
+		switch envelope.Type {
+		case "user":
+			kind = models.AgentTranscriptUser
+		case "assistant":
+		default:
+			return
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, envelope.Timestamp)
+		message := envelope.Message
+		message.parseContent()
+		if text := strings.TrimSpace(message.TextContent); text != "" {
+			entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+		}
+		for i := range message.Content {
+			block := &message.Content[i]
+			switch block.Type {
+			case "text":
+				if text := strings.TrimSpace(block.Text); text != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+				}
+			case "thinking":
+				if text := strings.TrimSpace(block.Thinking); text != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: text})
+				}
+			case "tool_use":
+				toolNames[block.ID] = block.Name
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolCall,
+					Timestamp: ts,
+					ToolName:  block.Name,
+					ToolInput: indentTranscriptJSON(block.Input),
+				})
+			case "tool_result":
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolResult,
+					Timestamp: ts,
+					ToolName:  toolNames[block.ToolUseID],
+					Text:      transcriptText(block.Content),
+					IsError:   block.IsError,
+				})
+			}
+		}
+	})
+	return entries, err
+}
+
+func readPiTranscript(path string) ([]models.AgentTranscriptEntry, error) {
+	var entries []models.AgentTranscriptEntry
+	err := scanTranscriptLines(path, func(line []byte) {
+		var entry piEntry
+		if json.Unmarshal(line, &entry) != nil || entry.Type != "message" || entry.Message == nil {
+			return
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, entry.Timestamp)
+		message := entry.Message
+		switch message.Role {
+		case "toolResult":
+			entries = append(entries, models.AgentTranscriptEntry{
+				Kind:      models.AgentTranscriptToolResult,
+				Timestamp: ts,
+				ToolName:  normalizePiToolName(message.ToolName),
+				Text:      transcriptText(message.Content),
+				IsError:   message.IsError,
+			})
+			return
+		case "user", "assistant":
+		default:
+			return
+		}
+		kind := models.AgentTranscriptAssistant
+		if message.Role == "user" {
+			kind = models.AgentTranscriptUser
+		}
+		if len(message.Content) > 0 && message.Content[0] == '"' {
+			if text := transcriptText(message.Content); text != "" {
+				entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+			}
+			return
+		}
+		for _, block := range parsePiBlocks(message.Content) {
+			switch block.Type {
+			case "text":
+				if text := strings.TrimSpace(block.Text); text != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+				}
+			case "thinking":
+				if text := strings.TrimSpace(block.Thinking); text != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: text})
+				}
+			case "toolCall":
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolCall,
+					Timestamp: ts,
+					ToolName:  normalizePiToolName(block.Name),
+					ToolInput: indentTranscriptJSON(block.Arguments),
+				})
+			}
+		}
+	})
+	return entries, err
+}
+
+func readCodexTranscript(path string) ([]models.AgentTranscriptEntry, error) {
+	var entries []models.AgentTranscriptEntry
+	toolNames := make(map[string]string)
+	err := scanTranscriptLines(path, func(line []byte) {
+		var rollout codexRolloutLine
+		if json.Unmarshal(line, &rollout) != nil {
+			return
+		}
+		raw := rollout.Payload
+		if len(raw) == 0 {
+			// Legacy rollouts hold bare response items.
+			raw = line
+		} else if rollout.Type != "response_item" {
+			return
+		}
+		var item codexItem
+		if json.Unmarshal(raw, &item) != nil {
+			return
+		}
+		ts, _ := time.Parse(time.RFC3339Nano, rollout.Timestamp)
+		switch item.Type {
+		case "message":
+			kind := models.AgentTranscriptAssistant
+			if item.Role == "user" {
+				kind = models.AgentTranscriptUser
+			} else if item.Role != "assistant" {
+				return
+			}
+			if text := codexTranscriptText(item.Content); text != "" {
+				entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+			}
+		case "reasoning":
+			if text := codexTranscriptText(item.Summary); text != "" {
+				entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: text})
+			}
+		case "function_call", "custom_tool_call", "local_shell_call":
+			name := codexToolCall(item).name
+			toolNames[item.CallID] = name
+			var input string
+			switch item.Type {
+			case "function_call":
+				input = indentTranscriptJSON(json.RawMessage(item.Arguments))
+			case "custom_tool_call":
+				input = item.Input
+			default:
+				if item.Action != nil {
+					input = strings.Join(item.Action.Command, " ")
+				}
+			}
+			entries = append(entries, models.AgentTranscriptEntry{
+				Kind:      models.AgentTranscriptToolCall,
+				Timestamp: ts,
+				ToolName:  name,
+				ToolInput: input,
+			})
+		case "function_call_output", "custom_tool_call_output", "local_shell_call_output":
+			text, isError := codexToolOutput(item.Output)
+			entries = append(entries, models.AgentTranscriptEntry{
+				Kind:      models.AgentTranscriptToolResult,
+				Timestamp: ts,
+				ToolName:  toolNames[item.CallID],
+				Text:      text,
+				IsError:   isError,
+			})
+		}
+	})
+	return entries, err
+}
+
+// codexTranscriptText joins the text blocks of a message, leaving out the
+// environment and instruction preambles Codex injects as user input.
+func codexTranscriptText(content []codexContent) string {
+	var parts []string
+	for _, block := range content {
+		text := strings.TrimSpace(block.Text)
+		if text == "" || strings.HasPrefix(text, "<environment_context>") ||
+			strings.HasPrefix(text, "<user_instructions>") || strings.HasPrefix(text, "# AGENTS.md") {
+			continue
+		}
+		parts = append(parts, text)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// codexToolOutput unwraps a tool output, which older Codex versions encode
+// as a JSON document holding the output and the exit code.
+func codexToolOutput(raw json.RawMessage) (string, bool) {
+	output := transcriptText(raw)
+	var wrapped struct {
+		Output   *string `json:"output"`
+		Metadata struct {
+			ExitCode int `json:"exit_code"`
+		} `json:"metadata"`
+	}
+	if json.Unmarshal([]byte(output), &wrapped) == nil && wrapped.Output != nil {
+		return strings.TrimSpace(*wrapped.Output), wrapped.Metadata.ExitCode != 0
+	}
+	return output, false
+}
+
+func readGeminiTranscript(path string) ([]models.AgentTranscriptEntry, error) {
+	//nolint:gosec // Transcript paths come from local agent directories discovered by the application.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var conversation geminiConversation
+	if err := json.Unmarshal(data, &conversation); err != nil {
+		return nil, err
+	}
+	var entries []models.AgentTranscriptEntry
+	for i := range conversation.Messages {
+		message := &conversation.Messages[i]
+		ts, _ := time.Parse(time.RFC3339Nano, message.Timestamp)
+		text := transcriptText(message.Content)
+		switch message.Type {
+		case "user":
+			if text != "" {
+				entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptUser, Timestamp: ts, Text: text})
+			}
+		case "gemini":
+			for _, thought := range message.Thoughts {
+				thinking := strings.TrimSpace(thought.Description)
+				if subject := strings.TrimSpace(thought.Subject); subject != "" {
+					thinking = strings.TrimSpace("**" + subject + "**\n" + thinking)
+				}
+				if thinking != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: thinking})
+				}
+			}
+			if text != "" {
+				entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptAssistant, Timestamp: ts, Text: text})
+			}
+			for j := range message.ToolCalls {
+				call := &message.ToolCalls[j]
+				name := normalizeGeminiToolName(call.Name)
+				callTS, _ := time.Parse(time.RFC3339Nano, firstNonEmpty(call.Timestamp, message.Timestamp))
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolCall,
+					Timestamp: callTS,
+					ToolName:  name,
+					ToolInput: indentTranscriptJSON(call.Args),
+				})
+				if len(call.ResultDisplay) == 0 && call.Status != "error" {
+					continue
+				}
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolResult,
+					Timestamp: callTS,
+					ToolName:  name,
+					Text:      geminiResultText(call.ResultDisplay),
+					IsError:   call.Status == "error",
+				})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// geminiResultText returns what the Gemini CLI displayed for a tool result:
+// plain text, or the diff of a file edit.
+func geminiResultText(raw json.RawMessage) string {
+	if len(raw) > 0 && raw[0] == '{' {
+		var display struct {
+			FileDiff string `json:"fileDiff"`
+		}
+		if json.Unmarshal(raw, &display) == nil {
+			return strings.TrimSpace(display.FileDiff)
+		}
+	}
+	return transcriptText(raw)
+}
+
+func readOpenCodeTranscript(root, sessionID string) []models.AgentTranscriptEntry {
+	var messages []openCodeMessage
+	readOpenCodeDir(filepath.Join(root, "message", sessionID), func(path string) {
+		var message openCodeMessage
+		if readOpenCodeJSON(path, &message) == nil && message.ID != "" {
+			messages = append(messages, message)
+		}
+	})
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+
+	var entries []models.AgentTranscriptEntry
+	for _, message := range messages {
+		kind := models.AgentTranscriptAssistant
+		if message.Role == "user" {
+			kind = models.AgentTranscriptUser
+		}
+		ts := time.UnixMilli(message.Time.Created)
+		for _, part := range openCodeMessageParts(root, message.ID) {
+			switch part.Type {
+			case "text":
+				if text := strings.TrimSpace(part.Text); text != "" && !part.Synthetic {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: kind, Timestamp: ts, Text: text})
+				}
+			case "reasoning":
+				if text := strings.TrimSpace(part.Text); text != "" {
+					entries = append(entries, models.AgentTranscriptEntry{Kind: models.AgentTranscriptThinking, Timestamp: ts, Text: text})
+				}
+			case "tool":
+				name := normalizeOpenCodeToolName(part.Tool)
+				entries = append(entries, models.AgentTranscriptEntry{
+					Kind:      models.AgentTranscriptToolCall,
+					Timestamp: ts,
+					ToolName:  name,
+					ToolInput: indentTranscriptJSON(part.State.Input),
+				})
+				switch part.State.Status {
+				case "completed", "error":
+					entries = append(entries, models.AgentTranscriptEntry{
+						Kind:      models.AgentTranscriptToolResult,
+						Timestamp: ts,
+						ToolName:  name,
+						Text:      strings.TrimSpace(firstNonEmpty(part.State.Output, part.State.Error)),
+						IsError:   part.State.Status == "error",
+					})
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// readAiderTranscript reads the run of an Aider chat history that started
+// at started, or the latest run when it cannot be found.
+func readAiderTranscript(path, started string) ([]models.AgentTranscriptEntry, error) {
+	//nolint:gosec // History paths are built from the worktrees of the current repository.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	idx := strings.LastIndex(content, aiderChatStarted+started+"\n")
+	if started == "" || idx < 0 {
+		idx = strings.LastIndex(content, aiderChatStarted)
+	}
+	if idx < 0 {
+		return nil, nil
+	}
+	content = content[idx:]
+	if next := strings.Index(content[1:], aiderChatStarted); next >= 0 {
+		content = content[:next+1]
+	}
+
+	var entries []models.AgentTranscriptEntry
+	var kind models.AgentTranscriptEntryKind
+	var lines []string
+	flush := func() {
+		if text := strings.TrimSpace(strings.Join(lines, "\n")); text != "" {
+			entry := models.AgentTranscriptEntry{Kind: kind, Text: text}
+			if kind == models.AgentTranscriptToolResult {
+				entry.ToolName = "Aider"
+			}
+			entries = append(entries, entry)
+		}
+		lines = lines[:0]
+	}
+	add := func(lineKind models.AgentTranscriptEntryKind, line string) {
+		if lineKind != kind {
+			flush()
+			kind = lineKind
+		}
+		lines = append(lines, line)
+	}
+	for line := range strings.SplitSeq(content, "\n") {
+		switch {
+		case strings.HasPrefix(line, aiderChatStarted):
+		case strings.HasPrefix(line, "#### "):
+			add(models.AgentTranscriptUser, strings.TrimPrefix(line, "#### "))
+		case strings.HasPrefix(line, "> "):
+			add(models.AgentTranscriptToolResult, strings.TrimPrefix(line, "> "))
+		case kind == models.AgentTranscriptAssistant || strings.TrimSpace(line) != "":
+			add(models.AgentTranscriptAssistant, line)
+		}
+	}
+	flush()
+	return entries, nil
+}
+
+// transcriptText returns the text of a content field that is either a plain
+// string or a list of blocks holding text.
+func transcriptText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	if raw[0] == '"' {
+		var text string
+		_ = json.Unmarshal(raw, &text)
+		return strings.TrimSpace(text)
+	}
+	var blocks []struct {
+		Text string `json:"text"`
+	}
+	_ = json.Unmarshal(raw, &blocks)
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if text := strings.TrimSpace(block.Text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// indentTranscriptJSON pretty-prints tool arguments, returning anything that
+// is not JSON unchanged.
+func indentTranscriptJSON(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" || string(raw) == "{}" {
+		return ""
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, raw, "", "  "); err != nil {
+		return string(raw)
+	}
+	return out.String()
+}
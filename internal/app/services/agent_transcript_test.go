@@ -0,0 +1,185 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func transcriptKinds(entries []models.AgentTranscriptEntry) []models.AgentTranscriptEntryKind {
+	kinds := make([]models.AgentTranscriptEntryKind, 0, len(entries))
+	for _, entry := range entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	return kinds
+}
+
+func assertTranscriptKinds(t *testing.T, entries []models.AgentTranscriptEntry, want ...models.AgentTranscriptEntryKind) {
+	t.Helper()
+	got := transcriptKinds(entries)
+	if len(got) != len(want) {
+		t.Fatalf("expected kinds %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected kinds %v, got %v", want, got)
+		}
+	}
+}
+
+func TestLoadAgentTranscriptClaude(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	ts := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC).Format(time.RFC3339Nano)
+	writeJSONLLines(
+		t, path,
+		mustJSONLine(t, map[string]any{"type": "user", "isMeta": true, "timestamp": ts, "message": map[string]any{"role": "user", "content": "Caveat: local commands"}}),
+		mustJSONLine(t, map[string]any{"type": "user", "timestamp": ts, "message": map[string]any{"role": "user", "content": "Run the **tests**"}}),
+		mustJSONLine(t, map[string]any{"type": "assistant", "timestamp": ts, "message": map[string]any{
+			"role": "assistant",
+			"content": []map[string]any{
+				{"type": "thinking", "thinking": "The user wants tests run."},
+				{"type": "text", "text": "Running them now."},
+				{"type": "tool_use", "id": "toolu_1", "name": "Bash", "input": map[string]any{"command": "go test ./..."}},
+			},
+		}}),
+		mustJSONLine(t, map[string]any{"type": "user", "timestamp": ts, "message": map[string]any{
+			"role": "user",
+			"content": []map[string]any{
+				{"type": "tool_result", "tool_use_id": "toolu_1", "is_error": true, "content": []map[string]any{{"type": "text", "text": "FAIL ./pkg"}}},
+			},
+		}}),
+		mustJSONLine(t, map[string]any{"type": "summary", "summary": "Tests"}),
+	)
+
+	entries, err := LoadAgentTranscript(&models.AgentSession{Agent: models.AgentKindClaude, JSONLPath: path})
+	if err != nil {
+		t.Fatalf("LoadAgentTranscript returned error: %v", err)
+	}
+	assertTranscriptKinds(t, entries,
+		models.AgentTranscriptUser,
+		models.AgentTranscriptThinking,
+		models.AgentTranscriptAssistant,
+		models.AgentTranscriptToolCall,
+		models.AgentTranscriptToolResult,
+	)
+	if entries[0].Text != "Run the **tests**" || entries[0].Timestamp.IsZero() {
+		t.Fatalf("unexpected prompt entry %#v", entries[0])
+	}
+	if entries[3].ToolName != "Bash" || entries[3].ToolInput != "{\n  \"command\": \"go test ./...\"\n}" {
+		t.Fatalf("unexpected tool call %#v", entries[3])
+	}
+	if result := entries[4]; result.ToolName != "Bash" || result.Text != "FAIL ./pkg" || !result.IsError {
+		t.Fatalf("unexpected tool result %#v", result)
+	}
+}
+
+func TestLoadAgentTranscriptCodex(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rollout.jsonl")
+	ts := time.Now().UTC()
+	writeJSONLLines(
+		t, path,
+		codexLine(t, ts, "session_meta", map[string]any{"id": testCodexSessionID, "cwd": "/tmp/feature"}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "message",
+			"role":    "user",
+			"content": []map[string]any{{"type": "input_text", "text": "<environment_context>/tmp</environment_context>"}},
+		}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "message",
+			"role":    "user",
+			"content": []map[string]any{{"type": "input_text", "text": "Fix the parser"}},
+		}),
+		codexLine(t, ts, "event_msg", map[string]any{"type": "user_message", "message": "Fix the parser"}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "reasoning",
+			"summary": []map[string]any{{"type": "summary_text", "text": "Looking at the parser"}},
+		}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":      "function_call",
+			"name":      "shell",
+			"arguments": `{"command":["bash","-lc","go test ./..."]}`,
+			"call_id":   "call_1",
+		}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "function_call_output",
+			"call_id": "call_1",
+			"output":  `{"output":"ok","metadata":{"exit_code":1}}`,
+		}),
+		codexLine(t, ts, "response_item", map[string]any{
+			"type":    "message",
+			"role":    "assistant",
+			"content": []map[string]any{{"type": "output_text", "text": "Fixed."}},
+		}),
+	)
+
+	entries, err := LoadAgentTranscript(&models.AgentSession{Agent: models.AgentKindCodex, JSONLPath: path})
+	if err != nil {
+		t.Fatalf("LoadAgentTranscript returned error: %v", err)
+	}
+	assertTranscriptKinds(t, entries,
+		models.AgentTranscriptUser,
+		models.AgentTranscriptThinking,
+		models.AgentTranscriptToolCall,
+		models.AgentTranscriptToolResult,
+		models.AgentTranscriptAssistant,
+	)
+	if entries[0].Text != "Fix the parser" {
+		t.Fatalf("expected the environment preamble to be skipped, got %q", entries[0].Text)
+	}
+	if result := entries[3]; result.ToolName != "Bash" || result.Text != "ok" || !result.IsError {
+		t.Fatalf("unexpected tool result %#v", result)
+	}
+}
+
+func TestLoadAgentTranscriptAiderReadsSessionRun(t *testing.T) {
+	t.Parallel()
+
+	worktreePath := t.TempDir()
+	path := filepath.Join(worktreePath, aiderHistoryFile)
+	if err := os.WriteFile(path, []byte(testAiderHistory), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := LoadAgentTranscript(&models.AgentSession{
+		ID:        worktreePath + "@2026-01-01 09:00:00",
+		Agent:     models.AgentKindAider,
+		CWD:       worktreePath,
+		JSONLPath: path,
+	})
+	if err != nil {
+		t.Fatalf("LoadAgentTranscript returned error: %v", err)
+	}
+	assertTranscriptKinds(t, entries, models.AgentTranscriptUser)
+	if entries[0].Text != "An older run" {
+		t.Fatalf("expected the older run, got %q", entries[0].Text)
+	}
+
+	entries, err = LoadAgentTranscript(&models.AgentSession{ID: worktreePath + "@2026-01-02 10:30:00", Agent: models.AgentKindAider, CWD: worktreePath, JSONLPath: path})
+	if err != nil {
+		t.Fatalf("LoadAgentTranscript returned error: %v", err)
+	}
+	assertTranscriptKinds(t, entries,
+		models.AgentTranscriptToolResult,
+		models.AgentTranscriptUser,
+		models.AgentTranscriptAssistant,
+		models.AgentTranscriptToolResult,
+	)
+	if entries[2].Text != "I will add the flag to the CLI." {
+		t.Fatalf("unexpected reply %q", entries[2].Text)
+	}
+}
+
+func TestLoadAgentTranscriptWithoutTranscript(t *testing.T) {
+	t.Parallel()
+
+	if _, err := LoadAgentTranscript(&models.AgentSession{Agent: models.AgentKindCopilot}); err == nil {
+		t.Fatal("expected an error for a session without a transcript")
+	}
+}
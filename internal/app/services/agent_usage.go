@@ -0,0 +1,90 @@
+package services
+
+import (
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// defaultAgentPrices lists public list prices, in US dollars per million
+// tokens, for the models the supported agents use by default. Entries from
+// agent_sessions.prices take precedence.
+var defaultAgentPrices = config.AgentPriceTable{
+	"claude-opus-4-5":   {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.5},
+	"claude-opus-4":     {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+	"claude-sonnet-4":   {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-3-7-sonnet": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-haiku-4":    {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.1},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+	"gpt-5":             {Input: 1.25, Output: 10, CacheRead: 0.125},
+	"gpt-5-mini":        {Input: 0.25, Output: 2, CacheRead: 0.025},
+	"gpt-5-nano":        {Input: 0.05, Output: 0.4, CacheRead: 0.005},
+	"gpt-4.1":           {Input: 2, Output: 8, CacheRead: 0.5},
+	"o3":                {Input: 2, Output: 8, CacheRead: 0.5},
+	"o4-mini":           {Input: 1.1, Output: 4.4, CacheRead: 0.275},
+}
+
+// AgentModelPrice returns the price of model: the configured or built-in
+// entry with the longest prefix of the model name.
+func AgentModelPrice(model string, prices config.AgentPriceTable) (config.AgentModelPrice, bool) {
+	model = strings.ToLower(strings.TrimSpace(model))
+	if model == "" {
+		return config.AgentModelPrice{}, false
+	}
+	var best string
+	var price config.AgentModelPrice
+	for _, table := range []config.AgentPriceTable{defaultAgentPrices, prices} {
+		for prefix, p := range table {
+			// Configured entries win ties with built-in ones.
+			if strings.HasPrefix(model, prefix) && len(prefix) >= len(best) {
+				best, price = prefix, p
+			}
+		}
+	}
+	return price, best != ""
+}
+
+// EstimateAgentCost returns the cost in US dollars of a session's token
+// usage, and false when the model has no known price.
+func EstimateAgentCost(session *models.AgentSession, prices config.AgentPriceTable) (float64, bool) {
+	if session == nil {
+		return 0, false
+	}
+	price, ok := AgentModelPrice(session.Model, prices)
+	if !ok {
+		return 0, false
+	}
+	usage := session.Usage
+	cost := float64(usage.Input)*price.Input +
+		float64(usage.Output)*price.Output +
+		float64(usage.CacheWrite)*price.CacheWrite +
+		float64(usage.CacheRead)*price.CacheRead
+	return cost / 1_000_000, true
+}
+
+// AgentUsageSummary adds up the usage and estimated cost of several sessions.
+type AgentUsageSummary struct {
+	Usage models.AgentTokenUsage
+	Cost  float64
+	// Unpriced is set when some usage belongs to a model without a price, so
+	// Cost is a lower bound.
+	Unpriced bool
+}
+
+// SummariseAgentUsage totals the usage of sessions.
+func SummariseAgentUsage(sessions []*models.AgentSession, prices config.AgentPriceTable) AgentUsageSummary {
+	var summary AgentUsageSummary
+	for _, session := range sessions {
+		if session == nil || session.Usage.Total() == 0 {
+			continue
+		}
+		summary.Usage = summary.Usage.Add(session.Usage)
+		if cost, ok := EstimateAgentCost(session, prices); ok {
+			summary.Cost += cost
+		} else {
+			summary.Unpriced = true
+		}
+	}
+	return summary
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestParseClaudeSessionUsageCountsEachMessageOnce(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "session.jsonl")
+	ts := time.Now().UTC().Format(time.RFC3339Nano)
+	usage := map[string]any{"input_tokens": 10, "output_tokens": 200, "cache_creation_input_tokens": 1000, "cache_read_input_tokens": 5000}
+	assistant := func(id string, block map[string]any) string {
+		return mustJSONLine(t, map[string]any{"type": "assistant", "timestamp": ts, "cwd": "/tmp/feature", "message": map[string]any{
+			"id": id, "role": "assistant", "model": "claude-sonnet-4-5", "usage": usage,
+			"content": []map[string]any{block},
+		}})
+	}
+	writeJSONLLines(
+		t, path,
+		mustJSONLine(t, map[string]any{"type": "user", "timestamp": ts, "cwd": "/tmp/feature", "message": map[string]any{"role": "user", "content": "hi"}}),
+		// Claude Code writes one line per content block, repeating the usage.
+		assistant("msg_1", map[string]any{"type": "thinking", "thinking": "hmm"}),
+		assistant("msg_1", map[string]any{"type": "text", "text": "Hello"}),
+		assistant("msg_2", map[string]any{"type": "text", "text": "Done"}),
+	)
+
+	session, err := parseClaudeSession(path, "")
+	if err != nil {
+		t.Fatalf("parseClaudeSession returned error: %v", err)
+	}
+	want := models.AgentTokenUsage{Input: 20, Output: 400, CacheWrite: 2000, CacheRead: 10000}
+	if session.Usage != want {
+		t.Fatalf("expected usage %+v, got %+v", want, session.Usage)
+	}
+}
+
+func TestParseCodexSessionUsageUsesLatestTotal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "rollout.jsonl")
+	ts := time.Now().UTC()
+	tokenCount := func(input, cached, output int) string {
+		return codexLine(t, ts, "event_msg", map[string]any{"type": "token_count", "info": map[string]any{
+			"total_token_usage": map[string]any{"input_tokens": input, "cached_input_tokens": cached, "output_tokens": output},
+		}})
+	}
+	writeJSONLLines(
+		t, path,
+		codexLine(t, ts, "session_meta", map[string]any{"id": testCodexSessionID, "cwd": "/tmp/feature"}),
+		tokenCount(1000, 200, 50),
+		codexLine(t, ts, "event_msg", map[string]any{"type": "token_count", "info": nil}),
+		tokenCount(3000, 1200, 150),
+	)
+
+	session, err := parseCodexSession(path, "")
+	if err != nil {
+		t.Fatalf("parseCodexSession returned error: %v", err)
+	}
+	want := models.AgentTokenUsage{Input: 1800, Output: 150, CacheRead: 1200}
+	if session.Usage != want {
+		t.Fatalf("expected usage %+v, got %+v", want, session.Usage)
+	}
+}
+
+func TestEstimateAgentCost(t *testing.T) {
+	t.Parallel()
+
+	session := &models.AgentSession{
+		Model: "claude-opus-4-5-20251101",
+		Usage: models.AgentTokenUsage{Input: 1_000_000, Output: 100_000, CacheRead: 2_000_000},
+	}
+	cost, ok := EstimateAgentCost(session, nil)
+	if !ok || math.Abs(cost-8.5) > 1e-9 {
+		t.Fatalf("expected the opus 4.5 price to win over opus 4, got %v (%v)", cost, ok)
+	}
+
+	prices := config.AgentPriceTable{"claude-opus-4-5": {Input: 1, Output: 1}}
+	if cost, _ := EstimateAgentCost(session, prices); math.Abs(cost-1.1) > 1e-9 {
+		t.Fatalf("expected the configured price to override the built-in one, got %v", cost)
+	}
+
+	unknown := &models.AgentSession{Model: "local-llama", Usage: models.AgentTokenUsage{Output: 10}}
+	if _, ok := EstimateAgentCost(unknown, nil); ok {
+		t.Fatal("expected no estimate for an unknown model")
+	}
+	summary := SummariseAgentUsage([]*models.AgentSession{session, unknown, nil}, nil)
+	if !summary.Unpriced || summary.Usage.Output != 100_010 || math.Abs(summary.Cost-8.5) > 1e-9 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+}
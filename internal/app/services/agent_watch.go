@@ -16,8 +16,9 @@ type AgentWatchService struct {
 	Started bool
 	Waiting bool
 	Roots   []string
-	// SpoolRoots is the subset of Roots that contain hook spool .json files.
-	// .json events are only accepted from these roots; all roots accept .jsonl.
+	// SpoolRoots is the subset of Roots that contain .json files: the hook
+	// spool and agents whose transcripts are JSON documents. .json events are
+	// only accepted from these roots; all roots accept .jsonl.
 	SpoolRoots []string
 	Events     chan struct{}
 	Done       chan struct{}
@@ -18,8 +18,8 @@ type GitCIProvider interface {
 	// GetHeadSHA returns the HEAD commit SHA for a worktree path.
 	GetHeadSHA(ctx context.Context, path string) string
 
-	// IsGitHub returns true if the repository is hosted on GitHub.
-	IsGitHub(ctx context.Context) bool
+	// SupportsCommitCI returns true if the forge reports CI status for commits without a PR.
+	SupportsCommitCI(ctx context.Context) bool
 }
 
 // CIFetchService creates commands for fetching CI status.
@@ -10,12 +10,12 @@ import (
 
 // mockGitCIProvider implements GitCIProvider for testing.
 type mockGitCIProvider struct {
-	checks         []*models.CICheck
-	commitChecks   []*models.CICheck
-	headSHA        string
-	isGitHub       bool
-	fetchPRErr     error
-	fetchCommitErr error
+	checks           []*models.CICheck
+	commitChecks     []*models.CICheck
+	headSHA          string
+	supportsCommitCI bool
+	fetchPRErr       error
+	fetchCommitErr   error
 }
 
 func (m *mockGitCIProvider) FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error) {
@@ -30,8 +30,8 @@ func (m *mockGitCIProvider) GetHeadSHA(ctx context.Context, path string) string
 	return m.headSHA
 }
 
-func (m *mockGitCIProvider) IsGitHub(ctx context.Context) bool {
-	return m.isGitHub
+func (m *mockGitCIProvider) SupportsCommitCI(ctx context.Context) bool {
+	return m.supportsCommitCI
 }
 
 func TestCIFetchService_CreateFetchForPR(t *testing.T) {
@@ -14,6 +14,8 @@ type FilterService struct {
 	WorktreeSearchQuery string
 	StatusSearchQuery   string
 	LogSearchQuery      string
+	// StatusAgentOnly limits the status pane to files edited by an agent.
+	StatusAgentOnly bool
 }
 
 // NewFilterService creates a new FilterService with an optional initial filter.
@@ -78,7 +80,7 @@ func (f *FilterService) HasActiveFilterForPane(paneIndex int) bool {
 		// Info-only pane, no filter
 		return false
 	case 2:
-		return strings.TrimSpace(f.StatusFilterQuery) != ""
+		return strings.TrimSpace(f.StatusFilterQuery) != "" || f.StatusAgentOnly
 	case 3:
 		return strings.TrimSpace(f.LogFilterQuery) != ""
 	}
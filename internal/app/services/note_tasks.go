@@ -0,0 +1,140 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownTaskLineRE = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s*)(.*)$`)
+	todoKeywordLineRE  = regexp.MustCompile(`^(\s*)(TODO|DONE)(:?\s*)(.*)$`)
+)
+
+// NoteTask is a checkbox (`- [ ] ...`) or TODO/DONE keyword line found in a
+// worktree note.
+type NoteTask struct {
+	Line    int
+	Done    bool
+	Text    string
+	Keyword bool
+}
+
+// ParseNoteTasks returns the tasks of a note in line order.
+func ParseNoteTasks(noteText string) []NoteTask {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	tasks := make([]NoteTask, 0, len(lines))
+
+	for i, line := range lines {
+		done, text, ok := parseMarkdownTaskLine(line)
+		keyword := false
+		if !ok {
+			done, text, ok = parseTodoKeywordLine(line)
+			keyword = ok
+		}
+		if !ok {
+			continue
+		}
+		tasks = append(tasks, NoteTask{Line: i, Done: done, Text: text, Keyword: keyword})
+	}
+
+	return tasks
+}
+
+// ToggleNoteTask flips the state of the task on the given line, keeping the
+// rest of the note untouched.
+func ToggleNoteTask(noteText string, task NoteTask) (string, bool) {
+	if task.Keyword {
+		return toggleTodoKeywordLine(noteText, task.Line)
+	}
+	return toggleMarkdownTaskLine(noteText, task.Line)
+}
+
+// AppendNoteTask adds an open checkbox task at the end of a note.
+func AppendNoteTask(noteText, text string) string {
+	if strings.TrimSpace(noteText) == "" {
+		return "- [ ] " + text
+	}
+	return strings.TrimRight(noteText, "\n") + "\n- [ ] " + text
+}
+
+func parseMarkdownTaskLine(line string) (checked bool, text string, ok bool) {
+	parts := markdownTaskLineRE.FindStringSubmatch(line)
+	if len(parts) != 5 {
+		return false, "", false
+	}
+
+	checked = strings.EqualFold(parts[2], "x")
+	text = strings.TrimSpace(parts[4])
+	if text == "" {
+		text = "(untitled task)"
+	}
+	return checked, text, true
+}
+
+func toggleMarkdownTaskLine(noteText string, lineIndex int) (string, bool) {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return noteText, false
+	}
+
+	line := lines[lineIndex]
+	idx := markdownTaskLineRE.FindStringSubmatchIndex(line)
+	if len(idx) < 6 {
+		return noteText, false
+	}
+
+	checkStart := idx[4]
+	checkEnd := idx[5]
+	if checkStart < 0 || checkEnd <= checkStart || checkEnd > len(line) {
+		return noteText, false
+	}
+
+	replacement := "x"
+	if strings.EqualFold(line[checkStart:checkEnd], "x") {
+		replacement = " "
+	}
+	lines[lineIndex] = line[:checkStart] + replacement + line[checkEnd:]
+	return strings.Join(lines, "\n"), true
+}
+
+func parseTodoKeywordLine(line string) (checked bool, text string, ok bool) {
+	parts := todoKeywordLineRE.FindStringSubmatch(line)
+	if len(parts) != 5 {
+		return false, "", false
+	}
+	checked = parts[2] == "DONE"
+	text = strings.TrimSpace(parts[4])
+	if text == "" {
+		text = "(untitled task)"
+	}
+	return checked, text, true
+}
+
+func toggleTodoKeywordLine(noteText string, lineIndex int) (string, bool) {
+	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
+	lines := strings.Split(normalized, "\n")
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return noteText, false
+	}
+
+	line := lines[lineIndex]
+	idx := todoKeywordLineRE.FindStringSubmatchIndex(line)
+	if len(idx) < 6 {
+		return noteText, false
+	}
+
+	kwStart := idx[4]
+	kwEnd := idx[5]
+	keyword := line[kwStart:kwEnd]
+
+	var replacement string
+	if keyword == "TODO" {
+		replacement = "DONE"
+	} else {
+		replacement = "TODO"
+	}
+	lines[lineIndex] = line[:kwStart] + replacement + line[kwEnd:]
+	return strings.Join(lines, "\n"), true
+}
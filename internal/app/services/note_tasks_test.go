@@ -0,0 +1,199 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdownTaskLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantOK   bool
+		wantDone bool
+		wantText string
+	}{
+		{name: "unchecked", line: "- [ ] Write docs", wantOK: true, wantDone: false, wantText: "Write docs"},
+		{name: "checked upper", line: "* [X] Ship it", wantOK: true, wantDone: true, wantText: "Ship it"},
+		{name: "checked lower", line: "+ [x] Merge PR", wantOK: true, wantDone: true, wantText: "Merge PR"},
+		{name: "not task", line: "- TODO: plain text tag", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, text, ok := parseMarkdownTaskLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if done != tt.wantDone {
+				t.Fatalf("checked=%v want=%v", done, tt.wantDone)
+			}
+			if text != tt.wantText {
+				t.Fatalf("text=%q want=%q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestToggleMarkdownTaskLinePreservesFormatting(t *testing.T) {
+	note := "## Notes\n  - [ ]   Keep spacing exactly\n- [x] done"
+	updated, ok := toggleMarkdownTaskLine(note, 1)
+	if !ok {
+		t.Fatal("expected toggle to succeed")
+	}
+	lines := strings.Split(updated, "\n")
+	if len(lines) != 3 {
+		t.Fatalf("unexpected line count: %d", len(lines))
+	}
+	if lines[0] != "## Notes" {
+		t.Fatalf("expected heading unchanged, got %q", lines[0])
+	}
+	if lines[1] != "  - [x]   Keep spacing exactly" {
+		t.Fatalf("expected only checkbox marker to flip, got %q", lines[1])
+	}
+	if lines[2] != "- [x] done" {
+		t.Fatalf("expected unrelated line unchanged, got %q", lines[2])
+	}
+}
+
+func TestParseTodoKeywordLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantOK   bool
+		wantDone bool
+		wantText string
+	}{
+		{name: "todo with colon", line: "TODO: Review the PR", wantOK: true, wantDone: false, wantText: "Review the PR"},
+		{name: "todo without colon", line: "TODO fix the parser", wantOK: true, wantDone: false, wantText: "fix the parser"},
+		{name: "done with colon", line: "DONE: Set up CI", wantOK: true, wantDone: true, wantText: "Set up CI"},
+		{name: "done without colon", line: "DONE shipped it", wantOK: true, wantDone: true, wantText: "shipped it"},
+		{name: "leading whitespace", line: "  TODO: indented task", wantOK: true, wantDone: false, wantText: "indented task"},
+		{name: "bare todo", line: "TODO", wantOK: true, wantDone: false, wantText: "(untitled task)"},
+		{name: "bare done", line: "DONE", wantOK: true, wantDone: true, wantText: "(untitled task)"},
+		{name: "bare todo colon", line: "TODO:", wantOK: true, wantDone: false, wantText: "(untitled task)"},
+		{name: "lowercase not matched", line: "todo: lowercase", wantOK: false},
+		{name: "mid-line not matched", line: "some TODO: text", wantOK: false},
+		{name: "checkbox not matched", line: "- [ ] task", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done, text, ok := parseTodoKeywordLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if done != tt.wantDone {
+				t.Fatalf("checked=%v want=%v", done, tt.wantDone)
+			}
+			if text != tt.wantText {
+				t.Fatalf("text=%q want=%q", text, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestToggleTodoKeywordLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		note     string
+		line     int
+		wantLine string
+		wantOK   bool
+	}{
+		{
+			name:     "todo to done",
+			note:     "TODO: Review PR",
+			line:     0,
+			wantLine: "DONE: Review PR",
+			wantOK:   true,
+		},
+		{
+			name:     "done to todo",
+			note:     "DONE: Review PR",
+			line:     0,
+			wantLine: "TODO: Review PR",
+			wantOK:   true,
+		},
+		{
+			name:     "preserves indentation",
+			note:     "  TODO: indented",
+			line:     0,
+			wantLine: "  DONE: indented",
+			wantOK:   true,
+		},
+		{
+			name:     "preserves no colon",
+			note:     "TODO fix it",
+			line:     0,
+			wantLine: "DONE fix it",
+			wantOK:   true,
+		},
+		{
+			name:     "preserves surrounding lines",
+			note:     "# Heading\nTODO: task\n- [ ] checkbox",
+			line:     1,
+			wantLine: "DONE: task",
+			wantOK:   true,
+		},
+		{
+			name:   "out of range",
+			note:   "TODO: task",
+			line:   5,
+			wantOK: false,
+		},
+		{
+			name:   "non-keyword line",
+			note:   "plain text",
+			line:   0,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := toggleTodoKeywordLine(tt.note, tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ok=%v want=%v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			lines := strings.Split(result, "\n")
+			if lines[tt.line] != tt.wantLine {
+				t.Fatalf("got %q want %q", lines[tt.line], tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestParseNoteTasksAndToggle(t *testing.T) {
+	note := "# Plan\r\nTODO: Review the PR\n- [x] Write tests"
+	tasks := ParseNoteTasks(note)
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+	if tasks[0].Line != 1 || !tasks[0].Keyword || tasks[0].Done || tasks[0].Text != "Review the PR" {
+		t.Fatalf("unexpected keyword task: %+v", tasks[0])
+	}
+	if tasks[1].Line != 2 || tasks[1].Keyword || !tasks[1].Done {
+		t.Fatalf("unexpected checkbox task: %+v", tasks[1])
+	}
+
+	updated, ok := ToggleNoteTask(note, tasks[1])
+	if !ok || !strings.HasSuffix(updated, "- [ ] Write tests") {
+		t.Fatalf("expected checkbox to be cleared, got %q", updated)
+	}
+	if got := AppendNoteTask(updated+"\n\n", "Ship it"); !strings.HasSuffix(got, "Write tests\n- [ ] Ship it") {
+		t.Fatalf("unexpected appended note %q", got)
+	}
+	if got := AppendNoteTask("", "Ship it"); got != "- [ ] Ship it" {
+		t.Fatalf("unexpected appended note %q", got)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// DraftPR prefills the title and body of a new PR/MR for a worktree branch.
+// The title comes from the note description, the only commit subject, or the
+// branch name, in that order. The body is the note text followed by the list
+// of commits when there is more than one.
+func DraftPR(note models.WorktreeNote, branch string, commits []string) (title, body string) {
+	switch {
+	case strings.TrimSpace(note.Description) != "":
+		title = strings.TrimSpace(note.Description)
+	case len(commits) == 1:
+		title = commits[0]
+	default:
+		title = titleFromBranch(branch)
+	}
+
+	var sections []string
+	if text := strings.TrimSpace(note.Note); text != "" {
+		sections = append(sections, text)
+	}
+	if len(commits) > 1 {
+		var b strings.Builder
+		b.WriteString("## Commits\n")
+		for _, subject := range commits {
+			b.WriteString("\n- ")
+			b.WriteString(subject)
+		}
+		sections = append(sections, b.String())
+	}
+	return title, strings.Join(sections, "\n\n")
+}
+
+// titleFromBranch turns "feature/add-login_page" into "Add login page".
+func titleFromBranch(branch string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' {
+			return ' '
+		}
+		return r
+	}, path.Base(strings.TrimSpace(branch)))
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" || name == "." {
+		return ""
+	}
+	first, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(first)) + name[size:]
+}
+
+// SplitList splits a comma separated list, trimming entries and dropping
+// empty and duplicate ones whilst preserving order.
+func SplitList(value string) []string {
+	var result []string
+	seen := make(map[string]struct{})
+	for field := range strings.SplitSeq(value, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := seen[field]; ok || field == "" {
+			continue
+		}
+		seen[field] = struct{}{}
+		result = append(result, field)
+	}
+	return result
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftPR(t *testing.T) {
+	tests := []struct {
+		name      string
+		note      models.WorktreeNote
+		branch    string
+		commits   []string
+		wantTitle string
+		wantBody  string
+	}{
+		{
+			name:      "description wins",
+			note:      models.WorktreeNote{Description: "Add login page", Note: "  Closes #12  "},
+			branch:    "feature/login",
+			commits:   []string{"wip"},
+			wantTitle: "Add login page",
+			wantBody:  "Closes #12",
+		},
+		{
+			name:      "single commit subject",
+			branch:    "feature/login",
+			commits:   []string{"Add login page"},
+			wantTitle: "Add login page",
+		},
+		{
+			name:      "branch name with commit list",
+			note:      models.WorktreeNote{Note: "Context"},
+			branch:    "feature/add-login_page",
+			commits:   []string{"First", "Second"},
+			wantTitle: "Add login page",
+			wantBody:  "Context\n\n## Commits\n\n- First\n- Second",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := DraftPR(tt.note, tt.branch, tt.commits)
+			assert.Equal(t, tt.wantTitle, title)
+			assert.Equal(t, tt.wantBody, body)
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	assert.Equal(t, []string{"bob", "good first issue", "org/team"}, SplitList(" bob,good first issue,, org/team ,bob"))
+	assert.Nil(t, SplitList("  "))
+}
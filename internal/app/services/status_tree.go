@@ -82,6 +82,18 @@ func BuildStatusTree(files []StatusFile) *StatusTreeNode {
 	return root
 }
 
+// MarkAgentEdits returns a copy of files with Agent set on those a coding
+// agent edited. edited maps slash-separated paths relative to the worktree
+// root to the agent, as returned by AgentEditedFiles.
+func MarkAgentEdits(files []StatusFile, edited map[string]models.AgentKind) []StatusFile {
+	marked := make([]StatusFile, len(files))
+	for i, file := range files {
+		file.Agent = edited[file.Filename]
+		marked[i] = file
+	}
+	return marked
+}
+
 // SortStatusTree sorts tree nodes: directories first, then alphabetically.
 func SortStatusTree(node *StatusTreeNode) {
 	if node == nil || node.Children == nil {
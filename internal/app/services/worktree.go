@@ -18,6 +18,10 @@ type WorktreeService interface {
 	// CreateFromChanges moves changes from an existing worktree to a new one.
 	CreateFromChanges(ctx context.Context, opts CreateFromChangesOptions) error
 
+	// MoveChanges moves the uncommitted changes of a worktree to another
+	// existing worktree through a stash.
+	MoveChanges(ctx context.Context, opts MoveChangesOptions) error
+
 	// Delete removes a worktree and optionally its branch.
 	Delete(ctx context.Context, path, branch string, deleteBranch bool) error
 
@@ -33,7 +37,9 @@ type WorktreeService interface {
 	// UpdateFromBase updates a branch from its PR base branch.
 	UpdateFromBase(ctx context.Context, wt *models.WorktreeInfo, mergeMethod string, env map[string]string) (string, error)
 
-	// Absorb merges or rebases a worktree into the main branch.
+	// Absorb merges or rebases a worktree into the main branch. mainWorktree
+	// is nil in bare repositories without a worktree on the main branch; only
+	// the rebase method works there, fast-forwarding the branch ref directly.
 	Absorb(ctx context.Context, wt *models.WorktreeInfo, mainWorktree *models.WorktreeInfo, mergeMethod string) error
 
 	// GetPruneCandidates identifies worktrees and stale branches that have been merged and are candidates for pruning.
@@ -60,6 +66,12 @@ type CreateFromChangesOptions struct {
 	Env           map[string]string
 }
 
+// MoveChangesOptions contains parameters for moving changes between worktrees.
+type MoveChangesOptions struct {
+	SourcePath string
+	TargetPath string
+}
+
 // PruneCandidate represents a worktree or stale branch that is a candidate for pruning.
 type PruneCandidate struct {
 	Worktree *models.WorktreeInfo // nil for branch-only candidates
@@ -107,23 +119,9 @@ func (s *worktreeService) CreateFromChanges(ctx context.Context, opts CreateFrom
 		return fmt.Errorf("failed to create worktree directory: %w", err)
 	}
 
-	// Stash changes with descriptive message
-	stashMessage := fmt.Sprintf("git-wt-create move-current: %s", opts.NewBranch)
-	if !s.git.RunCommandChecked(
-		ctx,
-		[]string{"git", "stash", "push", "-u", "-m", stashMessage},
-		opts.SourcePath,
-		"Failed to create stash for moving changes",
-	) {
-		return fmt.Errorf("failed to create stash for moving changes")
-	}
-
-	// Get the stash ref
-	stashRef := s.git.RunGit(ctx, []string{"git", "stash", "list", "-1", "--format=%gd"}, "", []int{0}, true, false)
-	if stashRef == "" || !strings.HasPrefix(stashRef, "stash@{") {
-		// Try to restore stash if we can't get the ref
-		s.git.RunCommandChecked(ctx, []string{"git", "stash", "pop"}, opts.SourcePath, "Failed to restore stash")
-		return fmt.Errorf("failed to get stash reference")
+	stashSHA, err := s.stashChanges(ctx, opts.SourcePath, fmt.Sprintf("git-wt-create move-current: %s", opts.NewBranch))
+	if err != nil {
+		return err
 	}
 
 	// Create the new worktree from current branch
@@ -134,29 +132,119 @@ func (s *worktreeService) CreateFromChanges(ctx context.Context, opts CreateFrom
 		fmt.Sprintf("Failed to create worktree %s", opts.NewBranch),
 	) {
 		// If worktree creation fails, try to restore the stash
-		s.git.RunCommandChecked(ctx, []string{"git", "stash", "pop"}, opts.SourcePath, "Failed to restore stash")
+		s.restoreStash(ctx, opts.SourcePath, stashSHA)
 		return fmt.Errorf("failed to create worktree %s", opts.NewBranch)
 	}
 
 	// Apply stash to the new worktree
-	if !s.git.RunCommandChecked(
-		ctx,
-		[]string{"git", "stash", "apply", "--index", stashRef},
-		opts.TargetPath,
-		"Failed to apply stash to new worktree",
-	) {
+	if !s.applyStash(ctx, opts.TargetPath, stashSHA) {
 		// If stash apply fails, clean up the worktree and try to restore stash to original location
 		s.git.RunCommandChecked(ctx, []string{"git", "worktree", "remove", "--force", opts.TargetPath}, "", "Failed to remove worktree")
-		s.git.RunCommandChecked(ctx, []string{"git", "stash", "pop"}, opts.SourcePath, "Failed to restore stash")
+		s.restoreStash(ctx, opts.SourcePath, stashSHA)
 		return fmt.Errorf("failed to apply stash to new worktree")
 	}
 
 	// Drop the stash from the original location
-	s.git.RunCommandChecked(ctx, []string{"git", "stash", "drop", stashRef}, opts.SourcePath, "Failed to drop stash")
+	s.dropStash(ctx, opts.SourcePath, stashSHA)
 
 	return nil
 }
 
+func (s *worktreeService) MoveChanges(ctx context.Context, opts MoveChangesOptions) error {
+	// A failed apply resets and cleans the target, which must not cost it
+	// changes of its own. Untracked files are listed whatever
+	// status.showUntrackedFiles says, since git clean removes them regardless.
+	if status := s.git.RunGit(ctx, []string{"git", "status", "--porcelain", "--untracked-files=all"}, opts.TargetPath, []int{0}, true, false); status != "" {
+		return fmt.Errorf("%s has uncommitted changes; commit or stash them first", opts.TargetPath)
+	}
+
+	stashSHA, err := s.stashChanges(ctx, opts.SourcePath, fmt.Sprintf("git-wt move-changes: %s -> %s", filepath.Base(opts.SourcePath), filepath.Base(opts.TargetPath)))
+	if err != nil {
+		return err
+	}
+
+	if !s.applyStash(ctx, opts.TargetPath, stashSHA) {
+		// A conflicting apply leaves part of the changes in the target: drop
+		// them, then give the source back its changes, which apply cleanly
+		// since it was just stashed
+		s.git.RunCommandChecked(ctx, []string{"git", "reset", "--hard"}, opts.TargetPath, fmt.Sprintf("Failed to reset %s", opts.TargetPath))
+		s.git.RunCommandChecked(ctx, []string{"git", "clean", "-fd"}, opts.TargetPath, fmt.Sprintf("Failed to clean %s", opts.TargetPath))
+		s.restoreStash(ctx, opts.SourcePath, stashSHA)
+		return fmt.Errorf("failed to apply changes to %s; they were restored in %s", opts.TargetPath, opts.SourcePath)
+	}
+
+	s.dropStash(ctx, opts.SourcePath, stashSHA)
+	return nil
+}
+
+// stashChanges stashes the uncommitted changes of a worktree, untracked files
+// included, and returns the commit of the new stash. The commit is tracked
+// rather than stash@{0} so that later steps never act on an unrelated stash.
+func (s *worktreeService) stashChanges(ctx context.Context, sourcePath, message string) (string, error) {
+	previous := s.stashHead(ctx, sourcePath)
+	if !s.git.RunCommandChecked(
+		ctx,
+		[]string{"git", "stash", "push", "-u", "-m", message},
+		sourcePath,
+		"Failed to create stash for moving changes",
+	) {
+		return "", fmt.Errorf("failed to create stash for moving changes")
+	}
+
+	// git stash push succeeds without creating a stash when there is nothing
+	// to stash, leaving refs/stash where it was
+	stashSHA := s.stashHead(ctx, sourcePath)
+	if stashSHA == "" || stashSHA == previous {
+		return "", fmt.Errorf("%s has no uncommitted changes to move", sourcePath)
+	}
+	return stashSHA, nil
+}
+
+// stashHead returns the commit of the most recent stash, or "" when there is none.
+func (s *worktreeService) stashHead(ctx context.Context, path string) string {
+	return s.git.RunGit(ctx, []string{"git", "rev-parse", "-q", "--verify", "refs/stash"}, path, []int{0, 1}, true, true)
+}
+
+// stashEntry returns the stash@{n} reference of a stash commit, which git
+// stash drop and pop require, or "" when the stash is no longer listed.
+func (s *worktreeService) stashEntry(ctx context.Context, path, stashSHA string) string {
+	out := s.git.RunGit(ctx, []string{"git", "stash", "list", "--format=%gd %H"}, path, []int{0}, true, false)
+	for _, line := range strings.Split(out, "\n") {
+		if ref, hash, ok := strings.Cut(strings.TrimSpace(line), " "); ok && hash == stashSHA {
+			return ref
+		}
+	}
+	return ""
+}
+
+// applyStash applies a stash to a worktree, restoring the staged changes too.
+func (s *worktreeService) applyStash(ctx context.Context, targetPath, stashSHA string) bool {
+	return s.git.RunCommandChecked(
+		ctx,
+		[]string{"git", "stash", "apply", "--index", stashSHA},
+		targetPath,
+		fmt.Sprintf("Failed to apply stash to %s", targetPath),
+	)
+}
+
+// restoreStash puts stashed changes back into the worktree they came from.
+func (s *worktreeService) restoreStash(ctx context.Context, sourcePath, stashSHA string) {
+	ref := s.stashEntry(ctx, sourcePath, stashSHA)
+	if ref == "" {
+		return
+	}
+	s.git.RunCommandChecked(ctx, []string{"git", "stash", "pop", "--index", ref}, sourcePath, "Failed to restore stash")
+}
+
+// dropStash removes a stash once its changes have been moved.
+func (s *worktreeService) dropStash(ctx context.Context, sourcePath, stashSHA string) {
+	ref := s.stashEntry(ctx, sourcePath, stashSHA)
+	if ref == "" {
+		return
+	}
+	s.git.RunCommandChecked(ctx, []string{"git", "stash", "drop", ref}, sourcePath, "Failed to drop stash")
+}
+
 func (s *worktreeService) Delete(ctx context.Context, path, branch string, deleteBranch bool) error {
 	if path != "" {
 		if !s.git.RunCommandChecked(ctx, []string{"git", "worktree", "remove", "--force", path}, "", fmt.Sprintf("Failed to remove worktree %s", path)) {
@@ -214,8 +302,13 @@ func (s *worktreeService) UpdateFromBase(ctx context.Context, wt *models.Worktre
 }
 
 func (s *worktreeService) Absorb(ctx context.Context, wt, mainWorktree *models.WorktreeInfo, mergeMethod string) error {
+	if wt.Detached {
+		return fmt.Errorf("worktree has a detached HEAD, there is no branch to absorb")
+	}
 	mainBranch := s.git.GetMainBranch(ctx)
-	mainPath := mainWorktree.Path
+	if mainWorktree == nil && mergeMethod != "rebase" {
+		return fmt.Errorf("no worktree has %s checked out; create one or use the rebase merge method", mainBranch)
+	}
 
 	if mergeMethod == "rebase" {
 		// Rebase: first rebase the feature branch onto main, then fast-forward main
@@ -223,16 +316,26 @@ func (s *worktreeService) Absorb(ctx context.Context, wt, mainWorktree *models.W
 			return fmt.Errorf("rebase failed; resolve conflicts in %s and retry", wt.Path)
 		}
 		// Fast-forward main to the rebased branch
-		if !s.git.RunCommandChecked(ctx, []string{"git", "-C", mainPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
+		if !s.git.RunCommandChecked(ctx, fastForwardMainArgs(wt, mainWorktree, mainBranch), "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
 			return fmt.Errorf("fast-forward failed; the branch may have diverged")
 		}
-	} else if !s.git.RunCommandChecked(ctx, []string{"git", "-C", mainPath, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, mainBranch)) {
-		return fmt.Errorf("merge failed; resolve conflicts in %s and retry", mainPath)
+	} else if !s.git.RunCommandChecked(ctx, []string{"git", "-C", mainWorktree.Path, "merge", "--no-edit", wt.Branch}, "", fmt.Sprintf("Failed to merge %s into %s", wt.Branch, mainBranch)) {
+		return fmt.Errorf("merge failed; resolve conflicts in %s and retry", mainWorktree.Path)
 	}
 
 	return nil
 }
 
+// fastForwardMainArgs returns the git command fast-forwarding mainBranch to
+// the branch of wt. Without a main worktree the ref is updated with a local
+// fetch, which refuses anything but a fast-forward.
+func fastForwardMainArgs(wt, mainWorktree *models.WorktreeInfo, mainBranch string) []string {
+	if mainWorktree == nil {
+		return []string{"git", "-C", wt.Path, "fetch", ".", fmt.Sprintf("%s:%s", wt.Branch, mainBranch)}
+	}
+	return []string{"git", "-C", mainWorktree.Path, "merge", "--ff-only", wt.Branch}
+}
+
 func (s *worktreeService) GetPruneCandidates(ctx context.Context, worktrees []*models.WorktreeInfo, includeStaleBranches bool) ([]PruneCandidate, error) {
 	mainBranch := s.git.GetMainBranch(ctx)
 	mergedBranches := s.git.GetMergedBranches(ctx, mainBranch)
@@ -245,6 +348,9 @@ func FindPruneCandidates(worktrees []*models.WorktreeInfo, mergedBranches []stri
 	wtBranches := make(map[string]*models.WorktreeInfo)
 	checkedOutBranches := make(map[string]struct{})
 	for _, wt := range worktrees {
+		if wt.Detached {
+			continue
+		}
 		if wt.Branch != "" {
 			checkedOutBranches[wt.Branch] = struct{}{}
 		}
@@ -257,7 +363,7 @@ func FindPruneCandidates(worktrees []*models.WorktreeInfo, mergedBranches []stri
 
 	// 1. PR-based detection
 	for _, wt := range worktrees {
-		if wt.IsMain {
+		if wt.IsMain || wt.Detached {
 			continue
 		}
 		if wt.PR != nil && strings.EqualFold(wt.PR.State, "MERGED") {
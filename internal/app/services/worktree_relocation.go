@@ -0,0 +1,123 @@
+package services
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// RelocationMigration counts the stored entries moved to the new paths of
+// relocated worktrees.
+type RelocationMigration struct {
+	Notes         int
+	AccessHistory int
+	CacheEntries  int
+}
+
+// MigrateRelocatedWorktrees moves the notes, access history and cached
+// worktree entries recorded under the old path of each relocation to its new
+// path. noteType and env are forwarded to LoadWorktreeNotes/SaveWorktreeNoteEntry.
+func MigrateRelocatedWorktrees(repoKey, worktreeDir, worktreeNotesPath, noteType string, relocations []models.WorktreeRelocation, env map[string]string) (RelocationMigration, error) {
+	var migrated RelocationMigration
+	if len(relocations) == 0 {
+		return migrated, nil
+	}
+
+	notes, err := LoadWorktreeNotes(repoKey, worktreeDir, worktreeNotesPath, noteType, env)
+	if err != nil {
+		return migrated, err
+	}
+	for _, relocation := range relocations {
+		oldKey, note, ok := relocatedNote(repoKey, worktreeDir, worktreeNotesPath, noteType, relocation.OldPath, notes)
+		if !ok {
+			continue
+		}
+		newKey := relocatedNoteKey(repoKey, worktreeDir, worktreeNotesPath, noteType, relocation.NewPath)
+		if newKey == "" || newKey == oldKey {
+			continue
+		}
+		if noteType == config.NoteTypeSplitted {
+			if err := DeleteSplittedNoteFile(worktreeNotesPath, oldKey, env); err != nil {
+				return migrated, err
+			}
+		}
+		delete(notes, oldKey)
+		note.UpdatedAt = time.Now().Unix()
+		notes[newKey] = note
+		if err := SaveWorktreeNoteEntry(repoKey, worktreeDir, worktreeNotesPath, noteType, newKey, notes, env); err != nil {
+			return migrated, err
+		}
+		migrated.Notes++
+	}
+
+	history, err := LoadAccessHistory(repoKey, worktreeDir)
+	if err != nil {
+		return migrated, err
+	}
+	for _, relocation := range relocations {
+		ts, ok := history[relocation.OldPath]
+		if !ok {
+			continue
+		}
+		delete(history, relocation.OldPath)
+		if ts > history[relocation.NewPath] {
+			history[relocation.NewPath] = ts
+		}
+		migrated.AccessHistory++
+	}
+	if migrated.AccessHistory > 0 {
+		if err := SaveAccessHistory(repoKey, worktreeDir, history); err != nil {
+			return migrated, err
+		}
+	}
+
+	cached, err := LoadCache(repoKey, worktreeDir)
+	if err != nil {
+		return migrated, err
+	}
+	for _, wt := range cached {
+		if wt == nil {
+			continue
+		}
+		for _, relocation := range relocations {
+			if wt.Path == relocation.OldPath {
+				wt.Path = relocation.NewPath
+				migrated.CacheEntries++
+				break
+			}
+		}
+	}
+	if migrated.CacheEntries > 0 {
+		if err := SaveCache(repoKey, worktreeDir, cached); err != nil {
+			return migrated, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// relocatedNote finds the note stored for oldPath, falling back to the
+// absolute-path keys written by older shared-file notes.
+func relocatedNote(repoKey, worktreeDir, worktreeNotesPath, noteType, oldPath string, notes map[string]models.WorktreeNote) (string, models.WorktreeNote, bool) {
+	key := relocatedNoteKey(repoKey, worktreeDir, worktreeNotesPath, noteType, oldPath)
+	if note, ok := notes[key]; ok && key != "" {
+		return key, note, true
+	}
+	if noteType != config.NoteTypeSplitted && strings.TrimSpace(worktreeNotesPath) != "" {
+		legacyKey := filepath.Clean(oldPath)
+		if note, ok := notes[legacyKey]; ok {
+			return legacyKey, note, true
+		}
+	}
+	return "", models.WorktreeNote{}, false
+}
+
+func relocatedNoteKey(repoKey, worktreeDir, worktreeNotesPath, noteType, path string) string {
+	if noteType == config.NoteTypeSplitted {
+		return filepath.Base(path)
+	}
+	return WorktreeNoteKey(repoKey, worktreeDir, worktreeNotesPath, path)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestMigrateRelocatedWorktrees(t *testing.T) {
+	worktreeDir := t.TempDir()
+	repoKey := "repo"
+	relocations := []models.WorktreeRelocation{
+		{OldPath: "/old/repo/feature", NewPath: "/new/repo/feature"},
+		{OldPath: "/old/repo/bare", NewPath: "/new/repo/bare"},
+	}
+
+	require.NoError(t, SaveWorktreeNotes(repoKey, worktreeDir, "", "", map[string]models.WorktreeNote{
+		"/old/repo/feature": {Note: "keep me", UpdatedAt: 1},
+		"/old/repo/other":   {Note: "untouched", UpdatedAt: 1},
+	}, nil))
+	require.NoError(t, SaveAccessHistory(repoKey, worktreeDir, map[string]int64{
+		"/old/repo/feature": 100,
+		"/old/repo/bare":    50,
+		"/new/repo/bare":    75,
+	}))
+	require.NoError(t, SaveCache(repoKey, worktreeDir, []*models.WorktreeInfo{
+		{Path: "/old/repo/feature", Branch: "feature"},
+		{Path: "/old/repo/other", Branch: "other"},
+	}))
+
+	migrated, err := MigrateRelocatedWorktrees(repoKey, worktreeDir, "", "", relocations, nil)
+	require.NoError(t, err)
+	assert.Equal(t, RelocationMigration{Notes: 1, AccessHistory: 2, CacheEntries: 1}, migrated)
+
+	notes, err := LoadWorktreeNotes(repoKey, worktreeDir, "", "", nil)
+	require.NoError(t, err)
+	assert.NotContains(t, notes, "/old/repo/feature")
+	assert.Equal(t, "keep me", notes["/new/repo/feature"].Note)
+	assert.Equal(t, "untouched", notes["/old/repo/other"].Note)
+
+	history, err := LoadAccessHistory(repoKey, worktreeDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"/new/repo/feature": 100, "/new/repo/bare": 75}, history)
+
+	cached, err := LoadCache(repoKey, worktreeDir)
+	require.NoError(t, err)
+	require.Len(t, cached, 2)
+	assert.Equal(t, "/new/repo/feature", cached[0].Path)
+	assert.Equal(t, "/old/repo/other", cached[1].Path)
+}
+
+func TestMigrateRelocatedWorktreesSplittedNotes(t *testing.T) {
+	notesDir := t.TempDir()
+	pathTemplate := filepath.Join(notesDir, "$WORKTREE_NAME.md")
+
+	require.NoError(t, SaveWorktreeNotes("repo", t.TempDir(), pathTemplate, config.NoteTypeSplitted, map[string]models.WorktreeNote{
+		"feature": {Note: "moved note", UpdatedAt: 1},
+	}, nil))
+
+	migrated, err := MigrateRelocatedWorktrees("repo", t.TempDir(), pathTemplate, config.NoteTypeSplitted, []models.WorktreeRelocation{
+		{OldPath: "/old/feature", NewPath: "/new/feature"},
+		{OldPath: "/old/feature-old-name", NewPath: "/new/feature-renamed"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, migrated.Notes, "same directory name keeps the same note file")
+
+	require.NoError(t, SaveWorktreeNotes("repo", t.TempDir(), pathTemplate, config.NoteTypeSplitted, map[string]models.WorktreeNote{
+		"legacy": {Note: "renamed note", UpdatedAt: 1},
+	}, nil))
+	migrated, err = MigrateRelocatedWorktrees("repo", t.TempDir(), pathTemplate, config.NoteTypeSplitted, []models.WorktreeRelocation{
+		{OldPath: "/old/legacy", NewPath: "/new/current"},
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, migrated.Notes)
+
+	_, err = os.Stat(filepath.Join(notesDir, "legacy.md"))
+	assert.True(t, os.IsNotExist(err), "expected old note file to be removed")
+	notes, err := LoadWorktreeNotes("repo", t.TempDir(), pathTemplate, config.NoteTypeSplitted, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed note", notes["current"].Note)
+	assert.Equal(t, "moved note", notes["feature"].Note)
+}
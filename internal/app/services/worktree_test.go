@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/chmouel/lazyworktree/internal/models"
@@ -12,14 +13,28 @@ import (
 type mockGitService struct {
 	mainBranch     string
 	mergedBranches []string
+	commands       [][]string
+	output         string              // Returned by RunGit
+	outputs        map[string]string   // RunGit output by command, overriding output
+	sequences      map[string][]string // RunGit outputs by command, one per call, overriding outputs
+	failCommand    string              // RunCommandChecked fails for commands starting with it
 }
 
-func (m *mockGitService) RunGit(_ context.Context, _ []string, _ string, _ []int, _, _ bool) string {
-	return ""
+func (m *mockGitService) RunGit(_ context.Context, args []string, _ string, _ []int, _, _ bool) string {
+	key := strings.Join(args, " ")
+	if sequence := m.sequences[key]; len(sequence) > 0 {
+		m.sequences[key] = sequence[1:]
+		return sequence[0]
+	}
+	if output, ok := m.outputs[key]; ok {
+		return output
+	}
+	return m.output
 }
 
-func (m *mockGitService) RunCommandChecked(_ context.Context, _ []string, _, _ string) bool {
-	return true
+func (m *mockGitService) RunCommandChecked(_ context.Context, args []string, cwd, _ string) bool {
+	m.commands = append(m.commands, append([]string{cwd}, args...))
+	return m.failCommand == "" || !strings.HasPrefix(strings.Join(args, " "), m.failCommand)
 }
 
 func (m *mockGitService) GetMainBranch(_ context.Context) string {
@@ -120,3 +135,109 @@ func TestGetPruneCandidatesExcludesCheckedOutMainWorktreeBranch(t *testing.T) {
 	assert.Equal(t, "stale-branch", candidates[0].Branch)
 	assert.Nil(t, candidates[0].Worktree)
 }
+
+func TestAbsorbWithoutMainWorktree(t *testing.T) {
+	wt := &models.WorktreeInfo{Path: "/repo/feature", Branch: "feature"}
+
+	git := &mockGitService{mainBranch: "main"}
+	svc := NewWorktreeService(git)
+	require.NoError(t, svc.Absorb(context.Background(), wt, nil, "rebase"))
+	assert.Equal(t, [][]string{
+		{"", "git", "-C", "/repo/feature", "rebase", "main"},
+		{"", "git", "-C", "/repo/feature", "fetch", ".", "feature:main"},
+	}, git.commands)
+
+	git = &mockGitService{mainBranch: "main"}
+	svc = NewWorktreeService(git)
+	err := svc.Absorb(context.Background(), wt, nil, "merge")
+	require.ErrorContains(t, err, "no worktree has main checked out")
+	assert.Empty(t, git.commands)
+
+	err = svc.Absorb(context.Background(), &models.WorktreeInfo{Path: "/repo/release", Branch: "(detached)", Detached: true}, nil, "rebase")
+	require.ErrorContains(t, err, "detached HEAD")
+}
+
+// movedStash returns a mock in which pushing a stash moves refs/stash from
+// previous to "def456", with an older unrelated stash listed after it.
+func movedStash(previous string) *mockGitService {
+	return &mockGitService{
+		outputs: map[string]string{
+			"git status --porcelain --untracked-files=all": "",
+			"git stash list --format=%gd %H":               "stash@{0} def456\nstash@{1} abc123",
+		},
+		sequences: map[string][]string{
+			"git rev-parse -q --verify refs/stash": {previous, "def456"},
+		},
+	}
+}
+
+func TestMoveChanges(t *testing.T) {
+	opts := MoveChangesOptions{SourcePath: "/repo/feature", TargetPath: "/repo/review"}
+
+	git := movedStash("abc123")
+	svc := NewWorktreeService(git)
+	require.NoError(t, svc.MoveChanges(context.Background(), opts))
+	assert.Equal(t, [][]string{
+		{"/repo/feature", "git", "stash", "push", "-u", "-m", "git-wt move-changes: feature -> review"},
+		{"/repo/review", "git", "stash", "apply", "--index", "def456"},
+		{"/repo/feature", "git", "stash", "drop", "stash@{0}"},
+	}, git.commands)
+
+	// A source without earlier stashes
+	git = movedStash("")
+	svc = NewWorktreeService(git)
+	require.NoError(t, svc.MoveChanges(context.Background(), opts))
+	assert.Contains(t, git.commands, []string{"/repo/feature", "git", "stash", "drop", "stash@{0}"})
+
+	git = &mockGitService{outputs: map[string]string{"git status --porcelain --untracked-files=all": " M main.go"}}
+	svc = NewWorktreeService(git)
+	err := svc.MoveChanges(context.Background(), opts)
+	require.ErrorContains(t, err, "/repo/review has uncommitted changes")
+	assert.Empty(t, git.commands, "expected nothing to be stashed when the target is dirty")
+
+	// Untracked files count even when status.showUntrackedFiles hides them,
+	// since a failed apply runs git clean on the target
+	git = &mockGitService{outputs: map[string]string{"git status --porcelain --untracked-files=all": "?? notes/todo.txt"}}
+	svc = NewWorktreeService(git)
+	err = svc.MoveChanges(context.Background(), opts)
+	require.ErrorContains(t, err, "/repo/review has uncommitted changes")
+	assert.Empty(t, git.commands, "expected nothing to be stashed when the target has untracked files")
+}
+
+func TestMoveChangesCleanSourceKeepsExistingStash(t *testing.T) {
+	opts := MoveChangesOptions{SourcePath: "/repo/feature", TargetPath: "/repo/review"}
+
+	// Nothing to stash: git stash push succeeds but refs/stash still points
+	// at an older stash, which must be neither applied nor dropped
+	git := &mockGitService{
+		outputs: map[string]string{
+			"git status --porcelain --untracked-files=all": "",
+			"git rev-parse -q --verify refs/stash":         "abc123",
+			"git stash list --format=%gd %H":               "stash@{0} abc123",
+		},
+	}
+	svc := NewWorktreeService(git)
+	err := svc.MoveChanges(context.Background(), opts)
+	require.ErrorContains(t, err, "/repo/feature has no uncommitted changes to move")
+	assert.Equal(t, [][]string{
+		{"/repo/feature", "git", "stash", "push", "-u", "-m", "git-wt move-changes: feature -> review"},
+	}, git.commands)
+}
+
+func TestMoveChangesConflictResetsTarget(t *testing.T) {
+	opts := MoveChangesOptions{SourcePath: "/repo/feature", TargetPath: "/repo/review"}
+
+	// The stash conflicts with the target branch, so applying it fails
+	git := movedStash("abc123")
+	git.failCommand = "git stash apply"
+	svc := NewWorktreeService(git)
+	err := svc.MoveChanges(context.Background(), opts)
+	require.ErrorContains(t, err, "restored in /repo/feature")
+	assert.Equal(t, [][]string{
+		{"/repo/feature", "git", "stash", "push", "-u", "-m", "git-wt move-changes: feature -> review"},
+		{"/repo/review", "git", "stash", "apply", "--index", "def456"},
+		{"/repo/review", "git", "reset", "--hard"},
+		{"/repo/review", "git", "clean", "-fd"},
+		{"/repo/feature", "git", "stash", "pop", "--index", "stash@{0}"},
+	}, git.commands)
+}
@@ -0,0 +1,261 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showStashes opens the stash browser, applying stashes to the selected
+// worktree.
+func (m *Model) showStashes() tea.Cmd {
+	wt := m.selectedWorktree()
+	if wt == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+
+	m.loading.active = true
+	m.loading.operation = "stashes"
+	m.setLoadingScreen("Loading stashes...")
+	return m.loadStashesCmd(wt.Path, "")
+}
+
+func (m *Model) loadStashesCmd(worktreePath, status string) tea.Cmd {
+	return func() tea.Msg {
+		return stashesLoadedMsg{
+			worktreePath: worktreePath,
+			stashes:      m.state.services.git.ListStashes(m.ctx),
+			status:       status,
+		}
+	}
+}
+
+func (m *Model) loadStashPreviewCmd(stash *models.StashEntry) tea.Cmd {
+	return func() tea.Msg {
+		return stashPreviewMsg{
+			hash: stash.Hash,
+			diff: m.state.services.git.StashDiff(m.ctx, stash),
+		}
+	}
+}
+
+// stashActionCmd runs a stash operation, then reloads the stash list.
+func (m *Model) stashActionCmd(worktreePath, done string, action func() error) tea.Cmd {
+	return func() tea.Msg {
+		if err := action(); err != nil {
+			return stashesLoadedMsg{
+				worktreePath: worktreePath,
+				stashes:      m.state.services.git.ListStashes(m.ctx),
+				actionErr:    err,
+			}
+		}
+		return stashesLoadedMsg{
+			worktreePath: worktreePath,
+			stashes:      m.state.services.git.ListStashes(m.ctx),
+			status:       done,
+			changed:      true,
+		}
+	}
+}
+
+// stashScreen returns the stash browser when it is on top.
+func (m *Model) stashScreen() *appscreen.StashScreen {
+	if m.state.ui.screenManager.Type() != appscreen.TypeStash {
+		return nil
+	}
+	scr, _ := m.state.ui.screenManager.Current().(*appscreen.StashScreen)
+	return scr
+}
+
+// handleStashesLoaded opens the stash browser, or refreshes it when it is
+// already displayed.
+func (m *Model) handleStashesLoaded(msg stashesLoadedMsg) (tea.Model, tea.Cmd) {
+	if m.loading.operation == "stashes" {
+		m.loading.active = false
+		m.loading.operation = ""
+		m.clearLoadingScreen()
+	}
+
+	var refresh tea.Cmd
+	if msg.changed {
+		refresh = m.refreshWorktrees()
+	}
+
+	if scr := m.stashScreen(); scr != nil {
+		scr.Worktrees = m.attachedBranches()
+		scr.SetStashes(msg.stashes)
+		scr.StatusMessage = msg.status
+		if msg.actionErr != nil {
+			scr.StatusMessage = msg.actionErr.Error()
+		}
+		return m, tea.Batch(refresh, scr.PreviewCmd())
+	}
+	if msg.actionErr != nil {
+		m.showInfo(msg.actionErr.Error(), refresh)
+		return m, nil
+	}
+
+	var wt *models.WorktreeInfo
+	for _, candidate := range m.state.data.worktrees {
+		if candidate.Path == msg.worktreePath {
+			wt = candidate
+			break
+		}
+	}
+	if wt == nil {
+		return m, refresh
+	}
+
+	scr := appscreen.NewStashScreen(
+		msg.stashes,
+		m.attachedBranches(),
+		fmt.Sprintf("Stashes (apply to %s)", worktreeDisplayName(wt)),
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		m.theme,
+	)
+	scr.OnPreview = m.loadStashPreviewCmd
+	scr.OnApply = func(stash *models.StashEntry) tea.Cmd {
+		scr.StatusMessage = fmt.Sprintf("Applying %s...", stash.Ref)
+		return m.stashActionCmd(wt.Path, fmt.Sprintf("Applied %s to %s.", stash.Ref, worktreeDisplayName(wt)), func() error {
+			return m.state.services.git.ApplyStash(m.ctx, stash, wt.Path, false)
+		})
+	}
+	scr.OnPop = func(stash *models.StashEntry) tea.Cmd {
+		scr.StatusMessage = fmt.Sprintf("Popping %s...", stash.Ref)
+		return m.stashActionCmd(wt.Path, fmt.Sprintf("Popped %s into %s.", stash.Ref, worktreeDisplayName(wt)), func() error {
+			return m.state.services.git.ApplyStash(m.ctx, stash, wt.Path, true)
+		})
+	}
+	scr.OnDrop = func(stash *models.StashEntry) tea.Cmd {
+		confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Drop stash?\n\n%s: %s", stash.Ref, stash.Message), m.theme)
+		confirmScreen.OnConfirm = func() tea.Cmd {
+			return m.stashActionCmd(wt.Path, fmt.Sprintf("Dropped %s.", stash.Ref), func() error {
+				return m.state.services.git.DropStash(m.ctx, stash)
+			})
+		}
+		m.state.ui.screenManager.Push(confirmScreen)
+		return nil
+	}
+	scr.OnRefresh = func() tea.Cmd {
+		scr.Previews = make(map[string]string)
+		return m.loadStashesCmd(wt.Path, "")
+	}
+	m.state.ui.screenManager.Push(scr)
+	return m, tea.Batch(refresh, scr.PreviewCmd())
+}
+
+// handleStashPreview stores the patch of a stash shown by the stash browser.
+func (m *Model) handleStashPreview(msg stashPreviewMsg) tea.Cmd {
+	if scr := m.stashScreen(); scr != nil {
+		scr.Previews[msg.hash] = msg.diff
+	}
+	return nil
+}
+
+// showMoveChanges moves the uncommitted changes of the selected worktree to
+// another worktree picked from a list.
+func (m *Model) showMoveChanges() tea.Cmd {
+	source := m.selectedWorktree()
+	if source == nil {
+		m.showInfo(errNoWorktreeSelected, nil)
+		return nil
+	}
+	if !source.Dirty {
+		m.showInfo(fmt.Sprintf("No changes to move in %s.", worktreeDisplayName(source)), nil)
+		return nil
+	}
+
+	items := make([]appscreen.SelectionItem, 0, len(m.state.data.worktrees))
+	for _, wt := range m.state.data.worktrees {
+		if wt.Path == source.Path || wt.Prunable {
+			continue
+		}
+		desc := wt.BranchLabel()
+		if wt.Dirty {
+			desc += " (has changes)"
+		}
+		items = append(items, appscreen.SelectionItem{
+			ID:          wt.Path,
+			Label:       worktreeDisplayName(wt),
+			Description: desc,
+		})
+	}
+	if len(items) == 0 {
+		m.showInfo("No other worktrees to move the changes to.", nil)
+		return nil
+	}
+
+	listScreen := appscreen.NewListSelectionScreen(
+		items,
+		fmt.Sprintf("Move changes from %s to worktree", worktreeDisplayName(source)),
+		filterWorktreesPlaceholder,
+		"No worktrees found.",
+		m.state.view.WindowWidth,
+		m.state.view.WindowHeight,
+		"",
+		m.theme,
+	)
+	listScreen.OnSelect = func(item appscreen.SelectionItem) tea.Cmd {
+		var target *models.WorktreeInfo
+		for _, wt := range m.state.data.worktrees {
+			if wt.Path == item.ID {
+				target = wt
+				break
+			}
+		}
+		if target == nil {
+			return func() tea.Msg {
+				return errMsg{err: fmt.Errorf("target worktree not found")}
+			}
+		}
+		if target.Dirty {
+			// Applying on top of other changes could leave a mix of both on conflict
+			m.showInfo(fmt.Sprintf("%s has uncommitted changes.\n\nCommit or stash them before moving changes into it.", worktreeDisplayName(target)), nil)
+			return nil
+		}
+		return m.moveChangesCmd(source, target)
+	}
+	listScreen.OnCancel = func() tea.Cmd {
+		return nil
+	}
+
+	m.state.ui.screenManager.Push(listScreen)
+	return textinput.Blink
+}
+
+func (m *Model) moveChangesCmd(source, target *models.WorktreeInfo) tea.Cmd {
+	return func() tea.Msg {
+		err := m.state.services.worktree.MoveChanges(m.ctx, services.MoveChangesOptions{
+			SourcePath: source.Path,
+			TargetPath: target.Path,
+		})
+		return moveChangesResultMsg{source: source, target: target, err: err}
+	}
+}
+
+func (m *Model) handleMoveChangesResult(msg moveChangesResultMsg) tea.Cmd {
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Moving changes failed\n\nFrom: %s\nTo: %s\n\nError: %v",
+			worktreeDisplayName(msg.source), worktreeDisplayName(msg.target), msg.err), m.refreshWorktrees())
+		return nil
+	}
+	m.showInfo(fmt.Sprintf("Changes moved\n\nFrom: %s\nTo: %s (%s)",
+		worktreeDisplayName(msg.source), worktreeDisplayName(msg.target), msg.target.BranchLabel()), m.refreshWorktrees())
+	return nil
+}
+
+// worktreeDisplayName names a worktree the way worktree pickers do.
+func worktreeDisplayName(wt *models.WorktreeInfo) string {
+	if wt.IsMain {
+		return "main"
+	}
+	return filepath.Base(wt.Path)
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestHandleStashesLoadedOpensAndRefreshes(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	stashes := []*models.StashEntry{
+		{Ref: "stash@{0}", Hash: "aaaa", Branch: "feature", Message: "wip"},
+		{Ref: "stash@{1}", Hash: "bbbb", Branch: "gone", Message: "old"},
+	}
+
+	_, cmd := m.handleStashesLoaded(stashesLoadedMsg{worktreePath: wt.Path, stashes: stashes})
+	scr := m.stashScreen()
+	require.NotNil(t, scr)
+	assert.NotNil(t, cmd, "the preview of the first stash is loaded")
+	assert.Equal(t, "Stashes (apply to feature)", scr.Title)
+	assert.Equal(t, "feature", scr.Worktrees["feature"])
+
+	m.handleStashPreview(stashPreviewMsg{hash: "aaaa", diff: "+added"})
+	assert.Equal(t, "+added", scr.Previews["aaaa"])
+
+	scr.Cursor = 1
+	m.handleStashesLoaded(stashesLoadedMsg{worktreePath: wt.Path, stashes: stashes[1:], status: "Dropped stash@{0}.", changed: true})
+	assert.Same(t, scr, m.stashScreen())
+	assert.Equal(t, "bbbb", scr.Selected().Hash)
+	assert.Equal(t, "Dropped stash@{0}.", scr.StatusMessage)
+
+	m.handleStashesLoaded(stashesLoadedMsg{worktreePath: wt.Path, stashes: stashes[1:], actionErr: errors.New("git stash pop failed: CONFLICT")})
+	assert.Equal(t, "git stash pop failed: CONFLICT", scr.StatusMessage)
+}
+
+func TestStashDropAsksForConfirmation(t *testing.T) {
+	m, wt := newMergePRTestModel(t, nil)
+	m.handleStashesLoaded(stashesLoadedMsg{worktreePath: wt.Path, stashes: []*models.StashEntry{
+		{Ref: "stash@{0}", Hash: "aaaa", Branch: "feature", Message: "wip"},
+	}})
+	scr := m.stashScreen()
+	require.NotNil(t, scr)
+
+	assert.Nil(t, scr.OnDrop(scr.Selected()))
+	confirm, ok := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	require.True(t, ok)
+	assert.Contains(t, confirm.Message, "stash@{0}: wip")
+}
+
+func TestShowMoveChanges(t *testing.T) {
+	m := newTestModel(t)
+	source := &models.WorktreeInfo{Path: "/tmp/wt/feature", Branch: "feature", Dirty: true}
+	clean := &models.WorktreeInfo{Path: "/tmp/wt/review", Branch: "review"}
+	dirty := &models.WorktreeInfo{Path: "/tmp/wt/other", Branch: "other", Dirty: true}
+	m.state.data.worktrees = []*models.WorktreeInfo{source, clean, dirty}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	m.showMoveChanges()
+	list, ok := m.state.ui.screenManager.Current().(*appscreen.ListSelectionScreen)
+	require.True(t, ok)
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, clean.Path, list.Items[0].ID)
+	assert.Equal(t, "other (has changes)", list.Items[1].Description)
+
+	assert.Nil(t, list.OnSelect(list.Items[1]))
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "other has uncommitted changes")
+
+	assert.NotNil(t, list.OnSelect(list.Items[0]))
+}
+
+func TestShowMoveChangesWithoutChanges(t *testing.T) {
+	m, _ := newMergePRTestModel(t, nil)
+
+	assert.Nil(t, m.showMoveChanges())
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "No changes to move")
+}
+
+func TestHandleMoveChangesResult(t *testing.T) {
+	m := newTestModel(t)
+	source := &models.WorktreeInfo{Path: "/tmp/wt/feature", Branch: "feature"}
+	target := &models.WorktreeInfo{Path: "/tmp/wt/review", Branch: "review"}
+
+	m.handleMoveChangesResult(moveChangesResultMsg{source: source, target: target})
+	info, ok := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "Changes moved")
+	assert.Contains(t, info.Message, "To: review (review)")
+
+	m.handleMoveChangesResult(moveChangesResultMsg{source: source, target: target, err: errors.New("failed to apply changes")})
+	info, ok = m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	require.True(t, ok)
+	assert.Contains(t, info.Message, "Moving changes failed")
+}
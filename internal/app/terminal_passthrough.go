@@ -0,0 +1,16 @@
+package app
+
+import (
+	"os"
+	"strings"
+)
+
+// wrapTmuxPassthrough wraps a terminal escape sequence (kitty graphics, OSC
+// notifications, ...) in a tmux DCS passthrough when running inside tmux, so
+// it reaches the outer terminal. The tmux allow-passthrough option must be on.
+func wrapTmuxPassthrough(seq string) string {
+	if seq == "" || strings.TrimSpace(os.Getenv("TMUX")) == "" {
+		return seq
+	}
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
@@ -0,0 +1,20 @@
+package app
+
+import "testing"
+
+func TestWrapTmuxPassthrough(t *testing.T) {
+	seq := "\x1b]9;done\x1b\\"
+
+	t.Setenv("TMUX", "")
+	if got := wrapTmuxPassthrough(seq); got != seq {
+		t.Fatalf("expected the sequence unchanged outside tmux, got %q", got)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if got, want := wrapTmuxPassthrough(seq), "\x1bPtmux;\x1b\x1b]9;done\x1b\x1b\\\x1b\\"; got != want {
+		t.Fatalf("wrapTmuxPassthrough() = %q, want %q", got, want)
+	}
+	if got := wrapTmuxPassthrough(""); got != "" {
+		t.Fatalf("expected an empty sequence to stay empty, got %q", got)
+	}
+}
@@ -15,6 +15,16 @@ func newTestModel(t *testing.T) *Model {
 	return NewModel(&config.AppConfig{WorktreeDir: t.TempDir()}, "")
 }
 
+// withRemoteRepo runs the test from a fresh repository whose origin is
+// remoteURL, so the git service detects the matching forge.
+func withRemoteRepo(t *testing.T, remoteURL string) {
+	t.Helper()
+	repo := t.TempDir()
+	runGit(t, repo, "init")
+	runGit(t, repo, "remote", "add", "origin", remoteURL)
+	withCwd(t, repo)
+}
+
 func mockGitWorktreeList(t *testing.T, m *Model, paths ...string) {
 	t.Helper()
 	if m.state.services.git == nil {
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// showToggleWorktreeLock unlocks the selected worktree, or asks for an
+// optional reason and locks it.
+func (m *Model) showToggleWorktreeLock() tea.Cmd {
+	if m.state.data.selectedIndex < 0 || m.state.data.selectedIndex >= len(m.state.data.filteredWts) {
+		return nil
+	}
+	wt := m.state.data.filteredWts[m.state.data.selectedIndex]
+	if wt.IsMain {
+		m.showInfo("Cannot lock the main worktree.", nil)
+		return nil
+	}
+
+	path := wt.Path
+	name := filepath.Base(path)
+	if wt.Locked {
+		return m.setWorktreeLockCmd(path, fmt.Sprintf("Unlocked %s", name), func() bool {
+			return m.state.services.git.UnlockWorktree(m.ctx, path)
+		})
+	}
+
+	inputScr := appscreen.NewInputScreen(
+		fmt.Sprintf("Lock '%s' (reason is optional)", name),
+		"e.g. on removable drive, reserved for an agent",
+		"",
+		m.theme,
+		m.config.IconsEnabled(),
+	)
+	inputScr.OnSubmit = func(value string, _ bool) tea.Cmd {
+		reason := strings.TrimSpace(value)
+		return m.setWorktreeLockCmd(path, fmt.Sprintf("Locked %s", name), func() bool {
+			return m.state.services.git.LockWorktree(m.ctx, path, reason)
+		})
+	}
+	inputScr.OnCancel = func() tea.Cmd {
+		return nil
+	}
+	m.state.ui.screenManager.Push(inputScr)
+	return textinput.Blink
+}
+
+// setWorktreeLockCmd runs a lock or unlock and reloads the worktrees.
+func (m *Model) setWorktreeLockCmd(path, status string, run func() bool) tea.Cmd {
+	return func() tea.Msg {
+		if !run() {
+			return worktreeLockResultMsg{path: path, err: fmt.Errorf("failed to update the lock of %s", path)}
+		}
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return worktreeLockResultMsg{path: path, status: status, worktrees: worktrees, err: err}
+	}
+}
+
+func (m *Model) handleWorktreeLockResult(msg worktreeLockResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.showInfo(fmt.Sprintf("Error: %v", msg.err), nil)
+		return m, nil
+	}
+	model, cmd := m.handleWorktreesLoaded(worktreesLoadedMsg{worktrees: msg.worktrees})
+	m.statusContent = msg.status
+	return model, cmd
+}
+
+// lockedWorktreeMessage explains why an action is refused on a locked worktree.
+func lockedWorktreeMessage(action string, wt *models.WorktreeInfo) string {
+	reason := ""
+	if wt.LockReason != "" {
+		reason = fmt.Sprintf(" (%s)", wt.LockReason)
+	}
+	return fmt.Sprintf("Cannot %s: worktree is locked%s.\n\nPath: %s\n\nUnlock it first with K.", action, reason, wt.Path)
+}
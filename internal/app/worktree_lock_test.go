@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestShowToggleWorktreeLockLocksWithReason(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+
+	wtPath := "/tmp/usb"
+	porcelain := fmt.Sprintf("worktree %s\nHEAD abc123\nbranch refs/heads/usb\nlocked on removable drive\n\n", wtPath)
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		command := strings.Join(append([]string{name}, args...), " ")
+		commands = append(commands, command)
+		if command == "git worktree list --porcelain" {
+			// #nosec G204 -- test uses controlled fixture output for the command runner.
+			return exec.CommandContext(ctx, "printf", "%s", porcelain)
+		}
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	m.state.data.filteredWts = []*models.WorktreeInfo{{Path: wtPath, Branch: "usb"}}
+	m.state.data.selectedIndex = 0
+
+	if cmd := m.showToggleWorktreeLock(); cmd == nil {
+		t.Fatal("expected input command")
+	}
+	if m.state.ui.screenManager.Type() != appscreen.TypeInput {
+		t.Fatalf("expected input screen, got %v", m.state.ui.screenManager.Type())
+	}
+
+	inputScr := m.state.ui.screenManager.Current().(*appscreen.InputScreen)
+	lockCmd := inputScr.OnSubmit("  on removable drive ", false)
+	if lockCmd == nil {
+		t.Fatal("expected lock command")
+	}
+	result, ok := lockCmd().(worktreeLockResultMsg)
+	if !ok {
+		t.Fatal("expected worktreeLockResultMsg")
+	}
+	if result.err != nil {
+		t.Fatalf("unexpected lock error: %v", result.err)
+	}
+	if !strings.Contains(strings.Join(commands, "\n"), "git worktree lock --reason on removable drive "+wtPath) {
+		t.Fatalf("expected lock command with reason, got %v", commands)
+	}
+
+	m.handleWorktreeLockResult(result)
+	if len(m.state.data.worktrees) != 1 || !m.state.data.worktrees[0].Locked {
+		t.Fatalf("expected reloaded worktree to be locked, got %+v", m.state.data.worktrees)
+	}
+	if m.statusContent != "Locked usb" {
+		t.Fatalf("unexpected status %q", m.statusContent)
+	}
+}
+
+func TestShowToggleWorktreeLockUnlocksDirectly(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	m.state.data.filteredWts = []*models.WorktreeInfo{{Path: "/tmp/usb", Branch: "usb", Locked: true}}
+	m.state.data.selectedIndex = 0
+
+	cmd := m.showToggleWorktreeLock()
+	if cmd == nil {
+		t.Fatal("expected unlock command")
+	}
+	if m.state.ui.screenManager.IsActive() {
+		t.Fatal("expected no prompt when unlocking")
+	}
+	if _, ok := cmd().(worktreeLockResultMsg); !ok {
+		t.Fatal("expected worktreeLockResultMsg")
+	}
+	if len(commands) == 0 || commands[0] != "git worktree unlock /tmp/usb" {
+		t.Fatalf("expected unlock command, got %v", commands)
+	}
+}
+
+func TestLockedWorktreeIsRefusedByDeleteRenameAndAbsorb(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/usb", Branch: "usb", Locked: true, LockReason: "on removable drive"},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 1
+
+	for name, show := range map[string]func() tea.Cmd{
+		"delete": m.showDeleteWorktree,
+		"rename": m.showRenameWorktree,
+		"absorb": m.showAbsorbWorktree,
+	} {
+		if cmd := show(); cmd != nil {
+			t.Fatalf("%s: expected nil command for locked worktree", name)
+		}
+		if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+			t.Fatalf("%s: expected info screen, got %v", name, m.state.ui.screenManager.Type())
+		}
+		infoScr := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+		if !strings.Contains(infoScr.Message, "Cannot "+name+": worktree is locked (on removable drive)") {
+			t.Fatalf("%s: unexpected message %q", name, infoScr.Message)
+		}
+		m.state.ui.screenManager.Pop()
+	}
+}
+
+func TestPruneMergedSkipsLockedWorktrees(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir(), DisablePR: true}
+	m := NewModel(cfg, "")
+
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/tmp/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/tmp/usb", Branch: "usb", Locked: true, LockReason: "on removable drive", PR: &models.PRInfo{State: "MERGED"}},
+	}
+
+	if cmd := m.performMergedWorktreeCheck(); cmd == nil {
+		t.Fatal("expected checklist command")
+	}
+	checkScr, ok := m.state.ui.screenManager.Current().(*appscreen.ChecklistScreen)
+	if !ok {
+		t.Fatalf("expected checklist screen, got %T", m.state.ui.screenManager.Current())
+	}
+	if len(checkScr.Items) != 1 {
+		t.Fatalf("expected one candidate, got %+v", checkScr.Items)
+	}
+	item := checkScr.Items[0]
+	if item.Checked {
+		t.Fatal("expected locked worktree to be unchecked by default")
+	}
+	if !strings.Contains(item.Description, "LOCKED") || !strings.Contains(item.Description, "on removable drive") {
+		t.Fatalf("expected lock warning in description, got %q", item.Description)
+	}
+
+	pruneCmd := checkScr.OnSubmit([]appscreen.ChecklistItem{item})
+	if pruneCmd == nil {
+		t.Fatal("expected prune command")
+	}
+	result, ok := pruneCmd().(pruneResultMsg)
+	if !ok {
+		t.Fatal("expected pruneResultMsg")
+	}
+	if result.lockedSkipped != 1 || result.pruned != 0 {
+		t.Fatalf("expected locked worktree to be skipped, got %+v", result)
+	}
+	for _, command := range commands {
+		if strings.Contains(command, "worktree remove") || strings.Contains(command, "branch -D") {
+			t.Fatalf("unexpected removal command %q", command)
+		}
+	}
+
+	m.handlePruneResult(result)
+	if !strings.Contains(m.statusContent, "skipped 1 locked worktrees") {
+		t.Fatalf("unexpected status %q", m.statusContent)
+	}
+}
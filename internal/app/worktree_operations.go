@@ -504,7 +504,11 @@ func (m *Model) showDeleteWorktree() tea.Cmd {
 	if wt.IsMain {
 		return nil
 	}
-	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Delete worktree?\n\nPath: %s\nBranch: %s", wt.Path, wt.Branch), m.theme)
+	if wt.Locked {
+		m.showInfo(lockedWorktreeMessage("delete", wt), nil)
+		return nil
+	}
+	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Delete worktree?\n\nPath: %s\nBranch: %s", wt.Path, wt.BranchLabel()), m.theme)
 	confirmScreen.OnConfirm = m.deleteWorktreeOnlyCmd(wt)
 	m.state.ui.screenManager.Push(confirmScreen)
 	return nil
@@ -521,6 +525,10 @@ func (m *Model) showRenameWorktree() tea.Cmd {
 		m.showInfo("Cannot rename the main worktree.", nil)
 		return nil
 	}
+	if wt.Locked {
+		m.showInfo(lockedWorktreeMessage("rename", wt), nil)
+		return nil
+	}
 
 	currentWorktreeName := filepath.Base(wt.Path)
 	prompt := fmt.Sprintf("Enter new name for '%s'", currentWorktreeName)
@@ -700,7 +708,7 @@ func (m *Model) showWorktreeNoteEditor(worktreePath string) tea.Cmd {
 
 // showPruneMerged initiates the prune merged worktrees workflow.
 func (m *Model) showPruneMerged() tea.Cmd {
-	if m.config.DisablePR || !m.state.services.git.IsGitHubOrGitLab(m.ctx) {
+	if m.config.DisablePR || !m.state.services.git.HasForge(m.ctx) {
 		return m.performMergedWorktreeCheck()
 	}
 
@@ -817,12 +825,18 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 		if hasDirtyChanges {
 			desc += " - HAS UNCOMMITTED CHANGES!"
 		}
+		if info.wt.Locked {
+			desc += " - LOCKED, will be skipped"
+			if info.wt.LockReason != "" {
+				desc += ": " + info.wt.LockReason
+			}
+		}
 
 		items = append(items, appscreen.ChecklistItem{
 			ID:          branch,
 			Label:       wtName,
 			Description: desc,
-			Checked:     !hasDirtyChanges, // Uncheck dirty worktrees by default
+			Checked:     !hasDirtyChanges && !info.wt.Locked, // Uncheck dirty and locked worktrees by default
 		})
 	}
 
@@ -882,9 +896,14 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 			pruned := 0
 			failed := 0
 			orphansDeleted := 0
+			lockedSkipped := 0
 
-			// Prune merged worktrees
+			// Prune merged worktrees, leaving locked ones alone
 			for _, wt := range toPrune {
+				if wt.Locked {
+					lockedSkipped++
+					continue
+				}
 				// Run terminate commands for each worktree with its environment
 				if len(terminateCmds) > 0 {
 					env := m.buildCommandEnvForWorktree(wt)
@@ -946,6 +965,7 @@ func (m *Model) performMergedWorktreeCheck() tea.Cmd {
 				failed:          failed,
 				orphansDeleted:  orphansDeleted,
 				branchesDeleted: branchesDeleted,
+				lockedSkipped:   lockedSkipped,
 			}
 		}
 
@@ -971,6 +991,14 @@ func (m *Model) showAbsorbWorktree() tea.Cmd {
 		m.showInfo("Cannot absorb the main worktree.", nil)
 		return nil
 	}
+	if wt.Locked {
+		m.showInfo(lockedWorktreeMessage("absorb", wt), nil)
+		return nil
+	}
+	if wt.Detached {
+		m.showInfo(fmt.Sprintf("Cannot absorb: worktree has a detached HEAD at %s, there is no branch to merge.", wt.HeadRef()), nil)
+		return nil
+	}
 
 	mainBranch := m.state.services.git.GetMainBranch(m.ctx)
 
@@ -988,23 +1016,28 @@ func (m *Model) showAbsorbWorktree() tea.Cmd {
 			break
 		}
 	}
-	if mainWorktree == nil {
-		m.showInfo("Cannot find main worktree.", nil)
-		return nil
-	}
-
-	// Check if main worktree has uncommitted changes
-	if mainWorktree.Dirty {
-		m.showInfo(fmt.Sprintf("Cannot absorb: main worktree has uncommitted changes.\n\nCommit or stash changes in:\n%s", mainWorktree.Path), nil)
-		return nil
-	}
-
-	mainPath := mainWorktree.Path
 	mergeMethod := m.config.MergeMethod
 	if mergeMethod == "" {
 		mergeMethod = mergeMethodRebase
 	}
 
+	// Bare repositories may have no worktree on the main branch: the rebase
+	// method can still fast-forward the branch ref, a merge needs a checkout.
+	fastForwardArgs := []string{"git", "-C", wt.Path, "fetch", ".", fmt.Sprintf("%s:%s", wt.Branch, mainBranch)}
+	mainPath := ""
+	if mainWorktree != nil {
+		// Check if main worktree has uncommitted changes
+		if mainWorktree.Dirty {
+			m.showInfo(fmt.Sprintf("Cannot absorb: main worktree has uncommitted changes.\n\nCommit or stash changes in:\n%s", mainWorktree.Path), nil)
+			return nil
+		}
+		mainPath = mainWorktree.Path
+		fastForwardArgs = []string{"git", "-C", mainPath, "merge", "--ff-only", wt.Branch}
+	} else if mergeMethod != mergeMethodRebase {
+		m.showInfo(fmt.Sprintf("Cannot absorb: no worktree has %s checked out.\n\nCreate a worktree for %s or set merge_method to rebase.", mainBranch, mainBranch), nil)
+		return nil
+	}
+
 	confirmScreen := appscreen.NewConfirmScreen(fmt.Sprintf("Absorb worktree into %s (%s)?\n\nPath: %s\nBranch: %s -> %s", mainBranch, mergeMethod, wt.Path, wt.Branch, mainBranch), m.theme)
 	confirmScreen.OnConfirm = func() tea.Cmd {
 		return func() tea.Msg {
@@ -1018,7 +1051,7 @@ func (m *Model) showAbsorbWorktree() tea.Cmd {
 					}
 				}
 				// Fast-forward main to the rebased branch
-				if !m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "-C", mainPath, "merge", "--ff-only", wt.Branch}, "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
+				if !m.state.services.git.RunCommandChecked(m.ctx, fastForwardArgs, "", fmt.Sprintf("Failed to fast-forward %s to %s", mainBranch, wt.Branch)) {
 					return absorbMergeResultMsg{
 						path:   wt.Path,
 						branch: wt.Branch,
@@ -1050,7 +1083,9 @@ func (m *Model) deleteWorktreeCmd(wt *models.WorktreeInfo) func() tea.Cmd {
 	terminateCmds := m.collectTerminateCommands()
 	afterCmd := func() tea.Msg {
 		m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "worktree", "remove", "--force", wt.Path}, "", fmt.Sprintf("Failed to remove worktree %s", wt.Path))
-		m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "branch", "-D", wt.Branch}, "", fmt.Sprintf("Failed to delete branch %s", wt.Branch))
+		if !wt.Detached {
+			m.state.services.git.RunCommandChecked(m.ctx, []string{"git", "branch", "-D", wt.Branch}, "", fmt.Sprintf("Failed to delete branch %s", wt.Branch))
+		}
 
 		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
 		return worktreesLoadedMsg{
@@ -1068,6 +1103,11 @@ func (m *Model) deleteWorktreeCmd(wt *models.WorktreeInfo) func() tea.Cmd {
 func (m *Model) deleteWorktreeOnlyCmd(wt *models.WorktreeInfo) func() tea.Cmd {
 	env := m.buildCommandEnvForWorktree(wt)
 	terminateCmds := m.collectTerminateCommands()
+	// A detached worktree has no branch to offer for deletion afterwards
+	branch := wt.Branch
+	if wt.Detached {
+		branch = ""
+	}
 
 	afterCmd := func() tea.Msg {
 		// Only remove worktree
@@ -1081,14 +1121,14 @@ func (m *Model) deleteWorktreeOnlyCmd(wt *models.WorktreeInfo) func() tea.Cmd {
 		if !success {
 			return worktreeDeletedMsg{
 				path:   wt.Path,
-				branch: wt.Branch,
+				branch: branch,
 				err:    fmt.Errorf("worktree deletion failed"),
 			}
 		}
 
 		return worktreeDeletedMsg{
 			path:   wt.Path,
-			branch: wt.Branch,
+			branch: branch,
 			err:    nil,
 		}
 	}
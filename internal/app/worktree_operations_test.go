@@ -646,10 +646,11 @@ func TestShowAbsorbWorktreeCreatesConfirmScreen(t *testing.T) {
 func TestShowAbsorbWorktreeNoMainWorktree(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
+		MergeMethod: "merge",
 	}
 	m := NewModel(cfg, "")
 
-	// Set up only a feature worktree (no main)
+	// Set up only a feature worktree (no main): merging needs a checkout of main
 	m.state.data.worktrees = []*models.WorktreeInfo{
 		{Path: "/path/to/feature", Branch: "feature-branch", IsMain: false},
 	}
@@ -665,6 +666,70 @@ func TestShowAbsorbWorktreeNoMainWorktree(t *testing.T) {
 	}
 }
 
+func TestShowAbsorbWorktreeBareRepositoryFastForwardsMainRef(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	// Bare repository layout without a worktree on the main branch
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/path/to/feature", Branch: "feature-branch", IsMain: false},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 0
+
+	m.showAbsorbWorktree()
+	if m.state.ui.screenManager.Type() != appscreen.TypeConfirm {
+		t.Fatalf("expected confirm screen, got %v", m.state.ui.screenManager.Type())
+	}
+	confirmScreen := m.state.ui.screenManager.Current().(*appscreen.ConfirmScreen)
+	result, ok := confirmScreen.OnConfirm()().(absorbMergeResultMsg)
+	if !ok {
+		t.Fatal("expected absorbMergeResultMsg")
+	}
+	if result.err != nil {
+		t.Fatalf("unexpected absorb error: %v", result.err)
+	}
+
+	joined := strings.Join(commands, "\n")
+	if !strings.Contains(joined, "git -C /path/to/feature rebase main") {
+		t.Fatalf("expected rebase onto main, got %v", commands)
+	}
+	if !strings.Contains(joined, "git -C /path/to/feature fetch . feature-branch:main") {
+		t.Fatalf("expected main ref to be fast-forwarded, got %v", commands)
+	}
+}
+
+func TestShowAbsorbWorktreeDetached(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+
+	m.state.data.worktrees = []*models.WorktreeInfo{
+		{Path: "/path/to/main", Branch: mainWorktreeName, IsMain: true},
+		{Path: "/path/to/release", Branch: "(detached)", Detached: true, Head: "0123456789abcdef", HeadTag: "v1.0"},
+	}
+	m.state.data.filteredWts = m.state.data.worktrees
+	m.state.data.selectedIndex = 1
+
+	m.showAbsorbWorktree()
+	if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+		t.Fatalf("expected info screen, got %v", m.state.ui.screenManager.Type())
+	}
+	infoScr := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	if !strings.Contains(infoScr.Message, "detached HEAD at v1.0") {
+		t.Fatalf("expected detached HEAD warning, got %q", infoScr.Message)
+	}
+}
+
 func TestShowAbsorbWorktreeOnMainBranch(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
@@ -738,6 +803,44 @@ func TestShowDeleteWorktree(t *testing.T) {
 	}
 }
 
+func TestDeleteDetachedWorktreeSkipsBranch(t *testing.T) {
+	cfg := &config.AppConfig{
+		WorktreeDir: t.TempDir(),
+	}
+	m := NewModel(cfg, "")
+
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	wt := &models.WorktreeInfo{Path: "/tmp/release", Branch: "(detached)", Detached: true, Head: "0123456789abcdef"}
+	msg := m.deleteWorktreeOnlyCmd(wt)()()
+	deleted, ok := msg.(worktreeDeletedMsg)
+	if !ok {
+		t.Fatalf("expected worktreeDeletedMsg, got %T", msg)
+	}
+	if deleted.branch != "" {
+		t.Fatalf("expected no branch for a detached worktree, got %q", deleted.branch)
+	}
+
+	_, cmd := m.Update(deleted)
+	if cmd == nil {
+		t.Fatal("expected worktrees to be reloaded")
+	}
+	if m.state.ui.screenManager.IsActive() {
+		t.Fatalf("expected no branch deletion prompt, got %v", m.state.ui.screenManager.Type())
+	}
+
+	m.deleteWorktreeCmd(wt)()()
+	for _, command := range commands {
+		if strings.Contains(command, "branch -D") {
+			t.Fatalf("expected no branch deletion, got %v", commands)
+		}
+	}
+}
+
 func TestShowRenameWorktree(t *testing.T) {
 	cfg := &config.AppConfig{
 		WorktreeDir: t.TempDir(),
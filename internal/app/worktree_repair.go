@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// repairMovedWorktrees reconnects worktrees whose directories were moved
+// outside of git, looking for them in the repository worktree directory.
+func (m *Model) repairMovedWorktrees() tea.Cmd {
+	m.loading.active = true
+	m.loading.operation = "repair"
+	m.setLoadingScreen("Repairing moved worktrees...")
+	return func() tea.Msg {
+		relocated, missing := m.state.services.git.FindRelocatedWorktrees(m.ctx, m.repairCandidateDirs())
+		newPaths := make([]string, 0, len(relocated))
+		for _, relocation := range relocated {
+			newPaths = append(newPaths, relocation.NewPath)
+		}
+		if !m.state.services.git.RepairWorktrees(m.ctx, newPaths) {
+			return worktreesRepairedMsg{err: fmt.Errorf("failed to repair worktrees")}
+		}
+		worktrees, err := m.state.services.git.GetWorktrees(m.ctx)
+		return worktreesRepairedMsg{relocated: relocated, missing: missing, worktrees: worktrees, err: err}
+	}
+}
+
+// repairCandidateDirs lists the directories of the repository worktree dir,
+// where moved worktrees are expected to be found.
+func (m *Model) repairCandidateDirs() []string {
+	repoWorktreeDir := m.getRepoWorktreeDir()
+	entries, err := os.ReadDir(repoWorktreeDir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dirs = append(dirs, filepath.Join(repoWorktreeDir, entry.Name()))
+	}
+	return dirs
+}
+
+// handleWorktreesRepaired moves notes and access history to the new paths
+// before reloading, so they are not pruned as belonging to stale worktrees.
+func (m *Model) handleWorktreesRepaired(msg worktreesRepairedMsg) (tea.Model, tea.Cmd) {
+	m.loading.operation = ""
+	if msg.err != nil {
+		m.loading.active = false
+		m.clearLoadingScreen()
+		m.showInfo(fmt.Sprintf("Error: %v", msg.err), nil)
+		return m, nil
+	}
+
+	historyMoved := false
+	for _, relocation := range msg.relocated {
+		m.migrateWorktreeNote(relocation.OldPath, relocation.NewPath)
+		if ts, ok := m.state.data.accessHistory[relocation.OldPath]; ok {
+			delete(m.state.data.accessHistory, relocation.OldPath)
+			if ts > m.state.data.accessHistory[relocation.NewPath] {
+				m.state.data.accessHistory[relocation.NewPath] = ts
+			}
+			historyMoved = true
+		}
+	}
+	if historyMoved {
+		m.saveAccessHistory()
+	}
+
+	model, cmd := m.handleWorktreesLoaded(worktreesLoadedMsg{worktrees: msg.worktrees})
+	m.showInfo(formatRepairReport(msg), nil)
+	return model, cmd
+}
+
+func formatRepairReport(msg worktreesRepairedMsg) string {
+	var b strings.Builder
+	if len(msg.relocated) == 0 {
+		b.WriteString("No moved worktrees found.")
+	} else {
+		b.WriteString("Repaired moved worktrees:\n")
+		for _, relocation := range msg.relocated {
+			fmt.Fprintf(&b, "\n  %s\n  -> %s\n", relocation.OldPath, relocation.NewPath)
+		}
+		b.WriteString("\nNotes, access history and cache entries now follow the new paths.")
+	}
+	if len(msg.missing) > 0 {
+		b.WriteString("\n\nStill missing (run 'lazyworktree repair <new path>' or 'git worktree prune'):\n")
+		for _, path := range msg.missing {
+			fmt.Fprintf(&b, "\n  %s", path)
+		}
+	}
+	return b.String()
+}
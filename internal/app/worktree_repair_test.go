@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestRepairMovedWorktreesRunsGitRepair(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+
+	var commands []string
+	m.commandRunner = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, strings.Join(append([]string{name}, args...), " "))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	}
+
+	cmd := m.repairMovedWorktrees()
+	if cmd == nil {
+		t.Fatal("expected repair command")
+	}
+	if m.state.ui.screenManager.Type() != appscreen.TypeLoading {
+		t.Fatalf("expected loading screen, got %v", m.state.ui.screenManager.Type())
+	}
+	result, ok := cmd().(worktreesRepairedMsg)
+	if !ok {
+		t.Fatal("expected worktreesRepairedMsg")
+	}
+	if result.err != nil {
+		t.Fatalf("unexpected repair error: %v", result.err)
+	}
+	if !strings.Contains(strings.Join(commands, "\n"), "git worktree repair") {
+		t.Fatalf("expected git worktree repair to run, got %v", commands)
+	}
+}
+
+func TestHandleWorktreesRepairedMigratesNotesAndHistory(t *testing.T) {
+	cfg := &config.AppConfig{WorktreeDir: t.TempDir()}
+	m := NewModel(cfg, "")
+
+	oldPath := "/old/worktrees/repo/feature"
+	newPath := "/new/worktrees/repo/feature"
+	m.worktreeNotes = map[string]models.WorktreeNote{
+		oldPath: {Note: "follow the worktree", UpdatedAt: 1},
+	}
+	m.state.data.accessHistory = map[string]int64{oldPath: 42}
+
+	m.handleWorktreesRepaired(worktreesRepairedMsg{
+		relocated: []models.WorktreeRelocation{{OldPath: oldPath, NewPath: newPath}},
+		missing:   []string{"/old/worktrees/repo/gone"},
+		worktrees: []*models.WorktreeInfo{
+			{Path: "/main", Branch: mainWorktreeName, IsMain: true},
+			{Path: newPath, Branch: "feature"},
+		},
+	})
+
+	if note, ok := m.getWorktreeNote(newPath); !ok || note.Note != "follow the worktree" {
+		t.Fatalf("expected note to follow the worktree, got %+v", m.worktreeNotes)
+	}
+	if _, ok := m.worktreeNotes[oldPath]; ok {
+		t.Fatal("expected old note key to be removed")
+	}
+	if m.state.data.accessHistory[newPath] != 42 {
+		t.Fatalf("expected access history to follow the worktree, got %v", m.state.data.accessHistory)
+	}
+	if _, ok := m.state.data.accessHistory[oldPath]; ok {
+		t.Fatal("expected old access history entry to be removed")
+	}
+	for _, wt := range m.state.data.worktrees {
+		if wt.Path == newPath && wt.LastSwitchedTS != 42 {
+			t.Fatalf("expected reloaded worktree to keep its access time, got %d", wt.LastSwitchedTS)
+		}
+	}
+
+	if m.state.ui.screenManager.Type() != appscreen.TypeInfo {
+		t.Fatalf("expected info screen, got %v", m.state.ui.screenManager.Type())
+	}
+	infoScr := m.state.ui.screenManager.Current().(*appscreen.InfoScreen)
+	for _, want := range []string{oldPath, "-> " + newPath, "Still missing", "/old/worktrees/repo/gone"} {
+		if !strings.Contains(infoScr.Message, want) {
+			t.Fatalf("expected %q in report, got %q", want, infoScr.Message)
+		}
+	}
+}
@@ -75,3 +75,13 @@ func (m *Model) selectWorktreeByPath(path string) bool {
 
 	return false
 }
+
+// worktreeByPath returns the loaded worktree at path, or nil.
+func (m *Model) worktreeByPath(path string) *models.WorktreeInfo {
+	for _, wt := range m.state.data.worktrees {
+		if wt.Path == path {
+			return wt
+		}
+	}
+	return nil
+}
@@ -3,21 +3,16 @@ package app
 import (
 	"fmt"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	appscreen "github.com/chmouel/lazyworktree/internal/app/screen"
+	"github.com/chmouel/lazyworktree/internal/app/services"
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
-var (
-	markdownTaskLineRE = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s*)(.*)$`)
-	todoKeywordLineRE  = regexp.MustCompile(`^(\s*)(TODO|DONE)(:?\s*)(.*)$`)
-)
-
 type worktreeTaskRef struct {
 	ID           string
 	WorktreePath string
@@ -170,63 +165,28 @@ func taskboardWorktreeName(wt *models.WorktreeInfo) string {
 }
 
 func extractTaskRefs(worktreePath, noteText string) []worktreeTaskRef {
-	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
-	lines := strings.Split(normalized, "\n")
-	taskRefs := make([]worktreeTaskRef, 0, len(lines))
-
-	for i, line := range lines {
-		checked, text, ok := parseMarkdownTaskLine(line)
-		isKeyword := false
-		if !ok {
-			checked, text, ok = parseTodoKeywordLine(line)
-			if ok {
-				isKeyword = true
-			}
-		}
-		if !ok {
-			continue
-		}
-		id := fmt.Sprintf("%s:%d", filepath.Clean(worktreePath), i)
+	tasks := services.ParseNoteTasks(noteText)
+	taskRefs := make([]worktreeTaskRef, 0, len(tasks))
+	for _, task := range tasks {
 		taskRefs = append(taskRefs, worktreeTaskRef{
-			ID:           id,
+			ID:           fmt.Sprintf("%s:%d", filepath.Clean(worktreePath), task.Line),
 			WorktreePath: worktreePath,
-			LineIndex:    i,
-			Checked:      checked,
-			Text:         text,
-			IsKeyword:    isKeyword,
+			LineIndex:    task.Line,
+			Checked:      task.Done,
+			Text:         task.Text,
+			IsKeyword:    task.Keyword,
 		})
 	}
-
 	return taskRefs
 }
 
-func parseMarkdownTaskLine(line string) (checked bool, text string, ok bool) {
-	parts := markdownTaskLineRE.FindStringSubmatch(line)
-	if len(parts) != 5 {
-		return false, "", false
-	}
-
-	checked = strings.EqualFold(parts[2], "x")
-	text = strings.TrimSpace(parts[4])
-	if text == "" {
-		text = "(untitled task)"
-	}
-	return checked, text, true
-}
-
 func (m *Model) toggleTaskInWorktreeNote(ref worktreeTaskRef) bool {
 	note, ok := m.getWorktreeNote(ref.WorktreePath)
 	if !ok {
 		return false
 	}
 
-	var next string
-	var changed bool
-	if ref.IsKeyword {
-		next, changed = toggleTodoKeywordLine(note.Note, ref.LineIndex)
-	} else {
-		next, changed = toggleMarkdownTaskLine(note.Note, ref.LineIndex)
-	}
+	next, changed := services.ToggleNoteTask(note.Note, services.NoteTask{Line: ref.LineIndex, Keyword: ref.IsKeyword})
 	if !changed {
 		return false
 	}
@@ -235,79 +195,6 @@ func (m *Model) toggleTaskInWorktreeNote(ref worktreeTaskRef) bool {
 }
 
 func (m *Model) appendTaskToWorktreeNote(path, text string) {
-	note, ok := m.getWorktreeNote(path)
-	var updated string
-	if ok && strings.TrimSpace(note.Note) != "" {
-		updated = strings.TrimRight(note.Note, "\n") + "\n- [ ] " + text
-	} else {
-		updated = "- [ ] " + text
-	}
-	m.setWorktreeNote(path, updated)
-}
-
-func toggleMarkdownTaskLine(noteText string, lineIndex int) (string, bool) {
-	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
-	lines := strings.Split(normalized, "\n")
-	if lineIndex < 0 || lineIndex >= len(lines) {
-		return noteText, false
-	}
-
-	line := lines[lineIndex]
-	idx := markdownTaskLineRE.FindStringSubmatchIndex(line)
-	if len(idx) < 6 {
-		return noteText, false
-	}
-
-	checkStart := idx[4]
-	checkEnd := idx[5]
-	if checkStart < 0 || checkEnd <= checkStart || checkEnd > len(line) {
-		return noteText, false
-	}
-
-	replacement := "x"
-	if strings.EqualFold(line[checkStart:checkEnd], "x") {
-		replacement = " "
-	}
-	lines[lineIndex] = line[:checkStart] + replacement + line[checkEnd:]
-	return strings.Join(lines, "\n"), true
-}
-
-func parseTodoKeywordLine(line string) (checked bool, text string, ok bool) {
-	parts := todoKeywordLineRE.FindStringSubmatch(line)
-	if len(parts) != 5 {
-		return false, "", false
-	}
-	checked = parts[2] == "DONE"
-	text = strings.TrimSpace(parts[4])
-	if text == "" {
-		text = "(untitled task)"
-	}
-	return checked, text, true
-}
-
-func toggleTodoKeywordLine(noteText string, lineIndex int) (string, bool) {
-	normalized := strings.ReplaceAll(noteText, "\r\n", "\n")
-	lines := strings.Split(normalized, "\n")
-	if lineIndex < 0 || lineIndex >= len(lines) {
-		return noteText, false
-	}
-
-	line := lines[lineIndex]
-	idx := todoKeywordLineRE.FindStringSubmatchIndex(line)
-	if len(idx) < 6 {
-		return noteText, false
-	}
-
-	kwStart := idx[4]
-	kwEnd := idx[5]
-	keyword := line[kwStart:kwEnd]
-
-	var replacement string
-	if keyword == "TODO" {
-		replacement = "DONE"
-	} else {
-		replacement = "TODO"
-	}
-	lines[lineIndex] = line[:kwStart] + replacement + line[kwEnd:]
-	return strings.Join(lines, "\n"), true
+	note, _ := m.getWorktreeNote(path)
+	m.setWorktreeNote(path, services.AppendNoteTask(note.Note, text))
 }
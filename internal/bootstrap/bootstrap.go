@@ -60,14 +60,19 @@ func Run(args []string) int {
 			createCommand(),
 			renameCommand(),
 			deleteCommand(),
+			lockCommand(),
+			unlockCommand(),
 			cleanupCommand(),
+			repairCommand(),
 			listCommand(),
 			doctorCommand(),
 			worktreesCommand(),
 			notesCommand(),
 			execCommand(),
 			noteCommand(),
+			prCommand(),
 			describeCommand(),
+			mcpCommand(),
 			setupHooksCommand(),
 			agentEventCommand(),
 		},
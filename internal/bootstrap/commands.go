@@ -150,7 +150,7 @@ func completionWorktreeBasenames(ctx context.Context, cmd *appiCli.Command) []st
 		return listSubcommandWorktreeNamesFunc(ctx, cmd)
 	}
 
-	if (cmd.Name != "delete" && cmd.Name != "rename" && cmd.Name != "show" && cmd.Name != "edit") || cmd.NArg() != 0 {
+	if (cmd.Name != "delete" && cmd.Name != "rename" && cmd.Name != "lock" && cmd.Name != "unlock" && cmd.Name != "show" && cmd.Name != "edit") || cmd.NArg() != 0 {
 		return nil
 	}
 
@@ -440,6 +440,89 @@ func renameCommand() *appiCli.Command {
 	}
 }
 
+func lockCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "lock",
+		Usage:     "Lock a worktree so it cannot be deleted, moved or pruned",
+		ArgsUsage: "[worktree]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleLockAction(ctx, cmd, true)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.StringFlag{
+				Name:  "reason",
+				Usage: "Why the worktree is locked, shown in the TUI and the list output",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+			&appiCli.BoolFlag{
+				Name:  "json",
+				Usage: "Output result as JSON",
+			},
+		},
+	}
+}
+
+func unlockCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "unlock",
+		Usage:     "Unlock a locked worktree",
+		ArgsUsage: "[worktree]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleLockAction(ctx, cmd, false)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+			&appiCli.BoolFlag{
+				Name:  "json",
+				Usage: "Output result as JSON",
+			},
+		},
+	}
+}
+
+func repairCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "repair",
+		Usage:     "Reconnect worktrees whose directories were moved and migrate their notes",
+		ArgsUsage: "[path...]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handleRepairAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report the moved worktrees found without repairing them",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+			&appiCli.BoolFlag{
+				Name:  "json",
+				Usage: "Output result as JSON",
+			},
+		},
+	}
+}
+
 // validateMutualExclusivity checks that at most one flag in a group is set.
 func validateMutualExclusivity(checks map[string]bool, groupName string) error {
 	var setFlags []string
@@ -980,6 +1063,14 @@ func sortWorktreesByPath(worktrees []*models.WorktreeInfo) {
 	})
 }
 
+func worktreePaths(worktrees []*models.WorktreeInfo) []string {
+	paths := make([]string, 0, len(worktrees))
+	for _, wt := range worktrees {
+		paths = append(paths, wt.Path)
+	}
+	return paths
+}
+
 // handleListAction handles the list subcommand action.
 func handleListAction(ctx context.Context, cmd *appiCli.Command) error {
 	defer func() {
@@ -1056,8 +1147,13 @@ func outputListJSON(ctx context.Context, gitSvc *git.Service, cfg *config.AppCon
 
 	// Load agent sessions (best-effort).
 	var agentSvc *services.AgentSessionService
+	var prices config.AgentPriceTable
+	if cfg != nil {
+		prices = cfg.AgentPrices
+	}
 	if !noAgent {
 		agentSvc = services.NewAgentSessionService(nil)
+		agentSvc.SetWorktreePaths(worktreePaths(worktrees))
 		_, _ = agentSvc.Refresh()
 	}
 
@@ -1067,12 +1163,18 @@ func outputListJSON(ctx context.Context, gitSvc *git.Service, cfg *config.AppCon
 			Path:       wt.Path,
 			Name:       name,
 			Branch:     wt.Branch,
+			Head:       wt.Head,
+			Detached:   wt.Detached,
+			HeadTag:    wt.HeadTag,
 			IsMain:     wt.IsMain,
 			Dirty:      wt.Dirty,
 			Ahead:      wt.Ahead,
 			Behind:     wt.Behind,
 			Unpushed:   wt.Unpushed,
 			LastActive: wt.LastActive,
+			Locked:     wt.Locked,
+			LockReason: wt.LockReason,
+			Prunable:   wt.Prunable,
 		}
 
 		// Populate note fields.
@@ -1106,6 +1208,7 @@ func outputListJSON(ctx context.Context, gitSvc *git.Service, cfg *config.AppCon
 					LastActivity: s.LastActivity.Format("2006-01-02T15:04:05Z07:00"),
 					TaskLabel:    s.TaskLabel,
 					Model:        s.Model,
+					Usage:        buildAgentUsageJSON(services.SummariseAgentUsage([]*models.AgentSession{s}, prices)),
 				})
 			}
 		}
@@ -1154,7 +1257,7 @@ func outputListVerbose(worktrees []*models.WorktreeInfo) error {
 	for _, wt := range worktrees {
 		name := filepath.Base(wt.Path)
 		status := buildStatusString(wt)
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, wt.Branch, status, wt.LastActive, wt.Path)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, wt.BranchLabel(), status, wt.LastActive, wt.Path)
 	}
 
 	if err := w.Flush(); err != nil {
@@ -1183,6 +1286,9 @@ func buildStatusString(wt *models.WorktreeInfo) string {
 	if !wt.HasUpstream && wt.Unpushed > 0 {
 		parts = append(parts, fmt.Sprintf("?%d", wt.Unpushed))
 	}
+	if wt.Locked {
+		parts = append(parts, " locked")
+	}
 
 	return strings.Join(parts, "")
 }
@@ -1325,6 +1431,127 @@ func emitCleanupJSON(summary cli.CleanupSummary) error {
 	return enc.Encode(output)
 }
 
+// handleRepairAction handles the repair subcommand action. Positional
+// arguments are extra directories to search for moved worktrees.
+func handleRepairAction(ctx context.Context, cmd *appiCli.Command) error {
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.String("debug-log"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+	dryRun := cmd.Bool("dry-run")
+	silent := cmd.Bool("silent") || cmd.Bool("json")
+
+	summary, err := cli.Repair(ctx, gitSvc, cfg, cmd.Args().Slice(), dryRun, silent, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		_ = log.Close()
+		return err
+	}
+
+	if cmd.Bool("json") {
+		output := repairJSON{
+			DryRun:        dryRun,
+			Repaired:      make([]repairedWorktreeJSON, 0, len(summary.Relocated)),
+			Missing:       summary.Missing,
+			Notes:         summary.Migrated.Notes,
+			AccessHistory: summary.Migrated.AccessHistory,
+			CacheEntries:  summary.Migrated.CacheEntries,
+		}
+		if output.Missing == nil {
+			output.Missing = []string{}
+		}
+		for _, relocation := range summary.Relocated {
+			output.Repaired = append(output.Repaired, repairedWorktreeJSON{
+				Name:    filepath.Base(relocation.NewPath),
+				OldPath: relocation.OldPath,
+				NewPath: relocation.NewPath,
+			})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(output); err != nil {
+			_ = log.Close()
+			return err
+		}
+	}
+
+	_ = log.Close()
+	return nil
+}
+
+// handleLockAction handles the lock and unlock subcommand actions. Without a
+// worktree argument the worktree of the current directory is used.
+func handleLockAction(ctx context.Context, cmd *appiCli.Command, lock bool) error {
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.String("debug-log"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+
+	if cmd.NArg() > 1 {
+		err := fmt.Errorf("too many arguments: expected a single worktree name or path")
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		_ = log.Close()
+		return err
+	}
+
+	worktreePath := cmd.Args().Get(0)
+	if worktreePath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			_ = log.Close()
+			return fmt.Errorf("failed to determine current directory: %w", err)
+		}
+		worktreePath = cwd
+	}
+
+	silent := cmd.Bool("silent") || cmd.Bool("json")
+	var wt *models.WorktreeInfo
+	if lock {
+		wt, err = cli.LockWorktree(ctx, gitSvc, cfg, worktreePath, cmd.String("reason"), silent)
+	} else {
+		wt, err = cli.UnlockWorktree(ctx, gitSvc, cfg, worktreePath, silent)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		_ = log.Close()
+		return err
+	}
+
+	if cmd.Bool("json") {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(lockJSON{
+			Name:       filepath.Base(wt.Path),
+			Path:       wt.Path,
+			Locked:     wt.Locked,
+			LockReason: wt.LockReason,
+		}); err != nil {
+			_ = log.Close()
+			return err
+		}
+	}
+
+	_ = log.Close()
+	return nil
+}
+
 // handleRenameAction handles the rename subcommand action.
 func handleRenameAction(ctx context.Context, cmd *appiCli.Command) error {
 	cfg, err := loadCLIConfigFunc(
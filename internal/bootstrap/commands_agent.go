@@ -12,7 +12,7 @@ import (
 )
 
 // agentEventCommand is the hidden hook shim invoked by Claude Code, Codex
-// CLI, and Copilot CLI lifecycle hooks. It reads the hook payload from stdin
+// CLI, Copilot CLI, and Gemini CLI lifecycle hooks. It reads the hook payload from stdin
 // and spools a normalised event for the TUI to consume. It always exits
 // successfully so a broken spool never disrupts the agent.
 func agentEventCommand() *appiCli.Command {
@@ -23,14 +23,15 @@ func agentEventCommand() *appiCli.Command {
 		Flags: []appiCli.Flag{
 			&appiCli.StringFlag{
 				Name:     "agent",
-				Usage:    "Agent kind reporting the event (claude, codex, copilot, pi)",
+				Usage:    "Agent kind reporting the event (claude, codex, copilot, gemini, pi)",
 				Required: true,
 			},
 		},
 		Action: func(_ context.Context, cmd *appiCli.Command) error {
 			agent := models.AgentKind(cmd.String("agent"))
 			switch agent {
-			case models.AgentKindClaude, models.AgentKindCodex, models.AgentKindCopilot, models.AgentKindPi:
+			case models.AgentKindClaude, models.AgentKindCodex, models.AgentKindCopilot, models.AgentKindGemini,
+				models.AgentKindOpenCode, models.AgentKindAider, models.AgentKindPi:
 			default:
 				return nil
 			}
@@ -43,11 +44,11 @@ func agentEventCommand() *appiCli.Command {
 }
 
 // setupHooksCommand installs the agent-event shim into the Claude Code,
-// Codex CLI, and Copilot CLI hook configurations.
+// Codex CLI, Copilot CLI, and Gemini CLI hook configurations.
 func setupHooksCommand() *appiCli.Command {
 	return &appiCli.Command{
 		Name:  "setup-hooks",
-		Usage: "Install agent session hooks for Claude Code, Codex CLI, and Copilot CLI",
+		Usage: "Install agent session hooks for Claude Code, Codex CLI, Copilot CLI, and Gemini CLI",
 		Flags: []appiCli.Flag{
 			&appiCli.BoolFlag{
 				Name:  "dry-run",
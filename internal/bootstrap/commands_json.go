@@ -40,6 +40,31 @@ type deleteJSON struct {
 	BranchDeleted bool   `json:"branch_deleted"`
 }
 
+// lockJSON is the JSON output for the lock and unlock subcommands.
+type lockJSON struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Locked     bool   `json:"locked"`
+	LockReason string `json:"lock_reason,omitempty"`
+}
+
+// repairedWorktreeJSON is a worktree reconnected by the repair subcommand.
+type repairedWorktreeJSON struct {
+	Name    string `json:"name"`
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+}
+
+// repairJSON is the JSON output for the repair subcommand.
+type repairJSON struct {
+	DryRun        bool                   `json:"dry_run"`
+	Repaired      []repairedWorktreeJSON `json:"repaired"`
+	Missing       []string               `json:"missing"`
+	Notes         int                    `json:"notes_migrated"`
+	AccessHistory int                    `json:"access_history_migrated"`
+	CacheEntries  int                    `json:"cache_entries_migrated"`
+}
+
 // renameJSON is the JSON output for the rename subcommand.
 type renameJSON struct {
 	OldName string `json:"old_name"`
@@ -48,6 +73,18 @@ type renameJSON struct {
 	NewPath string `json:"new_path"`
 }
 
+// prCreateJSON is the JSON output for the pr create subcommand.
+type prCreateJSON struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+	Branch string `json:"branch"`
+	Base   string `json:"base"`
+	Title  string `json:"title"`
+	Draft  bool   `json:"draft"`
+}
+
 // cleanupItemJSON is a single candidate acted upon during cleanup.
 type cleanupItemJSON struct {
 	Kind          string `json:"kind"`
@@ -90,16 +127,28 @@ type execJSON struct {
 
 // agentSessionJSON is the JSON representation of an agent session within list output.
 type agentSessionJSON struct {
-	ID           string `json:"id"`
-	Agent        string `json:"agent"`
-	Status       string `json:"status"`
-	Activity     string `json:"activity"`
-	Liveness     string `json:"liveness,omitempty"`
-	Source       string `json:"source,omitempty"`
-	IsOpen       bool   `json:"is_open"`
-	LastActivity string `json:"last_activity,omitempty"`
-	TaskLabel    string `json:"task_label,omitempty"`
-	Model        string `json:"model,omitempty"`
+	ID           string          `json:"id"`
+	Agent        string          `json:"agent"`
+	Status       string          `json:"status"`
+	Activity     string          `json:"activity"`
+	Liveness     string          `json:"liveness,omitempty"`
+	Source       string          `json:"source,omitempty"`
+	IsOpen       bool            `json:"is_open"`
+	LastActivity string          `json:"last_activity,omitempty"`
+	TaskLabel    string          `json:"task_label,omitempty"`
+	Model        string          `json:"model,omitempty"`
+	Usage        *agentUsageJSON `json:"usage,omitempty"`
+}
+
+// agentUsageJSON is the token usage of one session or of every session in a
+// worktree. CostUSD is omitted when no model involved has a known price.
+type agentUsageJSON struct {
+	InputTokens      int64    `json:"input_tokens"`
+	OutputTokens     int64    `json:"output_tokens"`
+	CacheWriteTokens int64    `json:"cache_write_tokens"`
+	CacheReadTokens  int64    `json:"cache_read_tokens"`
+	CostUSD          *float64 `json:"cost_usd,omitempty"`
+	CostIncomplete   bool     `json:"cost_incomplete,omitempty"`
 }
 
 // worktreeJSONExtended is the enriched JSON output for the list subcommand.
@@ -108,12 +157,18 @@ type worktreeJSONExtended struct {
 	Path          string             `json:"path"`
 	Name          string             `json:"name"`
 	Branch        string             `json:"branch"`
+	Head          string             `json:"head,omitempty"`
+	Detached      bool               `json:"detached,omitempty"`
+	HeadTag       string             `json:"head_tag,omitempty"`
 	IsMain        bool               `json:"is_main"`
 	Dirty         bool               `json:"dirty"`
 	Ahead         int                `json:"ahead"`
 	Behind        int                `json:"behind"`
 	Unpushed      int                `json:"unpushed,omitempty"`
 	LastActive    string             `json:"last_active"`
+	Locked        bool               `json:"locked,omitempty"`
+	LockReason    string             `json:"lock_reason,omitempty"`
+	Prunable      bool               `json:"prunable,omitempty"`
 	Description   string             `json:"description,omitempty"`
 	Tags          []string           `json:"tags,omitempty"`
 	NotePresent   bool               `json:"note_present"`
@@ -189,6 +244,9 @@ type machineWorktreeJSON struct {
 	Path          string   `json:"path"`
 	Name          string   `json:"name"`
 	Branch        string   `json:"branch"`
+	Head          string   `json:"head,omitempty"`
+	Detached      bool     `json:"detached,omitempty"`
+	HeadTag       string   `json:"head_tag,omitempty"`
 	Repo          string   `json:"repo"`
 	IsMain        bool     `json:"is_main"`
 	Dirty         bool     `json:"dirty"`
@@ -196,6 +254,9 @@ type machineWorktreeJSON struct {
 	Behind        int      `json:"behind"`
 	Unpushed      int      `json:"unpushed,omitempty"`
 	LastActive    string   `json:"last_active,omitempty"`
+	Locked        bool     `json:"locked,omitempty"`
+	LockReason    string   `json:"lock_reason,omitempty"`
+	Prunable      bool     `json:"prunable,omitempty"`
 	Description   string   `json:"description,omitempty"`
 	Tags          []string `json:"tags,omitempty"`
 	NotePresent   bool     `json:"note_present"`
@@ -222,4 +283,27 @@ type machineWorktreeContextJSON struct {
 	Worktree      machineWorktreeJSON `json:"worktree"`
 	Note          *noteShowJSON       `json:"note,omitempty"`
 	AgentSessions []agentSessionJSON  `json:"agent_sessions,omitempty"`
+	AgentUsage    *agentUsageJSON     `json:"agent_usage,omitempty"`
+	CI            *ciContextJSON      `json:"ci,omitempty"`
+}
+
+// ciContextJSON is the CI state of a worktree's HEAD, with a summary of why
+// the failed checks failed.
+type ciContextJSON struct {
+	Checks   []ciCheckJSON   `json:"checks"`
+	Failures []ciFailureJSON `json:"failures,omitempty"`
+}
+
+type ciCheckJSON struct {
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	Link       string `json:"link,omitempty"`
+}
+
+type ciFailureJSON struct {
+	Check   string `json:"check"`
+	Test    string `json:"test,omitempty"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message,omitempty"`
 }
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"text/tabwriter"
 
 	"github.com/chmouel/lazyworktree/internal/app/services"
@@ -19,6 +20,9 @@ import (
 	appiCli "github.com/urfave/cli/v3"
 )
 
+// prStateOpen is the normalised state of an open PR/MR.
+const prStateOpen = "OPEN"
+
 type commandExitError struct {
 	err      error
 	exitCode int
@@ -165,7 +169,7 @@ func worktreesGetCommand() *appiCli.Command {
 func worktreesContextCommand() *appiCli.Command {
 	return &appiCli.Command{
 		Name:      "context",
-		Usage:     "Read note and agent-session context for one worktree",
+		Usage:     "Read note, agent-session and CI context for one worktree",
 		ArgsUsage: "<worktree>",
 		ShellComplete: func(ctx context.Context, cmd *appiCli.Command) {
 			if cmd.NArg() == 0 {
@@ -179,7 +183,7 @@ func worktreesContextCommand() *appiCli.Command {
 			},
 			&appiCli.StringFlag{
 				Name:  "include",
-				Usage: "Comma-separated context sections: notes,agents (default: notes,agents)",
+				Usage: "Comma-separated context sections: notes,agents,ci (default: notes,agents; ci is opt-in as it queries the forge)",
 				Value: "notes,agents",
 			},
 		},
@@ -397,9 +401,9 @@ func handleWorktreesContextAction(ctx context.Context, cmd *appiCli.Command) err
 		return writeMaybeJSONError(cmd.Bool("json"), "invalid_input", fmt.Errorf("expected exactly one worktree argument"), nil)
 	}
 
-	includeNotes, includeAgents := parseIncludeFlags(cmd.String("include"))
-	if !includeNotes && !includeAgents {
-		return writeMaybeJSONError(cmd.Bool("json"), "invalid_input", fmt.Errorf("--include must contain notes, agents, or both"), nil)
+	includeNotes, includeAgents, includeCI := parseIncludeFlags(cmd.String("include"))
+	if !includeNotes && !includeAgents && !includeCI {
+		return writeMaybeJSONError(cmd.Bool("json"), "invalid_input", fmt.Errorf("--include must contain at least one of notes, agents or ci"), nil)
 	}
 
 	state, err := loadWorktreeCommandState(ctx, cmd, includeAgents)
@@ -419,7 +423,17 @@ func handleWorktreesContextAction(ctx context.Context, cmd *appiCli.Command) err
 		payload.Note = buildNoteJSON(state.cfg, state.repoKey, state.deps.notesMap, resolved.worktree)
 	}
 	if includeAgents {
-		payload.AgentSessions = buildAgentSessionJSONs(state.deps.agentSvc, resolved.worktree.Path)
+		payload.AgentSessions = buildAgentSessionJSONs(state.deps.agentSvc, state.cfg.AgentPrices, resolved.worktree.Path)
+		if state.deps.agentSvc != nil {
+			sessions := state.deps.agentSvc.SessionsForWorktree(resolved.worktree.Path)
+			payload.AgentUsage = buildAgentUsageJSON(services.SummariseAgentUsage(sessions, state.cfg.AgentPrices))
+		}
+	}
+	if includeCI {
+		payload.CI, err = buildCIContextJSON(ctx, state.gitSvc, resolved.worktree)
+		if err != nil {
+			return writeMaybeJSONError(cmd.Bool("json"), "ci_error", err, nil)
+		}
 	}
 
 	if cmd.Bool("json") {
@@ -438,9 +452,90 @@ func handleWorktreesContextAction(ctx context.Context, cmd *appiCli.Command) err
 			fmt.Fprintf(os.Stdout, "- %s %s %s\n", session.Agent, session.Status, session.TaskLabel)
 		}
 	}
+	if usage := payload.AgentUsage; usage != nil {
+		fmt.Fprintf(os.Stdout, "\nAgent usage: %d input, %d output, %d cache write, %d cache read tokens",
+			usage.InputTokens, usage.OutputTokens, usage.CacheWriteTokens, usage.CacheReadTokens)
+		if usage.CostUSD != nil {
+			fmt.Fprintf(os.Stdout, " (~$%.2f)", *usage.CostUSD)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+	if payload.CI != nil && len(payload.CI.Checks) > 0 {
+		fmt.Fprintln(os.Stdout, "\nCI checks:")
+		for _, check := range payload.CI.Checks {
+			fmt.Fprintf(os.Stdout, "- %s %s\n", check.Name, check.Conclusion)
+		}
+		if len(payload.CI.Failures) > 0 {
+			fmt.Fprintln(os.Stdout, "\nCI failures:")
+			for _, failure := range payload.CI.Failures {
+				fmt.Fprintf(os.Stdout, "- %s\n", formatCIFailureLine(failure))
+			}
+		}
+	}
 	return nil
 }
 
+// buildCIContextJSON fetches the CI checks of a worktree, through its open PR
+// when there is one, and summarises the failures of the failed checks.
+func buildCIContextJSON(ctx context.Context, gitSvc *git.Service, wt *models.WorktreeInfo) (*ciContextJSON, error) {
+	var checks []*models.CICheck
+	pr, err := gitSvc.FetchPRForWorktreeWithError(ctx, wt.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case pr != nil && pr.State == prStateOpen:
+		checks, err = gitSvc.FetchCIStatus(ctx, pr.Number, wt.Branch)
+	case gitSvc.SupportsCommitCI(ctx):
+		if sha := gitSvc.GetHeadSHA(ctx, wt.Path); sha != "" {
+			checks, err = gitSvc.FetchCIStatusByCommit(ctx, sha, wt.Path)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ciContextJSON{Checks: make([]ciCheckJSON, 0, len(checks))}
+	failures := make([][]models.CIFailure, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		output.Checks = append(output.Checks, ciCheckJSON{Name: check.Name, Conclusion: check.Conclusion, Link: check.Link})
+		if check.Conclusion != "failure" || check.Link == "" {
+			continue
+		}
+		wg.Go(func() {
+			// A summary is best effort: the check itself is still reported.
+			failures[i], _ = gitSvc.FetchCIFailures(ctx, check, wt.Path)
+		})
+	}
+	wg.Wait()
+
+	for _, checkFailures := range failures {
+		for _, failure := range checkFailures {
+			output.Failures = append(output.Failures, ciFailureJSON(failure))
+		}
+	}
+	return output, nil
+}
+
+func formatCIFailureLine(failure ciFailureJSON) string {
+	parts := []string{failure.Check}
+	if failure.File != "" {
+		location := failure.File
+		if failure.Line > 0 {
+			location = fmt.Sprintf("%s:%d", location, failure.Line)
+		}
+		parts = append(parts, location)
+	}
+	if failure.Test != "" {
+		parts = append(parts, failure.Test)
+	}
+	if failure.Message != "" {
+		parts = append(parts, failure.Message)
+	}
+	return strings.Join(parts, " ")
+}
+
 func handleNotesGetAction(ctx context.Context, cmd *appiCli.Command) error {
 	if cmd.NArg() != 1 {
 		return writeMaybeJSONError(cmd.Bool("json"), "invalid_input", fmt.Errorf("expected exactly one worktree argument"), nil)
@@ -514,11 +609,14 @@ func loadWorktreeCommandState(ctx context.Context, cmd *appiCli.Command, include
 		deps.notesMap, _ = services.LoadWorktreeNotes(repoKey, cfg.WorktreeDir, cfg.WorktreeNotesPath, cfg.WorktreeNoteType, mainEnv)
 	}
 	if includeAgents {
-		agentSvc := services.NewAgentSessionServiceFromConfig(
-			cfg.AgentSessionClaudeRoot,
-			cfg.AgentSessionPiRoot,
-			nil,
-		)
+		agentSvc := services.NewAgentSessionServiceFromConfig(services.AgentSessionRoots{
+			Claude:   cfg.AgentSessionClaudeRoot,
+			Pi:       cfg.AgentSessionPiRoot,
+			Codex:    cfg.AgentSessionCodexRoot,
+			Gemini:   cfg.AgentSessionGeminiRoot,
+			OpenCode: cfg.AgentSessionOpenCodeRoot,
+		}, nil)
+		agentSvc.SetWorktreePaths(worktreePaths(worktrees))
 		_, _ = agentSvc.Refresh()
 		deps.agentSvc = agentSvc
 	}
@@ -615,6 +713,9 @@ func buildMachineWorktreeJSON(wt *models.WorktreeInfo, deps worktreeReadDeps) ma
 		Path:       wt.Path,
 		Name:       filepath.Base(wt.Path),
 		Branch:     wt.Branch,
+		Head:       wt.Head,
+		Detached:   wt.Detached,
+		HeadTag:    wt.HeadTag,
 		Repo:       deps.repoKey,
 		IsMain:     wt.IsMain,
 		Dirty:      wt.Dirty,
@@ -622,6 +723,9 @@ func buildMachineWorktreeJSON(wt *models.WorktreeInfo, deps worktreeReadDeps) ma
 		Behind:     wt.Behind,
 		Unpushed:   wt.Unpushed,
 		LastActive: wt.LastActive,
+		Locked:     wt.Locked,
+		LockReason: wt.LockReason,
+		Prunable:   wt.Prunable,
 	}
 
 	if deps.notesMap != nil && deps.cfg != nil {
@@ -668,7 +772,7 @@ func buildNoteJSON(cfg *config.AppConfig, repoKey string, notesMap map[string]mo
 	}
 }
 
-func buildAgentSessionJSONs(agentSvc *services.AgentSessionService, wtPath string) []agentSessionJSON {
+func buildAgentSessionJSONs(agentSvc *services.AgentSessionService, prices config.AgentPriceTable, wtPath string) []agentSessionJSON {
 	if agentSvc == nil {
 		return nil
 	}
@@ -691,22 +795,42 @@ func buildAgentSessionJSONs(agentSvc *services.AgentSessionService, wtPath strin
 			LastActivity: session.LastActivity.Format("2006-01-02T15:04:05Z07:00"),
 			TaskLabel:    session.TaskLabel,
 			Model:        session.Model,
+			Usage:        buildAgentUsageJSON(services.SummariseAgentUsage([]*models.AgentSession{session}, prices)),
 		})
 	}
 	return output
 }
 
-func parseIncludeFlags(raw string) (bool, bool) {
-	var includeNotes, includeAgents bool
+// buildAgentUsageJSON returns nil when the sessions reported no token usage.
+func buildAgentUsageJSON(summary services.AgentUsageSummary) *agentUsageJSON {
+	if summary.Usage.Total() == 0 {
+		return nil
+	}
+	usage := &agentUsageJSON{
+		InputTokens:      summary.Usage.Input,
+		OutputTokens:     summary.Usage.Output,
+		CacheWriteTokens: summary.Usage.CacheWrite,
+		CacheReadTokens:  summary.Usage.CacheRead,
+	}
+	if cost := summary.Cost; cost > 0 || !summary.Unpriced {
+		usage.CostUSD = &cost
+		usage.CostIncomplete = summary.Unpriced
+	}
+	return usage
+}
+
+func parseIncludeFlags(raw string) (includeNotes, includeAgents, includeCI bool) {
 	for _, part := range strings.Split(raw, ",") {
 		switch strings.TrimSpace(strings.ToLower(part)) {
 		case "notes":
 			includeNotes = true
 		case "agents":
 			includeAgents = true
+		case "ci":
+			includeCI = true
 		}
 	}
-	return includeNotes, includeAgents
+	return includeNotes, includeAgents, includeCI
 }
 
 func outputMachineWorktreesTable(items []machineWorktreeJSON) error {
@@ -735,6 +859,9 @@ func buildMachineStatusString(item *machineWorktreeJSON) string {
 	if item.Unpushed > 0 {
 		parts = append(parts, fmt.Sprintf("?%d", item.Unpushed))
 	}
+	if item.Locked {
+		parts = append(parts, " locked")
+	}
 	return strings.Join(parts, "")
 }
 
@@ -144,6 +144,7 @@ func TestWorktreesContextUsesConfiguredAgentRoots(t *testing.T) {
 			"message": map[string]any{
 				"role":  "assistant",
 				"model": "claude-sonnet-4",
+				"usage": map[string]any{"input_tokens": 100_000, "output_tokens": 20_000},
 				"content": []map[string]any{
 					{"type": "tool_use", "name": "Read", "input": map[string]any{"file_path": filepath.Join(featurePath, "README.md")}},
 				},
@@ -174,6 +175,43 @@ func TestWorktreesContextUsesConfiguredAgentRoots(t *testing.T) {
 	assert.Equal(t, "session-1", payload.AgentSessions[0].ID)
 	assert.Equal(t, "claude", payload.AgentSessions[0].Agent)
 	assert.Equal(t, "feature", payload.Worktree.Name)
+	require.NotNil(t, payload.AgentUsage)
+	assert.Equal(t, int64(100_000), payload.AgentUsage.InputTokens)
+	require.NotNil(t, payload.AgentUsage.CostUSD)
+	assert.InDelta(t, 0.6, *payload.AgentUsage.CostUSD, 1e-9)
+}
+
+func TestWorktreesContextIncludesCI(t *testing.T) {
+	repoRoot, worktreeRoot, _, _ := initMachineTestRepo(t)
+	t.Setenv("HOME", t.TempDir())
+
+	// Without a forge remote there are no checks, but the section is present.
+	output, errOutput, err := runMachineCommand(t, repoRoot, []string{
+		"lazyworktree",
+		"--worktree-dir", worktreeRoot,
+		"worktrees",
+		"context",
+		"feature",
+		"--include", "ci",
+		"--json",
+	})
+	require.NoError(t, err, errOutput)
+
+	var payload machineWorktreeContextJSON
+	require.NoError(t, json.Unmarshal(output, &payload))
+	require.NotNil(t, payload.CI)
+	assert.Empty(t, payload.CI.Checks)
+	assert.Nil(t, payload.Note)
+	assert.Empty(t, payload.AgentSessions)
+}
+
+func TestFormatCIFailureLine(t *testing.T) {
+	assert.Equal(t, "test parse_test.go:42 TestParse expected 1, got 2", formatCIFailureLine(ciFailureJSON{
+		Check: "test", Test: "TestParse", File: "parse_test.go", Line: 42, Message: "expected 1, got 2",
+	}))
+	assert.Equal(t, "build Job failed: script failure", formatCIFailureLine(ciFailureJSON{
+		Check: "build", Message: "Job failed: script failure",
+	}))
 }
 
 func initMachineTestRepo(t *testing.T) (string, string, string, *git.Service) {
@@ -0,0 +1,705 @@
+package bootstrap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/buildinfo"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+// mcpProtocolVersions lists the Model Context Protocol revisions the server
+// speaks, newest first.
+var mcpProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// JSON-RPC 2.0 error codes.
+const (
+	mcpParseError     = -32700
+	mcpInvalidRequest = -32600
+	mcpMethodNotFound = -32601
+	mcpInvalidParams  = -32602
+)
+
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type mcpToolResult struct {
+	Content []mcpContent `json:"content"`
+	IsError bool         `json:"isError,omitempty"`
+}
+
+type mcpContent struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// mcpTool is one tool exposed to MCP clients. Tools return a value encoded
+// as JSON text, or an error reported to the client as a failed tool call.
+type mcpTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+
+	call func(ctx context.Context, s *mcpServer, args json.RawMessage) (any, error)
+}
+
+// mcpServer answers MCP requests for the repository of the current
+// directory. State is reloaded for every tool call so the answers follow
+// worktrees created or removed by other tools.
+type mcpServer struct {
+	load  func(ctx context.Context, includeAgents bool) (*worktreeCommandState, error)
+	tools []mcpTool
+}
+
+type mcpTaskJSON struct {
+	Worktree string `json:"worktree"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Done     bool   `json:"done"`
+	Text     string `json:"text"`
+}
+
+type mcpCreateJSON struct {
+	Path     string               `json:"path"`
+	Worktree *machineWorktreeJSON `json:"worktree,omitempty"`
+}
+
+type mcpHookJSON struct {
+	Path string `json:"path"`
+	Hook string `json:"hook"`
+}
+
+func mcpCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "mcp",
+		Usage: "Run a Model Context Protocol server on stdio for coding agents",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			// Operations report progress on stdout; keep it for protocol
+			// messages only.
+			out := os.Stdout
+			os.Stdout = os.Stderr
+			defer func() { os.Stdout = out }()
+			return newMCPServer(cmd).serve(ctx, os.Stdin, out)
+		},
+	}
+}
+
+func newMCPServer(cmd *appiCli.Command) *mcpServer {
+	return &mcpServer{
+		load: func(ctx context.Context, includeAgents bool) (*worktreeCommandState, error) {
+			return loadWorktreeCommandState(ctx, cmd, includeAgents)
+		},
+		tools: mcpTools(),
+	}
+}
+
+// serve reads newline-delimited JSON-RPC messages until the input closes.
+func (s *mcpServer) serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var req mcpRequest
+		var resp *mcpResponse
+		if err := json.Unmarshal(line, &req); err != nil {
+			resp = mcpErrorResponse(json.RawMessage("null"), mcpParseError, err.Error())
+		} else {
+			resp = s.handle(ctx, &req)
+		}
+		if resp == nil {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// handle answers one request. Notifications get no response.
+func (s *mcpServer) handle(ctx context.Context, req *mcpRequest) *mcpResponse {
+	if len(req.ID) == 0 {
+		return nil
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return mcpErrorResponse(req.ID, mcpInvalidRequest, "invalid JSON-RPC 2.0 request")
+	}
+
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		version := mcpProtocolVersions[0]
+		if slices.Contains(mcpProtocolVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+		return mcpResultResponse(req.ID, map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": "lazyworktree", "version": buildinfo.Version()},
+		})
+	case "ping":
+		return mcpResultResponse(req.ID, map[string]any{})
+	case "tools/list":
+		return mcpResultResponse(req.ID, map[string]any{"tools": s.tools})
+	case "tools/call":
+		var params struct {
+			Name      string          `json:"name"`
+			Arguments json.RawMessage `json:"arguments"`
+		}
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return mcpErrorResponse(req.ID, mcpInvalidParams, err.Error())
+		}
+		idx := slices.IndexFunc(s.tools, func(tool mcpTool) bool { return tool.Name == params.Name })
+		if idx < 0 {
+			return mcpErrorResponse(req.ID, mcpInvalidParams, fmt.Sprintf("unknown tool: %s", params.Name))
+		}
+		return mcpResultResponse(req.ID, s.callTool(ctx, &s.tools[idx], params.Arguments))
+	default:
+		return mcpErrorResponse(req.ID, mcpMethodNotFound, fmt.Sprintf("method not found: %s", req.Method))
+	}
+}
+
+func (s *mcpServer) callTool(ctx context.Context, tool *mcpTool, args json.RawMessage) mcpToolResult {
+	if len(args) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+	value, err := tool.call(ctx, s, args)
+	if err != nil {
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return mcpToolResult{Content: []mcpContent{{Type: "text", Text: err.Error()}}, IsError: true}
+	}
+	return mcpToolResult{Content: []mcpContent{{Type: "text", Text: string(data)}}}
+}
+
+// resolve loads the repository state and finds the worktree named by an
+// argument, accepting the same names, branches and paths as the CLI.
+func (s *mcpServer) resolve(ctx context.Context, worktree string, includeAgents bool) (*worktreeCommandState, *models.WorktreeInfo, error) {
+	if worktree == "" {
+		return nil, nil, fmt.Errorf("worktree is required")
+	}
+	state, err := s.load(ctx, includeAgents)
+	if err != nil {
+		return nil, nil, err
+	}
+	kind := "name"
+	if filepath.IsAbs(worktree) {
+		kind = "path"
+	}
+	resolved, err := resolveWorktreeForMachine(state.cfg, state.repoKey, state.mainWorktree, state.worktrees, worktree, kind)
+	if err != nil {
+		return nil, nil, err
+	}
+	return state, resolved.worktree, nil
+}
+
+func mcpTools() []mcpTool {
+	worktreeArg := mcpString("Worktree name, branch or absolute path")
+	return []mcpTool{
+		{
+			Name:        "list_worktrees",
+			Description: "List the worktrees of the repository with their branch, status, note summary and agent activity.",
+			InputSchema: mcpObjectSchema(nil, nil),
+			call:        mcpListWorktrees,
+		},
+		{
+			Name:        "resolve_worktree",
+			Description: "Resolve a worktree from a name, a path, or a working directory inside it. Pass exactly one argument.",
+			InputSchema: mcpObjectSchema(nil, map[string]any{
+				"name": mcpString("Worktree name, basename or branch"),
+				"path": mcpString("Absolute path of the worktree or a path inside it"),
+				"cwd":  mcpString("Working directory to resolve"),
+			}),
+			call: mcpResolveWorktree,
+		},
+		{
+			Name:        "worktree_context",
+			Description: "Read the note, agent sessions, token usage and optionally the CI checks of one worktree.",
+			InputSchema: mcpObjectSchema([]string{"worktree"}, map[string]any{
+				"worktree": worktreeArg,
+				"ci":       mcpBool("Also fetch CI checks and failure summaries"),
+			}),
+			call: mcpWorktreeContext,
+		},
+		{
+			Name:        "get_note",
+			Description: "Read the note of a worktree, with its description, icon and tags.",
+			InputSchema: mcpObjectSchema([]string{"worktree"}, map[string]any{"worktree": worktreeArg}),
+			call:        mcpGetNote,
+		},
+		{
+			Name:        "set_note",
+			Description: "Update the note of a worktree. Only the fields provided are changed.",
+			InputSchema: mcpObjectSchema([]string{"worktree"}, map[string]any{
+				"worktree":    worktreeArg,
+				"note":        mcpString("Markdown note text, replacing the current one"),
+				"description": mcpString("Short description shown in the worktree list"),
+				"icon":        mcpString("Icon shown next to the worktree"),
+				"tags": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "Tags, replacing the current ones",
+				},
+			}),
+			call: mcpSetNote,
+		},
+		{
+			Name:        "list_tasks",
+			Description: "List the checkbox and TODO/DONE tasks of worktree notes. Without a worktree, list the tasks of every worktree.",
+			InputSchema: mcpObjectSchema(nil, map[string]any{"worktree": worktreeArg}),
+			call:        mcpListTasks,
+		},
+		{
+			Name:        "add_task",
+			Description: "Append an open task to the note of a worktree.",
+			InputSchema: mcpObjectSchema([]string{"worktree", "text"}, map[string]any{
+				"worktree": worktreeArg,
+				"text":     mcpString("Task description"),
+			}),
+			call: mcpAddTask,
+		},
+		{
+			Name:        "set_task",
+			Description: "Mark a task of a worktree note as done or open. Tasks are identified by the line returned by list_tasks.",
+			InputSchema: mcpObjectSchema([]string{"worktree", "line", "done"}, map[string]any{
+				"worktree": worktreeArg,
+				"line":     mcpInteger("Line of the task in the note, starting at 0"),
+				"done":     mcpBool("Whether the task is done"),
+			}),
+			call: mcpSetTask,
+		},
+		{
+			Name:        "create_worktree",
+			Description: "Create a worktree from a branch, a pull/merge request or an issue, running the init commands. Pass exactly one of branch, pr or issue.",
+			InputSchema: mcpObjectSchema(nil, map[string]any{
+				"branch":      mcpString("Existing branch to create the worktree from"),
+				"name":        mcpString("Name of the worktree and its new branch when creating from a branch"),
+				"pr":          mcpInteger("Pull/merge request number"),
+				"issue":       mcpInteger("Issue number"),
+				"base_branch": mcpString("Base branch for a worktree created from an issue (default: current branch)"),
+			}),
+			call: mcpCreateWorktree,
+		},
+		{
+			Name:        "run_lifecycle_hook",
+			Description: "Run the init or terminate commands of a worktree from the configuration and the trusted .wt file.",
+			InputSchema: mcpObjectSchema([]string{"worktree", "hook"}, map[string]any{
+				"worktree": worktreeArg,
+				"hook": map[string]any{
+					"type":        "string",
+					"enum":        []string{cli.LifecycleHookInit, cli.LifecycleHookTerminate},
+					"description": "Lifecycle hook to run",
+				},
+			}),
+			call: mcpRunLifecycleHook,
+		},
+		{
+			Name:        "ci_status",
+			Description: "Fetch the CI checks of a worktree, through its open pull/merge request or its HEAD commit, with summaries of the failures.",
+			InputSchema: mcpObjectSchema([]string{"worktree"}, map[string]any{"worktree": worktreeArg}),
+			call:        mcpCIStatus,
+		},
+	}
+}
+
+func mcpListWorktrees(ctx context.Context, s *mcpServer, _ json.RawMessage) (any, error) {
+	state, err := s.load(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]machineWorktreeJSON, 0, len(state.worktrees))
+	for _, wt := range state.worktrees {
+		items = append(items, buildMachineWorktreeJSON(wt, state.deps))
+	}
+	return machineWorktreeListJSON{Repo: state.repoKey, Count: len(items), Items: items}, nil
+}
+
+func mcpResolveWorktree(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+		CWD  string `json:"cwd"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	var input, kind string
+	for _, option := range []struct{ kind, value string }{{"name", args.Name}, {"path", args.Path}, {"cwd", args.CWD}} {
+		if option.value == "" {
+			continue
+		}
+		if input != "" {
+			return nil, fmt.Errorf("specify exactly one of name, path or cwd")
+		}
+		input, kind = option.value, option.kind
+	}
+	if input == "" {
+		return nil, fmt.Errorf("specify exactly one of name, path or cwd")
+	}
+
+	state, err := s.load(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := resolveWorktreeForMachine(state.cfg, state.repoKey, state.mainWorktree, state.worktrees, input, kind)
+	if err != nil {
+		return nil, err
+	}
+	return machineWorktreeResolveJSON{
+		Input:      input,
+		ResolvedBy: resolved.resolvedBy,
+		Worktree:   buildMachineWorktreeJSON(resolved.worktree, state.deps),
+	}, nil
+}
+
+func mcpWorktreeContext(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+		CI       bool   `json:"ci"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, true)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := machineWorktreeContextJSON{
+		Worktree:      buildMachineWorktreeJSON(wt, state.deps),
+		Note:          buildNoteJSON(state.cfg, state.repoKey, state.deps.notesMap, wt),
+		AgentSessions: buildAgentSessionJSONs(state.deps.agentSvc, state.cfg.AgentPrices, wt.Path),
+	}
+	if state.deps.agentSvc != nil {
+		sessions := state.deps.agentSvc.SessionsForWorktree(wt.Path)
+		payload.AgentUsage = buildAgentUsageJSON(services.SummariseAgentUsage(sessions, state.cfg.AgentPrices))
+	}
+	if args.CI {
+		if payload.CI, err = buildCIContextJSON(ctx, state.gitSvc, wt); err != nil {
+			return nil, err
+		}
+	}
+	return payload, nil
+}
+
+func mcpGetNote(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	return mcpNoteJSON(ctx, state, wt)
+}
+
+func mcpSetNote(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree    string   `json:"worktree"`
+		Note        string   `json:"note"`
+		Description string   `json:"description"`
+		Icon        string   `json:"icon"`
+		Tags        []string `json:"tags"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	note := models.WorktreeNote{Note: args.Note, Description: args.Description, Icon: args.Icon, Tags: args.Tags}
+	if err := cli.NoteSet(ctx, state.gitSvc, state.cfg, wt.Path, note); err != nil {
+		return nil, err
+	}
+	return mcpNoteJSON(ctx, state, wt)
+}
+
+func mcpListTasks(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+
+	var state *worktreeCommandState
+	var worktrees []*models.WorktreeInfo
+	if args.Worktree != "" {
+		var wt *models.WorktreeInfo
+		var err error
+		if state, wt, err = s.resolve(ctx, args.Worktree, false); err != nil {
+			return nil, err
+		}
+		worktrees = []*models.WorktreeInfo{wt}
+	} else {
+		var err error
+		if state, err = s.load(ctx, false); err != nil {
+			return nil, err
+		}
+		worktrees = state.worktrees
+	}
+
+	tasks := make([]mcpTaskJSON, 0)
+	for _, wt := range worktrees {
+		note, ok := findNoteForWorktree(state.cfg, state.repoKey, state.deps.notesMap, wt.Path)
+		if !ok {
+			continue
+		}
+		for _, task := range services.ParseNoteTasks(note.Note) {
+			tasks = append(tasks, mcpTaskJSON{
+				Worktree: filepath.Base(wt.Path),
+				Path:     wt.Path,
+				Line:     task.Line,
+				Done:     task.Done,
+				Text:     task.Text,
+			})
+		}
+	}
+	return tasks, nil
+}
+
+func mcpAddTask(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+		Text     string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	if args.Text == "" {
+		return nil, fmt.Errorf("text is required")
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	note, _ := findNoteForWorktree(state.cfg, state.repoKey, state.deps.notesMap, wt.Path)
+	updated := services.AppendNoteTask(note.Note, args.Text)
+	if err := cli.NoteSet(ctx, state.gitSvc, state.cfg, wt.Path, models.WorktreeNote{Note: updated}); err != nil {
+		return nil, err
+	}
+	return mcpStoredTask(ctx, state, wt, len(services.ParseNoteTasks(updated))-1)
+}
+
+func mcpSetTask(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+		Line     int    `json:"line"`
+		Done     bool   `json:"done"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	note, _ := findNoteForWorktree(state.cfg, state.repoKey, state.deps.notesMap, wt.Path)
+	tasks := services.ParseNoteTasks(note.Note)
+	idx := slices.IndexFunc(tasks, func(task services.NoteTask) bool { return task.Line == args.Line })
+	if idx < 0 {
+		return nil, fmt.Errorf("no task on line %d of the note of %s", args.Line, filepath.Base(wt.Path))
+	}
+	task := tasks[idx]
+	if task.Done == args.Done {
+		return mcpTaskJSON{Worktree: filepath.Base(wt.Path), Path: wt.Path, Line: task.Line, Done: task.Done, Text: task.Text}, nil
+	}
+	updated, _ := services.ToggleNoteTask(note.Note, task)
+	if err := cli.NoteSet(ctx, state.gitSvc, state.cfg, wt.Path, models.WorktreeNote{Note: updated}); err != nil {
+		return nil, err
+	}
+	return mcpStoredTask(ctx, state, wt, idx)
+}
+
+// mcpStoredTask returns the task at index in the note of wt as saved, whose
+// lines may have moved from the text written since NoteSet trims it.
+func mcpStoredTask(ctx context.Context, state *worktreeCommandState, wt *models.WorktreeInfo, index int) (mcpTaskJSON, error) {
+	note, _, err := cli.NoteGet(ctx, state.gitSvc, state.cfg, wt.Path)
+	if err != nil {
+		return mcpTaskJSON{}, err
+	}
+	tasks := services.ParseNoteTasks(note.Note)
+	if index < 0 || index >= len(tasks) {
+		return mcpTaskJSON{}, fmt.Errorf("the task was not found in the saved note of %s", filepath.Base(wt.Path))
+	}
+	task := tasks[index]
+	return mcpTaskJSON{Worktree: filepath.Base(wt.Path), Path: wt.Path, Line: task.Line, Done: task.Done, Text: task.Text}, nil
+}
+
+func mcpCreateWorktree(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Branch     string `json:"branch"`
+		Name       string `json:"name"`
+		PR         int    `json:"pr"`
+		Issue      int    `json:"issue"`
+		BaseBranch string `json:"base_branch"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	sources := 0
+	for _, set := range []bool{args.Branch != "", args.PR > 0, args.Issue > 0} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("specify exactly one of branch, pr or issue")
+	}
+
+	state, err := s.load(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	var path string
+	switch {
+	case args.PR > 0:
+		if state.cfg.DisablePR {
+			return nil, fmt.Errorf("PR/MR integration is disabled in configuration")
+		}
+		path, err = createFromPRFunc(ctx, state.gitSvc, state.cfg, args.PR, false, true)
+	case args.Issue > 0:
+		baseBranch := args.BaseBranch
+		if baseBranch == "" {
+			if baseBranch, err = state.gitSvc.GetCurrentBranch(ctx); err != nil {
+				return nil, err
+			}
+		}
+		path, err = createFromIssueFunc(ctx, state.gitSvc, state.cfg, args.Issue, baseBranch, false, true)
+	default:
+		path, err = createFromBranchFunc(ctx, state.gitSvc, state.cfg, args.Branch, args.Name, false, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := mcpCreateJSON{Path: path}
+	if refreshed, err := s.load(ctx, false); err == nil {
+		if wt := detectWorktreeFromPath(path, refreshed.worktrees); wt != nil {
+			view := buildMachineWorktreeJSON(wt, refreshed.deps)
+			result.Worktree = &view
+		}
+	}
+	return result, nil
+}
+
+func mcpRunLifecycleHook(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+		Hook     string `json:"hook"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	path, err := cli.RunLifecycleHook(ctx, state.gitSvc, state.cfg, wt.Path, args.Hook, true)
+	if err != nil {
+		return nil, err
+	}
+	return mcpHookJSON{Path: path, Hook: args.Hook}, nil
+}
+
+func mcpCIStatus(ctx context.Context, s *mcpServer, raw json.RawMessage) (any, error) {
+	var args struct {
+		Worktree string `json:"worktree"`
+	}
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, err
+	}
+	state, wt, err := s.resolve(ctx, args.Worktree, false)
+	if err != nil {
+		return nil, err
+	}
+	return buildCIContextJSON(ctx, state.gitSvc, wt)
+}
+
+// mcpNoteJSON reads the note back through the CLI so the answer reflects
+// what was saved.
+func mcpNoteJSON(ctx context.Context, state *worktreeCommandState, wt *models.WorktreeInfo) (*noteShowJSON, error) {
+	note, path, err := cli.NoteGet(ctx, state.gitSvc, state.cfg, wt.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &noteShowJSON{
+		WorktreeName: filepath.Base(path),
+		Path:         path,
+		Note:         note.Note,
+		Description:  note.Description,
+		Icon:         note.Icon,
+		Tags:         note.Tags,
+		UpdatedAt:    note.UpdatedAt,
+	}, nil
+}
+
+func mcpResultResponse(id json.RawMessage, result any) *mcpResponse {
+	return &mcpResponse{JSONRPC: "2.0", ID: id, Result: result}
+}
+
+func mcpErrorResponse(id json.RawMessage, code int, message string) *mcpResponse {
+	return &mcpResponse{JSONRPC: "2.0", ID: id, Error: &mcpError{Code: code, Message: message}}
+}
+
+func mcpObjectSchema(required []string, properties map[string]any) map[string]any {
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func mcpString(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+func mcpInteger(description string) map[string]any {
+	return map[string]any{"type": "integer", "description": description}
+}
+
+func mcpBool(description string) map[string]any {
+	return map[string]any{"type": "boolean", "description": description}
+}
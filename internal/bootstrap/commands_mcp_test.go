@@ -0,0 +1,227 @@
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appiCli "github.com/urfave/cli/v3"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestMCPServerInitializeAndListTools(t *testing.T) {
+	repoRoot, worktreeRoot, _, _ := initMachineTestRepo(t)
+
+	responses := runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`not json`,
+	)
+	require.Len(t, responses, 4)
+
+	var initResult struct {
+		ProtocolVersion string `json:"protocolVersion"`
+		ServerInfo      struct {
+			Name string `json:"name"`
+		} `json:"serverInfo"`
+	}
+	require.NoError(t, json.Unmarshal(responses[0].Result, &initResult))
+	assert.Equal(t, "2025-03-26", initResult.ProtocolVersion)
+	assert.Equal(t, "lazyworktree", initResult.ServerInfo.Name)
+
+	var listResult struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	require.NoError(t, json.Unmarshal(responses[1].Result, &listResult))
+	names := make([]string, 0, len(listResult.Tools))
+	for _, tool := range listResult.Tools {
+		names = append(names, tool.Name)
+	}
+	assert.Subset(t, names, []string{"list_worktrees", "resolve_worktree", "get_note", "set_note", "list_tasks", "add_task", "set_task", "create_worktree", "run_lifecycle_hook", "ci_status"})
+
+	require.NotNil(t, responses[2].Error)
+	assert.Equal(t, mcpMethodNotFound, responses[2].Error.Code)
+	require.NotNil(t, responses[3].Error)
+	assert.Equal(t, mcpParseError, responses[3].Error.Code)
+}
+
+func TestMCPServerNotesAndTasks(t *testing.T) {
+	repoRoot, worktreeRoot, featurePath, _ := initMachineTestRepo(t)
+	t.Setenv("HOME", t.TempDir())
+
+	responses := runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"resolve_worktree","arguments":{"name":"feature"}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"set_note","arguments":{"worktree":"feature","note":"Plan\n- [ ] Write tests","description":"Feature work"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"add_task","arguments":{"worktree":"feature","text":"Ship it"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"set_task","arguments":{"worktree":"feature","line":1,"done":true}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"tools/call","params":{"name":"list_tasks","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"tools/call","params":{"name":"get_note","arguments":{"worktree":"missing"}}}`,
+	)
+	require.Len(t, responses, 6)
+
+	var resolved machineWorktreeResolveJSON
+	decodeMCPToolResult(t, responses[0], &resolved)
+	assert.Equal(t, featurePath, resolved.Worktree.Path)
+
+	var note noteShowJSON
+	decodeMCPToolResult(t, responses[1], &note)
+	assert.Equal(t, "Plan\n- [ ] Write tests", note.Note)
+	assert.Equal(t, "Feature work", note.Description)
+
+	var added mcpTaskJSON
+	decodeMCPToolResult(t, responses[2], &added)
+	assert.Equal(t, mcpTaskJSON{Worktree: "feature", Path: featurePath, Line: 2, Text: "Ship it"}, added)
+
+	var toggled mcpTaskJSON
+	decodeMCPToolResult(t, responses[3], &toggled)
+	assert.True(t, toggled.Done)
+	assert.Equal(t, "Write tests", toggled.Text)
+
+	var tasks []mcpTaskJSON
+	decodeMCPToolResult(t, responses[4], &tasks)
+	require.Len(t, tasks, 2)
+	assert.True(t, tasks[0].Done)
+	assert.False(t, tasks[1].Done)
+
+	var failed mcpToolResult
+	require.NoError(t, json.Unmarshal(responses[5].Result, &failed))
+	assert.True(t, failed.IsError)
+	assert.Contains(t, failed.Content[0].Text, "worktree not found")
+}
+
+func TestMCPServerTaskLinesMatchSavedNote(t *testing.T) {
+	repoRoot, worktreeRoot, _, _ := initMachineTestRepo(t)
+	t.Setenv("HOME", t.TempDir())
+
+	responses := runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"set_note","arguments":{"worktree":"feature","note":"Plan\n- [ ] Write tests"}}}`,
+	)
+	require.Len(t, responses, 1)
+	notesFiles, err := filepath.Glob(filepath.Join(worktreeRoot, "*", models.WorktreeNotesFilename))
+	require.NoError(t, err)
+	require.Len(t, notesFiles, 1)
+	// Notes edited by hand may start with blank lines, which saving trims.
+	prependBlankLines := func() {
+		t.Helper()
+		data, err := os.ReadFile(notesFiles[0])
+		require.NoError(t, err)
+		var notes map[string]models.WorktreeNote
+		require.NoError(t, json.Unmarshal(data, &notes))
+		for key, note := range notes {
+			note.Note = "\n\n" + strings.TrimSpace(note.Note)
+			notes[key] = note
+		}
+		data, err = json.Marshal(notes)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(notesFiles[0], data, 0o600))
+	}
+
+	prependBlankLines()
+	responses = runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"add_task","arguments":{"worktree":"feature","text":"Ship it"}}}`,
+	)
+	var added mcpTaskJSON
+	decodeMCPToolResult(t, responses[0], &added)
+	assert.Equal(t, 2, added.Line)
+	assert.Equal(t, "Ship it", added.Text)
+
+	prependBlankLines()
+	responses = runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"set_task","arguments":{"worktree":"feature","line":1,"done":true}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"list_tasks","arguments":{}}}`,
+	)
+	var toggled mcpTaskJSON
+	decodeMCPToolResult(t, responses[0], &toggled)
+	assert.Equal(t, "Write tests", toggled.Text)
+	assert.True(t, toggled.Done)
+	assert.Equal(t, 1, toggled.Line)
+
+	var tasks []mcpTaskJSON
+	decodeMCPToolResult(t, responses[1], &tasks)
+	require.Len(t, tasks, 2)
+	assert.Equal(t, []int{1, 2}, []int{tasks[0].Line, tasks[1].Line})
+}
+
+func TestMCPServerReportsToolErrors(t *testing.T) {
+	repoRoot, worktreeRoot, _, _ := initMachineTestRepo(t)
+
+	responses := runMCPSession(t, repoRoot, worktreeRoot,
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"create_worktree","arguments":{"branch":"main","pr":3}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"run_lifecycle_hook","arguments":{"worktree":"feature","hook":"restart"}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"ping"}`,
+	)
+	require.Len(t, responses, 3)
+
+	var createResult mcpToolResult
+	require.NoError(t, json.Unmarshal(responses[0].Result, &createResult))
+	assert.True(t, createResult.IsError)
+	assert.Contains(t, createResult.Content[0].Text, "exactly one of branch, pr or issue")
+
+	var hookResult mcpToolResult
+	require.NoError(t, json.Unmarshal(responses[1].Result, &hookResult))
+	assert.True(t, hookResult.IsError)
+	assert.Contains(t, hookResult.Content[0].Text, "unknown lifecycle hook")
+
+	assert.Nil(t, responses[2].Error)
+}
+
+type mcpTestResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *mcpError       `json:"error"`
+}
+
+func decodeMCPToolResult(t *testing.T, response mcpTestResponse, v any) {
+	t.Helper()
+
+	require.Nil(t, response.Error)
+	var result mcpToolResult
+	require.NoError(t, json.Unmarshal(response.Result, &result))
+	require.False(t, result.IsError, result.Content)
+	require.Len(t, result.Content, 1)
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].Text), v))
+}
+
+func runMCPSession(t *testing.T, cwd, worktreeRoot string, requests ...string) []mcpTestResponse {
+	t.Helper()
+
+	oldWD, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(cwd))
+	t.Cleanup(func() {
+		_ = os.Chdir(oldWD)
+	})
+
+	var out bytes.Buffer
+	app := &appiCli.Command{
+		Name:  "lazyworktree",
+		Flags: globalFlags(),
+		Commands: []*appiCli.Command{{
+			Name: "mcp",
+			Action: func(ctx context.Context, cmd *appiCli.Command) error {
+				return newMCPServer(cmd).serve(ctx, strings.NewReader(strings.Join(requests, "\n")+"\n"), &out)
+			},
+		}},
+	}
+	require.NoError(t, app.Run(context.Background(), []string{"lazyworktree", "--worktree-dir", worktreeRoot, "mcp"}))
+
+	var responses []mcpTestResponse
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var response mcpTestResponse
+		require.NoError(t, dec.Decode(&response))
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,158 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/log"
+	"github.com/chmouel/lazyworktree/internal/models"
+	appiCli "github.com/urfave/cli/v3"
+)
+
+type createPRFuncType func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, worktreePathOrName string, opts cli.PRCreateOptions, silent bool) (*models.PRInfo, string, error)
+
+var createPRFunc createPRFuncType = func(ctx context.Context, gitSvc *git.Service, cfg *config.AppConfig, worktreePathOrName string, opts cli.PRCreateOptions, silent bool) (*models.PRInfo, string, error) {
+	return cli.CreatePR(ctx, gitSvc, cfg, worktreePathOrName, opts, silent)
+}
+
+func prCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:  "pr",
+		Usage: "Manage the PR/MR of a worktree",
+		Commands: []*appiCli.Command{
+			prCreateCommand(),
+		},
+	}
+}
+
+func prCreateCommand() *appiCli.Command {
+	return &appiCli.Command{
+		Name:      "create",
+		Usage:     "Push a worktree branch and open a PR/MR for it",
+		ArgsUsage: "[worktree-name]",
+		Action: func(ctx context.Context, cmd *appiCli.Command) error {
+			if handleSubcommandCompletion(ctx, cmd) {
+				return nil
+			}
+			return handlePRCreateAction(ctx, cmd)
+		},
+		ShellComplete: subcommandShellComplete,
+		Flags: []appiCli.Flag{
+			&appiCli.StringFlag{
+				Name:  "base",
+				Usage: "Base branch (default: the main branch)",
+			},
+			&appiCli.StringFlag{
+				Name:  "title",
+				Usage: "Title (default: note description, single commit subject, or branch name)",
+			},
+			&appiCli.StringFlag{
+				Name:  "body",
+				Usage: "Body (default: note text followed by the commit list)",
+			},
+			&appiCli.BoolFlag{
+				Name:  "draft",
+				Usage: "Open as a draft",
+			},
+			&appiCli.StringSliceFlag{
+				Name:  "reviewer",
+				Usage: "Request a review (repeatable or comma separated)",
+			},
+			&appiCli.StringSliceFlag{
+				Name:  "label",
+				Usage: "Add a label (repeatable or comma separated)",
+			},
+			&appiCli.BoolFlag{
+				Name:  "no-push",
+				Usage: "Do not push the branch before creating the PR/MR",
+			},
+			&appiCli.BoolFlag{
+				Name:  "silent",
+				Usage: "Suppress progress messages",
+			},
+			&appiCli.BoolFlag{
+				Name:  "json",
+				Usage: "Output result as JSON",
+			},
+		},
+	}
+}
+
+func handlePRCreateAction(ctx context.Context, cmd *appiCli.Command) error {
+	cfg, err := loadCLIConfigFunc(
+		cmd.String("config-file"),
+		cmd.String("worktree-dir"),
+		cmd.String("debug-log"),
+		cmd.StringSlice("config"),
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		return err
+	}
+
+	gitSvc := newCLIGitServiceFunc(cfg)
+
+	worktreeName := ""
+	if cmd.NArg() > 0 {
+		worktreeName = cmd.Args().Get(0)
+	}
+
+	opts := cli.PRCreateOptions{
+		Base:      cmd.String("base"),
+		Title:     cmd.String("title"),
+		Body:      cmd.String("body"),
+		Draft:     cmd.Bool("draft"),
+		NoPush:    cmd.Bool("no-push"),
+		Reviewers: splitListFlag(cmd.StringSlice("reviewer")),
+		Labels:    splitListFlag(cmd.StringSlice("label")),
+	}
+
+	jsonOutput := cmd.Bool("json")
+	pr, wtPath, err := createPRFunc(ctx, gitSvc, cfg, worktreeName, opts, cmd.Bool("silent") || jsonOutput)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		_ = log.Close()
+		return err
+	}
+
+	if jsonOutput {
+		output := prCreateJSON{
+			Name:   filepath.Base(wtPath),
+			Path:   wtPath,
+			Number: pr.Number,
+			URL:    pr.URL,
+			Branch: pr.Branch,
+			Base:   pr.BaseBranch,
+			Title:  pr.Title,
+			Draft:  pr.IsDraft,
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(output); err != nil {
+			_ = log.Close()
+			return err
+		}
+		_ = log.Close()
+		return nil
+	}
+
+	fmt.Println(pr.URL)
+	_ = log.Close()
+	return nil
+}
+
+// splitListFlag flattens repeated and comma separated flag values.
+func splitListFlag(values []string) []string {
+	var result []string
+	for _, value := range values {
+		result = append(result, services.SplitList(value)...)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urfavecli "github.com/urfave/cli/v3"
+
+	"github.com/chmouel/lazyworktree/internal/cli"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestHandlePRCreateActionJSON(t *testing.T) {
+	oldLoadCLIConfig := loadCLIConfigFunc
+	oldNewCLIGitService := newCLIGitServiceFunc
+	oldCreatePR := createPRFunc
+	t.Cleanup(func() {
+		loadCLIConfigFunc = oldLoadCLIConfig
+		newCLIGitServiceFunc = oldNewCLIGitService
+		createPRFunc = oldCreatePR
+	})
+
+	loadCLIConfigFunc = func(string, string, string, []string) (*config.AppConfig, error) {
+		return &config.AppConfig{}, nil
+	}
+	newCLIGitServiceFunc = func(*config.AppConfig) *git.Service {
+		return &git.Service{}
+	}
+	var gotName string
+	var gotOpts cli.PRCreateOptions
+	var gotSilent bool
+	createPRFunc = func(_ context.Context, _ *git.Service, _ *config.AppConfig, name string, opts cli.PRCreateOptions, silent bool) (*models.PRInfo, string, error) {
+		gotName, gotOpts, gotSilent = name, opts, silent
+		return &models.PRInfo{Number: 9, URL: "https://github.com/org/repo/pull/9", Branch: "feature", BaseBranch: "main", Title: "Add feature", IsDraft: true}, "/tmp/wt/feature", nil
+	}
+
+	origStdout := os.Stdout
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = writer
+	t.Cleanup(func() { os.Stdout = origStdout })
+
+	app := &urfavecli.Command{Name: "lazyworktree", Commands: []*urfavecli.Command{prCommand()}}
+	args := []string{"lazyworktree", "pr", "create", "--draft", "--reviewer", "bob,carol", "--label", "bug", "--label", "good first issue", "--json", "feature"}
+	require.NoError(t, app.Run(context.Background(), args))
+	_ = writer.Close()
+
+	out, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	var result prCreateJSON
+	require.NoError(t, json.Unmarshal(out, &result))
+	assert.Equal(t, prCreateJSON{
+		Name:   "feature",
+		Path:   "/tmp/wt/feature",
+		Number: 9,
+		URL:    "https://github.com/org/repo/pull/9",
+		Branch: "feature",
+		Base:   "main",
+		Title:  "Add feature",
+		Draft:  true,
+	}, result)
+
+	assert.Equal(t, "feature", gotName)
+	assert.True(t, gotSilent, "--json should suppress progress output")
+	assert.True(t, gotOpts.Draft)
+	assert.Equal(t, []string{"bob", "carol"}, gotOpts.Reviewers)
+	assert.Equal(t, []string{"bug", "good first issue"}, gotOpts.Labels)
+}
@@ -66,6 +66,8 @@ func newCLIGitService(cfg *config.AppConfig) *git.Service {
 	gitSvc.SetGitPager(cfg.GitPager)
 	gitSvc.SetGitPagerArgs(cfg.GitPagerArgs)
 	gitSvc.SetCIRemote(cfg.CIRemote)
+	gitSvc.SetGiteaConfig(cfg.GiteaToken, cfg.GiteaHosts)
+	gitSvc.SetForgeClient(cfg.ForgeClient)
 	return gitSvc
 }
 
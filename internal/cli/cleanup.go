@@ -163,6 +163,14 @@ func findCleanupCandidates(
 
 	candidates := make([]cleanupCandidate, 0, len(pruneCandidates))
 	for _, candidate := range pruneCandidates {
+		if candidate.Worktree != nil && candidate.Worktree.Locked {
+			reason := ""
+			if candidate.Worktree.LockReason != "" {
+				reason = fmt.Sprintf(" (%s)", candidate.Worktree.LockReason)
+			}
+			fmt.Fprintf(stderr, "Skipping locked worktree %s%s\n", candidate.Worktree.Path, reason)
+			continue
+		}
 		kind := cleanupWorktree
 		if candidate.Worktree == nil {
 			kind = cleanupBranch
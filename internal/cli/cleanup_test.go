@@ -169,6 +169,36 @@ func TestCleanupCancelled(t *testing.T) {
 	assert.Empty(t, svc.runCommandCheckedCalls)
 }
 
+func TestCleanupSkipsLockedWorktrees(t *testing.T) {
+	t.Parallel()
+
+	svc := &fakeGitService{
+		resolveRepoName:     "repo",
+		mainWorktreePath:    "/main",
+		mainBranch:          "main",
+		mergedBranches:      []string{"feature", "usb"},
+		runCommandCheckedOK: true,
+		worktrees: []*models.WorktreeInfo{
+			{Path: "/main", Branch: "main", IsMain: true},
+			{Path: "/worktrees/repo/feature", Branch: "feature"},
+			{Path: "/worktrees/repo/usb", Branch: "usb", Locked: true, LockReason: "on removable drive"},
+		},
+	}
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	cfg.DisablePR = true
+
+	var stderr bytes.Buffer
+	summary, err := Cleanup(context.Background(), svc, cfg, true, false, strings.NewReader(""), &stderr)
+	require.NoError(t, err)
+
+	assert.Contains(t, stderr.String(), "Skipping locked worktree /worktrees/repo/usb (on removable drive)")
+	assert.Equal(t, 1, summary.Worktrees)
+	assert.True(t, commandWasRun(svc.runCommandCheckedCalls, "git", "worktree", "remove", "--force", "/worktrees/repo/feature"))
+	assert.False(t, commandWasRun(svc.runCommandCheckedCalls, "git", "worktree", "remove", "--force", "/worktrees/repo/usb"))
+	assert.False(t, commandWasRun(svc.runCommandCheckedCalls, "git", "branch", "-D", "usb"))
+}
+
 func TestFindCleanupCandidatesRefreshesMergedPRState(t *testing.T) {
 	t.Parallel()
 
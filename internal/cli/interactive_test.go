@@ -230,6 +230,10 @@ func (m *mockGitServiceForInteractive) FetchPRForWorktreeWithError(context.Conte
 	return nil, nil
 }
 
+func (m *mockGitServiceForInteractive) FindRelocatedWorktrees(context.Context, []string) ([]models.WorktreeRelocation, []string) {
+	return nil, nil
+}
+
 func (m *mockGitServiceForInteractive) GetCurrentBranch(context.Context) (string, error) {
 	return "main", nil
 }
@@ -238,9 +242,15 @@ func (m *mockGitServiceForInteractive) GetWorktrees(context.Context) ([]*models.
 	return nil, nil
 }
 
+func (m *mockGitServiceForInteractive) LockWorktree(context.Context, string, string) bool {
+	return true
+}
 func (m *mockGitServiceForInteractive) RenameWorktree(context.Context, string, string, string, string) bool {
 	return true
 }
+func (m *mockGitServiceForInteractive) RepairWorktrees(context.Context, []string) bool {
+	return true
+}
 func (m *mockGitServiceForInteractive) ResolveRepoName(context.Context) string { return "repo" }
 func (m *mockGitServiceForInteractive) RunCommandChecked(context.Context, []string, string, string) bool {
 	return true
@@ -249,6 +259,7 @@ func (m *mockGitServiceForInteractive) RunCommandChecked(context.Context, []stri
 func (m *mockGitServiceForInteractive) RunGit(context.Context, []string, string, []int, bool, bool) string {
 	return ""
 }
+func (m *mockGitServiceForInteractive) UnlockWorktree(context.Context, string) bool { return true }
 
 func TestSelectIssueInteractive_NoIssues(t *testing.T) {
 	gitSvc := &mockGitServiceForInteractive{issues: []*models.IssueInfo{}}
@@ -51,13 +51,17 @@ type gitService interface {
 	FetchIssue(ctx context.Context, issueNumber int) (*models.IssueInfo, error)
 	FetchPR(ctx context.Context, prNumber int) (*models.PRInfo, error)
 	FetchPRForWorktreeWithError(ctx context.Context, worktreePath string) (*models.PRInfo, error)
+	FindRelocatedWorktrees(ctx context.Context, candidates []string) ([]models.WorktreeRelocation, []string)
 	GetCurrentBranch(ctx context.Context) (string, error)
 	GetMainWorktreePath(ctx context.Context) string
 	GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, error)
+	LockWorktree(ctx context.Context, path, reason string) bool
 	RenameWorktree(ctx context.Context, oldPath, newPath, oldBranch, newBranch string) bool
+	RepairWorktrees(ctx context.Context, paths []string) bool
 	ResolveRepoName(ctx context.Context) string
 	RunCommandChecked(ctx context.Context, args []string, cwd string, errorMsg string) bool
 	RunGit(ctx context.Context, args []string, cwd string, exitCodes []int, silent bool, ignoreErrors bool) string
+	UnlockWorktree(ctx context.Context, path string) bool
 }
 
 var _ gitService = (*git.Service)(nil)
@@ -576,6 +580,9 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	if err != nil {
 		return err
 	}
+	if selectedWorktree.Locked {
+		return lockedWorktreeError(selectedWorktree)
+	}
 
 	// Run terminate commands
 	var lazyCtxProvider func() appservices.LazyWorktreeContext
@@ -604,7 +611,11 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	// Delete branch only if worktree name matches branch name (unless --no-branch was specified)
 	if deleteBranch {
 		worktreeName := filepath.Base(selectedWorktree.Path)
-		if worktreeName == selectedWorktree.Branch {
+		if selectedWorktree.Detached {
+			if !silent {
+				fmt.Fprintf(os.Stderr, "Skipping branch deletion: worktree has a detached HEAD at %s\n", selectedWorktree.HeadRef())
+			}
+		} else if worktreeName == selectedWorktree.Branch {
 			if !gitSvc.RunCommandChecked(
 				ctx,
 				[]string{"git", "branch", "-D", selectedWorktree.Branch},
@@ -621,6 +632,78 @@ func DeleteWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	return nil
 }
 
+// LockWorktree locks a worktree so that git and lazyworktree refuse to move,
+// delete or prune it. The reason is optional.
+func LockWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePathOrName, reason string, silent bool) (*models.WorktreeInfo, error) {
+	wt, err := findLockableWorktree(ctx, gitSvc, cfg, worktreePathOrName)
+	if err != nil {
+		return nil, err
+	}
+	if wt.Locked {
+		return nil, lockedWorktreeError(wt)
+	}
+
+	reason = strings.TrimSpace(reason)
+	if !gitSvc.LockWorktree(ctx, wt.Path, reason) {
+		return nil, fmt.Errorf("failed to lock worktree %s", wt.Path)
+	}
+	wt.Locked = true
+	wt.LockReason = reason
+
+	if !silent {
+		fmt.Fprintf(os.Stderr, "Locked worktree: %s\n", wt.Path)
+	}
+	return wt, nil
+}
+
+// UnlockWorktree removes the lock from a worktree.
+func UnlockWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePathOrName string, silent bool) (*models.WorktreeInfo, error) {
+	wt, err := findLockableWorktree(ctx, gitSvc, cfg, worktreePathOrName)
+	if err != nil {
+		return nil, err
+	}
+	if !wt.Locked {
+		return nil, fmt.Errorf("worktree is not locked: %s", wt.Path)
+	}
+
+	if !gitSvc.UnlockWorktree(ctx, wt.Path) {
+		return nil, fmt.Errorf("failed to unlock worktree %s", wt.Path)
+	}
+	wt.Locked = false
+	wt.LockReason = ""
+
+	if !silent {
+		fmt.Fprintf(os.Stderr, "Unlocked worktree: %s\n", wt.Path)
+	}
+	return wt, nil
+}
+
+func findLockableWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePathOrName string) (*models.WorktreeInfo, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get worktrees: %w", err)
+	}
+
+	nonMainWorktrees := make([]*models.WorktreeInfo, 0, len(worktrees))
+	for _, wt := range worktrees {
+		if !wt.IsMain {
+			nonMainWorktrees = append(nonMainWorktrees, wt)
+		}
+	}
+	if len(nonMainWorktrees) == 0 {
+		return nil, fmt.Errorf("no worktrees to lock or unlock; the main worktree cannot be locked")
+	}
+
+	return FindWorktreeByPathOrName(worktreePathOrName, nonMainWorktrees, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx), gitSvc.GetMainWorktreePath(ctx))
+}
+
+func lockedWorktreeError(wt *models.WorktreeInfo) error {
+	if wt.LockReason != "" {
+		return fmt.Errorf("worktree is locked (%s): %s; run 'lazyworktree unlock' first", wt.LockReason, wt.Path)
+	}
+	return fmt.Errorf("worktree is locked: %s; run 'lazyworktree unlock' first", wt.Path)
+}
+
 // RenameWorktree renames a worktree. The branch is renamed only when the
 // current worktree name and branch name are the same.
 func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePath, newName string, silent bool) error {
@@ -664,6 +747,9 @@ func RenameWorktree(ctx context.Context, gitSvc gitService, cfg *config.AppConfi
 	if err != nil {
 		return err
 	}
+	if selectedWorktree.Locked {
+		return lockedWorktreeError(selectedWorktree)
+	}
 
 	currentWorktreeName := filepath.Base(selectedWorktree.Path)
 	if currentWorktreeName == newWorktreeName {
@@ -818,6 +904,43 @@ func runTerminateCommands(ctx context.Context, gitSvc gitService, cfg *config.Ap
 	return nil
 }
 
+// Lifecycle hooks accepted by RunLifecycleHook.
+const (
+	LifecycleHookInit      = "init"
+	LifecycleHookTerminate = "terminate"
+)
+
+// RunLifecycleHook runs the init or terminate commands of an existing
+// worktree, with the same environment and trust checks as create and delete.
+// Returns the worktree path.
+func RunLifecycleHook(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, worktreePathOrName, hook string, silent bool) (string, error) {
+	worktrees, err := gitSvc.GetWorktrees(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktrees: %w", err)
+	}
+	target, err := FindWorktreeByPathOrName(worktreePathOrName, worktrees, cfg.WorktreeDir, gitSvc.ResolveRepoName(ctx), gitSvc.GetMainWorktreePath(ctx))
+	if err != nil {
+		return "", err
+	}
+
+	lazyCtxProvider := func() appservices.LazyWorktreeContext { return appservices.LazyWorktreeContext{} }
+	if !cfg.DisablePR {
+		lazyCtxProvider = func() appservices.LazyWorktreeContext {
+			return lazyWorktreeContextForWorktree(ctx, gitSvc, target)
+		}
+	}
+
+	switch hook {
+	case LifecycleHookInit:
+		err = runInitCommands(ctx, gitSvc, cfg, target.Branch, target.Path, lazyCtxProvider(), silent)
+	case LifecycleHookTerminate:
+		err = runTerminateCommands(ctx, gitSvc, cfg, target.Branch, target.Path, lazyCtxProvider, silent)
+	default:
+		return "", fmt.Errorf("unknown lifecycle hook %q (expected %q or %q)", hook, LifecycleHookInit, LifecycleHookTerminate)
+	}
+	return target.Path, err
+}
+
 // checkTrust verifies TOFU trust for .wt file commands.
 func checkTrust(_ context.Context, cfg *config.AppConfig, wtFilePath string) error {
 	trustMode := strings.ToLower(cfg.TrustMode)
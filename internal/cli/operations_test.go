@@ -67,6 +67,16 @@ type fakeGitService struct {
 	lastRenameOldBranch    string
 	lastRenameNewBranch    string
 	runCommandCheckedCalls [][]string
+
+	lastLockPath   string
+	lastLockReason string
+	lastUnlockPath string
+
+	relocations       []models.WorktreeRelocation
+	missingWorktrees  []string
+	lastRepairPaths   []string
+	repairCalled      bool
+	relocationLookups []string
 }
 
 func (f *fakeGitService) CheckoutPRBranch(_ context.Context, _ int, _, localBranch string) bool {
@@ -127,6 +137,11 @@ func (f *fakeGitService) FetchPRForWorktreeWithError(_ context.Context, _ string
 	return f.prForWorktree, f.prForWorktreeErr
 }
 
+func (f *fakeGitService) FindRelocatedWorktrees(_ context.Context, candidates []string) ([]models.WorktreeRelocation, []string) {
+	f.relocationLookups = append([]string{}, candidates...)
+	return f.relocations, f.missingWorktrees
+}
+
 func (f *fakeGitService) GetCurrentBranch(_ context.Context) (string, error) {
 	return f.currentBranch, f.currentBranchErr
 }
@@ -151,6 +166,23 @@ func (f *fakeGitService) GetWorktrees(_ context.Context) ([]*models.WorktreeInfo
 	return f.worktrees, f.worktreesErr
 }
 
+func (f *fakeGitService) LockWorktree(_ context.Context, path, reason string) bool {
+	f.lastLockPath = path
+	f.lastLockReason = reason
+	return f.runCommandCheckedOK
+}
+
+func (f *fakeGitService) UnlockWorktree(_ context.Context, path string) bool {
+	f.lastUnlockPath = path
+	return f.runCommandCheckedOK
+}
+
+func (f *fakeGitService) RepairWorktrees(_ context.Context, paths []string) bool {
+	f.repairCalled = true
+	f.lastRepairPaths = append([]string{}, paths...)
+	return f.runCommandCheckedOK
+}
+
 func (f *fakeGitService) RenameWorktree(_ context.Context, oldPath, newPath, oldBranch, newBranch string) bool {
 	f.renameWorktreeCalled = true
 	f.lastRenameOldPath = oldPath
@@ -896,6 +928,53 @@ func TestRunTerminateCommands(t *testing.T) {
 	}
 }
 
+func TestRunLifecycleHook(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	wtPath := filepath.Join(tmpDir, "feature")
+
+	cfg := &config.AppConfig{
+		InitCommands:      []string{"echo init"},
+		TerminateCommands: []string{"echo terminate"},
+		DisablePR:         true,
+	}
+	svc := &fakeGitService{
+		mainWorktreePath: tmpDir,
+		resolveRepoName:  testRepoName,
+		worktrees: []*models.WorktreeInfo{
+			{Path: tmpDir, Branch: "main", IsMain: true},
+			{Path: wtPath, Branch: "feature"},
+		},
+	}
+
+	path, err := RunLifecycleHook(ctx, svc, cfg, "feature", LifecycleHookInit, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != wtPath || svc.lastExecutedCwd != wtPath {
+		t.Fatalf("expected init commands to run in %q, got path=%q cwd=%q", wtPath, path, svc.lastExecutedCwd)
+	}
+	if !slices.Equal(svc.lastExecutedCommands, []string{"echo init"}) {
+		t.Fatalf("unexpected init commands: %v", svc.lastExecutedCommands)
+	}
+
+	if _, err := RunLifecycleHook(ctx, svc, cfg, "feature", LifecycleHookTerminate, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(svc.lastExecutedCommands, []string{"echo terminate"}) {
+		t.Fatalf("unexpected terminate commands: %v", svc.lastExecutedCommands)
+	}
+
+	if _, err := RunLifecycleHook(ctx, svc, cfg, "feature", "restart", true); err == nil {
+		t.Fatal("expected error for unknown hook")
+	}
+	if _, err := RunLifecycleHook(ctx, svc, cfg, "missing", LifecycleHookInit, true); err == nil {
+		t.Fatal("expected error for unknown worktree")
+	}
+}
+
 func TestLazyWorktreeContextForWorktreeFetchesPR(t *testing.T) {
 	t.Parallel()
 
@@ -1183,6 +1262,91 @@ func TestDeleteWorktree(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
+
+	t.Run("refuses locked worktree", func(t *testing.T) {
+		wtPath := filepath.Join(tmpDir, testRepoName, "usb")
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "usb", Locked: true, LockReason: "on removable drive"}},
+			runCommandCheckedOK: true,
+		}
+
+		err := DeleteWorktree(ctx, svc, cfg, "usb", true, true)
+		require.ErrorContains(t, err, "worktree is locked (on removable drive)")
+		assert.Empty(t, svc.runCommandCheckedCalls)
+	})
+
+	t.Run("keeps branches of detached worktrees", func(t *testing.T) {
+		wtPath := filepath.Join(tmpDir, testRepoName, "(detached)")
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "(detached)", Detached: true, Head: "0123456789abcdef"}},
+			runCommandCheckedOK: true,
+		}
+
+		require.NoError(t, DeleteWorktree(ctx, svc, cfg, wtPath, true, true))
+		assert.Equal(t, [][]string{{"git", "worktree", "remove", "--force", wtPath}}, svc.runCommandCheckedCalls)
+	})
+}
+
+func TestLockAndUnlockWorktree(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	cfg := &config.AppConfig{WorktreeDir: tmpDir}
+	wtPath := filepath.Join(tmpDir, testRepoName, "agent")
+
+	t.Run("locks with reason", func(t *testing.T) {
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "agent"}},
+			runCommandCheckedOK: true,
+		}
+
+		wt, err := LockWorktree(ctx, svc, cfg, "agent", "  reserved for agent  ", true)
+		require.NoError(t, err)
+		assert.Equal(t, wtPath, svc.lastLockPath)
+		assert.Equal(t, "reserved for agent", svc.lastLockReason)
+		assert.True(t, wt.Locked)
+		assert.Equal(t, "reserved for agent", wt.LockReason)
+	})
+
+	t.Run("refuses to lock twice", func(t *testing.T) {
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "agent", Locked: true}},
+			runCommandCheckedOK: true,
+		}
+
+		_, err := LockWorktree(ctx, svc, cfg, "agent", "", true)
+		require.ErrorContains(t, err, "worktree is locked")
+		assert.Empty(t, svc.lastLockPath)
+	})
+
+	t.Run("unlocks", func(t *testing.T) {
+		svc := &fakeGitService{
+			resolveRepoName:     testRepoName,
+			worktrees:           []*models.WorktreeInfo{{Path: wtPath, Branch: "agent", Locked: true, LockReason: "reserved"}},
+			runCommandCheckedOK: true,
+		}
+
+		wt, err := UnlockWorktree(ctx, svc, cfg, "agent", true)
+		require.NoError(t, err)
+		assert.Equal(t, wtPath, svc.lastUnlockPath)
+		assert.False(t, wt.Locked)
+		assert.Empty(t, wt.LockReason)
+	})
+
+	t.Run("unlock of unlocked worktree fails", func(t *testing.T) {
+		svc := &fakeGitService{
+			resolveRepoName: testRepoName,
+			worktrees:       []*models.WorktreeInfo{{Path: wtPath, Branch: "agent"}},
+		}
+
+		_, err := UnlockWorktree(ctx, svc, cfg, "agent", true)
+		require.ErrorContains(t, err, "worktree is not locked")
+	})
 }
 
 func TestNoteShow(t *testing.T) {
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type prGitService interface {
+	gitService
+	GetMainBranch(ctx context.Context) string
+	CommitSubjects(ctx context.Context, worktreePath, base string) []string
+	PushBranchForPR(ctx context.Context, worktreePath, branch string, env map[string]string) (string, bool, error)
+	CreatePR(ctx context.Context, worktreePath string, opts git.CreatePROptions) (*models.PRInfo, error)
+}
+
+// PRCreateOptions holds the user choices for CreatePR. Empty Base, Title, and
+// Body are prefilled from the main branch, the worktree note, and the commits.
+type PRCreateOptions struct {
+	Base      string
+	Title     string
+	Body      string
+	Draft     bool
+	NoPush    bool
+	Reviewers []string
+	Labels    []string
+}
+
+// CreatePR pushes the branch of a worktree when needed and opens a PR/MR for
+// it. An empty worktreePathOrName targets the worktree containing the current
+// directory. It returns the created PR/MR and the worktree path.
+func CreatePR(ctx context.Context, gitSvc prGitService, cfg *config.AppConfig, worktreePathOrName string, opts PRCreateOptions, silent bool) (*models.PRInfo, string, error) {
+	nc, err := resolveNoteContext(ctx, gitSvc, cfg, worktreePathOrName)
+	if err != nil {
+		return nil, "", err
+	}
+	wt := nc.worktree
+	if strings.TrimSpace(wt.Branch) == "" {
+		return nil, wt.Path, fmt.Errorf("cannot open a PR/MR from a detached worktree")
+	}
+
+	base := strings.TrimSpace(opts.Base)
+	if base == "" {
+		base = gitSvc.GetMainBranch(ctx)
+	}
+	if base == wt.Branch {
+		return nil, wt.Path, fmt.Errorf("branch %q cannot be its own base", base)
+	}
+
+	_, note, _ := nc.note()
+	title, body := appservices.DraftPR(note, wt.Branch, gitSvc.CommitSubjects(ctx, wt.Path, base))
+	if strings.TrimSpace(opts.Title) != "" {
+		title = strings.TrimSpace(opts.Title)
+	}
+	if opts.Body != "" {
+		body = opts.Body
+	}
+
+	head := wt.Branch
+	if !opts.NoPush {
+		if !silent {
+			fmt.Fprintf(os.Stderr, "Pushing %s...\n", wt.Branch)
+		}
+		head, _, err = gitSvc.PushBranchForPR(ctx, wt.Path, wt.Branch, nil)
+		if err != nil {
+			return nil, wt.Path, err
+		}
+	}
+
+	if !silent {
+		fmt.Fprintf(os.Stderr, "Creating PR/MR %s → %s...\n", wt.Branch, base)
+	}
+	pr, err := gitSvc.CreatePR(ctx, wt.Path, git.CreatePROptions{
+		Head:      head,
+		Base:      base,
+		Title:     title,
+		Body:      body,
+		Draft:     opts.Draft,
+		Reviewers: opts.Reviewers,
+		Labels:    opts.Labels,
+	})
+	if err != nil {
+		return nil, wt.Path, err
+	}
+	return pr, wt.Path, nil
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/git"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+type fakePRGitService struct {
+	*fakeGitService
+	commits    []string
+	pushHead   string
+	pushErr    error
+	pushCalled bool
+	created    *git.CreatePROptions
+}
+
+func (f *fakePRGitService) CommitSubjects(context.Context, string, string) []string {
+	return f.commits
+}
+
+func (f *fakePRGitService) PushBranchForPR(_ context.Context, _, branch string, _ map[string]string) (string, bool, error) {
+	f.pushCalled = true
+	if f.pushHead != "" {
+		return f.pushHead, true, f.pushErr
+	}
+	return branch, true, f.pushErr
+}
+
+func (f *fakePRGitService) CreatePR(_ context.Context, _ string, opts git.CreatePROptions) (*models.PRInfo, error) {
+	f.created = &opts
+	return &models.PRInfo{Number: 3, URL: "https://example.com/pull/3", Branch: opts.Head, BaseBranch: opts.Base, Title: opts.Title}, nil
+}
+
+func newFakePRGitService(t *testing.T) (*fakePRGitService, *config.AppConfig, string) {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	wtPath := filepath.Join(tmpDir, "my-feature")
+	require.NoError(t, os.MkdirAll(wtPath, 0o750))
+
+	svc := &fakePRGitService{fakeGitService: &fakeGitService{
+		resolveRepoName: testRepoName,
+		mainBranch:      "main",
+		worktrees: []*models.WorktreeInfo{
+			{Path: wtPath, Branch: "my-feature"},
+			{Path: filepath.Join(tmpDir, "main"), Branch: "main", IsMain: true},
+		},
+	}}
+	return svc, &config.AppConfig{WorktreeDir: tmpDir}, wtPath
+}
+
+func TestCreatePR(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("prefills from note and commits", func(t *testing.T) {
+		svc, cfg, wtPath := newFakePRGitService(t)
+		svc.commits = []string{"First", "Second"}
+		svc.pushHead = "alice:my-feature"
+
+		key := appservices.WorktreeNoteKey(testRepoName, cfg.WorktreeDir, "", wtPath)
+		notes := map[string]models.WorktreeNote{key: {Note: "Context", Description: "Add feature", UpdatedAt: 1}}
+		require.NoError(t, appservices.SaveWorktreeNotes(testRepoName, cfg.WorktreeDir, "", "", notes, nil))
+
+		pr, path, err := CreatePR(ctx, svc, cfg, "my-feature", PRCreateOptions{Draft: true, Labels: []string{"bug"}}, true)
+		require.NoError(t, err)
+		assert.Equal(t, 3, pr.Number)
+		assert.Equal(t, wtPath, path)
+		require.NotNil(t, svc.created)
+		assert.Equal(t, git.CreatePROptions{
+			Head:   "alice:my-feature",
+			Base:   "main",
+			Title:  "Add feature",
+			Body:   "Context\n\n## Commits\n\n- First\n- Second",
+			Draft:  true,
+			Labels: []string{"bug"},
+		}, *svc.created)
+	})
+
+	t.Run("explicit values and no push", func(t *testing.T) {
+		svc, cfg, _ := newFakePRGitService(t)
+
+		_, _, err := CreatePR(ctx, svc, cfg, "my-feature", PRCreateOptions{Base: "release", Title: "Title", Body: "Body", NoPush: true}, true)
+		require.NoError(t, err)
+		assert.False(t, svc.pushCalled)
+		assert.Equal(t, "my-feature", svc.created.Head)
+		assert.Equal(t, "release", svc.created.Base)
+		assert.Equal(t, "Title", svc.created.Title)
+		assert.Equal(t, "Body", svc.created.Body)
+	})
+
+	t.Run("push failure stops creation", func(t *testing.T) {
+		svc, cfg, _ := newFakePRGitService(t)
+		svc.pushErr = errors.New("push failed: rejected")
+
+		_, _, err := CreatePR(ctx, svc, cfg, "my-feature", PRCreateOptions{}, true)
+		require.Error(t, err)
+		assert.Nil(t, svc.created)
+	})
+
+	t.Run("rejects branch as its own base", func(t *testing.T) {
+		svc, cfg, _ := newFakePRGitService(t)
+
+		_, _, err := CreatePR(ctx, svc, cfg, "my-feature", PRCreateOptions{Base: "my-feature"}, true)
+		require.Error(t, err)
+		assert.Nil(t, svc.created)
+	})
+}
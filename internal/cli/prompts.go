@@ -25,5 +25,5 @@ func formatWorktreeForList(wt *models.WorktreeInfo) string {
 		}
 	}
 
-	return fmt.Sprintf("%s (%s%s)", wt.Branch, status, extraInfo)
+	return fmt.Sprintf("%s (%s%s)", wt.BranchLabel(), status, extraInfo)
 }
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// RepairSummary reports the worktrees reconnected by Repair, the stale
+// worktrees that could not be located, and the stored entries migrated to the
+// new paths.
+type RepairSummary struct {
+	Relocated []models.WorktreeRelocation
+	Missing   []string
+	Migrated  appservices.RelocationMigration
+}
+
+// Repair finds worktrees whose directories were moved outside of git, such as
+// after moving worktree_dir or renaming a parent folder, and reconnects them
+// with "git worktree repair". Directories of the repository worktree dir are
+// searched, along with any extra paths given. Notes, access history and cache
+// entries follow the worktrees to their new paths. With dryRun nothing is
+// changed and the relocations found are only reported.
+func Repair(ctx context.Context, gitSvc gitService, cfg *config.AppConfig, paths []string, dryRun, silent bool, stderr io.Writer) (RepairSummary, error) {
+	repoKey := gitSvc.ResolveRepoName(ctx)
+	mainPath := gitSvc.GetMainWorktreePath(ctx)
+
+	candidates, err := repairCandidates(resolveWorktreeBaseDir(cfg.WorktreeDir, mainPath, repoKey), paths)
+	if err != nil {
+		return RepairSummary{}, err
+	}
+
+	relocated, missing := gitSvc.FindRelocatedWorktrees(ctx, candidates)
+	summary := RepairSummary{Relocated: relocated, Missing: missing}
+
+	if !dryRun {
+		newPaths := make([]string, 0, len(relocated))
+		for _, relocation := range relocated {
+			newPaths = append(newPaths, relocation.NewPath)
+		}
+		if !gitSvc.RepairWorktrees(ctx, newPaths) {
+			return summary, fmt.Errorf("failed to repair worktrees")
+		}
+
+		env := appservices.BuildCommandEnv("", "", repoKey, mainPath)
+		migrated, err := appservices.MigrateRelocatedWorktrees(repoKey, cfg.WorktreeDir, cfg.WorktreeNotesPath, cfg.WorktreeNoteType, relocated, env)
+		summary.Migrated = migrated
+		if err != nil {
+			return summary, fmt.Errorf("worktrees repaired but migrating their data failed: %w", err)
+		}
+	}
+
+	if !silent {
+		fmt.Fprint(stderr, formatRepairSummary(summary, dryRun))
+	}
+	return summary, nil
+}
+
+// repairCandidates lists the directories a moved worktree may now live in:
+// the extra paths given, then the visible directories of repoDir.
+func repairCandidates(repoDir string, paths []string) ([]string, error) {
+	candidates := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		info, err := os.Stat(absPath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot repair %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("cannot repair %s: not a directory", path)
+		}
+		candidates = append(candidates, absPath)
+	}
+
+	entries, err := os.ReadDir(repoDir)
+	if err != nil {
+		return candidates, nil
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		candidates = append(candidates, filepath.Join(repoDir, entry.Name()))
+	}
+	return candidates, nil
+}
+
+func formatRepairSummary(summary RepairSummary, dryRun bool) string {
+	var b strings.Builder
+	verb := "Repaired"
+	if dryRun {
+		verb = "Would repair"
+	}
+	for _, relocation := range summary.Relocated {
+		fmt.Fprintf(&b, "%s %s -> %s\n", verb, relocation.OldPath, relocation.NewPath)
+	}
+	for _, path := range summary.Missing {
+		fmt.Fprintf(&b, "Missing %s: pass its new location, or remove it with 'git worktree prune'\n", path)
+	}
+	if len(summary.Relocated) == 0 {
+		b.WriteString("No moved worktrees found.\n")
+		return b.String()
+	}
+	if !dryRun {
+		migrated := summary.Migrated
+		fmt.Fprintf(&b, "Migrated %d %s, %d access history %s and %d cache %s\n",
+			migrated.Notes, pluralise(migrated.Notes, "note", "notes"),
+			migrated.AccessHistory, pluralise(migrated.AccessHistory, "entry", "entries"),
+			migrated.CacheEntries, pluralise(migrated.CacheEntries, "entry", "entries"))
+	}
+	return b.String()
+}
@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appservices "github.com/chmouel/lazyworktree/internal/app/services"
+	"github.com/chmouel/lazyworktree/internal/config"
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestRepairReconnectsMovedWorktrees(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	repoDir := filepath.Join(cfg.WorktreeDir, "repo")
+	newPath := filepath.Join(repoDir, "feature")
+	require.NoError(t, os.MkdirAll(newPath, 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, ".hidden"), 0o750))
+	extraPath := t.TempDir()
+
+	oldPath := "/old/worktrees/repo/feature"
+	require.NoError(t, appservices.SaveWorktreeNotes("repo", cfg.WorktreeDir, "", "", map[string]models.WorktreeNote{
+		oldPath: {Note: "follow me", UpdatedAt: 1},
+	}, nil))
+	require.NoError(t, appservices.SaveAccessHistory("repo", cfg.WorktreeDir, map[string]int64{oldPath: 42}))
+
+	svc := &fakeGitService{
+		resolveRepoName:     "repo",
+		mainWorktreePath:    "/main",
+		runCommandCheckedOK: true,
+		relocations:         []models.WorktreeRelocation{{OldPath: oldPath, NewPath: newPath}},
+		missingWorktrees:    []string{"/old/worktrees/repo/gone"},
+	}
+
+	var stderr bytes.Buffer
+	summary, err := Repair(context.Background(), svc, cfg, []string{extraPath}, false, false, &stderr)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{extraPath, newPath}, svc.relocationLookups)
+	assert.True(t, svc.repairCalled)
+	assert.Equal(t, []string{newPath}, svc.lastRepairPaths)
+	assert.Equal(t, appservices.RelocationMigration{Notes: 1, AccessHistory: 1}, summary.Migrated)
+	assert.Equal(t, []string{"/old/worktrees/repo/gone"}, summary.Missing)
+
+	output := stderr.String()
+	assert.Contains(t, output, "Repaired "+oldPath+" -> "+newPath)
+	assert.Contains(t, output, "Missing /old/worktrees/repo/gone")
+	assert.Contains(t, output, "Migrated 1 note, 1 access history entry and 0 cache entries")
+
+	notes, err := appservices.LoadWorktreeNotes("repo", cfg.WorktreeDir, "", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "follow me", notes[newPath].Note)
+}
+
+func TestRepairDryRunChangesNothing(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	svc := &fakeGitService{
+		resolveRepoName:     "repo",
+		runCommandCheckedOK: true,
+		relocations:         []models.WorktreeRelocation{{OldPath: "/old/feature", NewPath: "/new/feature"}},
+	}
+
+	var stderr bytes.Buffer
+	summary, err := Repair(context.Background(), svc, cfg, nil, true, false, &stderr)
+	require.NoError(t, err)
+
+	assert.False(t, svc.repairCalled)
+	assert.Len(t, summary.Relocated, 1)
+	assert.Contains(t, stderr.String(), "Would repair /old/feature -> /new/feature")
+	assert.NotContains(t, stderr.String(), "Migrated")
+}
+
+func TestRepairRejectsMissingPath(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	svc := &fakeGitService{resolveRepoName: "repo", runCommandCheckedOK: true}
+
+	_, err := Repair(context.Background(), svc, cfg, []string{filepath.Join(cfg.WorktreeDir, "nope")}, false, true, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot repair")
+	assert.False(t, svc.repairCalled)
+}
+
+func TestRepairReportsFailure(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	cfg.WorktreeDir = t.TempDir()
+	svc := &fakeGitService{resolveRepoName: "repo"}
+
+	var stderr bytes.Buffer
+	_, err := Repair(context.Background(), svc, cfg, nil, false, false, &stderr)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to repair worktrees")
+	assert.Empty(t, stderr.String())
+}
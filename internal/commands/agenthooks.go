@@ -28,13 +28,14 @@ type SetupAgentHooksOptions struct {
 	ClaudeSettingsPath string
 	CodexHooksPath     string
 	CopilotHooksPath   string
+	GeminiSettingsPath string
 	Stdout             io.Writer
 }
 
 // SetupAgentHooks installs lazyworktree agent-event hooks into the Claude
-// Code, Codex CLI, and Copilot CLI user-level hook configurations. Existing
-// settings are preserved; a timestamped backup is written before any
-// modification.
+// Code, Codex CLI, Copilot CLI, and Gemini CLI user-level hook
+// configurations. Existing settings are preserved; a timestamped backup is
+// written before any modification.
 func SetupAgentHooks(opts SetupAgentHooksOptions) error {
 	out := opts.Stdout
 	if out == nil {
@@ -43,7 +44,8 @@ func SetupAgentHooks(opts SetupAgentHooksOptions) error {
 	claudePath := opts.ClaudeSettingsPath
 	codexPath := opts.CodexHooksPath
 	copilotPath := opts.CopilotHooksPath
-	if claudePath == "" || codexPath == "" || copilotPath == "" {
+	geminiPath := opts.GeminiSettingsPath
+	if claudePath == "" || codexPath == "" || copilotPath == "" || geminiPath == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
 			return fmt.Errorf("resolve home directory: %w", err)
@@ -65,6 +67,9 @@ func SetupAgentHooks(opts SetupAgentHooksOptions) error {
 			}
 			copilotPath = filepath.Join(copilotHome, "hooks", "lazyworktree.json")
 		}
+		if geminiPath == "" {
+			geminiPath = filepath.Join(home, ".gemini", "settings.json")
+		}
 	}
 	shim := agentEventShimCommand()
 
@@ -92,9 +97,16 @@ func SetupAgentHooks(opts SetupAgentHooksOptions) error {
 		}); err != nil {
 		return err
 	}
+	if err := installHooksFile(out, opts.DryRun, "Gemini CLI", geminiPath,
+		[]string{"SessionStart", "BeforeAgent", "AfterAgent", "Notification", "SessionEnd"},
+		shim+" --agent gemini", false, nil); err != nil {
+		return err
+	}
 	fmt.Fprintln(out, "\nNotes:")
 	fmt.Fprintln(out, "- Codex CLI requires approving new hooks with the /hooks command inside a Codex session.")
 	fmt.Fprintln(out, "- Copilot CLI loads hook files at startup; restart any running copilot session.")
+	fmt.Fprintln(out, "- Gemini CLI reads settings at startup; restart any running gemini session.")
+	fmt.Fprintln(out, "- OpenCode and Aider have no command hooks; their sessions are read from disk.")
 	return nil
 }
 
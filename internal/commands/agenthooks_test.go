@@ -18,6 +18,7 @@ func setupHooksTestOpts(t *testing.T, dryRun bool) (SetupAgentHooksOptions, *byt
 		ClaudeSettingsPath: filepath.Join(dir, "claude", "settings.json"),
 		CodexHooksPath:     filepath.Join(dir, "codex", "hooks.json"),
 		CopilotHooksPath:   filepath.Join(dir, "copilot", "hooks", "lazyworktree.json"),
+		GeminiSettingsPath: filepath.Join(dir, "gemini", "settings.json"),
 		Stdout:             out,
 	}, out
 }
@@ -38,7 +39,8 @@ func readHooksFile(t *testing.T, path string) map[string]any {
 func hasMatcherHook(groups []any, matcher, agent string) bool {
 	for _, group := range groups {
 		groupMap, _ := group.(map[string]any)
-		if groupMap["matcher"] != matcher {
+		groupMatcher, _ := groupMap["matcher"].(string)
+		if groupMatcher != matcher {
 			continue
 		}
 		handlers, _ := groupMap["hooks"].([]any)
@@ -156,7 +158,7 @@ func TestSetupAgentHooksIsIdempotent(t *testing.T) {
 	if err := SetupAgentHooks(opts); err != nil {
 		t.Fatalf("first run failed: %v", err)
 	}
-	paths := []string{opts.ClaudeSettingsPath, opts.CodexHooksPath, opts.CopilotHooksPath}
+	paths := []string{opts.ClaudeSettingsPath, opts.CodexHooksPath, opts.CopilotHooksPath, opts.GeminiSettingsPath}
 	first := make([][]byte, len(paths))
 	for i, path := range paths {
 		first[i], _ = os.ReadFile(path) //nolint:gosec // Test-owned temporary path.
@@ -209,6 +211,32 @@ func TestSetupAgentHooksPreservesExistingSettings(t *testing.T) {
 	}
 }
 
+func TestSetupAgentHooksInstallsGeminiHooks(t *testing.T) {
+	opts, _ := setupHooksTestOpts(t, false)
+	if err := os.MkdirAll(filepath.Dir(opts.GeminiSettingsPath), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	existing := `{"theme":"GitHub","general":{"vimMode":true}}`
+	if err := os.WriteFile(opts.GeminiSettingsPath, []byte(existing), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetupAgentHooks(opts); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	root := readHooksFile(t, opts.GeminiSettingsPath)
+	if root["theme"] != "GitHub" {
+		t.Fatal("existing gemini settings lost")
+	}
+	hooks, _ := root["hooks"].(map[string]any)
+	for _, event := range []string{"SessionStart", "BeforeAgent", "AfterAgent", "Notification", "SessionEnd"} {
+		groups, _ := hooks[event].([]any)
+		if !hasMatcherHook(groups, "", "gemini") {
+			t.Fatalf("gemini hooks missing %s", event)
+		}
+	}
+}
+
 func TestSetupAgentHooksDryRunWritesNothing(t *testing.T) {
 	opts, out := setupHooksTestOpts(t, true)
 	if err := SetupAgentHooks(opts); err != nil {
@@ -223,6 +251,9 @@ func TestSetupAgentHooksDryRunWritesNothing(t *testing.T) {
 	if _, err := os.Stat(opts.CopilotHooksPath); !os.IsNotExist(err) {
 		t.Fatal("dry run must not write copilot hooks")
 	}
+	if _, err := os.Stat(opts.GeminiSettingsPath); !os.IsNotExist(err) {
+		t.Fatal("dry run must not write gemini settings")
+	}
 	if !strings.Contains(out.String(), "would update") {
 		t.Fatalf("expected dry-run preview, got: %s", out.String())
 	}
@@ -235,6 +266,7 @@ func TestSetupAgentHooksHonoursCodexHome(t *testing.T) {
 	t.Setenv("COPILOT_HOME", filepath.Join(root, "copilot"))
 	opts := SetupAgentHooksOptions{
 		ClaudeSettingsPath: filepath.Join(root, "claude", "settings.json"),
+		GeminiSettingsPath: filepath.Join(root, "gemini", "settings.json"),
 		Stdout:             &bytes.Buffer{},
 	}
 	if err := SetupAgentHooks(opts); err != nil {
@@ -252,6 +284,7 @@ func TestSetupAgentHooksHonoursCopilotHome(t *testing.T) {
 	t.Setenv("CODEX_HOME", filepath.Join(root, "codex"))
 	opts := SetupAgentHooksOptions{
 		ClaudeSettingsPath: filepath.Join(root, "claude", "settings.json"),
+		GeminiSettingsPath: filepath.Join(root, "gemini", "settings.json"),
 		Stdout:             &bytes.Buffer{},
 	}
 	if err := SetupAgentHooks(opts); err != nil {
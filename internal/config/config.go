@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -26,62 +27,85 @@ type CommitConfig struct {
 	AutoGenerateCommand string `yaml:"auto_generate_command"`
 }
 
+// AgentModelPrice is the price of a model in US dollars per million tokens.
+type AgentModelPrice struct {
+	Input      float64
+	Output     float64
+	CacheWrite float64
+	CacheRead  float64
+}
+
+// AgentPriceTable maps a model name prefix to its price.
+type AgentPriceTable map[string]AgentModelPrice
+
 // AppConfig defines the global lazyworktree configuration options.
 type AppConfig struct {
-	WorktreeDir             string
-	InitCommands            []string
-	TerminateCommands       []string
-	SortMode                string // Sort mode: "path", "active" (commit date), "switched" (last accessed)
-	AutoFetchPRs            bool
-	DisablePR               bool // Disable all PR/MR fetching and display
-	SearchAutoSelect        bool // Start with filter focused and select first match on Enter.
-	MaxUntrackedDiffs       int
-	MaxDiffChars            int
-	MaxNameLength           int // Maximum length for worktree names in table display (0 disables truncation)
-	GitPagerArgs            []string
-	GitPagerArgsSet         bool `yaml:"-"`
-	GitPager                string
-	GitPagerInteractive     bool // Interactive tools need terminal control, skip piping to less
-	GitPagerCommandMode     bool // Command-mode tools run their own git commands (e.g. lumen diff)
-	TrustMode               string
-	DebugLog                string
-	Pager                   string
-	CIScriptPager           string // Pager for CI check logs, implicitly interactive
-	CIRemote                string // Preferred remote for CI/PR queries (GitHub only): "" (auto: prefer upstream), or a remote name (e.g. "upstream", "origin"); does not change repository identity
-	Editor                  string
-	AutoRefresh             bool
-	CIAutoRefresh           bool // Periodically refresh CI status (GitHub only, uses API rate limits)
-	RefreshIntervalSeconds  int
-	CustomCommands          CustomCommandsConfig
-	Keybindings             KeybindingsConfig
-	BranchNameScript        string // Script to generate branch name suggestions from diff
-	WorktreeNoteScript      string // Script to generate worktree notes from PR/issue content
-	WorktreeNotesPath       string // Optional path to a single shared JSON file for worktree notes
-	WorktreeNoteType        string // Note storage type: "onejson" (default) or "splitted"
-	Theme                   string // Theme name: see AvailableThemes in internal/theme
-	MergeMethod             string // Merge method for absorb: "rebase" or "merge" (default: "rebase")
-	FuzzyFinderInput        bool   // Enable fuzzy finder for input suggestions (default: false)
-	IconSet                 string // Icon set: "nerd-font-v3", "text" (default: "nerd-font-v3"). Legacy "emoji" and "none" map to "text".
-	AvatarBadges            string // PR/MR author avatar badges: "auto", "never", or "always" (default: "auto").
-	IssueBranchNameTemplate string // Template for issue branch names with placeholders: {number}, {title} (default: "issue-{number}-{title}")
-	PRBranchNameTemplate    string // Template for PR branch names with placeholders: {number}, {title}, {generated}, {pr_author} (default: "pr-{number}-{title}")
-	SessionPrefix           string // Prefix for tmux/zellij session names (default: "wt-")
-	Layout                  string // Pane arrangement: "default" or "top" (default: "default")
-	PruneStaleBranches      bool   // Include merged branches without worktrees in prune (default: false)
-	PaletteMRU              bool   // Enable MRU sorting for command palette (default: false)
-	PaletteMRULimit         int    // Number of MRU items to show (default: 5)
-	AgentSessionClaudeRoot  string // Custom root for Claude transcript discovery (default: ~/.claude/projects)
-	AgentSessionPiRoot      string // Custom root for pi transcript discovery (default: ~/.pi/agent/sessions)
-	AgentSessionsDisabled   bool   // Disable the Agent Sessions pane and transcript watching (default: false)
-	AgentProcessScan        bool   // Deprecated: opt in to ps/lsof process scanning for agent liveness (default: false; prefer setup-hooks)
-	AgentRefreshDebounceMs  int    // Debounce window (ms) for agent transcript refreshes (default: 600; 0 disables throttling)
-	CustomCreateMenus       []*CustomCreateMenu
-	CustomThemes            map[string]*CustomTheme // User-defined custom themes
-	LayoutSizes             *LayoutSizes            // Configurable pane size weights (nil = use defaults)
-	ConfigPath              string                  `yaml:"-"` // Path to the configuration file
-	DeprecationWarnings     []string                `yaml:"-"` // Warnings about deprecated config keys detected at load time
-	Commit                  CommitConfig            `yaml:"commit"`
-	UpdateOnExisting        bool                    `yaml:"-" json:"-"`
+	WorktreeDir              string
+	InitCommands             []string
+	TerminateCommands        []string
+	SortMode                 string // Sort mode: "path", "active" (commit date), "switched" (last accessed)
+	AutoFetchPRs             bool
+	DisablePR                bool // Disable all PR/MR fetching and display
+	SearchAutoSelect         bool // Start with filter focused and select first match on Enter.
+	MaxUntrackedDiffs        int
+	MaxDiffChars             int
+	MaxNameLength            int // Maximum length for worktree names in table display (0 disables truncation)
+	GitPagerArgs             []string
+	GitPagerArgsSet          bool `yaml:"-"`
+	GitPager                 string
+	GitPagerInteractive      bool // Interactive tools need terminal control, skip piping to less
+	GitPagerCommandMode      bool // Command-mode tools run their own git commands (e.g. lumen diff)
+	TrustMode                string
+	DebugLog                 string
+	Pager                    string
+	CIScriptPager            string // Pager for CI check logs, implicitly interactive
+	CIRemote                 string // Preferred remote for CI/PR queries (GitHub only): "" (auto: prefer upstream), or a remote name (e.g. "upstream", "origin"); does not change repository identity
+	ForgeClient              string // How forge data is fetched: "cli" (gh/glab) or "http" (in-process API client) (default: "cli")
+	Editor                   string
+	AutoRefresh              bool
+	CIAutoRefresh            bool // Periodically refresh CI status (GitHub only, uses API rate limits)
+	RefreshIntervalSeconds   int
+	CustomCommands           CustomCommandsConfig
+	Keybindings              KeybindingsConfig
+	BranchNameScript         string // Script to generate branch name suggestions from diff
+	WorktreeNoteScript       string // Script to generate worktree notes from PR/issue content
+	WorktreeNotesPath        string // Optional path to a single shared JSON file for worktree notes
+	WorktreeNoteType         string // Note storage type: "onejson" (default) or "splitted"
+	Theme                    string // Theme name: see AvailableThemes in internal/theme
+	MergeMethod              string // Merge method for absorb: "rebase" or "merge" (default: "rebase")
+	FuzzyFinderInput         bool   // Enable fuzzy finder for input suggestions (default: false)
+	IconSet                  string // Icon set: "nerd-font-v3", "text" (default: "nerd-font-v3"). Legacy "emoji" and "none" map to "text".
+	AvatarBadges             string // PR/MR author avatar badges: "auto", "never", or "always" (default: "auto").
+	IssueBranchNameTemplate  string // Template for issue branch names with placeholders: {number}, {title} (default: "issue-{number}-{title}")
+	PRBranchNameTemplate     string // Template for PR branch names with placeholders: {number}, {title}, {generated}, {pr_author} (default: "pr-{number}-{title}")
+	SessionPrefix            string // Prefix for tmux/zellij session names (default: "wt-")
+	Layout                   string // Pane arrangement: "default" or "top" (default: "default")
+	PruneStaleBranches       bool   // Include merged branches without worktrees in prune (default: false)
+	PaletteMRU               bool   // Enable MRU sorting for command palette (default: false)
+	PaletteMRULimit          int    // Number of MRU items to show (default: 5)
+	AgentSessionClaudeRoot   string // Custom root for Claude transcript discovery (default: ~/.claude/projects)
+	AgentSessionPiRoot       string // Custom root for pi transcript discovery (default: ~/.pi/agent/sessions)
+	AgentSessionCodexRoot    string // Custom root for Codex rollout discovery (default: $CODEX_HOME/sessions)
+	AgentSessionGeminiRoot   string // Custom root for Gemini CLI chat discovery (default: ~/.gemini/tmp)
+	AgentSessionOpenCodeRoot string // Custom root for OpenCode session discovery (default: $XDG_DATA_HOME/opencode/storage)
+	AgentSessionsDisabled    bool   // Disable the Agent Sessions pane and transcript watching (default: false)
+	AgentProcessScan         bool   // Deprecated: opt in to ps/lsof process scanning for agent liveness (default: false; prefer setup-hooks)
+	AgentRefreshDebounceMs   int    // Debounce window (ms) for agent transcript refreshes (default: 600; 0 disables throttling)
+	GiteaToken               string // API token for Gitea/Forgejo hosts (falls back to the tea login file)
+	CustomCreateMenus        []*CustomCreateMenu
+	GiteaHosts               []string                // Extra Gitea/Forgejo hosts or base URLs (codeberg.org and tea logins are always recognised)
+	CustomThemes             map[string]*CustomTheme // User-defined custom themes
+	AgentLaunchCommands      map[string]string       // Per-agent commands for the start agent action, keyed by agent name
+	AgentPrices              AgentPriceTable         // Per-model token prices, keyed by model name prefix, merged over the built-in table
+	AgentNotifyChannels      []string                // Notification channels: "osc9", "osc777", "desktop", "tmux", "command" (default: none)
+	AgentNotifyEvents        []string                // Session transitions that notify: "waiting", "approval", "idle" (default: all)
+	AgentNotifyCommand       string                  // Shell command run by the "command" notification channel
+	AgentNotifyRateLimit     int                     // Minimum seconds between notifications for one agent in one worktree (default: 60)
+	LayoutSizes              *LayoutSizes            // Configurable pane size weights (nil = use defaults)
+	ConfigPath               string                  `yaml:"-"` // Path to the configuration file
+	DeprecationWarnings      []string                `yaml:"-"` // Warnings about deprecated config keys detected at load time
+	Commit                   CommitConfig            `yaml:"commit"`
+	UpdateOnExisting         bool                    `yaml:"-" json:"-"`
 }
 
 // RepoConfig represents repository-scoped commands from .wt
@@ -108,6 +132,7 @@ func DefaultConfig() *AppConfig {
 		TrustMode:               "tofu",
 		Theme:                   "",
 		MergeMethod:             "rebase",
+		ForgeClient:             "cli",
 		IssueBranchNameTemplate: "issue-{number}-{title}",
 		PRBranchNameTemplate:    "pr-{number}-{title}",
 		SessionPrefix:           "wt-",
@@ -117,6 +142,8 @@ func DefaultConfig() *AppConfig {
 		IconSet:                 "nerd-font-v3",
 		AvatarBadges:            "auto",
 		AgentRefreshDebounceMs:  600,
+		AgentNotifyEvents:       []string{"waiting", "approval", "idle"},
+		AgentNotifyRateLimit:    60,
 		CustomThemes:            make(map[string]*CustomTheme),
 		Keybindings:             make(KeybindingsConfig),
 		CustomCommands: CustomCommandsConfig{
@@ -185,6 +212,12 @@ func parseConfig(data map[string]any) (*AppConfig, error) {
 		}
 		cfg.CIRemote = ciRemote
 	}
+	if forgeClient, ok := data["forge_client"].(string); ok {
+		forgeClient = strings.ToLower(strings.TrimSpace(forgeClient))
+		if forgeClient == "cli" || forgeClient == "http" {
+			cfg.ForgeClient = forgeClient
+		}
+	}
 	if editor, ok := data["editor"].(string); ok {
 		editor = strings.TrimSpace(editor)
 		if editor != "" {
@@ -224,11 +257,85 @@ func parseConfig(data map[string]any) (*AppConfig, error) {
 				}
 			}
 		}
+		if codexRoot, ok := agentData["codex_root"].(string); ok {
+			codexRoot = strings.TrimSpace(codexRoot)
+			if codexRoot != "" {
+				expanded, err := utils.ExpandPath(codexRoot)
+				if err == nil {
+					cfg.AgentSessionCodexRoot = expanded
+				}
+			}
+		}
+		if geminiRoot, ok := agentData["gemini_root"].(string); ok {
+			geminiRoot = strings.TrimSpace(geminiRoot)
+			if geminiRoot != "" {
+				expanded, err := utils.ExpandPath(geminiRoot)
+				if err == nil {
+					cfg.AgentSessionGeminiRoot = expanded
+				}
+			}
+		}
+		if openCodeRoot, ok := agentData["opencode_root"].(string); ok {
+			openCodeRoot = strings.TrimSpace(openCodeRoot)
+			if openCodeRoot != "" {
+				expanded, err := utils.ExpandPath(openCodeRoot)
+				if err == nil {
+					cfg.AgentSessionOpenCodeRoot = expanded
+				}
+			}
+		}
+		if launchData, ok := agentData["launch_commands"].(map[string]any); ok {
+			cfg.AgentLaunchCommands = make(map[string]string, len(launchData))
+			for agent, value := range launchData {
+				command, ok := value.(string)
+				agent = strings.ToLower(strings.TrimSpace(agent))
+				if ok && agent != "" && strings.TrimSpace(command) != "" {
+					cfg.AgentLaunchCommands[agent] = strings.TrimSpace(command)
+				}
+			}
+		}
+		if pricesData, ok := agentData["prices"].(map[string]any); ok {
+			cfg.AgentPrices = make(AgentPriceTable, len(pricesData))
+			for model, value := range pricesData {
+				priceData, ok := value.(map[string]any)
+				model = strings.ToLower(strings.TrimSpace(model))
+				if !ok || model == "" {
+					continue
+				}
+				cfg.AgentPrices[model] = AgentModelPrice{
+					Input:      max(0, coerceFloat(priceData["input"], 0)),
+					Output:     max(0, coerceFloat(priceData["output"], 0)),
+					CacheWrite: max(0, coerceFloat(priceData["cache_write"], 0)),
+					CacheRead:  max(0, coerceFloat(priceData["cache_read"], 0)),
+				}
+			}
+		}
+		if notifyData, ok := agentData["notifications"].(map[string]any); ok {
+			if _, ok := notifyData["channels"]; ok {
+				cfg.AgentNotifyChannels = normalizeChoiceList(notifyData["channels"], "osc9", "osc777", "desktop", "tmux", "command")
+			}
+			if _, ok := notifyData["events"]; ok {
+				cfg.AgentNotifyEvents = normalizeChoiceList(notifyData["events"], "waiting", "approval", "idle")
+			}
+			if command, ok := notifyData["command"].(string); ok {
+				cfg.AgentNotifyCommand = strings.TrimSpace(command)
+			}
+			cfg.AgentNotifyRateLimit = max(0, coerceInt(notifyData["rate_limit_seconds"], cfg.AgentNotifyRateLimit))
+		}
 		cfg.AgentSessionsDisabled = coerceBool(agentData["disabled"], cfg.AgentSessionsDisabled)
 		cfg.AgentProcessScan = coerceBool(agentData["process_scan"], cfg.AgentProcessScan)
 		cfg.AgentRefreshDebounceMs = coerceInt(agentData["refresh_debounce_ms"], cfg.AgentRefreshDebounceMs)
 	}
 
+	if giteaData, ok := data["gitea"].(map[string]any); ok {
+		if token, ok := giteaData["token"].(string); ok {
+			cfg.GiteaToken = strings.TrimSpace(token)
+		}
+		if _, ok := giteaData["hosts"]; ok {
+			cfg.GiteaHosts = normalizeCommandList(giteaData["hosts"])
+		}
+	}
+
 	cfg.InitCommands = normalizeCommandList(data["init_commands"])
 	cfg.TerminateCommands = normalizeCommandList(data["terminate_commands"])
 
@@ -477,6 +584,19 @@ func normalizeCommandList(val any) []string {
 	return res
 }
 
+// normalizeChoiceList parses a string or list of strings, keeping the
+// lowercased values found in allowed.
+func normalizeChoiceList(val any, allowed ...string) []string {
+	res := []string{}
+	for _, item := range normalizeCommandList(val) {
+		item = strings.ToLower(item)
+		if slices.Contains(allowed, item) && !slices.Contains(res, item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
 func normalizeArgsList(val any) []string {
 	if s, ok := val.(string); ok {
 		s = strings.TrimSpace(s)
@@ -587,6 +707,9 @@ func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if _, ok := overrideData["ci_remote"]; ok {
 		cfg.CIRemote = overrideCfg.CIRemote
 	}
+	if _, ok := overrideData["forge_client"]; ok {
+		cfg.ForgeClient = overrideCfg.ForgeClient
+	}
 	if _, ok := overrideData["editor"]; ok {
 		cfg.Editor = overrideCfg.Editor
 	}
@@ -629,6 +752,27 @@ func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if overrideNestedData(overrideData, "agent_sessions", "pi_root") {
 		cfg.AgentSessionPiRoot = overrideCfg.AgentSessionPiRoot
 	}
+	if overrideNestedData(overrideData, "agent_sessions", "codex_root") {
+		cfg.AgentSessionCodexRoot = overrideCfg.AgentSessionCodexRoot
+	}
+	if overrideNestedData(overrideData, "agent_sessions", "gemini_root") {
+		cfg.AgentSessionGeminiRoot = overrideCfg.AgentSessionGeminiRoot
+	}
+	if overrideNestedData(overrideData, "agent_sessions", "opencode_root") {
+		cfg.AgentSessionOpenCodeRoot = overrideCfg.AgentSessionOpenCodeRoot
+	}
+	if overrideNestedData(overrideData, "agent_sessions", "launch_commands") {
+		cfg.AgentLaunchCommands = overrideCfg.AgentLaunchCommands
+	}
+	if overrideNestedData(overrideData, "agent_sessions", "prices") {
+		cfg.AgentPrices = overrideCfg.AgentPrices
+	}
+	if overrideNestedData(overrideData, "agent_sessions", "notifications") {
+		cfg.AgentNotifyChannels = overrideCfg.AgentNotifyChannels
+		cfg.AgentNotifyEvents = overrideCfg.AgentNotifyEvents
+		cfg.AgentNotifyCommand = overrideCfg.AgentNotifyCommand
+		cfg.AgentNotifyRateLimit = overrideCfg.AgentNotifyRateLimit
+	}
 	if overrideNestedData(overrideData, "agent_sessions", "disabled") {
 		cfg.AgentSessionsDisabled = overrideCfg.AgentSessionsDisabled
 	}
@@ -638,6 +782,12 @@ func (cfg *AppConfig) ApplyCLIOverrides(overrides []string) error {
 	if overrideNestedData(overrideData, "agent_sessions", "refresh_debounce_ms") {
 		cfg.AgentRefreshDebounceMs = overrideCfg.AgentRefreshDebounceMs
 	}
+	if overrideNestedData(overrideData, "gitea", "token") {
+		cfg.GiteaToken = overrideCfg.GiteaToken
+	}
+	if overrideNestedData(overrideData, "gitea", "hosts") {
+		cfg.GiteaHosts = overrideCfg.GiteaHosts
+	}
 
 	// Arrays - check if they exist in override data
 	if _, ok := overrideData["init_commands"]; ok {
@@ -985,6 +1135,20 @@ func coerceInt(v any, def int) int {
 	return def
 }
 
+func coerceFloat(v any, def float64) float64 {
+	switch n := v.(type) {
+	case int:
+		return float64(n)
+	case float64:
+		return n
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
+
 func getString(data map[string]any, key string) string {
 	if v, ok := data[key]; ok && v != nil {
 		return strings.TrimSpace(fmt.Sprint(v))
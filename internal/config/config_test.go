@@ -139,6 +139,27 @@ func TestParseConfigCIRemote(t *testing.T) {
 	}
 }
 
+func TestParseConfigForgeClient(t *testing.T) {
+	tests := []struct {
+		name  string
+		input map[string]any
+		want  string
+	}{
+		{name: "unset defaults to cli", input: map[string]any{}, want: "cli"},
+		{name: "http", input: map[string]any{"forge_client": "http"}, want: "http"},
+		{name: "case and whitespace", input: map[string]any{"forge_client": " HTTP "}, want: "http"},
+		{name: "invalid keeps default", input: map[string]any{"forge_client": "grpc"}, want: "cli"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := parseConfig(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.ForgeClient)
+		})
+	}
+}
+
 func TestNormalizeCommandList(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1268,16 +1289,74 @@ func TestParseConfig(t *testing.T) {
 			},
 		},
 		{
-			name: "agent_sessions claude_root and pi_root parsed",
+			name: "agent_sessions transcript roots parsed",
 			data: map[string]interface{}{
 				"agent_sessions": map[string]any{
-					"claude_root": "/custom/claude",
-					"pi_root":     "/custom/pi",
+					"claude_root":   "/custom/claude",
+					"pi_root":       "/custom/pi",
+					"codex_root":    "/custom/codex",
+					"gemini_root":   "/custom/gemini",
+					"opencode_root": "/custom/opencode",
 				},
 			},
 			validate: func(t *testing.T, cfg *AppConfig) {
 				assert.Equal(t, "/custom/claude", cfg.AgentSessionClaudeRoot)
 				assert.Equal(t, "/custom/pi", cfg.AgentSessionPiRoot)
+				assert.Equal(t, "/custom/codex", cfg.AgentSessionCodexRoot)
+				assert.Equal(t, "/custom/gemini", cfg.AgentSessionGeminiRoot)
+				assert.Equal(t, "/custom/opencode", cfg.AgentSessionOpenCodeRoot)
+			},
+		},
+		{
+			name: "agent_sessions launch commands parsed",
+			data: map[string]interface{}{
+				"agent_sessions": map[string]any{
+					"launch_commands": map[string]any{
+						" Claude ": "claude --permission-mode acceptEdits",
+						"codex":    "  ",
+						"pi":       42,
+					},
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, map[string]string{"claude": "claude --permission-mode acceptEdits"}, cfg.AgentLaunchCommands)
+			},
+		},
+		{
+			name: "agent_sessions prices parsed",
+			data: map[string]interface{}{
+				"agent_sessions": map[string]any{
+					"prices": map[string]any{
+						" Claude-Sonnet-4 ": map[string]any{"input": 3, "output": "15", "cache_write": 3.75, "cache_read": 0.3},
+						"gpt-5":             map[string]any{"input": -1, "output": 10},
+						"broken":            "cheap",
+					},
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, AgentPriceTable{
+					"claude-sonnet-4": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+					"gpt-5":           {Output: 10},
+				}, cfg.AgentPrices)
+			},
+		},
+		{
+			name: "agent_sessions notifications parsed",
+			data: map[string]interface{}{
+				"agent_sessions": map[string]any{
+					"notifications": map[string]any{
+						"channels":           []any{"OSC9", "tmux", "pager", "tmux"},
+						"events":             "approval",
+						"command":            "  notify-me  ",
+						"rate_limit_seconds": -5,
+					},
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, []string{"osc9", "tmux"}, cfg.AgentNotifyChannels)
+				assert.Equal(t, []string{"approval"}, cfg.AgentNotifyEvents)
+				assert.Equal(t, "notify-me", cfg.AgentNotifyCommand)
+				assert.Equal(t, 0, cfg.AgentNotifyRateLimit)
 			},
 		},
 		{
@@ -1318,6 +1397,31 @@ func TestParseConfig(t *testing.T) {
 				assert.Empty(t, cfg.AgentSessionPiRoot)
 			},
 		},
+		{
+			name: "gitea token and hosts parsed",
+			data: map[string]interface{}{
+				"gitea": map[string]any{
+					"token": "  abc  ",
+					"hosts": []any{"git.example.com", "https://forge.internal/gitea"},
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Equal(t, "abc", cfg.GiteaToken)
+				assert.Equal(t, []string{"git.example.com", "https://forge.internal/gitea"}, cfg.GiteaHosts)
+			},
+		},
+		{
+			name: "gitea single host string parsed",
+			data: map[string]interface{}{
+				"gitea": map[string]any{
+					"hosts": "git.example.com",
+				},
+			},
+			validate: func(t *testing.T, cfg *AppConfig) {
+				assert.Empty(t, cfg.GiteaToken)
+				assert.Equal(t, []string{"git.example.com"}, cfg.GiteaHosts)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,207 @@
+package git
+
+import (
+	"cmp"
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// maxCIFailures caps the failures kept per check so summaries stay short.
+const maxCIFailures = 20
+
+var (
+	ciLogTimestamp = regexp.MustCompile(`^\d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(\.\d+)?Z ?`)
+
+	// go test
+	goTestRun      = regexp.MustCompile(`^\s*=== (?:RUN|CONT)\s+(\S+)`)
+	goTestFail     = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+	goTestLocation = regexp.MustCompile(`^\s+(\S+\.go):(\d+): (.*)$`)
+	goBuildError   = regexp.MustCompile(`^(\S+\.go):(\d+):(?:\d+:)? (.+)$`)
+
+	// pytest
+	pytestHeader   = regexp.MustCompile(`^_{3,} (\S.*?) _{3,}$`)
+	pytestLocation = regexp.MustCompile(`^(\S+\.py):(\d+): (.*)$`)
+	pytestFailed   = regexp.MustCompile(`^(FAILED|ERROR) (\S+?\.py)(?:::(\S+))?(?: - (.*))?$`)
+
+	// jest
+	jestFailFile = regexp.MustCompile(`^\s*FAIL (\S+)`)
+	jestTest     = regexp.MustCompile(`^\s*● (.+)$`)
+	jestLocation = regexp.MustCompile(`\(?([^\s()]+\.[cm]?[jt]sx?):(\d+):\d+\)?$`)
+)
+
+// ParseCIFailures extracts failing tests and build errors with their file
+// and line from a CI job log. It understands go test, pytest and jest output.
+func ParseCIFailures(content string) []models.CIFailure {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = ciLogTimestamp.ReplaceAllString(ansi.Strip(line), "")
+	}
+
+	var failures []models.CIFailure
+	failures = append(failures, parseGoTestFailures(lines)...)
+	failures = append(failures, parsePytestFailures(lines)...)
+	failures = append(failures, parseJestFailures(lines)...)
+	return failures
+}
+
+func parseGoTestFailures(lines []string) []models.CIFailure {
+	var (
+		failed    []string
+		locations = make(map[string]models.CIFailure)
+		current   string
+		builds    []models.CIFailure
+	)
+	for _, line := range lines {
+		if m := goTestRun.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			continue
+		}
+		if m := goTestFail.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			failed = append(failed, current)
+			continue
+		}
+		if m := goTestLocation.FindStringSubmatch(line); m != nil && current != "" {
+			if _, ok := locations[current]; !ok {
+				lineNo, _ := strconv.Atoi(m[2])
+				locations[current] = models.CIFailure{File: m[1], Line: lineNo, Message: m[3]}
+			}
+			continue
+		}
+		if m := goBuildError.FindStringSubmatch(line); m != nil {
+			lineNo, _ := strconv.Atoi(m[2])
+			builds = append(builds, models.CIFailure{File: m[1], Line: lineNo, Message: m[3]})
+		}
+	}
+
+	var failures []models.CIFailure
+	for _, test := range failed {
+		// A failing subtest also fails its parent: keep the subtest only.
+		if hasFailedSubtest(failed, test) {
+			continue
+		}
+		failure := locations[test]
+		failure.Test = test
+		failures = append(failures, failure)
+	}
+	return append(failures, builds...)
+}
+
+func hasFailedSubtest(failed []string, test string) bool {
+	for _, other := range failed {
+		if strings.HasPrefix(other, test+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePytestFailures(lines []string) []models.CIFailure {
+	locations := make(map[string]models.CIFailure)
+	var section string
+	var failures []models.CIFailure
+	for _, line := range lines {
+		line = strings.TrimRight(line, " ")
+		if m := pytestHeader.FindStringSubmatch(line); m != nil {
+			section = m[1]
+			continue
+		}
+		if m := pytestLocation.FindStringSubmatch(line); m != nil && section != "" {
+			// The last location of a traceback is where the assertion failed.
+			lineNo, _ := strconv.Atoi(m[2])
+			locations[section] = models.CIFailure{File: m[1], Line: lineNo, Message: m[3]}
+			continue
+		}
+		if m := pytestFailed.FindStringSubmatch(line); m != nil {
+			failure := models.CIFailure{File: m[2], Test: m[3], Message: m[4]}
+			// Section headers name the test as Class.test_name.
+			key := strings.ReplaceAll(failure.Test, "::", ".")
+			if location, ok := locations[key]; ok && location.File == failure.File {
+				failure.Line = location.Line
+				failure.Message = cmp.Or(failure.Message, location.Message)
+			}
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+func parseJestFailures(lines []string) []models.CIFailure {
+	var (
+		failures []models.CIFailure
+		file     string
+		current  = -1
+	)
+	for _, line := range lines {
+		if m := jestFailFile.FindStringSubmatch(line); m != nil {
+			file, current = m[1], -1
+			continue
+		}
+		if file == "" {
+			continue
+		}
+		if m := jestTest.FindStringSubmatch(line); m != nil {
+			failures = append(failures, models.CIFailure{File: file, Test: strings.TrimSpace(m[1])})
+			current = len(failures) - 1
+			continue
+		}
+		if current < 0 {
+			continue
+		}
+		failure := &failures[current]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+		case strings.HasPrefix(trimmed, "at "):
+			m := jestLocation.FindStringSubmatch(trimmed)
+			if m != nil && failure.Line == 0 && !strings.Contains(m[1], "node_modules") {
+				failure.File = strings.TrimPrefix(m[1], "/")
+				failure.Line, _ = strconv.Atoi(m[2])
+			}
+		case failure.Message == "":
+			failure.Message = trimmed
+		}
+	}
+	return failures
+}
+
+// FetchCIFailures summarises why a finished CI check failed, combining the
+// failures reported by the forge with those parsed from the job log.
+func (s *Service) FetchCIFailures(ctx context.Context, check *models.CICheck, worktreePath string) ([]models.CIFailure, error) {
+	ciLog, err := s.FetchCICheckLog(ctx, check, worktreePath, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var failures []models.CIFailure
+	seen := make(map[string]bool)
+	for _, failure := range append(ciLog.Failures, ParseCIFailures(ciLog.Content)...) {
+		// Annotations and parsed output often point at the same line.
+		key := failure.File + ":" + strconv.Itoa(failure.Line)
+		if failure.File == "" {
+			key = cmp.Or(failure.Test, failure.Message)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		failure.Check = check.Name
+		failure.Message = truncateCIFailureMessage(failure.Message)
+		failures = append(failures, failure)
+		if len(failures) == maxCIFailures {
+			break
+		}
+	}
+	return failures, nil
+}
+
+func truncateCIFailureMessage(message string) string {
+	message, _, _ = strings.Cut(strings.TrimSpace(message), "\n")
+	return ansi.Truncate(message, 200, "…")
+}
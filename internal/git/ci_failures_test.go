@@ -0,0 +1,110 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestParseCIFailuresGoTest(t *testing.T) {
+	log := "2024-05-01T10:00:00.0000000Z === RUN   TestParse\n" +
+		"2024-05-01T10:00:00.0000000Z === RUN   TestParse/empty\n" +
+		"2024-05-01T10:00:00.0000000Z     parse_test.go:42: expected 1, got 2\n" +
+		"2024-05-01T10:00:00.0000000Z --- FAIL: TestParse (0.00s)\n" +
+		"2024-05-01T10:00:00.0000000Z     --- FAIL: TestParse/empty (0.00s)\n" +
+		"--- FAIL: TestOther (0.01s)\n" +
+		"    other_test.go:7: \x1b[31mboom\x1b[0m\n" +
+		"FAIL\n" +
+		"FAIL\tgithub.com/o/r/pkg\t0.02s\n" +
+		"pkg/build.go:12:5: undefined: missing\n"
+
+	failures := ParseCIFailures(log)
+	assert.Equal(t, []models.CIFailure{
+		{Test: "TestParse/empty", File: "parse_test.go", Line: 42, Message: "expected 1, got 2"},
+		{Test: "TestOther", File: "other_test.go", Line: 7, Message: "boom"},
+		{File: "pkg/build.go", Line: 12, Message: "undefined: missing"},
+	}, failures)
+}
+
+func TestParseCIFailuresPytest(t *testing.T) {
+	log := "=================================== FAILURES ===================================\n" +
+		"_______________________________ TestMath.test_add _______________________________\n" +
+		"\n" +
+		"    def test_add(self):\n" +
+		">       assert add(1, 1) == 3\n" +
+		"E       assert 2 == 3\n" +
+		"\n" +
+		"tests/test_math.py:12: AssertionError\n" +
+		"=========================== short test summary info ============================\n" +
+		"FAILED tests/test_math.py::TestMath::test_add - assert 2 == 3\n" +
+		"ERROR tests/test_io.py - ModuleNotFoundError: No module named 'foo'\n"
+
+	failures := ParseCIFailures(log)
+	assert.Equal(t, []models.CIFailure{
+		{Test: "TestMath::test_add", File: "tests/test_math.py", Line: 12, Message: "assert 2 == 3"},
+		{File: "tests/test_io.py", Message: "ModuleNotFoundError: No module named 'foo'"},
+	}, failures)
+}
+
+func TestParseCIFailuresJest(t *testing.T) {
+	log := "PASS src/ok.test.js\n" +
+		"FAIL src/sum.test.js\n" +
+		"  ● math › adds numbers\n" +
+		"\n" +
+		"    expect(received).toBe(expected) // Object.is equality\n" +
+		"\n" +
+		"      at Object.toBe (node_modules/expect/build/index.js:10:3)\n" +
+		"      at Object.<anonymous> (src/sum.test.js:5:17)\n"
+
+	failures := ParseCIFailures(log)
+	assert.Equal(t, []models.CIFailure{
+		{Test: "math › adds numbers", File: "src/sum.test.js", Line: 5, Message: "expect(received).toBe(expected) // Object.is equality"},
+	}, failures)
+}
+
+func TestFetchCIFailuresMergesForgeFailures(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeForge{
+		name: "fake",
+		logs: &CILog{
+			Content:  "--- FAIL: TestA (0.00s)\n    a_test.go:3: bad\n",
+			Failures: []models.CIFailure{{File: "a_test.go", Line: 3, Message: "bad"}, {Message: "Job failed: script failure"}},
+		},
+	}
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.SetForge(fake)
+
+	failures, err := service.FetchCIFailures(ctx, &models.CICheck{Name: "test", Link: "fake://check"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []models.CIFailure{
+		{Check: "test", File: "a_test.go", Line: 3, Message: "bad"},
+		{Check: "test", Message: "Job failed: script failure"},
+	}, failures)
+}
+
+func TestGitHubAnnotationFailures(t *testing.T) {
+	failures := githubAnnotationFailures([]githubAnnotation{
+		{Path: "pkg/a.go", StartLine: 4, Level: "failure", Message: "unused variable"},
+		{Path: "pkg/b.go", StartLine: 9, Level: "warning", Message: "deprecated"},
+		{Path: ".github", Level: "failure", Message: "Process completed with exit code 1."},
+		{Path: ".github", Level: "failure", Title: "Timeout", Message: "The job has exceeded the maximum execution time"},
+	})
+	assert.Equal(t, []models.CIFailure{
+		{File: "pkg/a.go", Line: 4, Message: "unused variable"},
+		{Message: "The job has exceeded the maximum execution time"},
+	}, failures)
+}
+
+func TestGitLabJobLogFailureReason(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+
+	ciLog := service.gitlabJobLog(gitlabJob{Status: "failed", FailureReason: "script_failure"}, "trace")
+	assert.False(t, ciLog.Running)
+	assert.Equal(t, []models.CIFailure{{Message: "Job failed: script failure"}}, ciLog.Failures)
+
+	assert.Empty(t, service.gitlabJobLog(gitlabJob{Status: "running"}, "").Failures)
+}
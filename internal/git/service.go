@@ -20,6 +20,7 @@ import (
 const (
 	gitHostGitLab  = "gitlab"
 	gitHostGithub  = "github"
+	gitHostGitea   = "gitea"
 	gitHostUnknown = "unknown"
 
 	gitOptionalLocksEnv = "GIT_OPTIONAL_LOCKS=0"
@@ -67,6 +68,16 @@ type Service struct {
 	useGitPager          bool
 	gitPagerArgs         []string
 	gitPager             string
+	giteaToken           string   // configured Gitea/Forgejo API token
+	giteaHosts           []string // configured Gitea/Forgejo hosts or base URLs
+	gitea                *giteaClient
+	forgeClient          string // "cli" (default) or "http"
+	githubAPI            *forgeAPIClient
+	githubAPIOnce        sync.Once
+	gitlabAPI            *forgeAPIClient
+	gitlabAPIOnce        sync.Once
+	forge                Forge
+	forgeOnce            sync.Once
 	commandRunner        func(ctx context.Context, name string, args ...string) *exec.Cmd
 }
 
@@ -142,6 +153,22 @@ func (s *Service) SetCIRemote(remote string) {
 	s.ciRemote = strings.TrimSpace(remote)
 }
 
+// SetGiteaConfig sets the API token and extra hosts used to recognise and
+// query Gitea/Forgejo remotes. Must be called before the first git host
+// resolution to take effect.
+func (s *Service) SetGiteaConfig(token string, hosts []string) {
+	s.giteaToken = strings.TrimSpace(token)
+	s.giteaHosts = append([]string{}, hosts...)
+}
+
+// SetForgeClient selects how forge data is fetched: ForgeClientCLI (the
+// default) shells out to gh/glab, ForgeClientHTTP talks to the APIs directly
+// and falls back to the CLIs when no token can be found. Must be called before
+// the first forge operation to take effect.
+func (s *Service) SetForgeClient(mode string) {
+	s.forgeClient = strings.ToLower(strings.TrimSpace(mode))
+}
+
 // SetGitPagerArgs sets additional arguments used when formatting diffs.
 func (s *Service) SetGitPagerArgs(args []string) {
 	if len(args) == 0 {
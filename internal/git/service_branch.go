@@ -17,6 +17,13 @@ func (s *Service) GetMainBranch(ctx context.Context) string {
 				s.mainBranch = parts[len(parts)-1]
 			}
 		}
+		if s.mainBranch == "" {
+			// Bare clones have no remote-tracking HEAD; their own HEAD names
+			// the default branch.
+			if barePath := s.bareRepositoryPath(ctx); barePath != "" {
+				s.mainBranch = s.RunGit(ctx, []string{"git", "--git-dir", barePath, "symbolic-ref", "--short", "HEAD"}, "", []int{0}, true, true)
+			}
+		}
 		if s.mainBranch == "" {
 			s.mainBranch = "main"
 		}
@@ -51,6 +58,10 @@ func (s *Service) remoteExists(ctx context.Context, name string) bool {
 
 func (s *Service) getRemoteURL(ctx context.Context) string {
 	s.remoteURLOnce.Do(func() {
+		// Allow tests to pre-seed the remote URL directly on the struct.
+		if s.remoteURL != "" {
+			return
+		}
 		remote := s.resolveRemoteName(ctx)
 		s.remoteURL = strings.TrimSpace(s.RunGit(ctx, []string{"git", "remote", "get-url", remote}, "", []int{0}, true, true))
 	})
@@ -107,7 +118,8 @@ func (s *Service) GetMergedBranches(ctx context.Context, baseBranch string) []st
 		line = strings.TrimPrefix(line, "* ")
 		line = strings.TrimPrefix(line, "+ ")
 		line = strings.TrimSpace(line)
-		if line == "" || line == baseBranch {
+		// Skip the detached HEAD entry, e.g. "(HEAD detached at v1.0)".
+		if line == "" || line == baseBranch || strings.HasPrefix(line, "(") {
 			continue
 		}
 		merged = append(merged, line)
@@ -126,3 +138,20 @@ func (s *Service) localBranchExists(ctx context.Context, branch string) bool {
 	)
 	return strings.TrimSpace(ref) != ""
 }
+
+// prBranchCandidates returns the head branch names a PR for the branch checked
+// out in worktreePath may use: the local branch, then its upstream branch when
+// tracking a differently named remote branch. Returns nil on a detached HEAD.
+func (s *Service) prBranchCandidates(ctx context.Context, worktreePath string) []string {
+	branch := s.RunGit(ctx, []string{"git", "rev-parse", "--abbrev-ref", "HEAD"}, worktreePath, []int{0}, true, true)
+	if branch == "" || branch == "HEAD" {
+		return nil
+	}
+	candidates := []string{branch}
+	if upstream := s.RunGit(ctx, []string{"git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}"}, worktreePath, []int{0, 128}, true, true); upstream != "" {
+		if _, remoteBranch, ok := strings.Cut(upstream, "/"); ok && remoteBranch != branch {
+			candidates = append(candidates, remoteBranch)
+		}
+	}
+	return candidates
+}
@@ -157,4 +157,10 @@ func TestGetMergedBranches(t *testing.T) {
 	merged = service.GetMergedBranches(ctx, "main")
 	assert.Contains(t, merged, "feature-branch")
 	assert.NotContains(t, merged, "unmerged-branch")
+
+	cmd = exec.Command("git", "checkout", "--detach", "main")
+	require.NoError(t, cmd.Run())
+
+	merged = service.GetMergedBranches(ctx, "main")
+	assert.Equal(t, []string{"feature-branch"}, merged, "detached HEAD must not be listed as a branch")
 }
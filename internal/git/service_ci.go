@@ -2,74 +2,98 @@ package git
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
-	"strings"
-	"time"
 
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
-// FetchCIStatus fetches CI check statuses for a PR from GitHub or GitLab.
+// CILog is a snapshot of the output of a CI job.
+type CILog struct {
+	Content string
+	// Offset is the byte offset of Content in the full log. It is non-zero
+	// when only the output printed since a previous fetch was downloaded.
+	Offset int
+	// Running reports whether the job is still queued or running, so the
+	// log may grow.
+	Running bool
+	// Failures lists the failures the forge reports for a failed job:
+	// check-run annotations on GitHub, the failure reason on GitLab.
+	Failures []models.CIFailure
+}
+
+// FetchCIStatus fetches CI check statuses for a PR from the detected forge.
 func (s *Service) FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error) {
-	host := s.DetectHost(ctx)
-	switch host {
-	case gitHostGithub:
-		return s.fetchGitHubCI(ctx, prNumber)
-	case gitHostGitLab:
-		return s.fetchGitLabCI(ctx, branch)
-	default:
+	f := s.Forge(ctx)
+	if f == nil {
 		return nil, nil
 	}
+	return f.FetchCIStatus(ctx, prNumber, branch)
 }
 
-// FetchCIStatusByCommit fetches CI check statuses for a commit SHA (GitHub only).
+// FetchCIStatusByCommit fetches CI check statuses for a commit SHA.
 // This is used for branches without an associated PR.
 func (s *Service) FetchCIStatusByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error) {
-	if s.DetectHost(ctx) != gitHostGithub {
+	f := s.Forge(ctx)
+	if f == nil {
 		return nil, nil
 	}
+	return f.FetchCIStatusByCommit(ctx, commitSHA, worktreePath)
+}
 
-	repoName := s.ResolveCITargetRepoName(ctx)
-	if repoName == "" || repoName == "unknown" || strings.HasPrefix(repoName, "local-") {
-		return nil, nil
-	}
+// SupportsCommitCI reports whether CI status can be fetched for branches without a PR/MR.
+func (s *Service) SupportsCommitCI(ctx context.Context) bool {
+	f := s.Forge(ctx)
+	return f != nil && f.SupportsCommitCI()
+}
 
-	// GitHub Check Runs API: GET /repos/{owner}/{repo}/commits/{ref}/check-runs
-	apiPath := fmt.Sprintf("repos/%s/commits/%s/check-runs", repoName, commitSHA)
-	out := s.RunGit(ctx, []string{"gh", "api", apiPath, "--jq", ".check_runs"},
-		worktreePath, []int{0, 1}, true, true)
+// forgeForCICheck returns the forge owning a CI check: the detected forge, or
+// GitHub for GitHub Actions links when the remote host is not recognised, as
+// with GitHub Enterprise. Checks are never handed to another detected forge,
+// as their links come from the forge API and may point anywhere.
+func (s *Service) forgeForCICheck(ctx context.Context, check *models.CICheck) Forge {
+	if check == nil {
+		return nil
+	}
+	if f := s.forgeOrGitHub(ctx); f.OwnsCICheck(ctx, check) {
+		return f
+	}
+	return nil
+}
 
-	if out == "" {
-		return nil, nil
+// CICheckLogCommand returns the argv printing the logs of a CI check, or nil
+// when no forge can retrieve them (callers typically open the link instead).
+func (s *Service) CICheckLogCommand(ctx context.Context, check *models.CICheck, failedOnly bool) []string {
+	f := s.forgeForCICheck(ctx, check)
+	if f == nil {
+		return nil
 	}
+	return f.CICheckLogCommand(ctx, check, failedOnly)
+}
 
-	var checkRuns []struct {
-		Name       string `json:"name"`
-		Status     string `json:"status"`     // queued, in_progress, completed
-		Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
-		HTMLURL    string `json:"html_url"`
-		StartedAt  string `json:"started_at"`
+// FetchCICheckLog returns the log of a CI job as printed so far. A positive
+// offset asks for the output past that many bytes only; forges that cannot
+// serve part of a log return all of it with a zero Offset.
+func (s *Service) FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, offset int) (*CILog, error) {
+	f := s.forgeForCICheck(ctx, check)
+	if f == nil {
+		return nil, ErrCILogUnsupported
 	}
+	return f.FetchCICheckLog(ctx, check, worktreePath, offset)
+}
 
-	if err := json.Unmarshal([]byte(out), &checkRuns); err != nil {
-		return nil, err
+// RerunCICheck restarts a CI check on its forge and returns a URL to follow it.
+func (s *Service) RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	f := s.forgeForCICheck(ctx, check)
+	if f == nil {
+		return "", ErrCIRerunUnsupported
 	}
+	return f.RerunCICheck(ctx, check, worktreePath)
+}
 
-	result := make([]*models.CICheck, 0, len(checkRuns))
-	for _, run := range checkRuns {
-		conclusion := s.mapGitHubConclusion(run.Status, run.Conclusion)
-		var startedAt time.Time
-		if run.StartedAt != "" {
-			startedAt, _ = time.Parse(time.RFC3339, run.StartedAt)
-		}
-		result = append(result, &models.CICheck{
-			Name:       run.Name,
-			Status:     strings.ToLower(run.Status),
-			Conclusion: conclusion,
-			Link:       run.HTMLURL,
-			StartedAt:  startedAt,
-		})
+// CancelCICheck cancels a running CI check on its forge and returns a URL to follow it.
+func (s *Service) CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	f := s.forgeForCICheck(ctx, check)
+	if f == nil {
+		return "", ErrCICancelUnsupported
 	}
-	return result, nil
+	return f.CancelCICheck(ctx, check, worktreePath)
 }
@@ -0,0 +1,198 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// ErrCIRerunUnsupported is returned when a forge cannot restart the given CI check.
+var ErrCIRerunUnsupported = errors.New("restarting this CI check is not supported")
+
+// ErrCICancelUnsupported is returned when a forge cannot cancel the given CI check.
+var ErrCICancelUnsupported = errors.New("cancelling this CI check is not supported")
+
+// ErrCILogUnsupported is returned when a forge cannot fetch the log of the given CI check.
+var ErrCILogUnsupported = errors.New("fetching the log of this CI check is not supported")
+
+// ErrCILiveLogUnsupported is returned when the log of a running CI job is
+// only published once the job finishes.
+var ErrCILiveLogUnsupported = errors.New("the log of this CI job is published once it finishes")
+
+// ErrReviewThreadUnsupported is returned when a forge cannot reply to or
+// resolve review threads.
+var ErrReviewThreadUnsupported = errors.New("replying to or resolving review threads is not supported")
+
+// Forge is a code-hosting provider (GitHub, GitLab, Gitea, ...) serving PR/MR,
+// issue, and CI data for the repository. Implementations are registered per
+// detected host with RegisterForge; tests can plug a fake in with SetForge.
+type Forge interface {
+	// Name returns the host identifier the forge is registered under.
+	Name() string
+	// AuthenticatedUsername returns the forge login of the current user, or "".
+	AuthenticatedUsername(ctx context.Context) string
+	// FetchPRMap returns PRs/MRs in any state keyed by head branch name.
+	FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error)
+	// FetchPRForWorktree returns the PR/MR for the branch checked out in a worktree, or nil.
+	FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error)
+	// FetchOpenPRs returns all open PRs/MRs.
+	FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error)
+	// FetchPR returns a single open PR/MR by number.
+	FetchPR(ctx context.Context, number int) (*models.PRInfo, error)
+	// FetchOpenIssues returns all open issues.
+	FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error)
+	// FetchIssue returns a single open issue by number.
+	FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error)
+	// FetchCIStatus returns CI checks for a PR/MR, falling back to its branch.
+	FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error)
+	// FetchCIStatusByCommit returns CI checks for a commit SHA.
+	FetchCIStatusByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error)
+	// SupportsCommitCI reports whether FetchCIStatusByCommit returns data for branches without a PR/MR.
+	SupportsCommitCI() bool
+	// OwnsCICheck reports whether a check (typically by its link) belongs to this forge.
+	OwnsCICheck(ctx context.Context, check *models.CICheck) bool
+	// CICheckLogCommand returns the argv printing the logs of a check, or nil
+	// when logs cannot be retrieved from the command line.
+	CICheckLogCommand(ctx context.Context, check *models.CICheck, failedOnly bool) []string
+	// RerunCICheck restarts a check and returns a URL to follow it. It returns
+	// ErrCIRerunUnsupported when the check cannot be restarted by this forge.
+	RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error)
+	// FetchCICheckLog returns the log of a job as printed so far, from offset
+	// on when the forge can serve part of it. It returns ErrCILogUnsupported
+	// when the check log cannot be fetched by this forge, and
+	// ErrCILiveLogUnsupported when the job is running and its log not yet
+	// published.
+	FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, offset int) (*CILog, error)
+	// CancelCICheck cancels a running check and returns a URL to follow it. It
+	// returns ErrCICancelUnsupported when the check cannot be cancelled.
+	CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error)
+	// CreatePR opens a PR/MR for an already pushed branch and returns it.
+	CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error)
+	// MergePR merges a PR/MR, or enables auto-merge for it when opts.Auto is set.
+	MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error
+	// FetchReviewThreads returns the inline review threads of a PR/MR.
+	FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error)
+	// ReplyToReviewThread adds a comment to a review thread. It returns
+	// ErrReviewThreadUnsupported when the forge cannot reply to threads.
+	ReplyToReviewThread(ctx context.Context, prNumber int, threadID, body string) error
+	// SetReviewThreadResolved resolves or unresolves a review thread. It
+	// returns ErrReviewThreadUnsupported when the forge cannot resolve threads.
+	SetReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error
+}
+
+// ForgeFactory builds a Forge bound to a Service.
+type ForgeFactory func(s *Service) Forge
+
+var (
+	forgeRegistryMu sync.RWMutex
+	forgeRegistry   = map[string]ForgeFactory{
+		gitHostGithub: newGitHubForge,
+		gitHostGitLab: newGitLabForge,
+		gitHostGitea:  newGiteaForge,
+	}
+)
+
+// RegisterForge registers (or replaces) the forge implementation used when
+// DetectHost reports host.
+func RegisterForge(host string, factory ForgeFactory) {
+	forgeRegistryMu.Lock()
+	defer forgeRegistryMu.Unlock()
+	forgeRegistry[host] = factory
+}
+
+func lookupForgeFactory(host string) ForgeFactory {
+	forgeRegistryMu.RLock()
+	defer forgeRegistryMu.RUnlock()
+	return forgeRegistry[host]
+}
+
+// SetForge overrides the forge used by the service, bypassing host detection.
+// Must be called before the first forge operation to take effect.
+func (s *Service) SetForge(f Forge) {
+	s.forge = f
+}
+
+// Forge returns the forge for the repository's detected host, or nil when the
+// host is not supported.
+func (s *Service) Forge(ctx context.Context) Forge {
+	s.forgeOnce.Do(func() {
+		// Allow callers to inject a forge directly.
+		if s.forge != nil {
+			return
+		}
+		if factory := lookupForgeFactory(s.DetectHost(ctx)); factory != nil {
+			s.forge = factory(s)
+		}
+	})
+	return s.forge
+}
+
+// forgeOrGitHub returns the detected forge, defaulting to GitHub for unknown
+// hosts so gh can still resolve GitHub Enterprise remotes on its own.
+func (s *Service) forgeOrGitHub(ctx context.Context) Forge {
+	if f := s.Forge(ctx); f != nil {
+		return f
+	}
+	return &githubForge{s: s}
+}
+
+// GetAuthenticatedUsername returns the authenticated forge username for this repository host.
+// Returns an empty string when no authenticated username can be resolved.
+func (s *Service) GetAuthenticatedUsername(ctx context.Context) string {
+	f := s.Forge(ctx)
+	if f == nil {
+		return ""
+	}
+	return f.AuthenticatedUsername(ctx)
+}
+
+// FetchPRMap gathers PR/MR information from the detected forge.
+// Returns a map keyed by branch name to PRInfo. Detects the host automatically
+// based on the repository's remote URL.
+func (s *Service) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	f := s.Forge(ctx)
+	// Skip PR fetching for repos without a supported forge remote
+	if f == nil {
+		return make(map[string]*models.PRInfo), nil
+	}
+	return f.FetchPRMap(ctx)
+}
+
+// FetchPRForWorktreeWithError fetches PR info and returns detailed error information.
+func (s *Service) FetchPRForWorktreeWithError(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	f := s.Forge(ctx)
+	if f == nil {
+		return nil, nil
+	}
+	return f.FetchPRForWorktree(ctx, worktreePath)
+}
+
+// FetchPRForWorktree fetches PR info for a specific worktree by running gh/glab in that directory.
+// This correctly detects PRs even when the local branch name differs from the remote branch.
+// Maintains backward compatibility by swallowing errors.
+func (s *Service) FetchPRForWorktree(ctx context.Context, worktreePath string) *models.PRInfo {
+	pr, _ := s.FetchPRForWorktreeWithError(ctx, worktreePath)
+	return pr
+}
+
+// FetchAllOpenPRs fetches all open PRs/MRs and returns them as a slice.
+func (s *Service) FetchAllOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	return s.forgeOrGitHub(ctx).FetchOpenPRs(ctx)
+}
+
+// FetchPR fetches a single PR by number.
+func (s *Service) FetchPR(ctx context.Context, prNumber int) (*models.PRInfo, error) {
+	return s.forgeOrGitHub(ctx).FetchPR(ctx, prNumber)
+}
+
+// FetchAllOpenIssues fetches all open issues and returns them as a slice.
+func (s *Service) FetchAllOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	return s.forgeOrGitHub(ctx).FetchOpenIssues(ctx)
+}
+
+// FetchIssue fetches a single issue by number.
+func (s *Service) FetchIssue(ctx context.Context, issueNumber int) (*models.IssueInfo, error) {
+	return s.forgeOrGitHub(ctx).FetchIssue(ctx, issueNumber)
+}
@@ -0,0 +1,501 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ForgeClientCLI queries forges by running the gh/glab command-line tools.
+	ForgeClientCLI = "cli"
+	// ForgeClientHTTP queries forges in-process over their REST/GraphQL APIs.
+	ForgeClientHTTP = "http"
+
+	forgeHTTPTimeout = 30 * time.Second
+	// forgeTokenTimeout bounds the token lookup, which may run credential helpers.
+	forgeTokenTimeout = 10 * time.Second
+)
+
+// forgeHTTPTransport is shared by every in-process forge client so keep-alive
+// connections are reused across requests, worktrees, and forges.
+var forgeHTTPTransport = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:   true,
+	MaxIdleConns:        64,
+	MaxIdleConnsPerHost: 16,
+	IdleConnTimeout:     90 * time.Second,
+	TLSHandshakeTimeout: 10 * time.Second,
+}
+
+// newForgeHTTPClient returns an http.Client using the shared transport.
+func newForgeHTTPClient() *http.Client {
+	return &http.Client{Timeout: forgeHTTPTimeout, Transport: forgeHTTPTransport}
+}
+
+// errForgeNotFound is returned when a forge API answers 404.
+var errForgeNotFound = errors.New("not found")
+
+// forgeTokenSource describes where to look for an API token for a forge host.
+type forgeTokenSource struct {
+	envVars    []string                 // environment variables checked in order
+	configFile func(host string) string // token stored by the forge CLI, if any
+}
+
+// forgeAPIClient is a minimal JSON client for a forge REST (and optionally
+// GraphQL) API. GET responses are cached by ETag so unchanged resources are
+// revalidated with a conditional request instead of being downloaded again.
+type forgeAPIClient struct {
+	name       string // forge name used in error messages
+	host       string // hostname used to resolve the token and build web links
+	restBase   string // REST API root, without trailing slash
+	graphqlURL string // GraphQL endpoint, empty when unsupported
+	authScheme string // Authorization scheme, e.g. "Bearer"
+	http       *http.Client
+
+	tokenOnce sync.Once
+	token     string
+	// resolveToken is overridable in tests; defaults to Service.resolveForgeToken.
+	resolveToken func(ctx context.Context) string
+
+	// etags caches GET responses by URL, least recently used first in
+	// etagOrder, up to forgeETagCacheLimit entries.
+	etagMu    sync.Mutex
+	etags     map[string]*list.Element
+	etagOrder list.List
+}
+
+// forgeETagCacheLimit caps the responses kept for conditional requests, as
+// their URLs include pages, PR numbers and job IDs.
+const forgeETagCacheLimit = 256
+
+type forgeCachedResponse struct {
+	endpoint string
+	etag     string
+	body     []byte
+}
+
+// cachedResponse returns the cached response of endpoint, marking it as
+// recently used.
+func (c *forgeAPIClient) cachedResponse(endpoint string) (forgeCachedResponse, bool) {
+	c.etagMu.Lock()
+	defer c.etagMu.Unlock()
+	elem, ok := c.etags[endpoint]
+	if !ok {
+		return forgeCachedResponse{}, false
+	}
+	c.etagOrder.MoveToBack(elem)
+	return elem.Value.(forgeCachedResponse), true
+}
+
+// storeResponse caches the response of endpoint, evicting the least recently
+// used one when the cache is full.
+func (c *forgeAPIClient) storeResponse(endpoint, etag string, body []byte) {
+	c.etagMu.Lock()
+	defer c.etagMu.Unlock()
+	cached := forgeCachedResponse{endpoint: endpoint, etag: etag, body: body}
+	if elem, ok := c.etags[endpoint]; ok {
+		elem.Value = cached
+		c.etagOrder.MoveToBack(elem)
+		return
+	}
+	if c.etags == nil {
+		c.etags = make(map[string]*list.Element)
+	}
+	if c.etagOrder.Len() >= forgeETagCacheLimit {
+		oldest := c.etagOrder.Front()
+		c.etagOrder.Remove(oldest)
+		delete(c.etags, oldest.Value.(forgeCachedResponse).endpoint)
+	}
+	c.etags[endpoint] = c.etagOrder.PushBack(cached)
+}
+
+// ready reports whether a token is available, resolving it on first use.
+// The token is cached for the whole process, so the lookup must not depend on
+// the first caller's context being cancelled.
+func (c *forgeAPIClient) ready(ctx context.Context) bool {
+	if c == nil {
+		return false
+	}
+	c.tokenOnce.Do(func() {
+		if c.resolveToken != nil {
+			lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), forgeTokenTimeout)
+			defer cancel()
+			c.token = c.resolveToken(lookupCtx)
+		}
+	})
+	return c.token != ""
+}
+
+// get performs a conditional GET against a REST path and decodes the JSON
+// response into out, reusing the cached body when the server answers 304.
+func (c *forgeAPIClient) get(ctx context.Context, path string, out any) error {
+	endpoint := c.restBase + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+
+	cached, hasCached := c.cachedResponse(endpoint)
+	if hasCached {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		return json.Unmarshal(cached.body, out)
+	}
+	body, err := c.readBody(resp, path)
+	if err != nil {
+		return err
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		c.storeResponse(endpoint, etag, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// getRaw performs an unconditional GET against a REST path and returns the
+// response body as is, following redirects to the storage serving it.
+func (c *forgeAPIClient) getRaw(ctx context.Context, path string) ([]byte, error) {
+	body, _, err := c.getRawFrom(ctx, path, 0)
+	return body, err
+}
+
+// getRawFrom is getRaw for the bytes past offset, requested with a Range
+// header when offset is positive. It returns the offset the body starts at,
+// which is 0 when the server ignored the range and sent everything.
+func (c *forgeAPIClient) getRawFrom(ctx context.Context, path string, offset int) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.restBase+path, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Accept", "*/*")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	switch {
+	case offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// Nothing was written past offset yet.
+		return nil, offset, nil
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		body, err := io.ReadAll(resp.Body)
+		return body, offset, err
+	}
+	body, err := c.readBody(resp, path)
+	return body, 0, err
+}
+
+// send performs a non-GET request against a REST path, decoding the JSON
+// response into out when it is non-nil.
+func (c *forgeAPIClient) send(ctx context.Context, method, path string, payload, out any) error {
+	var reader io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.restBase+path, reader)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := c.readBody(resp, path)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, out)
+}
+
+// graphql runs a GraphQL query and decodes its "data" member into out.
+func (c *forgeAPIClient) graphql(ctx context.Context, query string, variables map[string]any, out any) error {
+	if c.graphqlURL == "" {
+		return fmt.Errorf("%s API does not support GraphQL", c.name)
+	}
+	data, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphqlURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := c.readBody(resp, "graphql")
+	if err != nil {
+		return err
+	}
+	var envelope struct {
+		Data   json.RawMessage      `json:"data"`
+		Errors []forgeGraphQLDetail `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return err
+	}
+	partial := len(envelope.Data) > 0 && !bytes.Equal(envelope.Data, []byte("null"))
+	if partial {
+		if err := json.Unmarshal(envelope.Data, out); err != nil {
+			return err
+		}
+	}
+	if len(envelope.Errors) > 0 {
+		return &forgeGraphQLError{forge: c.name, details: envelope.Errors, partial: partial}
+	}
+	if !partial {
+		return fmt.Errorf("%s GraphQL: empty response", c.name)
+	}
+	return nil
+}
+
+// forgeGraphQLDetail is one entry of the errors array of a GraphQL response.
+type forgeGraphQLDetail struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// forgeGraphQLError reports the errors of a GraphQL response. When partial is
+// set, the data that could be resolved was still decoded, but fields the
+// errors refer to are missing from it.
+type forgeGraphQLError struct {
+	forge   string
+	details []forgeGraphQLDetail
+	partial bool
+}
+
+func (e *forgeGraphQLError) Error() string {
+	msg := fmt.Sprintf("%s GraphQL: %s", e.forge, e.details[0].Message)
+	if n := len(e.details) - 1; n > 0 {
+		msg += fmt.Sprintf(" (and %d more)", n)
+	}
+	return msg
+}
+
+// Is matches errForgeNotFound when every error is a NOT_FOUND one, as for a
+// missing pull request.
+func (e *forgeGraphQLError) Is(target error) bool {
+	if target != errForgeNotFound {
+		return false
+	}
+	for _, detail := range e.details {
+		if detail.Type != "NOT_FOUND" {
+			return false
+		}
+	}
+	return true
+}
+
+// isPartialGraphQLError reports whether err came with the data that could be
+// resolved, which callers may still use.
+func isPartialGraphQLError(err error) bool {
+	var gqlErr *forgeGraphQLError
+	return errors.As(err, &gqlErr) && gqlErr.partial
+}
+
+func (c *forgeAPIClient) setHeaders(req *http.Request) {
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", c.authScheme+" "+c.token)
+	}
+}
+
+// readBody returns the response body, translating error statuses into errors.
+func (c *forgeAPIClient) readBody(resp *http.Response, path string) ([]byte, error) {
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errForgeNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("%s API %s: %s: %s", c.name, path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (s *Service) useForgeHTTP() bool {
+	return s.forgeClient == ForgeClientHTTP
+}
+
+// splitRemoteURL returns the lowercase hostname of a remote URL and the
+// repository path on that host, without leading slash or ".git" suffix.
+func splitRemoteURL(remoteURL string) (host, repoPath string) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if remoteURL == "" {
+		return "", ""
+	}
+	if strings.Contains(remoteURL, "://") {
+		u, err := url.Parse(remoteURL)
+		if err != nil {
+			return "", ""
+		}
+		host, repoPath = u.Hostname(), u.Path
+	} else {
+		// scp-like syntax: git@host:owner/repo.git
+		if _, rest, ok := strings.Cut(remoteURL, "@"); ok {
+			remoteURL = rest
+		}
+		var ok bool
+		host, repoPath, ok = strings.Cut(remoteURL, ":")
+		if !ok {
+			return "", ""
+		}
+	}
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	return strings.ToLower(host), repoPath
+}
+
+// resolveForgeToken looks up an API token for host from the environment, the
+// forge CLI configuration, and finally the git credential helper.
+func (s *Service) resolveForgeToken(ctx context.Context, host string, source forgeTokenSource) string {
+	for _, name := range source.envVars {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token
+		}
+	}
+	if source.configFile != nil {
+		if token := source.configFile(host); token != "" {
+			return token
+		}
+	}
+	return s.gitCredentialToken(ctx, host)
+}
+
+// gitCredentialToken asks the configured git credential helpers for the
+// password stored for https://host, without ever prompting the user: terminal
+// prompts are disabled, askpass programs answer nothing, and Git Credential
+// Manager is told not to open its GUI.
+func (s *Service) gitCredentialToken(ctx context.Context, host string) string {
+	if host == "" {
+		return ""
+	}
+	cmd, err := s.prepareAllowedCommand(ctx, []string{"git", "-c", "credential.interactive=never", "credential", "fill"}, map[string]string{
+		"GIT_TERMINAL_PROMPT": "0",
+		"GIT_ASKPASS":         "true",
+		"SSH_ASKPASS":         "true",
+		"GCM_INTERACTIVE":     "never",
+	})
+	if err != nil {
+		return ""
+	}
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=https\nhost=%s\n\n", host))
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if password, ok := strings.CutPrefix(scanner.Text(), "password="); ok {
+			return strings.TrimSpace(password)
+		}
+	}
+	return ""
+}
+
+// ghHostsToken returns the oauth_token stored for host in the gh CLI hosts.yml.
+// Recent gh versions keep the token in the system keyring instead, in which
+// case the git credential helper (gh auth setup-git) usually provides it.
+func ghHostsToken(host string) string {
+	dir := os.Getenv("GH_CONFIG_DIR")
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "gh")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config", "gh")
+		}
+	}
+	if dir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "hosts.yml")) // #nosec G304 -- path derived from the user's config directory
+	if err != nil {
+		return ""
+	}
+	var hosts map[string]struct {
+		OAuthToken string `yaml:"oauth_token"`
+	}
+	if err := yaml.Unmarshal(data, &hosts); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(hosts[host].OAuthToken)
+}
+
+// glabConfigToken returns the token stored for host in the glab CLI config.yml.
+func glabConfigToken(host string) string {
+	dir := os.Getenv("GLAB_CONFIG_DIR")
+	if dir == "" {
+		if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+			dir = filepath.Join(xdg, "glab-cli")
+		} else if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, ".config", "glab-cli")
+		}
+	}
+	if dir == "" {
+		return ""
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "config.yml")) // #nosec G304 -- path derived from the user's config directory
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		Hosts map[string]struct {
+			Token string `yaml:"token"`
+		} `yaml:"hosts"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(cfg.Hosts[host].Token)
+}
@@ -0,0 +1,606 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newForgeAPITestClient returns a client with a fixed token talking to srv.
+func newForgeAPITestClient(srv *httptest.Server) *forgeAPIClient {
+	return &forgeAPIClient{
+		name:         "Test",
+		restBase:     srv.URL,
+		graphqlURL:   srv.URL + "/graphql",
+		authScheme:   "Bearer",
+		http:         srv.Client(),
+		resolveToken: func(context.Context) string { return "secret" },
+	}
+}
+
+// newForgeHTTPTestService returns a Service in HTTP forge mode inside a temporary
+// repository whose origin points at remoteURL.
+func newForgeHTTPTestService(t *testing.T, host, remoteURL string) *Service {
+	t.Helper()
+
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	runGit(t, repo, "remote", "add", "origin", remoteURL)
+	withCwd(t, repo)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = host
+	service.SetForgeClient(ForgeClientHTTP)
+	return service
+}
+
+func TestForgeAPIClientETagRevalidation(t *testing.T) {
+	var conditional []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		conditional = append(conditional, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"login":"alice"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := newForgeAPITestClient(srv)
+	require.True(t, client.ready(context.Background()))
+
+	for range 2 {
+		var user struct {
+			Login string `json:"login"`
+		}
+		require.NoError(t, client.get(context.Background(), "/user", &user))
+		assert.Equal(t, "alice", user.Login)
+	}
+	assert.Equal(t, []string{"", `"v1"`}, conditional)
+}
+
+func TestForgeAPIClientErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/graphql":
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Bad credentials"}]}`))
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	client := newForgeAPITestClient(srv)
+	client.ready(context.Background())
+
+	var out map[string]any
+	require.ErrorIs(t, client.get(context.Background(), "/missing", &out), errForgeNotFound)
+	err := client.get(context.Background(), "/broken", &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "boom")
+	err = client.graphql(context.Background(), "query { viewer { login } }", nil, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Bad credentials")
+}
+
+func TestForgeAPIClientGraphQLPartialErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if strings.Contains(req.Query, "missing") {
+			_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":null}},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a PullRequest"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":{"b0":{"id":1},"b1":null},"errors":[{"type":"FORBIDDEN","message":"Resource not accessible"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := newForgeAPITestClient(srv)
+	client.ready(context.Background())
+
+	var out map[string]any
+	err := client.graphql(context.Background(), "query { partial }", nil, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Resource not accessible")
+	assert.True(t, isPartialGraphQLError(err))
+	assert.NotErrorIs(t, err, errForgeNotFound)
+	assert.Contains(t, out, "b0", "expected the resolved data to be decoded")
+
+	err = client.graphql(context.Background(), "query { missing }", nil, &out)
+	require.ErrorIs(t, err, errForgeNotFound)
+}
+
+func TestForgeAPIClientETagCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	client := &forgeAPIClient{}
+	for i := range forgeETagCacheLimit {
+		client.storeResponse(fmt.Sprintf("/page/%d", i), "etag", nil)
+	}
+	_, ok := client.cachedResponse("/page/0")
+	require.True(t, ok)
+
+	client.storeResponse("/page/new", "etag", nil)
+	assert.Len(t, client.etags, forgeETagCacheLimit)
+	assert.Equal(t, forgeETagCacheLimit, client.etagOrder.Len())
+	_, ok = client.cachedResponse("/page/0")
+	assert.True(t, ok, "expected the recently read entry to be kept")
+	_, ok = client.cachedResponse("/page/1")
+	assert.False(t, ok, "expected the least recently used entry to be evicted")
+}
+
+func TestForgeAPIClientNotReadyWithoutToken(t *testing.T) {
+	client := &forgeAPIClient{resolveToken: func(context.Context) string { return "" }}
+	assert.False(t, client.ready(context.Background()))
+
+	var nilClient *forgeAPIClient
+	assert.False(t, nilClient.ready(context.Background()))
+}
+
+func TestForgeAPIClientTokenSurvivesCancelledCaller(t *testing.T) {
+	client := &forgeAPIClient{resolveToken: func(ctx context.Context) string {
+		if ctx.Err() != nil {
+			return ""
+		}
+		return "secret"
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.True(t, client.ready(ctx))
+	assert.True(t, client.ready(context.Background()))
+}
+
+func TestSplitRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote   string
+		host     string
+		repoPath string
+	}{
+		{"git@github.com:org/repo.git", "github.com", "org/repo"},
+		{"https://github.com/org/repo.git", "github.com", "org/repo"},
+		{"ssh://git@GitLab.example.com:2222/group/sub/repo.git", "gitlab.example.com", "group/sub/repo"},
+		{"https://user@gitlab.com/group/repo/", "gitlab.com", "group/repo"},
+		{"", "", ""},
+		{"not-a-remote", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			host, repoPath := splitRemoteURL(tt.remote)
+			assert.Equal(t, tt.host, host)
+			assert.Equal(t, tt.repoPath, repoPath)
+		})
+	}
+}
+
+func TestResolveForgeToken(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	t.Run("environment wins", func(t *testing.T) {
+		t.Setenv("LW_TEST_TOKEN", "from-env")
+		source := forgeTokenSource{
+			envVars:    []string{"LW_TEST_TOKEN"},
+			configFile: func(string) string { return "from-config" },
+		}
+		assert.Equal(t, "from-env", service.resolveForgeToken(ctx, "github.com", source))
+	})
+
+	t.Run("gh hosts file", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("GH_CONFIG_DIR", dir)
+		hosts := "github.com:\n    user: alice\n    oauth_token: gho_hosts\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte(hosts), 0o600))
+		assert.Equal(t, "gho_hosts", ghHostsToken("github.com"))
+		assert.Empty(t, ghHostsToken("ghe.example.com"))
+	})
+
+	t.Run("glab config file", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("GLAB_CONFIG_DIR", dir)
+		cfg := "hosts:\n    gitlab.com:\n        token: glpat-config\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yml"), []byte(cfg), 0o600))
+		assert.Equal(t, "glpat-config", glabConfigToken("gitlab.com"))
+	})
+
+	t.Run("git credential helper", func(t *testing.T) {
+		// The helper only answers when every interactive prompt is disabled.
+		stubDir := writeStub(t, "git", "#!/bin/sh\ncat >/dev/null\n"+
+			"[ \"$GIT_TERMINAL_PROMPT\" = 0 ] && [ \"$GIT_ASKPASS\" = true ] && [ \"$GCM_INTERACTIVE\" = never ] || exit 1\n"+
+			"printf 'protocol=https\\nhost=github.com\\nusername=alice\\npassword=from-helper\\n'\n")
+		withStubbedPath(t, stubDir)
+		source := forgeTokenSource{envVars: []string{"LW_TEST_UNSET_TOKEN"}}
+		assert.Equal(t, "from-helper", service.resolveForgeToken(ctx, "github.com", source))
+	})
+}
+
+func TestGitHubAPIForgePRMapSingleQuery(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	var queries int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries++
+		assert.Equal(t, "/graphql", r.URL.Path)
+		var req struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "org", req.Variables["owner"])
+		assert.Equal(t, "repo", req.Variables["name"])
+		assert.Contains(t, req.Query, "b0: pullRequests(headRefName: $b0")
+		assert.Contains(t, req.Query, "recent: pullRequests(")
+		assert.Contains(t, req.Query, "statusCheckRollup")
+
+		_, _ = w.Write([]byte(`{"data":{"repository":{
+			"b0":{"nodes":[
+				{"number":3,"state":"CLOSED","title":"Old","headRefName":"` + req.Variables["b0"].(string) + `"},
+				{"number":7,"state":"OPEN","title":"Current","url":"https://github.com/org/repo/pull/7","headRefName":"` + req.Variables["b0"].(string) + `","baseRefName":"main","isDraft":true,
+				 "author":{"__typename":"User","login":"alice","name":"Alice"},
+				 "commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"FAILURE"}}}]}}
+			]},
+			"recent":{"nodes":[
+				{"number":9,"state":"MERGED","title":"Bot","headRefName":"deps","author":{"__typename":"Bot","login":"renovate","avatarUrl":"https://avatars/renovate"},
+				 "commits":{"nodes":[{"commit":{"statusCheckRollup":{"state":"SUCCESS"}}}]}}
+			]}
+		}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	prMap, err := service.FetchPRMap(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, queries)
+	require.Len(t, prMap, 2)
+
+	branches := service.worktreeBranches(context.Background())
+	require.Len(t, branches, 1)
+	pr := prMap[branches[0]]
+	require.NotNil(t, pr)
+	assert.Equal(t, 7, pr.Number)
+	assert.Equal(t, prStateOpen, pr.State)
+	assert.True(t, pr.IsDraft)
+	assert.Equal(t, "failure", pr.CIStatus)
+	assert.Equal(t, "main", pr.BaseBranch)
+	assert.Equal(t, "Alice", pr.AuthorName)
+	assert.Equal(t, "https://github.com/alice.png?size=64", pr.AuthorAvatarURL)
+
+	bot := prMap["deps"]
+	require.NotNil(t, bot)
+	assert.True(t, bot.AuthorIsBot)
+	assert.Equal(t, "success", bot.CIStatus)
+	assert.Equal(t, "https://avatars/renovate", bot.AuthorAvatarURL)
+}
+
+func TestGitHubAPIForgeFetchPRErrors(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req.Variables["number"] == float64(404) {
+			_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":null}},"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a PullRequest with the number of 404."}]}`))
+			return
+		}
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	_, err := service.FetchPR(context.Background(), 404)
+	require.Error(t, err)
+	assert.Equal(t, "PR #404 not found", err.Error())
+
+	_, err = service.FetchPR(context.Background(), 7)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "not found")
+	assert.Contains(t, err.Error(), "Bad credentials")
+}
+
+func TestGitHubAPIForgeCIByCommitUsesETag(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "git@github.com:org/repo.git")
+
+	var notModified int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/org/repo/commits/abc123/check-runs", r.URL.Path)
+		if r.Header.Get("If-None-Match") == `W/"runs"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"runs"`)
+		_, _ = w.Write([]byte(`{"check_runs":[
+			{"name":"build","status":"completed","conclusion":"success","html_url":"https://github.com/org/repo/actions/runs/1/job/2","started_at":"2024-01-01T00:00:00Z"},
+			{"name":"lint","status":"in_progress","html_url":"https://github.com/org/repo/actions/runs/1/job/3"}
+		]}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	for range 2 {
+		checks, err := service.FetchCIStatusByCommit(context.Background(), "abc123", "")
+		require.NoError(t, err)
+		require.Len(t, checks, 2)
+		assert.Equal(t, ciSuccess, checks[0].Conclusion)
+		assert.False(t, checks[0].StartedAt.IsZero())
+		assert.Equal(t, ciPending, checks[1].Conclusion)
+	}
+	assert.Equal(t, 1, notModified)
+}
+
+func TestGitHubAPIForgeOpenIssuesSkipsPullRequests(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/org/repo/issues", r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+		_, _ = w.Write([]byte(`[
+			{"number":1,"state":"open","title":"Bug","html_url":"https://github.com/org/repo/issues/1","user":{"login":"bob","type":"User"}},
+			{"number":2,"state":"open","title":"PR","pull_request":{}}
+		]`))
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	issues, err := service.FetchAllOpenIssues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.Equal(t, "bob", issues[0].Author)
+}
+
+func TestGitHubAPIForgeCIStatusFromRollupContexts(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"repository":{"pullRequest":{"commits":{"nodes":[{"commit":{"statusCheckRollup":{"contexts":{"nodes":[
+			{"__typename":"CheckRun","name":"test","status":"COMPLETED","conclusion":"FAILURE","detailsUrl":"https://github.com/org/repo/actions/runs/5/job/6"},
+			{"__typename":"CheckRun","name":"e2e","status":"QUEUED"},
+			{"__typename":"StatusContext","context":"ci/jenkins","state":"SUCCESS","targetUrl":"https://jenkins/1"}
+		]}}}}]}}}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	checks, err := service.FetchCIStatus(context.Background(), 12, "feature")
+	require.NoError(t, err)
+	require.Len(t, checks, 3)
+	assert.Equal(t, ciFailure, checks[0].Conclusion)
+	assert.Equal(t, "failure", checks[0].Status)
+	assert.Equal(t, ciPending, checks[1].Conclusion)
+	assert.Equal(t, "ci/jenkins", checks[2].Name)
+	assert.Equal(t, ciSuccess, checks[2].Conclusion)
+}
+
+func TestGitHubAPIForgeRerun(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	check := &models.CICheck{Link: "https://github.com/org/repo/actions/runs/11/job/22"}
+	runURL, err := service.RerunCICheck(context.Background(), check, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/org/repo/actions/runs/11", runURL)
+	assert.Equal(t, []string{"/repos/org/repo/actions/jobs/22/rerun"}, paths)
+}
+
+func TestGitHubAPIForgeRunURLsUseEnterpriseHost(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.example.com/org/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+	service.githubAPI.host = "github.example.com"
+
+	ctx := context.Background()
+	check := &models.CICheck{Link: "https://github.example.com/org/repo/actions/runs/11/job/22"}
+	runURL, err := service.RerunCICheck(ctx, check, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/org/repo/actions/runs/11", runURL)
+
+	runURL, err = service.CancelCICheck(ctx, check, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/org/repo/actions/runs/11", runURL)
+}
+
+func TestGitHubAPIForgeFetchCICheckLog(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	status := "in_progress"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/org/repo/actions/jobs/22":
+			_, _ = w.Write([]byte(`{"status":"` + status + `"}`))
+		case "/repos/org/repo/actions/jobs/22/logs":
+			assert.Equal(t, "completed", status, "logs of a running job are not published")
+			_, _ = w.Write([]byte("##[group]Run make\nok\n"))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	check := &models.CICheck{Link: "https://github.com/org/repo/actions/runs/11/job/22"}
+	_, err := service.FetchCICheckLog(context.Background(), check, "", 0)
+	assert.ErrorIs(t, err, ErrCILiveLogUnsupported)
+
+	status = "completed"
+	ciLog, err := service.FetchCICheckLog(context.Background(), check, "", 0)
+	require.NoError(t, err)
+	assert.False(t, ciLog.Running)
+	assert.Equal(t, "##[group]Run make\nok\n", ciLog.Content)
+
+	_, err = service.FetchCICheckLog(context.Background(), &models.CICheck{Link: "https://github.com/org/repo/actions/runs/11"}, "", 0)
+	assert.ErrorIs(t, err, ErrCILogUnsupported)
+}
+
+func TestGitHubAPIForgeFallsBackWithoutToken(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+	service.githubAPI = &forgeAPIClient{resolveToken: func(context.Context) string { return "" }}
+
+	stubDir := writeStub(t, "gh", "#!/bin/sh\necho '[{\"headRefName\":\"feature\",\"state\":\"OPEN\",\"number\":4}]'\n")
+	withStubbedPath(t, stubDir)
+
+	prMap, err := service.FetchPRMap(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, prMap, "feature")
+	assert.Equal(t, 4, prMap["feature"].Number)
+}
+
+func TestGitLabAPIForgeCIStatus(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/sub/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fsub%2Frepo/pipelines":
+			assert.Equal(t, "feature/x", r.URL.Query().Get("ref"))
+			_, _ = w.Write([]byte(`[{"id":42}]`))
+		case "/projects/group%2Fsub%2Frepo/pipelines/42/jobs":
+			_, _ = w.Write([]byte(`[
+				{"name":"test","status":"failed","web_url":"https://gitlab.com/group/sub/repo/-/jobs/1","started_at":"2024-01-01T00:00:00Z"},
+				{"name":"deploy","status":"manual","web_url":"https://gitlab.com/group/sub/repo/-/jobs/2"}
+			]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.EscapedPath())
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	checks, err := service.FetchCIStatus(context.Background(), 0, "feature/x")
+	require.NoError(t, err)
+	require.Len(t, checks, 2)
+	assert.Equal(t, ciFailure, checks[0].Conclusion)
+	assert.False(t, checks[0].StartedAt.IsZero())
+	assert.Equal(t, "manual", checks[1].Conclusion)
+}
+
+func TestGitLabAPIForgeFetchCICheckLogFromOffset(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/repo.git")
+
+	trace := "line 1\nline 2\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Frepo/jobs/7":
+			_, _ = w.Write([]byte(`{"status":"running"}`))
+		case "/projects/group%2Frepo/jobs/7/trace":
+			var start int
+			if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err != nil {
+				_, _ = w.Write([]byte(trace))
+				return
+			}
+			if start >= len(trace) {
+				w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+				return
+			}
+			w.WriteHeader(http.StatusPartialContent)
+			_, _ = w.Write([]byte(trace[start:]))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	ctx := context.Background()
+	check := &models.CICheck{Link: "https://gitlab.com/group/repo/-/jobs/7"}
+	ciLog, err := service.FetchCICheckLog(ctx, check, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, CILog{Content: trace, Running: true}, *ciLog)
+
+	ciLog, err = service.FetchCICheckLog(ctx, check, "", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, ciLog.Offset)
+	assert.Equal(t, "line 2\n", ciLog.Content)
+
+	ciLog, err = service.FetchCICheckLog(ctx, check, "", len(trace))
+	require.NoError(t, err)
+	assert.Equal(t, len(trace), ciLog.Offset)
+	assert.Empty(t, ciLog.Content)
+}
+
+func TestGitLabAPIForgeRerunAndCancel(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/sub/repo.git")
+
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		paths = append(paths, r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`{"id":43,"web_url":"https://gitlab.com/group/sub/repo/-/pipelines/43"}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	ctx := context.Background()
+	runURL, err := service.RerunCICheck(ctx, &models.CICheck{Link: "https://gitlab.com/group/sub/repo/-/pipelines/42"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/group/sub/repo/-/pipelines/43", runURL)
+	_, err = service.CancelCICheck(ctx, &models.CICheck{Link: "https://gitlab.com/group/sub/repo/-/jobs/7"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"/projects/group%2Fsub%2Frepo/pipelines/42/retry",
+		"/projects/group%2Fsub%2Frepo/jobs/7/cancel",
+	}, paths)
+}
+
+func TestGitLabAPIForgePRMapAndIssue(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "https://gitlab.example.com/group/repo.git")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/merge_requests"):
+			assert.Equal(t, "all", r.URL.Query().Get("state"))
+			_, _ = w.Write([]byte(`[
+				{"iid":5,"state":"merged","title":"Old","source_branch":"feature","target_branch":"main"},
+				{"iid":6,"state":"opened","title":"New","source_branch":"feature","target_branch":"main","draft":true,"author":{"username":"alice","name":"Alice"}}
+			]`))
+		case strings.HasSuffix(r.URL.Path, "/issues/3"):
+			_, _ = w.Write([]byte(`{"iid":3,"state":"closed","title":"Done"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	prMap, err := service.FetchPRMap(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, prMap, "feature")
+	assert.Equal(t, 6, prMap["feature"].Number)
+	assert.True(t, prMap["feature"].IsDraft)
+	assert.Equal(t, "alice", prMap["feature"].Author)
+
+	_, err = service.FetchIssue(context.Background(), 3)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not open")
+}
@@ -0,0 +1,213 @@
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeForge is a minimal in-memory Forge used to exercise Service dispatch.
+type fakeForge struct {
+	name    string
+	prs     map[string]*models.PRInfo
+	checks  []*models.CICheck
+	reruns  []string
+	cancels []string
+	created []CreatePROptions
+	merged  []MergePROptions
+	threads []*models.PRReviewThread
+	replies []string
+	openPRs []*models.PRInfo
+	logs    *CILog
+}
+
+func (f *fakeForge) Name() string                                 { return f.name }
+func (f *fakeForge) AuthenticatedUsername(context.Context) string { return "fake-user" }
+func (f *fakeForge) FetchPRMap(context.Context) (map[string]*models.PRInfo, error) {
+	return f.prs, nil
+}
+
+func (f *fakeForge) FetchPRForWorktree(context.Context, string) (*models.PRInfo, error) {
+	return f.prs["feature"], nil
+}
+
+func (f *fakeForge) FetchOpenPRs(context.Context) ([]*models.PRInfo, error) {
+	if f.openPRs != nil {
+		return f.openPRs, nil
+	}
+	return []*models.PRInfo{f.prs["feature"]}, nil
+}
+
+func (f *fakeForge) FetchPR(_ context.Context, number int) (*models.PRInfo, error) {
+	return &models.PRInfo{Number: number}, nil
+}
+
+func (f *fakeForge) FetchOpenIssues(context.Context) ([]*models.IssueInfo, error) {
+	return []*models.IssueInfo{{Number: 1}}, nil
+}
+
+func (f *fakeForge) FetchIssue(_ context.Context, number int) (*models.IssueInfo, error) {
+	return &models.IssueInfo{Number: number}, nil
+}
+
+func (f *fakeForge) FetchCIStatus(context.Context, int, string) ([]*models.CICheck, error) {
+	return f.checks, nil
+}
+
+func (f *fakeForge) FetchCIStatusByCommit(context.Context, string, string) ([]*models.CICheck, error) {
+	return f.checks, nil
+}
+
+func (f *fakeForge) SupportsCommitCI() bool { return true }
+
+func (f *fakeForge) OwnsCICheck(_ context.Context, check *models.CICheck) bool {
+	return check.Link == "fake://check"
+}
+
+func (f *fakeForge) CICheckLogCommand(_ context.Context, check *models.CICheck, _ bool) []string {
+	return []string{"fake", "logs", check.Name}
+}
+
+func (f *fakeForge) RerunCICheck(_ context.Context, check *models.CICheck, _ string) (string, error) {
+	f.reruns = append(f.reruns, check.Name)
+	return "fake://rerun", nil
+}
+
+func (f *fakeForge) FetchCICheckLog(_ context.Context, check *models.CICheck, _ string, _ int) (*CILog, error) {
+	if f.logs != nil {
+		return f.logs, nil
+	}
+	return &CILog{Content: "log of " + check.Name}, nil
+}
+
+func (f *fakeForge) CancelCICheck(_ context.Context, check *models.CICheck, _ string) (string, error) {
+	f.cancels = append(f.cancels, check.Name)
+	return "fake://cancel", nil
+}
+
+func (f *fakeForge) CreatePR(_ context.Context, _ string, opts CreatePROptions) (*models.PRInfo, error) {
+	f.created = append(f.created, opts)
+	return createdPRInfo(opts, "fake://pull/7"), nil
+}
+
+func (f *fakeForge) MergePR(_ context.Context, _ string, _ int, opts MergePROptions) error {
+	f.merged = append(f.merged, opts)
+	return nil
+}
+
+func (f *fakeForge) FetchReviewThreads(context.Context, int) ([]*models.PRReviewThread, error) {
+	return f.threads, nil
+}
+
+func (f *fakeForge) ReplyToReviewThread(_ context.Context, _ int, threadID, body string) error {
+	f.replies = append(f.replies, threadID+": "+body)
+	return nil
+}
+
+func (f *fakeForge) SetReviewThreadResolved(context.Context, int, string, bool) error {
+	return nil
+}
+
+func TestSetForgeRoutesServiceCalls(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeForge{
+		name:   "fake",
+		prs:    map[string]*models.PRInfo{"feature": {Number: 42, Branch: "feature"}},
+		checks: []*models.CICheck{{Name: "build", Link: "fake://check"}},
+	}
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostUnknown
+	service.SetForge(fake)
+
+	assert.True(t, service.HasForge(ctx))
+	assert.True(t, service.SupportsCommitCI(ctx))
+	assert.Equal(t, "fake-user", service.GetAuthenticatedUsername(ctx))
+
+	prMap, err := service.FetchPRMap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 42, prMap["feature"].Number)
+
+	pr, err := service.FetchPRForWorktreeWithError(ctx, "/tmp/feature")
+	require.NoError(t, err)
+	assert.Equal(t, 42, pr.Number)
+
+	issue, err := service.FetchIssue(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, issue.Number)
+
+	checks, err := service.FetchCIStatusByCommit(ctx, "abc", "")
+	require.NoError(t, err)
+	assert.Len(t, checks, 1)
+
+	assert.Equal(t, []string{"fake", "logs", "build"}, service.CICheckLogCommand(ctx, checks[0], false))
+	runURL, err := service.RerunCICheck(ctx, checks[0], "")
+	require.NoError(t, err)
+	assert.Equal(t, "fake://rerun", runURL)
+	assert.Equal(t, []string{"build"}, fake.reruns)
+	runURL, err = service.CancelCICheck(ctx, checks[0], "")
+	require.NoError(t, err)
+	assert.Equal(t, "fake://cancel", runURL)
+	assert.Equal(t, []string{"build"}, fake.cancels)
+	ciLog, err := service.FetchCICheckLog(ctx, checks[0], "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "log of build", ciLog.Content)
+}
+
+func TestRegisterForgeUsedForDetectedHost(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeForge{name: "custom-forge", prs: map[string]*models.PRInfo{}}
+	RegisterForge("custom-forge", func(*Service) Forge { return fake })
+	t.Cleanup(func() {
+		forgeRegistryMu.Lock()
+		defer forgeRegistryMu.Unlock()
+		delete(forgeRegistry, "custom-forge")
+	})
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = "custom-forge"
+	require.NotNil(t, service.Forge(ctx))
+	assert.Equal(t, "custom-forge", service.Forge(ctx).Name())
+	assert.Equal(t, "fake-user", service.GetAuthenticatedUsername(ctx))
+}
+
+func TestForgeUnknownHost(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostUnknown
+
+	assert.Nil(t, service.Forge(ctx))
+	assert.False(t, service.HasForge(ctx))
+	assert.False(t, service.SupportsCommitCI(ctx))
+
+	// GitHub Actions links are still served on hosts that are not
+	// recognised, such as GitHub Enterprise.
+	check := &models.CICheck{Name: "build", Link: "https://github.com/owner/repo/actions/runs/123/job/456", Conclusion: ciFailure}
+	assert.Equal(t, []string{"gh", "run", "view", "123", "--log-failed"}, service.CICheckLogCommand(ctx, check, true))
+
+	_, err := service.RerunCICheck(ctx, &models.CICheck{Link: "https://ci.example.com/run/1"}, "")
+	assert.ErrorIs(t, err, ErrCIRerunUnsupported)
+}
+
+func TestGitHubActionsCICheckOnEnterpriseRemote(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostUnknown
+	service.remoteURL = "git@github.corp.example:owner/repo.git"
+
+	check := &models.CICheck{Name: "build", Link: "https://github.corp.example/owner/repo/actions/runs/123/job/456"}
+	assert.Equal(t, []string{"gh", "run", "view", "123", "--log"}, service.CICheckLogCommand(ctx, check, false))
+	assert.Nil(t, service.CICheckLogCommand(ctx, &models.CICheck{Link: "https://ci.example.com/run/1"}, false))
+}
+
+func TestGitHubActionsRunFromLink(t *testing.T) {
+	repo, runID, jobID := githubActionsRunFromLink("https://github.com/owner/repo/actions/runs/12345678/job/98765432")
+	assert.Equal(t, "owner/repo", repo)
+	assert.Equal(t, "12345678", runID)
+	assert.Equal(t, "98765432", jobID)
+
+	_, runID, _ = githubActionsRunFromLink("https://gitlab.com/group/repo/-/jobs/1")
+	assert.Empty(t, runID)
+}
@@ -0,0 +1,658 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const (
+	giteaAPIPrefix = "/api/v1"
+	giteaPageLimit = 50
+	// giteaMaxPages bounds pagination so huge repositories cannot stall a refresh.
+	giteaMaxPages = 20
+)
+
+// giteaKnownHosts lists public Gitea/Forgejo instances recognised without configuration.
+var giteaKnownHosts = []string{"codeberg.org"}
+
+// giteaRemoteRepo captures the owner and repository of a remote URL.
+var giteaRemoteRepo = regexp.MustCompile(`([^/:]+)/([^/]+?)(?:\.git)?/?$`)
+
+// giteaClient scopes a forge API client to a single Gitea/Forgejo repository.
+type giteaClient struct {
+	*forgeAPIClient
+	baseURL string // instance root, e.g. https://codeberg.org
+	owner   string
+	repo    string
+}
+
+// newGiteaClient returns a client for owner/repo on the instance at baseURL.
+func newGiteaClient(baseURL, token, owner, repo string, httpClient *http.Client) *giteaClient {
+	return &giteaClient{
+		forgeAPIClient: &forgeAPIClient{
+			name:       "gitea",
+			restBase:   baseURL + giteaAPIPrefix,
+			authScheme: "token",
+			token:      token,
+			http:       httpClient,
+		},
+		baseURL: baseURL,
+		owner:   owner,
+		repo:    repo,
+	}
+}
+
+type giteaUser struct {
+	Login     string `json:"login"`
+	FullName  string `json:"full_name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type giteaPullRequest struct {
+	Number  int       `json:"number"`
+	State   string    `json:"state"`
+	Merged  bool      `json:"merged"`
+	Draft   bool      `json:"draft"`
+	Title   string    `json:"title"`
+	Body    string    `json:"body"`
+	HTMLURL string    `json:"html_url"`
+	User    giteaUser `json:"user"`
+	Head    struct {
+		Ref  string `json:"ref"`
+		SHA  string `json:"sha"`
+		Repo *struct {
+			CloneURL string `json:"clone_url"`
+		} `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Assignees          []giteaUser `json:"assignees"`
+	RequestedReviewers []giteaUser `json:"requested_reviewers"`
+}
+
+type giteaIssue struct {
+	Number      int       `json:"number"`
+	State       string    `json:"state"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	User        giteaUser `json:"user"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+type giteaCombinedStatus struct {
+	Statuses []struct {
+		Context   string `json:"context"`
+		Status    string `json:"status"` // pending, success, error, failure, warning
+		TargetURL string `json:"target_url"`
+		CreatedAt string `json:"created_at"`
+	} `json:"statuses"`
+}
+
+// teaLogin mirrors a login entry of the tea CLI configuration file.
+type teaLogin struct {
+	Name    string `yaml:"name"`
+	URL     string `yaml:"url"`
+	Token   string `yaml:"token"`
+	SSHHost string `yaml:"ssh_host"`
+	User    string `yaml:"user"`
+}
+
+// teaConfigPath returns the location of the tea CLI login file.
+func teaConfigPath() string {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "tea", "config.yml")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "tea", "config.yml")
+}
+
+// loadTeaLogins reads the tea CLI logins, returning nil when unavailable.
+func loadTeaLogins() []teaLogin {
+	path := teaConfigPath()
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path) // #nosec G304 -- path derived from the user's config directory
+	if err != nil {
+		return nil
+	}
+	var cfg struct {
+		Logins []teaLogin `yaml:"logins"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil
+	}
+	return cfg.Logins
+}
+
+// giteaBaseURL normalises a configured host or URL into an instance base URL
+// and returns it together with the bare hostname used for matching remotes.
+func giteaBaseURL(entry string) (baseURL, hostname string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" {
+		return "", ""
+	}
+	if !strings.Contains(entry, "://") {
+		entry = "https://" + entry
+	}
+	u, err := url.Parse(entry)
+	if err != nil || u.Host == "" {
+		return "", ""
+	}
+	return strings.TrimSuffix(u.Scheme+"://"+u.Host+u.Path, "/"), strings.ToLower(u.Hostname())
+}
+
+// giteaRepoFromRemoteURL extracts owner and repository names from a remote URL.
+func giteaRepoFromRemoteURL(remoteURL string) (owner, repo string) {
+	matches := giteaRemoteRepo.FindStringSubmatch(strings.TrimSpace(remoteURL))
+	if len(matches) < 3 {
+		return "", ""
+	}
+	return matches[1], matches[2]
+}
+
+// newGiteaClientForHost returns a client when hostname belongs to a configured,
+// tea-registered, or well-known Gitea/Forgejo instance.
+func (s *Service) newGiteaClientForHost(ctx context.Context, hostname string) *giteaClient {
+	var baseURL, token string
+	for _, entry := range s.giteaHosts {
+		if candidate, host := giteaBaseURL(entry); host != "" && host == hostname {
+			baseURL = candidate
+			break
+		}
+	}
+	for _, login := range loadTeaLogins() {
+		candidate, host := giteaBaseURL(login.URL)
+		if host == "" || (host != hostname && !strings.EqualFold(login.SSHHost, hostname)) {
+			continue
+		}
+		if baseURL == "" {
+			baseURL = candidate
+		}
+		token = strings.TrimSpace(login.Token)
+		break
+	}
+	if baseURL == "" {
+		for _, known := range giteaKnownHosts {
+			if hostname == known {
+				baseURL = "https://" + known
+				break
+			}
+		}
+	}
+	if baseURL == "" {
+		return nil
+	}
+	if s.giteaToken != "" {
+		token = s.giteaToken
+	}
+
+	owner, repo := giteaRepoFromRemoteURL(s.getRemoteURL(ctx))
+	if owner == "" {
+		owner, repo = giteaRepoFromRemoteURL(s.getOriginRemoteURL(ctx))
+	}
+	if owner == "" {
+		return nil
+	}
+
+	return newGiteaClient(baseURL, token, owner, repo, newForgeHTTPClient())
+}
+
+// giteaForge implements Forge on top of the Gitea/Forgejo REST API.
+type giteaForge struct {
+	s *Service
+}
+
+func newGiteaForge(s *Service) Forge {
+	return &giteaForge{s: s}
+}
+
+func (f *giteaForge) Name() string { return gitHostGitea }
+
+func (f *giteaForge) AuthenticatedUsername(ctx context.Context) string {
+	return f.s.getGiteaAuthenticatedUsername(ctx)
+}
+
+func (f *giteaForge) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	return f.s.fetchGiteaPRs(ctx)
+}
+
+func (f *giteaForge) FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	return f.s.fetchGiteaPRForWorktreeWithError(ctx, worktreePath)
+}
+
+func (f *giteaForge) FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	return f.s.fetchGiteaOpenPRs(ctx)
+}
+
+func (f *giteaForge) FetchPR(ctx context.Context, number int) (*models.PRInfo, error) {
+	return f.s.fetchGiteaPR(ctx, number)
+}
+
+func (f *giteaForge) FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	return f.s.fetchGiteaOpenIssues(ctx)
+}
+
+func (f *giteaForge) FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error) {
+	return f.s.fetchGiteaIssue(ctx, number)
+}
+
+func (f *giteaForge) FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error) {
+	return f.s.fetchGiteaCI(ctx, prNumber, branch)
+}
+
+func (f *giteaForge) FetchCIStatusByCommit(ctx context.Context, commitSHA, _ string) ([]*models.CICheck, error) {
+	return f.s.fetchGiteaCI(ctx, 0, commitSHA)
+}
+
+func (f *giteaForge) SupportsCommitCI() bool { return true }
+
+func (f *giteaForge) OwnsCICheck(_ context.Context, check *models.CICheck) bool {
+	if f.s.gitea == nil || check.Link == "" {
+		return false
+	}
+	return strings.HasPrefix(check.Link, f.s.gitea.baseURL+"/")
+}
+
+// CICheckLogCommand returns nil so Gitea/Forgejo Actions logs open in the browser.
+func (f *giteaForge) CICheckLogCommand(context.Context, *models.CICheck, bool) []string {
+	return nil
+}
+
+func (f *giteaForge) RerunCICheck(context.Context, *models.CICheck, string) (string, error) {
+	return "", ErrCIRerunUnsupported
+}
+
+func (f *giteaForge) FetchCICheckLog(context.Context, *models.CICheck, string, int) (*CILog, error) {
+	return nil, ErrCILogUnsupported
+}
+
+func (f *giteaForge) CancelCICheck(context.Context, *models.CICheck, string) (string, error) {
+	return "", ErrCICancelUnsupported
+}
+
+func (f *giteaForge) CreatePR(ctx context.Context, _ string, opts CreatePROptions) (*models.PRInfo, error) {
+	return f.s.createGiteaPR(ctx, opts)
+}
+
+func (f *giteaForge) MergePR(ctx context.Context, _ string, number int, opts MergePROptions) error {
+	return f.s.mergeGiteaPR(ctx, number, opts)
+}
+
+func (f *giteaForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	return f.s.fetchGiteaReviewThreads(ctx, prNumber)
+}
+
+func (f *giteaForge) ReplyToReviewThread(context.Context, int, string, string) error {
+	return ErrReviewThreadUnsupported
+}
+
+func (f *giteaForge) SetReviewThreadResolved(context.Context, int, string, bool) error {
+	return ErrReviewThreadUnsupported
+}
+
+// giteaAPI returns the Gitea client for this repository, or nil when the host is not Gitea.
+func (s *Service) giteaAPI(ctx context.Context) *giteaClient {
+	if s.DetectHost(ctx) != gitHostGitea {
+		return nil
+	}
+	return s.gitea
+}
+
+// repoPath builds an API path scoped to the client's repository.
+func (c *giteaClient) repoPath(format string, args ...any) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(c.owner), url.PathEscape(c.repo)) + fmt.Sprintf(format, args...)
+}
+
+// normalizeGiteaPRState maps Gitea's open/closed state plus merged flag to the canonical form.
+func normalizeGiteaPRState(pr *giteaPullRequest) string {
+	switch {
+	case pr.Merged:
+		return "MERGED"
+	case strings.EqualFold(pr.State, "open"):
+		return prStateOpen
+	default:
+		return strings.ToUpper(pr.State)
+	}
+}
+
+// giteaIsDraft reports whether a pull request is a draft, honouring Gitea's WIP title prefixes.
+func giteaIsDraft(pr *giteaPullRequest) bool {
+	if pr.Draft {
+		return true
+	}
+	title := strings.ToUpper(strings.TrimSpace(pr.Title))
+	return strings.HasPrefix(title, "WIP:") || strings.HasPrefix(title, "[WIP]")
+}
+
+func giteaPRToInfo(pr *giteaPullRequest) *models.PRInfo {
+	info := &models.PRInfo{
+		Number:          pr.Number,
+		State:           normalizeGiteaPRState(pr),
+		Title:           pr.Title,
+		Body:            pr.Body,
+		URL:             pr.HTMLURL,
+		Branch:          pr.Head.Ref,
+		BaseBranch:      pr.Base.Ref,
+		Author:          pr.User.Login,
+		AuthorName:      pr.User.FullName,
+		AuthorAvatarURL: pr.User.AvatarURL,
+		IsDraft:         giteaIsDraft(pr),
+	}
+	for _, user := range pr.Assignees {
+		info.Assignees = append(info.Assignees, user.Login)
+	}
+	for _, user := range pr.RequestedReviewers {
+		info.ReviewRequests = append(info.ReviewRequests, user.Login)
+	}
+	return info
+}
+
+func giteaIssueToInfo(issue *giteaIssue) *models.IssueInfo {
+	return &models.IssueInfo{
+		Number:     issue.Number,
+		State:      "open",
+		Title:      issue.Title,
+		Body:       issue.Body,
+		URL:        issue.HTMLURL,
+		Author:     issue.User.Login,
+		AuthorName: issue.User.FullName,
+	}
+}
+
+// notifyGiteaError reports a failed Gitea API request once per key.
+func (s *Service) notifyGiteaError(key string, err error) {
+	if s.notifyOnce != nil {
+		s.notifyOnce(key, fmt.Sprintf("Gitea API request failed: %v", err), "error")
+	}
+}
+
+func (s *Service) getGiteaAuthenticatedUsername(ctx context.Context) string {
+	client := s.giteaAPI(ctx)
+	if client == nil || client.token == "" {
+		return ""
+	}
+	var user giteaUser
+	if err := client.get(ctx, "/user", &user); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(user.Login)
+}
+
+// eachGiteaPull walks the repository pull requests page by page, most recently
+// updated first, until fn returns false or a short page marks the last one.
+func (s *Service) eachGiteaPull(ctx context.Context, state string, fn func(*giteaPullRequest) bool) error {
+	client := s.giteaAPI(ctx)
+	if client == nil {
+		return nil
+	}
+	for page := 1; page <= giteaMaxPages; page++ {
+		var prs []giteaPullRequest
+		path := client.repoPath("/pulls?state=%s&sort=recentupdate&limit=%d&page=%d", state, giteaPageLimit, page)
+		if err := client.get(ctx, path, &prs); err != nil {
+			s.notifyGiteaError("gitea_pr_list", err)
+			return err
+		}
+		for i := range prs {
+			if !fn(&prs[i]) {
+				return nil
+			}
+		}
+		if len(prs) < giteaPageLimit {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (s *Service) listGiteaPulls(ctx context.Context, state string) ([]giteaPullRequest, error) {
+	var prs []giteaPullRequest
+	err := s.eachGiteaPull(ctx, state, func(pr *giteaPullRequest) bool {
+		prs = append(prs, *pr)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return prs, nil
+}
+
+func (s *Service) fetchGiteaPRs(ctx context.Context) (map[string]*models.PRInfo, error) {
+	prs, err := s.listGiteaPulls(ctx, "all")
+	if err != nil {
+		return nil, err
+	}
+
+	prMap := make(map[string]*models.PRInfo)
+	for i := range prs {
+		if prs[i].Head.Ref == "" {
+			continue
+		}
+		// Pages are newest first, keep the most recent PR for each branch.
+		if _, ok := prMap[prs[i].Head.Ref]; ok {
+			continue
+		}
+		prMap[prs[i].Head.Ref] = giteaPRToInfo(&prs[i])
+	}
+	return prMap, nil
+}
+
+// fetchGiteaPRForWorktreeWithError pages through pull requests matching their
+// head branch against the worktree, stopping at the first open match.
+func (s *Service) fetchGiteaPRForWorktreeWithError(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	candidates := s.prBranchCandidates(ctx, worktreePath)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	var match *models.PRInfo
+	err := s.eachGiteaPull(ctx, "all", func(pr *giteaPullRequest) bool {
+		if !slices.Contains(candidates, pr.Head.Ref) {
+			return true
+		}
+		info := giteaPRToInfo(pr)
+		// Prefer an open PR over older closed ones for the same branch.
+		if match == nil || (match.State != prStateOpen && info.State == prStateOpen) {
+			match = info
+		}
+		return match.State != prStateOpen
+	})
+	if err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+func (s *Service) fetchGiteaOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	prs, err := s.listGiteaPulls(ctx, "open")
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.PRInfo, 0, len(prs))
+	for i := range prs {
+		info := giteaPRToInfo(&prs[i])
+		if info.State != prStateOpen {
+			continue
+		}
+		// Combined status would require one request per PR, default to none
+		info.CIStatus = "none"
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (s *Service) getGiteaPull(ctx context.Context, prNumber int) (*giteaPullRequest, error) {
+	client := s.giteaAPI(ctx)
+	if client == nil {
+		return nil, fmt.Errorf("PR #%d not found", prNumber)
+	}
+	var pr giteaPullRequest
+	if err := client.get(ctx, client.repoPath("/pulls/%d", prNumber), &pr); err != nil {
+		if errors.Is(err, errForgeNotFound) {
+			return nil, fmt.Errorf("PR #%d not found", prNumber)
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+func (s *Service) fetchGiteaPR(ctx context.Context, prNumber int) (*models.PRInfo, error) {
+	pr, err := s.getGiteaPull(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	info := giteaPRToInfo(pr)
+	if info.State != prStateOpen {
+		return nil, fmt.Errorf("PR #%d is not open (state: %s)", prNumber, info.State)
+	}
+	info.CIStatus = "none"
+	return info, nil
+}
+
+func (s *Service) fetchGiteaOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	client := s.giteaAPI(ctx)
+	if client == nil {
+		return []*models.IssueInfo{}, nil
+	}
+	result := []*models.IssueInfo{}
+	for page := 1; page <= giteaMaxPages; page++ {
+		var issues []giteaIssue
+		if err := client.get(ctx, client.repoPath("/issues?state=open&type=issues&limit=%d&page=%d", giteaPageLimit, page), &issues); err != nil {
+			s.notifyGiteaError("gitea_issue_list", err)
+			return nil, err
+		}
+		for i := range issues {
+			if issues[i].PullRequest != nil || !strings.EqualFold(issues[i].State, "open") {
+				continue
+			}
+			result = append(result, giteaIssueToInfo(&issues[i]))
+		}
+		if len(issues) < giteaPageLimit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) fetchGiteaIssue(ctx context.Context, issueNumber int) (*models.IssueInfo, error) {
+	client := s.giteaAPI(ctx)
+	if client == nil {
+		return nil, fmt.Errorf("issue #%d not found", issueNumber)
+	}
+	var issue giteaIssue
+	if err := client.get(ctx, client.repoPath("/issues/%d", issueNumber), &issue); err != nil {
+		if errors.Is(err, errForgeNotFound) {
+			return nil, fmt.Errorf("issue #%d not found", issueNumber)
+		}
+		return nil, err
+	}
+	if issue.PullRequest != nil {
+		return nil, fmt.Errorf("issue #%d is a pull request", issueNumber)
+	}
+	if !strings.EqualFold(issue.State, "open") {
+		return nil, fmt.Errorf("issue #%d is not open (state: %s)", issueNumber, issue.State)
+	}
+	return giteaIssueToInfo(&issue), nil
+}
+
+// fetchGiteaCI fetches commit statuses (including Gitea/Forgejo Actions) for a ref.
+// When a PR number is given its head commit is used, otherwise ref is queried directly.
+func (s *Service) fetchGiteaCI(ctx context.Context, prNumber int, ref string) ([]*models.CICheck, error) {
+	client := s.giteaAPI(ctx)
+	if client == nil {
+		return nil, nil
+	}
+	if prNumber > 0 {
+		if pr, err := s.getGiteaPull(ctx, prNumber); err == nil && pr.Head.SHA != "" {
+			ref = pr.Head.SHA
+		}
+	}
+	if ref == "" {
+		return nil, nil
+	}
+
+	var combined giteaCombinedStatus
+	if err := client.get(ctx, client.repoPath("/commits/%s/status", url.PathEscape(ref)), &combined); err != nil {
+		if errors.Is(err, errForgeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	result := make([]*models.CICheck, 0, len(combined.Statuses))
+	for _, st := range combined.Statuses {
+		var startedAt time.Time
+		if st.CreatedAt != "" {
+			startedAt, _ = time.Parse(time.RFC3339, st.CreatedAt)
+		}
+		result = append(result, &models.CICheck{
+			Name:       st.Context,
+			Status:     strings.ToLower(st.Status),
+			Conclusion: giteaStatusToConclusion(st.Status),
+			Link:       st.TargetURL,
+			StartedAt:  startedAt,
+		})
+	}
+	return result, nil
+}
+
+func giteaStatusToConclusion(status string) string {
+	switch strings.ToLower(status) {
+	case "success":
+		return ciSuccess
+	case "failure", "error", "warning":
+		// Warnings flag problems the check did not treat as fatal; surface them
+		return ciFailure
+	case "pending":
+		return ciPending
+	default:
+		return status
+	}
+}
+
+// fetchGiteaPRRefInfo resolves the head commit of a Gitea PR and fetches its pull ref.
+func (s *Service) fetchGiteaPRRefInfo(ctx context.Context, prNumber int) (*prRefInfo, bool) {
+	pr, err := s.getGiteaPull(ctx, prNumber)
+	if err != nil {
+		s.notify(fmt.Sprintf("Failed to get PR #%d info: %v", prNumber, err), "error")
+		return nil, false
+	}
+	if pr.Head.SHA == "" {
+		s.notify(fmt.Sprintf("Failed to get PR #%d head commit", prNumber), "error")
+		return nil, false
+	}
+	remoteName := s.resolveRemoteName(ctx)
+	repoURL := s.getRemoteURL(ctx)
+	if pr.Head.Repo != nil && pr.Head.Repo.CloneURL != "" {
+		repoURL = pr.Head.Repo.CloneURL
+	}
+	mergeRef := fmt.Sprintf("refs/pull/%d/head", prNumber)
+	if !s.RunCommandChecked(ctx, []string{"git", "fetch", remoteName, mergeRef}, "", fmt.Sprintf("Failed to fetch PR #%d", prNumber)) {
+		return nil, false
+	}
+	return &prRefInfo{
+		headCommit: pr.Head.SHA,
+		repoURL:    repoURL,
+		remoteName: remoteName,
+		mergeRef:   mergeRef,
+	}, true
+}
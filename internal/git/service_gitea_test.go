@@ -0,0 +1,291 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGiteaTestService returns a Service pre-seeded to talk to a local Gitea stand-in.
+func newGiteaTestService(t *testing.T, routes map[string]any) (*Service, *[]string) {
+	t.Helper()
+
+	var authHeaders []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeaders = append(authHeaders, r.Header.Get("Authorization"))
+		payload, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(payload)
+	}))
+	t.Cleanup(srv.Close)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitea
+	service.gitea = newGiteaClient(srv.URL, "secret", "org", "repo", srv.Client())
+	return service, &authHeaders
+}
+
+func giteaTestPull(number int, state string, merged bool, branch string) map[string]any {
+	return map[string]any{
+		"number":   number,
+		"state":    state,
+		"merged":   merged,
+		"title":    "Change " + branch,
+		"body":     "body",
+		"html_url": "https://git.example.com/org/repo/pulls/" + branch,
+		"user":     map[string]any{"login": "alice", "full_name": "Alice", "avatar_url": "https://git.example.com/avatar/alice"},
+		"head":     map[string]any{"ref": branch, "sha": "abc123"},
+		"base":     map[string]any{"ref": "main"},
+	}
+}
+
+func TestFetchGiteaPRs(t *testing.T) {
+	service, auth := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/pulls?state=all&sort=recentupdate&limit=50&page=1": []any{
+			giteaTestPull(1, "open", false, "feature"),
+			giteaTestPull(2, "closed", true, "merged-branch"),
+			giteaTestPull(3, "closed", false, "closed-branch"),
+		},
+	})
+
+	prMap, err := service.FetchPRMap(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prMap, 3)
+
+	assert.Equal(t, prStateOpen, prMap["feature"].State)
+	assert.Equal(t, "MERGED", prMap["merged-branch"].State)
+	assert.Equal(t, "CLOSED", prMap["closed-branch"].State)
+	assert.Equal(t, "alice", prMap["feature"].Author)
+	assert.Equal(t, "Alice", prMap["feature"].AuthorName)
+	assert.Equal(t, "main", prMap["feature"].BaseBranch)
+	assert.Equal(t, []string{"token secret"}, *auth)
+}
+
+func TestFetchGiteaPRsPaginates(t *testing.T) {
+	firstPage := make([]any, 0, giteaPageLimit)
+	for i := 1; i <= giteaPageLimit; i++ {
+		firstPage = append(firstPage, giteaTestPull(i, "open", false, fmt.Sprintf("branch-%d", i)))
+	}
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/pulls?state=all&sort=recentupdate&limit=50&page=1": firstPage,
+		"/api/v1/repos/org/repo/pulls?state=all&sort=recentupdate&limit=50&page=2": []any{
+			giteaTestPull(99, "closed", true, "old-branch"),
+		},
+	})
+
+	prMap, err := service.FetchPRMap(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prMap, giteaPageLimit+1)
+	assert.Equal(t, 99, prMap["old-branch"].Number)
+}
+
+func TestFetchGiteaOpenPRsSkipsClosedAndDetectsWIP(t *testing.T) {
+	wip := giteaTestPull(4, "open", false, "wip")
+	wip["title"] = "WIP: not ready"
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/pulls?state=open&sort=recentupdate&limit=50&page=1": []any{
+			giteaTestPull(1, "open", false, "feature"),
+			giteaTestPull(2, "closed", true, "merged-branch"),
+			wip,
+		},
+	})
+
+	prs, err := service.FetchAllOpenPRs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prs, 2)
+	assert.Equal(t, 1, prs[0].Number)
+	assert.False(t, prs[0].IsDraft)
+	assert.True(t, prs[1].IsDraft)
+	assert.Equal(t, "none", prs[1].CIStatus)
+}
+
+func TestFetchGiteaPR(t *testing.T) {
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/pulls/7": giteaTestPull(7, "open", false, "feature"),
+		"/api/v1/repos/org/repo/pulls/8": giteaTestPull(8, "closed", true, "done"),
+	})
+	ctx := context.Background()
+
+	pr, err := service.FetchPR(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", pr.Branch)
+	assert.Equal(t, "main", pr.BaseBranch)
+
+	_, err = service.FetchPR(ctx, 8)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not open")
+
+	_, err = service.FetchPR(ctx, 9)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestFetchGiteaIssues(t *testing.T) {
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/issues?state=open&type=issues&limit=50&page=1": []any{
+			map[string]any{"number": 10, "state": "open", "title": "Bug", "body": "broken", "html_url": "https://git.example.com/org/repo/issues/10", "user": map[string]any{"login": "bob"}},
+			map[string]any{"number": 11, "state": "open", "title": "A PR", "pull_request": map[string]any{"merged": false}},
+		},
+		"/api/v1/repos/org/repo/issues/10": map[string]any{"number": 10, "state": "open", "title": "Bug", "user": map[string]any{"login": "bob"}},
+		"/api/v1/repos/org/repo/issues/12": map[string]any{"number": 12, "state": "closed", "title": "Old"},
+	})
+	ctx := context.Background()
+
+	issues, err := service.FetchAllOpenIssues(ctx)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, 10, issues[0].Number)
+	assert.Equal(t, "bob", issues[0].Author)
+
+	issue, err := service.FetchIssue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "Bug", issue.Title)
+
+	_, err = service.FetchIssue(ctx, 12)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not open")
+}
+
+func TestFetchGiteaIssuesPaginates(t *testing.T) {
+	firstPage := make([]any, 0, giteaPageLimit)
+	for i := 1; i <= giteaPageLimit; i++ {
+		firstPage = append(firstPage, map[string]any{"number": i, "state": "open", "title": fmt.Sprintf("Issue %d", i)})
+	}
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/issues?state=open&type=issues&limit=50&page=1": firstPage,
+		"/api/v1/repos/org/repo/issues?state=open&type=issues&limit=50&page=2": []any{
+			map[string]any{"number": 99, "state": "open", "title": "Oldest"},
+		},
+	})
+
+	issues, err := service.FetchAllOpenIssues(context.Background())
+	require.NoError(t, err)
+	require.Len(t, issues, giteaPageLimit+1)
+	assert.Equal(t, 99, issues[giteaPageLimit].Number)
+}
+
+func TestFetchGiteaCI(t *testing.T) {
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/repos/org/repo/pulls/5": giteaTestPull(5, "open", false, "feature"),
+		"/api/v1/repos/org/repo/commits/abc123/status": map[string]any{
+			"state": "failure",
+			"statuses": []any{
+				map[string]any{"context": "ci / build (push)", "status": "success", "target_url": "https://git.example.com/org/repo/actions/runs/1/jobs/0", "created_at": "2026-01-02T03:04:05Z"},
+				map[string]any{"context": "ci / test (push)", "status": "failure"},
+				map[string]any{"context": "ci / lint (push)", "status": "pending"},
+				map[string]any{"context": "ci / deploy (push)", "status": "error"},
+				map[string]any{"context": "ci / audit (push)", "status": "warning"},
+			},
+		},
+	})
+	ctx := context.Background()
+
+	checks, err := service.FetchCIStatus(ctx, 5, "local-feature")
+	require.NoError(t, err)
+	require.Len(t, checks, 5)
+	assert.Equal(t, "ci / build (push)", checks[0].Name)
+	assert.Equal(t, ciSuccess, checks[0].Conclusion)
+	assert.Equal(t, "https://git.example.com/org/repo/actions/runs/1/jobs/0", checks[0].Link)
+	assert.False(t, checks[0].StartedAt.IsZero())
+	assert.Equal(t, ciFailure, checks[1].Conclusion)
+	assert.Equal(t, ciPending, checks[2].Conclusion)
+	assert.Equal(t, ciFailure, checks[3].Conclusion)
+	assert.Equal(t, ciFailure, checks[4].Conclusion, "warnings are not hidden as skipped")
+
+	byCommit, err := service.FetchCIStatusByCommit(ctx, "abc123", "")
+	require.NoError(t, err)
+	assert.Len(t, byCommit, 5)
+
+	missing, err := service.FetchCIStatusByCommit(ctx, "deadbeef", "")
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+}
+
+func TestGetAuthenticatedUsernameGitea(t *testing.T) {
+	service, _ := newGiteaTestService(t, map[string]any{
+		"/api/v1/user": map[string]any{"login": "alice"},
+	})
+	assert.Equal(t, "alice", service.GetAuthenticatedUsername(context.Background()))
+}
+
+func TestFetchGiteaAPIErrorNotifies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	var notified []string
+	service := NewService(func(string, string) {}, func(key, _, _ string) { notified = append(notified, key) })
+	service.gitHost = gitHostGitea
+	service.gitea = newGiteaClient(srv.URL, "", "org", "repo", srv.Client())
+
+	_, err := service.FetchPRMap(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, []string{"gitea_pr_list"}, notified)
+}
+
+func TestDetectHostGiteaFromConfigAndTea(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("configured host", func(t *testing.T) {
+		t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+		repo := t.TempDir()
+		runGit(t, repo, "init")
+		runGit(t, repo, "remote", "add", "origin", "git@forge.internal:team/project.git")
+		withCwd(t, repo)
+
+		service := NewService(func(string, string) {}, func(string, string, string) {})
+		service.SetGiteaConfig("cfg-token", []string{"https://forge.internal/"})
+		require.Equal(t, gitHostGitea, service.DetectHost(ctx))
+		assert.True(t, service.HasForge(ctx))
+		assert.False(t, service.IsGitHubOrGitLab(ctx))
+		assert.Equal(t, "https://forge.internal", service.gitea.baseURL)
+		assert.Equal(t, "cfg-token", service.gitea.token)
+		assert.Equal(t, "team", service.gitea.owner)
+		assert.Equal(t, "project", service.gitea.repo)
+	})
+
+	t.Run("tea login", func(t *testing.T) {
+		configHome := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", configHome)
+		require.NoError(t, os.MkdirAll(filepath.Join(configHome, "tea"), 0o750))
+		teaConfig := "logins:\n  - name: work\n    url: https://git.work.example/\n    token: tea-token\n    ssh_host: ssh.work.example\n    user: alice\n"
+		require.NoError(t, os.WriteFile(filepath.Join(configHome, "tea", "config.yml"), []byte(teaConfig), 0o600))
+
+		repo := t.TempDir()
+		runGit(t, repo, "init")
+		runGit(t, repo, "remote", "add", "origin", "git@ssh.work.example:team/project.git")
+		withCwd(t, repo)
+
+		service := NewService(func(string, string) {}, func(string, string, string) {})
+		require.Equal(t, gitHostGitea, service.DetectHost(ctx))
+		assert.Equal(t, "https://git.work.example", service.gitea.baseURL)
+		assert.Equal(t, "tea-token", service.gitea.token)
+	})
+}
+
+func TestGiteaRepoFromRemoteURL(t *testing.T) {
+	cases := map[string][2]string{
+		"https://codeberg.org/forgejo/forgejo.git": {"forgejo", "forgejo"},
+		"git@codeberg.org:org/repo.git":            {"org", "repo"},
+		"ssh://git@host:2222/org/repo":             {"org", "repo"},
+		"https://host/sub/path/org/repo.git/":      {"org", "repo"},
+	}
+	for remote, want := range cases {
+		owner, repo := giteaRepoFromRemoteURL(remote)
+		assert.Equal(t, want[0], owner, remote)
+		assert.Equal(t, want[1], repo, remote)
+	}
+}
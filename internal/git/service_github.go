@@ -1,9 +1,11 @@
 package git
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os/exec"
 	"strconv"
 	"strings"
@@ -39,26 +41,261 @@ func extractAuthor(data map[string]any, keys authorKeys) (author, authorName, av
 	return
 }
 
-func githubAvatarURL(login string) string {
-	login = strings.TrimSpace(login)
-	if login == "" {
+// githubForge implements Forge on top of the gh CLI.
+type githubForge struct {
+	s *Service
+}
+
+func newGitHubForge(s *Service) Forge {
+	if s.useForgeHTTP() {
+		return &githubAPIForge{githubForge{s: s}}
+	}
+	return &githubForge{s: s}
+}
+
+func (f *githubForge) Name() string { return gitHostGithub }
+
+func (f *githubForge) AuthenticatedUsername(ctx context.Context) string {
+	return f.s.getGitHubAuthenticatedUsername(ctx)
+}
+
+func (f *githubForge) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	return f.s.fetchGitHubPRs(ctx)
+}
+
+func (f *githubForge) FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	return f.s.fetchGitHubPRForWorktreeWithError(ctx, worktreePath)
+}
+
+func (f *githubForge) FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	return f.s.fetchGitHubOpenPRs(ctx)
+}
+
+func (f *githubForge) FetchPR(ctx context.Context, number int) (*models.PRInfo, error) {
+	return f.s.fetchGitHubPR(ctx, number)
+}
+
+func (f *githubForge) FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	return f.s.fetchGitHubOpenIssues(ctx)
+}
+
+func (f *githubForge) FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error) {
+	return f.s.fetchGitHubIssue(ctx, number)
+}
+
+func (f *githubForge) FetchCIStatus(ctx context.Context, prNumber int, _ string) ([]*models.CICheck, error) {
+	return f.s.fetchGitHubCI(ctx, prNumber)
+}
+
+func (f *githubForge) FetchCIStatusByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error) {
+	return f.s.fetchGitHubCIByCommit(ctx, commitSHA, worktreePath)
+}
+
+func (f *githubForge) SupportsCommitCI() bool { return true }
+
+func (f *githubForge) OwnsCICheck(_ context.Context, check *models.CICheck) bool {
+	_, runID, _ := githubActionsRunFromLink(check.Link)
+	return runID != ""
+}
+
+func (f *githubForge) CICheckLogCommand(_ context.Context, check *models.CICheck, failedOnly bool) []string {
+	_, runID, _ := githubActionsRunFromLink(check.Link)
+	if runID == "" {
+		return nil
+	}
+	// Use --log-failed for failed checks, --log for others
+	logFlag := "--log"
+	if failedOnly {
+		logFlag = "--log-failed"
+	}
+	return []string{"gh", "run", "view", runID, logFlag}
+}
+
+func (f *githubForge) RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	repo, runID, jobID := githubActionsRunFromLink(check.Link)
+	if runID == "" {
+		return "", ErrCIRerunUnsupported
+	}
+	if repo == "" {
+		return "", fmt.Errorf("unable to determine repository from link")
+	}
+
+	args := []string{"gh", "run", "rerun", runID}
+	if jobID != "" {
+		args = append(args, "--job", jobID)
+	}
+	args = append(args, "-R", repo)
+
+	// gh run rerun produces no stdout on success, so we can't check output.
+	// RunGit with silent=false sends a notification on failure.
+	f.s.RunGit(ctx, args, worktreePath, []int{0}, true, false)
+
+	return githubActionsRunURL(linkHost(check.Link), repo, runID), nil
+}
+
+// CancelCICheck cancels the workflow run of a check; GitHub Actions cannot
+// cancel a single job.
+func (f *githubForge) CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	repo, runID, _ := githubActionsRunFromLink(check.Link)
+	if runID == "" {
+		return "", ErrCICancelUnsupported
+	}
+	if repo == "" {
+		return "", fmt.Errorf("unable to determine repository from link")
+	}
+
+	out, err := f.s.RunGitWithCombinedOutput(ctx, []string{"gh", "run", "cancel", runID, "-R", repo}, worktreePath, nil)
+	if err != nil {
+		return "", fmt.Errorf("gh run cancel failed: %s", strings.TrimSpace(string(out)))
+	}
+	return githubActionsRunURL(linkHost(check.Link), repo, runID), nil
+}
+
+// FetchCICheckLog reads the job and its log with gh api. GitHub only serves
+// the log of a job once it finishes, so running jobs return
+// ErrCILiveLogUnsupported and the whole log is always downloaded.
+func (f *githubForge) FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, _ int) (*CILog, error) {
+	repo, _, jobID := githubActionsRunFromLink(check.Link)
+	if jobID == "" || repo == "" {
+		return nil, ErrCILogUnsupported
+	}
+	jobPath := fmt.Sprintf("repos/%s/actions/jobs/%s", repo, jobID)
+
+	out, err := f.s.RunGitWithCombinedOutput(ctx, []string{"gh", "api", jobPath}, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %s", strings.TrimSpace(string(out)))
+	}
+	var job struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+	if err := json.Unmarshal(out, &job); err != nil {
+		return nil, fmt.Errorf("failed to parse job: %w", err)
+	}
+	if job.Status != "completed" {
+		return nil, ErrCILiveLogUnsupported
+	}
+
+	out, err = f.s.RunGitWithCombinedOutput(ctx, []string{"gh", "api", jobPath + "/logs"}, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gh api failed: %s", strings.TrimSpace(string(out)))
+	}
+	ciLog := &CILog{Content: string(out)}
+
+	if job.Conclusion == ciFailure {
+		// A job is a check run, so annotations are looked up by job ID.
+		annotationsPath := fmt.Sprintf("repos/%s/check-runs/%s/annotations", repo, jobID)
+		if out, err := f.s.RunGitWithCombinedOutput(ctx, []string{"gh", "api", annotationsPath}, worktreePath, nil); err == nil {
+			var annotations []githubAnnotation
+			if json.Unmarshal(out, &annotations) == nil {
+				ciLog.Failures = githubAnnotationFailures(annotations)
+			}
+		}
+	}
+	return ciLog, nil
+}
+
+// githubAnnotation is a check-run annotation, as returned by the REST API.
+type githubAnnotation struct {
+	Path      string `json:"path"`
+	StartLine int    `json:"start_line"`
+	Level     string `json:"annotation_level"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+}
+
+// githubAnnotationFailures keeps the failure annotations, dropping the
+// generic exit code annotation the runner adds to every failed job.
+func githubAnnotationFailures(annotations []githubAnnotation) []models.CIFailure {
+	var failures []models.CIFailure
+	for _, a := range annotations {
+		if a.Level != ciFailure || strings.HasPrefix(a.Message, "Process completed with exit code") {
+			continue
+		}
+		failure := models.CIFailure{Message: cmp.Or(a.Message, a.Title)}
+		if a.Path != ".github" {
+			failure.File = a.Path
+			failure.Line = a.StartLine
+		}
+		failures = append(failures, failure)
+	}
+	return failures
+}
+
+func (f *githubForge) CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	return f.s.createGitHubPR(ctx, worktreePath, opts)
+}
+
+func (f *githubForge) MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	return f.s.mergeGitHubPR(ctx, worktreePath, number, opts)
+}
+
+func (f *githubForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	return f.s.fetchGitHubReviewThreads(ctx, prNumber)
+}
+
+func (f *githubForge) ReplyToReviewThread(ctx context.Context, _ int, threadID, body string) error {
+	return f.s.replyToGitHubReviewThread(ctx, threadID, body)
+}
+
+func (f *githubForge) SetReviewThreadResolved(ctx context.Context, _ int, threadID string, resolved bool) error {
+	return f.s.setGitHubReviewThreadResolved(ctx, threadID, resolved)
+}
+
+// githubActionsRunFromLink extracts owner/repo, run ID, and job ID from a
+// GitHub Actions URL such as
+// https://github.com/owner/repo/actions/runs/12345678/job/98765432.
+// GitHub Enterprise hosts are recognised the same way DetectHost does.
+func githubActionsRunFromLink(link string) (repo, runID, jobID string) {
+	if link == "" {
+		return "", "", ""
+	}
+	parsed, err := url.Parse(link)
+	if err != nil || !strings.Contains(strings.ToLower(parsed.Hostname()), gitHostGithub) {
+		return "", "", ""
+	}
+	parts := strings.Split(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if len(parts) >= 2 {
+		repo = parts[0] + "/" + parts[1]
+	}
+	for i, part := range parts {
+		if i+1 >= len(parts) {
+			break
+		}
+		switch part {
+		case "runs":
+			runID = parts[i+1]
+		case "job":
+			jobID = parts[i+1]
+		}
+	}
+	return repo, runID, jobID
+}
+
+// githubActionsRunURL returns the page of a workflow run on host, which
+// defaults to github.com.
+func githubActionsRunURL(host, repo, runID string) string {
+	if host == "" {
+		host = "github.com"
+	}
+	return fmt.Sprintf("https://%s/%s/actions/runs/%s", host, repo, runID)
+}
+
+// linkHost returns the host (and port) of a URL, or "" when it cannot be parsed.
+func linkHost(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil {
 		return ""
 	}
-	return fmt.Sprintf("https://github.com/%s.png?size=64", login)
+	return parsed.Host
 }
 
-// GetAuthenticatedUsername returns the authenticated forge username for this repository host.
-// Returns an empty string when no authenticated username can be resolved.
-func (s *Service) GetAuthenticatedUsername(ctx context.Context) string {
-	host := s.DetectHost(ctx)
-	switch host {
-	case gitHostGithub:
-		return s.getGitHubAuthenticatedUsername(ctx)
-	case gitHostGitLab:
-		return s.getGitLabAuthenticatedUsername(ctx)
-	default:
+func githubAvatarURL(login string) string {
+	login = strings.TrimSpace(login)
+	if login == "" {
 		return ""
 	}
+	return fmt.Sprintf("https://github.com/%s.png?size=64", login)
 }
 
 func (s *Service) getGitHubAuthenticatedUsername(ctx context.Context) string {
@@ -66,22 +303,43 @@ func (s *Service) getGitHubAuthenticatedUsername(ctx context.Context) string {
 	return strings.TrimSpace(username)
 }
 
-// FetchPRMap gathers PR/MR information via supported host APIs (GitHub or GitLab).
-// Returns a map keyed by branch name to PRInfo. Detects the host automatically
-// based on the repository's remote URL.
-func (s *Service) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
-	host := s.DetectHost(ctx)
-
-	// Skip PR fetching for repos without GitHub/GitLab remotes
-	if host == gitHostUnknown {
-		return make(map[string]*models.PRInfo), nil
+// AuthenticatedTeams returns the slugs of the teams of the authenticated user
+// in the organisation owning the repository, so team review requests reach
+// the PR/MR inbox.
+func (f *githubForge) AuthenticatedTeams(ctx context.Context) []string {
+	owner, _, ok := strings.Cut(f.s.ResolveCITargetRepoName(ctx), "/")
+	if !ok || owner == "" {
+		return nil
+	}
+	raw := f.s.RunGit(ctx, []string{"gh", "api", "--paginate", "user/teams", "--jq", `.[] | [.organization.login, .slug] | @tsv`}, "", []int{0}, true, true)
+	var teams []githubTeam
+	for line := range strings.SplitSeq(raw, "\n") {
+		if org, slug, found := strings.Cut(strings.TrimSpace(line), "\t"); found {
+			team := githubTeam{Slug: slug}
+			team.Organization.Login = org
+			teams = append(teams, team)
+		}
 	}
+	return githubTeamSlugs(teams, owner)
+}
 
-	if host == gitHostGitLab {
-		return s.fetchGitLabPRs(ctx)
-	}
+// githubTeam is a team of the authenticated user as returned by /user/teams.
+type githubTeam struct {
+	Slug         string `json:"slug"`
+	Organization struct {
+		Login string `json:"login"`
+	} `json:"organization"`
+}
 
-	return s.fetchGitHubPRs(ctx)
+// githubTeamSlugs returns the slugs of the teams belonging to org.
+func githubTeamSlugs(teams []githubTeam, org string) []string {
+	var slugs []string
+	for _, team := range teams {
+		if team.Slug != "" && strings.EqualFold(team.Organization.Login, org) {
+			slugs = append(slugs, team.Slug)
+		}
+	}
+	return slugs
 }
 
 func (s *Service) fetchGitHubPRs(ctx context.Context) (map[string]*models.PRInfo, error) {
@@ -137,20 +395,6 @@ func (s *Service) fetchGitHubPRs(ctx context.Context) (map[string]*models.PRInfo
 	return prMap, nil
 }
 
-// FetchPRForWorktreeWithError fetches PR info and returns detailed error information.
-func (s *Service) FetchPRForWorktreeWithError(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
-	host := s.DetectHost(ctx)
-
-	switch host {
-	case gitHostGithub:
-		return s.fetchGitHubPRForWorktreeWithError(ctx, worktreePath)
-	case gitHostGitLab:
-		return s.fetchGitLabPRForWorktreeWithError(ctx, worktreePath)
-	default:
-		return nil, nil
-	}
-}
-
 func (s *Service) fetchGitHubPRForWorktreeWithError(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
 	// Run gh pr view with silent=false to capture actual errors
 	prRaw := s.RunGit(ctx, []string{
@@ -199,29 +443,11 @@ func (s *Service) fetchGitHubPRForWorktreeWithError(ctx context.Context, worktre
 	}, nil
 }
 
-// FetchPRForWorktree fetches PR info for a specific worktree by running gh/glab in that directory.
-// This correctly detects PRs even when the local branch name differs from the remote branch.
-// Maintains backward compatibility by swallowing errors.
-func (s *Service) FetchPRForWorktree(ctx context.Context, worktreePath string) *models.PRInfo {
-	pr, _ := s.FetchPRForWorktreeWithError(ctx, worktreePath)
-	return pr
-}
-
-// FetchAllOpenPRs fetches all open PRs/MRs and returns them as a slice.
-func (s *Service) FetchAllOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
-	host := s.DetectHost(ctx)
-	if host == gitHostGitLab {
-		return s.fetchGitLabOpenPRs(ctx)
-	}
-
-	return s.fetchGitHubOpenPRs(ctx)
-}
-
 func (s *Service) fetchGitHubOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
 	args := []string{
 		"gh", "pr", "list",
 		"--state", "open",
-		"--json", "headRefName,state,number,title,body,url,author,isDraft,statusCheckRollup",
+		"--json", "headRefName,state,number,title,body,url,author,isDraft,statusCheckRollup,assignees,reviewRequests",
 		"--limit", "100",
 	}
 	args = append(args, s.ghRepoArgs(ctx)...)
@@ -269,20 +495,29 @@ func (s *Service) fetchGitHubOpenPRs(ctx context.Context) ([]*models.PRInfo, err
 			AuthorIsBot:     authorIsBot,
 			IsDraft:         isDraft,
 			CIStatus:        ciStatus,
+			Assignees:       githubLogins(p["assignees"]),
+			ReviewRequests:  githubLogins(p["reviewRequests"]),
 		})
 	}
 
 	return result, nil
 }
 
-// FetchPR fetches a single PR by number.
-func (s *Service) FetchPR(ctx context.Context, prNumber int) (*models.PRInfo, error) {
-	host := s.DetectHost(ctx)
-	if host == gitHostGitLab {
-		return s.fetchGitLabPR(ctx, prNumber)
+// githubLogins extracts user logins (or team slugs) from a gh JSON list.
+func githubLogins(raw any) []string {
+	items, _ := raw.([]any)
+	logins := make([]string, 0, len(items))
+	for _, item := range items {
+		entry, _ := item.(map[string]any)
+		login, _ := entry["login"].(string)
+		if login == "" {
+			login, _ = entry["slug"].(string)
+		}
+		if login != "" {
+			logins = append(logins, login)
+		}
 	}
-
-	return s.fetchGitHubPR(ctx, prNumber)
+	return logins
 }
 
 func (s *Service) fetchGitHubPR(ctx context.Context, prNumber int) (*models.PRInfo, error) {
@@ -339,16 +574,6 @@ func (s *Service) fetchGitHubPR(ctx context.Context, prNumber int) (*models.PRIn
 	}, nil
 }
 
-// FetchAllOpenIssues fetches all open issues and returns them as a slice.
-func (s *Service) FetchAllOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
-	host := s.DetectHost(ctx)
-	if host == gitHostGitLab {
-		return s.fetchGitLabOpenIssues(ctx)
-	}
-
-	return s.fetchGitHubOpenIssues(ctx)
-}
-
 func (s *Service) fetchGitHubOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
 	args := []string{
 		"gh", "issue", "list",
@@ -397,16 +622,6 @@ func (s *Service) fetchGitHubOpenIssues(ctx context.Context) ([]*models.IssueInf
 	return result, nil
 }
 
-// FetchIssue fetches a single issue by number.
-func (s *Service) FetchIssue(ctx context.Context, issueNumber int) (*models.IssueInfo, error) {
-	host := s.DetectHost(ctx)
-	if host == gitHostGitLab {
-		return s.fetchGitLabIssue(ctx, issueNumber)
-	}
-
-	return s.fetchGitHubIssue(ctx, issueNumber)
-}
-
 func (s *Service) fetchGitHubIssue(ctx context.Context, issueNumber int) (*models.IssueInfo, error) {
 	args := []string{
 		"gh", "issue", "view", strconv.Itoa(issueNumber),
@@ -514,6 +729,49 @@ func (s *Service) fetchGitHubCI(ctx context.Context, prNumber int) ([]*models.CI
 	return result, nil
 }
 
+// fetchGitHubCIByCommit fetches check runs for a commit SHA via the GitHub Check Runs API.
+func (s *Service) fetchGitHubCIByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error) {
+	repoName := s.ResolveCITargetRepoName(ctx)
+	if repoName == "" || repoName == "unknown" || strings.HasPrefix(repoName, "local-") {
+		return nil, nil
+	}
+
+	// GitHub Check Runs API: GET /repos/{owner}/{repo}/commits/{ref}/check-runs
+	apiPath := fmt.Sprintf("repos/%s/commits/%s/check-runs", repoName, commitSHA)
+	out := s.RunGit(ctx, []string{"gh", "api", apiPath, "--jq", ".check_runs"},
+		worktreePath, []int{0, 1}, true, true)
+
+	if out == "" {
+		return nil, nil
+	}
+
+	var checkRuns []githubCheckRun
+	if err := json.Unmarshal([]byte(out), &checkRuns); err != nil {
+		return nil, err
+	}
+	return s.githubCheckRunsToCIChecks(checkRuns), nil
+}
+
+// githubCheckRunsToCIChecks converts REST check runs to our internal format.
+func (s *Service) githubCheckRunsToCIChecks(checkRuns []githubCheckRun) []*models.CICheck {
+	result := make([]*models.CICheck, 0, len(checkRuns))
+	for _, run := range checkRuns {
+		conclusion := s.mapGitHubConclusion(run.Status, run.Conclusion)
+		var startedAt time.Time
+		if run.StartedAt != "" {
+			startedAt, _ = time.Parse(time.RFC3339, run.StartedAt)
+		}
+		result = append(result, &models.CICheck{
+			Name:       run.Name,
+			Status:     strings.ToLower(run.Status),
+			Conclusion: conclusion,
+			Link:       run.HTMLURL,
+			StartedAt:  startedAt,
+		})
+	}
+	return result
+}
+
 func (s *Service) githubBucketToConclusion(bucket string) string {
 	switch strings.ToLower(bucket) {
 	case "pass":
@@ -0,0 +1,776 @@
+package git
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// githubMaxBranchAliases caps the per-branch lookups batched into the PR map query.
+const githubMaxBranchAliases = 50
+
+// githubPRFragment selects the PR fields mapped by githubGQLPullRequest.toInfo.
+const githubPRFragment = `fragment pr on PullRequest {
+  number state title body url isDraft headRefName baseRefName
+  author { __typename login avatarUrl ... on User { name } }
+  commits(last: 1) { nodes { commit { statusCheckRollup { state } } } }
+  assignees(first: 20) { nodes { login } }
+  reviewRequests(first: 20) { nodes { requestedReviewer { ... on User { login } ... on Team { slug } } } }
+}`
+
+type githubGQLPullRequest struct {
+	Number      int    `json:"number"`
+	State       string `json:"state"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	URL         string `json:"url"`
+	IsDraft     bool   `json:"isDraft"`
+	HeadRefName string `json:"headRefName"`
+	BaseRefName string `json:"baseRefName"`
+	Author      *struct {
+		Typename  string `json:"__typename"`
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+		Name      string `json:"name"`
+	} `json:"author"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string `json:"state"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *struct {
+				Login string `json:"login"`
+				Slug  string `json:"slug"`
+			} `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+}
+
+type githubGQLPRConnection struct {
+	Nodes []githubGQLPullRequest `json:"nodes"`
+}
+
+// githubCheckRun is a check run as returned by the GitHub REST API.
+type githubCheckRun struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress, completed
+	Conclusion string `json:"conclusion"` // success, failure, neutral, cancelled, skipped, timed_out, action_required
+	HTMLURL    string `json:"html_url"`
+	StartedAt  string `json:"started_at"`
+}
+
+type githubRESTIssue struct {
+	Number  int    `json:"number"`
+	State   string `json:"state"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+		Type  string `json:"type"`
+	} `json:"user"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+func (pr *githubGQLPullRequest) toInfo() *models.PRInfo {
+	info := &models.PRInfo{
+		Number:     pr.Number,
+		State:      pr.State,
+		Title:      pr.Title,
+		Body:       pr.Body,
+		URL:        pr.URL,
+		Branch:     pr.HeadRefName,
+		BaseBranch: pr.BaseRefName,
+		IsDraft:    pr.IsDraft,
+		CIStatus:   "none",
+	}
+	if pr.Author != nil {
+		info.Author = pr.Author.Login
+		info.AuthorName = pr.Author.Name
+		info.AuthorAvatarURL = pr.Author.AvatarURL
+		info.AuthorIsBot = pr.Author.Typename == "Bot"
+	}
+	if info.AuthorAvatarURL == "" {
+		info.AuthorAvatarURL = githubAvatarURL(info.Author)
+	}
+	if nodes := pr.Commits.Nodes; len(nodes) > 0 && nodes[0].Commit.StatusCheckRollup != nil {
+		info.CIStatus = githubRollupStateToCIStatus(nodes[0].Commit.StatusCheckRollup.State)
+	}
+	for _, node := range pr.Assignees.Nodes {
+		info.Assignees = append(info.Assignees, node.Login)
+	}
+	for _, node := range pr.ReviewRequests.Nodes {
+		if reviewer := node.RequestedReviewer; reviewer != nil {
+			info.ReviewRequests = append(info.ReviewRequests, cmp.Or(reviewer.Login, reviewer.Slug))
+		}
+	}
+	return info
+}
+
+// githubRollupStateToCIStatus maps a GraphQL StatusState to the PR list CI status.
+func githubRollupStateToCIStatus(state string) string {
+	switch strings.ToUpper(state) {
+	case "SUCCESS":
+		return "success"
+	case "FAILURE", "ERROR":
+		return "failure"
+	case "PENDING", "EXPECTED":
+		return "pending"
+	default:
+		return "none"
+	}
+}
+
+// preferPR reports whether candidate should replace current for the same
+// branch: open PRs win over closed or merged ones, otherwise the first seen
+// (most recently updated) is kept.
+func preferPR(current, candidate *models.PRInfo) bool {
+	return current == nil || (current.State != prStateOpen && candidate.State == prStateOpen)
+}
+
+// githubBranchPRsQuery builds a query returning the PRs of each branch under
+// the aliases b0..bN and, when withRecent is set, the most recently updated
+// PRs under "recent".
+func githubBranchPRsQuery(owner, name string, branches []string, withRecent bool) (string, map[string]any) {
+	vars := map[string]any{"owner": owner, "name": name}
+	var params, fields strings.Builder
+	for i, branch := range branches {
+		fmt.Fprintf(&params, ", $b%d: String!", i)
+		fmt.Fprintf(&fields, "    b%d: pullRequests(headRefName: $b%d, first: 5, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { ...pr } }\n", i, i)
+		vars[fmt.Sprintf("b%d", i)] = branch
+	}
+	if withRecent {
+		fields.WriteString("    recent: pullRequests(first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) { nodes { ...pr } }\n")
+	}
+	query := fmt.Sprintf("query($owner: String!, $name: String!%s) {\n  repository(owner: $owner, name: $name) {\n%s  }\n}\n%s",
+		params.String(), fields.String(), githubPRFragment)
+	return query, vars
+}
+
+// githubAPIForge implements Forge over the GitHub REST and GraphQL APIs,
+// falling back to the gh CLI when no token can be resolved. CI logs are still
+// printed with gh since they are streamed to a pager.
+type githubAPIForge struct {
+	githubForge
+}
+
+// githubAPIClient returns the shared GitHub API client for the CI/PR remote.
+func (s *Service) githubAPIClient(ctx context.Context) *forgeAPIClient {
+	s.githubAPIOnce.Do(func() {
+		// Allow tests to pre-seed the client directly on the struct.
+		if s.githubAPI != nil {
+			return
+		}
+		host, _ := splitRemoteURL(s.getRemoteURL(ctx))
+		source := forgeTokenSource{
+			envVars:    []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"},
+			configFile: ghHostsToken,
+		}
+		restBase, graphqlURL := "https://"+host+"/api/v3", "https://"+host+"/api/graphql"
+		if host == "" || host == "github.com" || host == "ssh.github.com" {
+			host = "github.com"
+			source.envVars = []string{"GH_TOKEN", "GITHUB_TOKEN"}
+			restBase, graphqlURL = "https://api.github.com", "https://api.github.com/graphql"
+		}
+		s.githubAPI = &forgeAPIClient{
+			name:       "GitHub",
+			host:       host,
+			restBase:   restBase,
+			graphqlURL: graphqlURL,
+			authScheme: "Bearer",
+			http:       newForgeHTTPClient(),
+		}
+		s.githubAPI.resolveToken = func(ctx context.Context) string {
+			token := s.resolveForgeToken(ctx, host, source)
+			if token == "" {
+				s.debugf("no GitHub token found for %s, falling back to gh", host)
+			}
+			return token
+		}
+	})
+	return s.githubAPI
+}
+
+// api returns the API client when a token is available, or nil to use gh.
+func (f *githubAPIForge) api(ctx context.Context) *forgeAPIClient {
+	if c := f.s.githubAPIClient(ctx); c.ready(ctx) {
+		return c
+	}
+	return nil
+}
+
+// repo returns the owner and name of the CI/PR repository.
+func (f *githubAPIForge) repo(ctx context.Context) (owner, name string, ok bool) {
+	_, repoPath := splitRemoteURL(f.s.getRemoteURL(ctx))
+	owner, name, ok = strings.Cut(repoPath, "/")
+	return owner, name, ok && owner != "" && name != "" && !strings.Contains(name, "/")
+}
+
+func (f *githubAPIForge) AuthenticatedUsername(ctx context.Context) string {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.AuthenticatedUsername(ctx)
+	}
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.get(ctx, "/user", &user); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(user.Login)
+}
+
+func (f *githubAPIForge) AuthenticatedTeams(ctx context.Context) []string {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.AuthenticatedTeams(ctx)
+	}
+	owner, _, ok := f.repo(ctx)
+	if !ok {
+		return nil
+	}
+	var teams []githubTeam
+	if err := c.get(ctx, "/user/teams?per_page=100", &teams); err != nil {
+		return nil
+	}
+	return githubTeamSlugs(teams, owner)
+}
+
+// FetchPRMap fetches recent PRs plus the PRs of every worktree branch with a
+// single GraphQL query, including the rollup CI state of their head commit.
+func (f *githubAPIForge) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchPRMap(ctx)
+	}
+
+	branches := f.s.worktreeBranches(ctx)
+	if len(branches) > githubMaxBranchAliases {
+		branches = branches[:githubMaxBranchAliases]
+	}
+	query, vars := githubBranchPRsQuery(owner, name, branches, true)
+	var data struct {
+		Repository map[string]githubGQLPRConnection `json:"repository"`
+	}
+	if err := c.graphql(ctx, query, vars, &data); err != nil {
+		f.s.notifyOnce("github_api_pr_list", fmt.Sprintf("Failed to fetch GitHub pull requests: %v", err), "error")
+		// Keep the pull requests that resolved; the notification says some
+		// may be missing.
+		if !isPartialGraphQLError(err) {
+			return nil, err
+		}
+	}
+
+	prMap := make(map[string]*models.PRInfo)
+	// Exact branch lookups first, then the recent list for anything else.
+	for i := range branches {
+		for _, pr := range data.Repository[fmt.Sprintf("b%d", i)].Nodes {
+			if info := pr.toInfo(); preferPR(prMap[info.Branch], info) {
+				prMap[info.Branch] = info
+			}
+		}
+	}
+	for _, pr := range data.Repository["recent"].Nodes {
+		info := pr.toInfo()
+		if info.Branch != "" && preferPR(prMap[info.Branch], info) {
+			prMap[info.Branch] = info
+		}
+	}
+	return prMap, nil
+}
+
+func (f *githubAPIForge) FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchPRForWorktree(ctx, worktreePath)
+	}
+	candidates := f.s.prBranchCandidates(ctx, worktreePath)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	query, vars := githubBranchPRsQuery(owner, name, candidates, false)
+	var data struct {
+		Repository map[string]githubGQLPRConnection `json:"repository"`
+	}
+	if err := c.graphql(ctx, query, vars, &data); err != nil {
+		return nil, fmt.Errorf("failed to fetch PR data: %w", err)
+	}
+
+	var match *models.PRInfo
+	for i := range candidates {
+		for _, pr := range data.Repository[fmt.Sprintf("b%d", i)].Nodes {
+			if info := pr.toInfo(); preferPR(match, info) {
+				match = info
+			}
+		}
+	}
+	return match, nil
+}
+
+func (f *githubAPIForge) FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchOpenPRs(ctx)
+	}
+
+	query := `query($owner: String!, $name: String!) {
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: OPEN, first: 100, orderBy: {field: CREATED_AT, direction: DESC}) { nodes { ...pr } }
+  }
+}
+` + githubPRFragment
+	var data struct {
+		Repository struct {
+			PullRequests githubGQLPRConnection `json:"pullRequests"`
+		} `json:"repository"`
+	}
+	if err := c.graphql(ctx, query, map[string]any{"owner": owner, "name": name}, &data); err != nil {
+		f.s.notifyOnce("github_api_pr_list", fmt.Sprintf("Failed to fetch GitHub pull requests: %v", err), "error")
+		return nil, err
+	}
+
+	result := make([]*models.PRInfo, 0, len(data.Repository.PullRequests.Nodes))
+	for _, pr := range data.Repository.PullRequests.Nodes {
+		info := pr.toInfo()
+		// Match the gh pr list output, which does not include the base branch.
+		info.BaseBranch = ""
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (f *githubAPIForge) FetchPR(ctx context.Context, number int) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchPR(ctx, number)
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) { pullRequest(number: $number) { ...pr } }
+}
+` + githubPRFragment
+	var data struct {
+		Repository struct {
+			PullRequest *githubGQLPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	err := c.graphql(ctx, query, map[string]any{"owner": owner, "name": name, "number": number}, &data)
+	if (err == nil && data.Repository.PullRequest == nil) || errors.Is(err, errForgeNotFound) {
+		return nil, fmt.Errorf("PR #%d not found", number)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", number, err)
+	}
+
+	info := data.Repository.PullRequest.toInfo()
+	if !strings.EqualFold(info.State, prStateOpen) {
+		return nil, fmt.Errorf("PR #%d is not open (state: %s)", number, info.State)
+	}
+	return info, nil
+}
+
+func (f *githubAPIForge) FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchOpenIssues(ctx)
+	}
+
+	var issues []githubRESTIssue
+	if err := c.get(ctx, githubRepoPath(owner, name, "/issues?state=open&per_page=100"), &issues); err != nil {
+		f.s.notifyOnce("github_api_issue_list", fmt.Sprintf("Failed to fetch GitHub issues: %v", err), "error")
+		return nil, err
+	}
+
+	result := make([]*models.IssueInfo, 0, len(issues))
+	for i := range issues {
+		// The issues endpoint also lists pull requests.
+		if issues[i].PullRequest != nil {
+			continue
+		}
+		result = append(result, githubIssueToInfo(&issues[i]))
+	}
+	return result, nil
+}
+
+func (f *githubAPIForge) FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchIssue(ctx, number)
+	}
+
+	var issue githubRESTIssue
+	if err := c.get(ctx, githubRepoPath(owner, name, fmt.Sprintf("/issues/%d", number)), &issue); err != nil || issue.PullRequest != nil {
+		return nil, fmt.Errorf("issue #%d not found", number)
+	}
+	if !strings.EqualFold(issue.State, "open") {
+		return nil, fmt.Errorf("issue #%d is not open (state: %s)", number, strings.ToUpper(issue.State))
+	}
+	return githubIssueToInfo(&issue), nil
+}
+
+func githubIssueToInfo(issue *githubRESTIssue) *models.IssueInfo {
+	return &models.IssueInfo{
+		Number:      issue.Number,
+		State:       "open",
+		Title:       issue.Title,
+		Body:        issue.Body,
+		URL:         issue.HTMLURL,
+		Author:      issue.User.Login,
+		AuthorIsBot: issue.User.Type == "Bot",
+	}
+}
+
+func (f *githubAPIForge) FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchCIStatus(ctx, prNumber, branch)
+	}
+
+	query := `query($owner: String!, $name: String!, $number: Int!) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      commits(last: 1) { nodes { commit { statusCheckRollup { contexts(first: 100) { nodes {
+        __typename
+        ... on CheckRun { name status conclusion detailsUrl startedAt }
+        ... on StatusContext { context state targetUrl createdAt }
+      } } } } } }
+    }
+  }
+}`
+	var data struct {
+		Repository struct {
+			PullRequest *struct {
+				Commits struct {
+					Nodes []struct {
+						Commit struct {
+							StatusCheckRollup *struct {
+								Contexts struct {
+									Nodes []struct {
+										Typename   string `json:"__typename"`
+										Name       string `json:"name"`
+										Status     string `json:"status"`
+										Conclusion string `json:"conclusion"`
+										DetailsURL string `json:"detailsUrl"`
+										StartedAt  string `json:"startedAt"`
+										Context    string `json:"context"`
+										State      string `json:"state"`
+										TargetURL  string `json:"targetUrl"`
+										CreatedAt  string `json:"createdAt"`
+									} `json:"nodes"`
+								} `json:"contexts"`
+							} `json:"statusCheckRollup"`
+						} `json:"commit"`
+					} `json:"nodes"`
+				} `json:"commits"`
+			} `json:"pullRequest"`
+		} `json:"repository"`
+	}
+	if err := c.graphql(ctx, query, map[string]any{"owner": owner, "name": name, "number": prNumber}, &data); err != nil {
+		return nil, err
+	}
+	pr := data.Repository.PullRequest
+	if pr == nil || len(pr.Commits.Nodes) == 0 || pr.Commits.Nodes[0].Commit.StatusCheckRollup == nil {
+		return nil, nil
+	}
+
+	contexts := pr.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
+	result := make([]*models.CICheck, 0, len(contexts))
+	for _, ctxNode := range contexts {
+		check := &models.CICheck{}
+		var startedAt string
+		if ctxNode.Typename == "StatusContext" {
+			check.Name = ctxNode.Context
+			check.Status = strings.ToLower(ctxNode.State)
+			check.Conclusion = githubStatusStateToConclusion(ctxNode.State)
+			check.Link = ctxNode.TargetURL
+			startedAt = ctxNode.CreatedAt
+		} else {
+			status := strings.ToLower(ctxNode.Status)
+			check.Name = ctxNode.Name
+			check.Status = status
+			if status == "completed" {
+				check.Status = strings.ToLower(ctxNode.Conclusion)
+				check.Conclusion = f.s.mapGitHubConclusion(status, ctxNode.Conclusion)
+			} else {
+				check.Conclusion = ciPending
+			}
+			check.Link = ctxNode.DetailsURL
+			startedAt = ctxNode.StartedAt
+		}
+		if startedAt != "" {
+			check.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+		}
+		result = append(result, check)
+	}
+	return result, nil
+}
+
+// githubStatusStateToConclusion maps a commit status state to our internal format.
+func githubStatusStateToConclusion(state string) string {
+	switch strings.ToUpper(state) {
+	case "SUCCESS":
+		return ciSuccess
+	case "FAILURE", "ERROR":
+		return ciFailure
+	case "PENDING", "EXPECTED":
+		return ciPending
+	default:
+		return strings.ToLower(state)
+	}
+}
+
+// FetchCIStatusByCommit lists check runs over REST so periodic refreshes of
+// unchanged commits are answered with 304 Not Modified.
+func (f *githubAPIForge) FetchCIStatusByCommit(ctx context.Context, commitSHA, worktreePath string) ([]*models.CICheck, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchCIStatusByCommit(ctx, commitSHA, worktreePath)
+	}
+
+	var resp struct {
+		CheckRuns []githubCheckRun `json:"check_runs"`
+	}
+	path := githubRepoPath(owner, name, fmt.Sprintf("/commits/%s/check-runs?per_page=100", url.PathEscape(commitSHA)))
+	if err := c.get(ctx, path, &resp); err != nil {
+		if errors.Is(err, errForgeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return f.s.githubCheckRunsToCIChecks(resp.CheckRuns), nil
+}
+
+func (f *githubAPIForge) RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.RerunCICheck(ctx, check, worktreePath)
+	}
+	repo, runID, jobID := githubActionsRunFromLink(check.Link)
+	if runID == "" {
+		return "", ErrCIRerunUnsupported
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return "", fmt.Errorf("unable to determine repository from link")
+	}
+
+	path := githubRepoPath(owner, name, fmt.Sprintf("/actions/runs/%s/rerun", url.PathEscape(runID)))
+	if jobID != "" {
+		path = githubRepoPath(owner, name, fmt.Sprintf("/actions/jobs/%s/rerun", url.PathEscape(jobID)))
+	}
+	if err := c.send(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return "", err
+	}
+	return githubActionsRunURL(c.host, repo, runID), nil
+}
+
+func (f *githubAPIForge) FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, offset int) (*CILog, error) {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.FetchCICheckLog(ctx, check, worktreePath, offset)
+	}
+	repo, _, jobID := githubActionsRunFromLink(check.Link)
+	owner, name, ok := strings.Cut(repo, "/")
+	if jobID == "" || !ok {
+		return nil, ErrCILogUnsupported
+	}
+
+	jobPath := githubRepoPath(owner, name, "/actions/jobs/"+url.PathEscape(jobID))
+	var job struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+	if err := c.get(ctx, jobPath, &job); err != nil {
+		return nil, err
+	}
+	if job.Status != "completed" {
+		return nil, ErrCILiveLogUnsupported
+	}
+
+	content, err := c.getRaw(ctx, jobPath+"/logs")
+	if err != nil {
+		return nil, err
+	}
+	ciLog := &CILog{Content: string(content)}
+
+	if job.Conclusion == ciFailure {
+		var annotations []githubAnnotation
+		if c.get(ctx, githubRepoPath(owner, name, "/check-runs/"+url.PathEscape(jobID)+"/annotations"), &annotations) == nil {
+			ciLog.Failures = githubAnnotationFailures(annotations)
+		}
+	}
+	return ciLog, nil
+}
+
+func (f *githubAPIForge) CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.CancelCICheck(ctx, check, worktreePath)
+	}
+	repo, runID, _ := githubActionsRunFromLink(check.Link)
+	if runID == "" {
+		return "", ErrCICancelUnsupported
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return "", fmt.Errorf("unable to determine repository from link")
+	}
+
+	path := githubRepoPath(owner, name, fmt.Sprintf("/actions/runs/%s/cancel", url.PathEscape(runID)))
+	if err := c.send(ctx, http.MethodPost, path, nil, nil); err != nil {
+		return "", err
+	}
+	return githubActionsRunURL(c.host, repo, runID), nil
+}
+
+// CreatePR opens a pull request through the REST API, then requests
+// reviewers ("org/team" entries are team reviewers) and adds labels.
+func (f *githubAPIForge) CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.CreatePR(ctx, worktreePath, opts)
+	}
+
+	var created struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+	}
+	payload := map[string]any{"head": opts.Head, "base": opts.Base, "title": opts.Title, "body": opts.Body, "draft": opts.Draft}
+	if err := c.send(ctx, http.MethodPost, githubRepoPath(owner, name, "/pulls"), payload, &created); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	if len(opts.Reviewers) > 0 {
+		users, teams := []string{}, []string{}
+		for _, reviewer := range opts.Reviewers {
+			if _, team, isTeam := strings.Cut(reviewer, "/"); isTeam {
+				teams = append(teams, team)
+			} else {
+				users = append(users, reviewer)
+			}
+		}
+		path := githubRepoPath(owner, name, fmt.Sprintf("/pulls/%d/requested_reviewers", created.Number))
+		if err := c.send(ctx, http.MethodPost, path, map[string]any{"reviewers": users, "team_reviewers": teams}, nil); err != nil {
+			f.s.notifyOnce("github_api_pr_reviewers", fmt.Sprintf("Failed to request reviewers: %v", err), "error")
+		}
+	}
+	if len(opts.Labels) > 0 {
+		path := githubRepoPath(owner, name, fmt.Sprintf("/issues/%d/labels", created.Number))
+		if err := c.send(ctx, http.MethodPost, path, map[string]any{"labels": opts.Labels}, nil); err != nil {
+			f.s.notifyOnce("github_api_pr_labels", fmt.Sprintf("Failed to add labels: %v", err), "error")
+		}
+	}
+	return createdPRInfo(opts, created.HTMLURL), nil
+}
+
+// MergePR merges a pull request through the REST API. Auto-merge is enabled
+// with the enablePullRequestAutoMerge GraphQL mutation.
+func (f *githubAPIForge) MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.MergePR(ctx, worktreePath, number, opts)
+	}
+
+	path := githubRepoPath(owner, name, fmt.Sprintf("/pulls/%d", number))
+	if !opts.Auto {
+		if err := c.send(ctx, http.MethodPut, path+"/merge", map[string]any{"merge_method": opts.Method}, nil); err != nil {
+			return fmt.Errorf("failed to merge pull request: %w", err)
+		}
+		return nil
+	}
+
+	var pr struct {
+		NodeID string `json:"node_id"`
+	}
+	if err := c.get(ctx, path, &pr); err != nil {
+		return fmt.Errorf("failed to look up pull request: %w", err)
+	}
+	const mutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`
+	var out map[string]any
+	if err := c.graphql(ctx, mutation, map[string]any{"id": pr.NodeID, "method": strings.ToUpper(opts.Method)}, &out); err != nil {
+		return fmt.Errorf("failed to enable auto-merge: %w", err)
+	}
+	return nil
+}
+
+// FetchReviewThreads fetches review threads through the GraphQL API.
+func (f *githubAPIForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	c := f.api(ctx)
+	owner, name, ok := f.repo(ctx)
+	if c == nil || !ok {
+		return f.githubForge.FetchReviewThreads(ctx, prNumber)
+	}
+	return collectGitHubReviewThreads(func(query string, variables map[string]any, out any) error {
+		return c.graphql(ctx, query, variables, out)
+	}, owner, name, prNumber)
+}
+
+func (f *githubAPIForge) ReplyToReviewThread(ctx context.Context, prNumber int, threadID, body string) error {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.ReplyToReviewThread(ctx, prNumber, threadID, body)
+	}
+	var out map[string]any
+	return c.graphql(ctx, githubReplyToThreadMutation, map[string]any{"id": threadID, "body": body}, &out)
+}
+
+func (f *githubAPIForge) SetReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error {
+	c := f.api(ctx)
+	if c == nil {
+		return f.githubForge.SetReviewThreadResolved(ctx, prNumber, threadID, resolved)
+	}
+	mutation := githubUnresolveThreadMutation
+	if resolved {
+		mutation = githubResolveThreadMutation
+	}
+	var out map[string]any
+	return c.graphql(ctx, mutation, map[string]any{"id": threadID}, &out)
+}
+
+// githubRepoPath builds a REST API path scoped to owner/name.
+func githubRepoPath(owner, name, suffix string) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(owner), url.PathEscape(name)) + suffix
+}
+
+// worktreeBranches returns the branches checked out in the repository's worktrees.
+func (s *Service) worktreeBranches(ctx context.Context) []string {
+	raw := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, true)
+	var branches []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		if branch, ok := strings.CutPrefix(line, "branch refs/heads/"); ok && branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
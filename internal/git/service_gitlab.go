@@ -1,16 +1,205 @@
 package git
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os/exec"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/chmouel/lazyworktree/internal/models"
 )
 
+// gitlabForge implements Forge on top of the glab CLI.
+type gitlabForge struct {
+	s *Service
+}
+
+func newGitLabForge(s *Service) Forge {
+	if s.useForgeHTTP() {
+		return &gitlabAPIForge{gitlabForge{s: s}}
+	}
+	return &gitlabForge{s: s}
+}
+
+func (f *gitlabForge) Name() string { return gitHostGitLab }
+
+func (f *gitlabForge) AuthenticatedUsername(ctx context.Context) string {
+	return f.s.getGitLabAuthenticatedUsername(ctx)
+}
+
+func (f *gitlabForge) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	return f.s.fetchGitLabPRs(ctx)
+}
+
+func (f *gitlabForge) FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	return f.s.fetchGitLabPRForWorktreeWithError(ctx, worktreePath)
+}
+
+func (f *gitlabForge) FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	return f.s.fetchGitLabOpenPRs(ctx)
+}
+
+func (f *gitlabForge) FetchPR(ctx context.Context, number int) (*models.PRInfo, error) {
+	return f.s.fetchGitLabPR(ctx, number)
+}
+
+func (f *gitlabForge) FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	return f.s.fetchGitLabOpenIssues(ctx)
+}
+
+func (f *gitlabForge) FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error) {
+	return f.s.fetchGitLabIssue(ctx, number)
+}
+
+func (f *gitlabForge) FetchCIStatus(ctx context.Context, _ int, branch string) ([]*models.CICheck, error) {
+	return f.s.fetchGitLabCI(ctx, branch)
+}
+
+// FetchCIStatusByCommit is not implemented for GitLab; pipelines are looked up by branch.
+func (f *gitlabForge) FetchCIStatusByCommit(context.Context, string, string) ([]*models.CICheck, error) {
+	return nil, nil
+}
+
+func (f *gitlabForge) SupportsCommitCI() bool { return false }
+
+func (f *gitlabForge) OwnsCICheck(ctx context.Context, check *models.CICheck) bool {
+	_, _, kind, _ := f.s.gitlabCIRef(ctx, check.Link)
+	return kind != ""
+}
+
+// CICheckLogCommand streams the job trace with glab ci trace. Pipeline links
+// return nil so they open in the browser.
+func (f *gitlabForge) CICheckLogCommand(ctx context.Context, check *models.CICheck, _ bool) []string {
+	host, project, kind, id := f.s.gitlabCIRef(ctx, check.Link)
+	if kind != gitlabCIJobs {
+		return nil
+	}
+	return []string{"glab", "ci", "trace", id, "-R", "https://" + host + "/" + project}
+}
+
+func (f *gitlabForge) RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	return f.s.gitlabCIAction(ctx, check, worktreePath, "retry", ErrCIRerunUnsupported)
+}
+
+func (f *gitlabForge) CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	return f.s.gitlabCIAction(ctx, check, worktreePath, "cancel", ErrCICancelUnsupported)
+}
+
+// FetchCICheckLog reads the job status and its trace with glab api, asking
+// only for the output past offset when it is positive.
+func (f *gitlabForge) FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, offset int) (*CILog, error) {
+	host, project, kind, id := f.s.gitlabCIRef(ctx, check.Link)
+	if kind != gitlabCIJobs {
+		return nil, ErrCILogUnsupported
+	}
+	jobPath := fmt.Sprintf("projects/%s/jobs/%s", url.PathEscape(project), id)
+
+	out, err := f.s.RunGitWithCombinedOutput(ctx, []string{"glab", "api", "--hostname", host, jobPath}, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("glab api failed: %s", strings.TrimSpace(string(out)))
+	}
+	var job gitlabJob
+	if err := json.Unmarshal(out, &job); err != nil {
+		return nil, fmt.Errorf("failed to parse job: %w", err)
+	}
+
+	if offset > 0 {
+		if trace, ok := f.fetchTraceFrom(ctx, host, jobPath+"/trace", worktreePath, offset); ok {
+			ciLog := f.s.gitlabJobLog(job, trace)
+			ciLog.Offset = offset
+			return ciLog, nil
+		}
+	}
+	out, err = f.s.RunGitWithCombinedOutput(ctx, []string{"glab", "api", "--hostname", host, jobPath + "/trace"}, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("glab api failed: %s", strings.TrimSpace(string(out)))
+	}
+	return f.s.gitlabJobLog(job, string(out)), nil
+}
+
+// fetchTraceFrom downloads the part of a job trace past offset with a Range
+// request. The range starts one byte early so an idle log still answers 206
+// rather than 416. It reports false when the server did not return a partial
+// response, so the caller downloads the whole trace.
+func (f *gitlabForge) fetchTraceFrom(ctx context.Context, host, tracePath, worktreePath string, offset int) (string, bool) {
+	args := []string{"glab", "api", "--hostname", host, "--include", "--header", fmt.Sprintf("Range: bytes=%d-", offset-1), tracePath}
+	out, err := f.s.RunGitWithCombinedOutput(ctx, args, worktreePath, nil)
+	if err != nil {
+		return "", false
+	}
+	status, body := splitHTTPResponse(out)
+	if status != http.StatusPartialContent || len(body) == 0 {
+		return "", false
+	}
+	return string(body[1:]), true
+}
+
+// splitHTTPResponse splits the output of glab api --include into the status
+// code and the body. The status is 0 when no status line is found.
+func splitHTTPResponse(out []byte) (int, []byte) {
+	head, body, ok := bytes.Cut(out, []byte("\r\n\r\n"))
+	if !ok {
+		head, body, ok = bytes.Cut(out, []byte("\n\n"))
+	}
+	if !ok {
+		return 0, nil
+	}
+	statusLine, _, _ := bytes.Cut(head, []byte("\n"))
+	fields := strings.Fields(string(statusLine))
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "HTTP/") {
+		return 0, nil
+	}
+	status, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, nil
+	}
+	return status, body
+}
+
+// gitlabJob holds the job fields needed to follow its log.
+type gitlabJob struct {
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason"`
+}
+
+// gitlabJobLog builds the log snapshot of a job, reporting its failure
+// reason (e.g. script_failure) as a failure.
+func (s *Service) gitlabJobLog(job gitlabJob, trace string) *CILog {
+	ciLog := &CILog{Content: trace, Running: s.gitlabStatusToConclusion(job.Status) == ciPending}
+	if job.FailureReason != "" {
+		reason := strings.ReplaceAll(job.FailureReason, "_", " ")
+		ciLog.Failures = []models.CIFailure{{Message: "Job failed: " + reason}}
+	}
+	return ciLog
+}
+
+func (f *gitlabForge) CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	return f.s.createGitLabMR(ctx, worktreePath, opts)
+}
+
+func (f *gitlabForge) MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	return f.s.mergeGitLabMR(ctx, worktreePath, number, opts)
+}
+
+func (f *gitlabForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	return f.s.fetchGitLabReviewThreads(ctx, prNumber)
+}
+
+func (f *gitlabForge) ReplyToReviewThread(ctx context.Context, prNumber int, threadID, body string) error {
+	return f.s.replyToGitLabReviewThread(ctx, prNumber, threadID, body)
+}
+
+func (f *gitlabForge) SetReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error {
+	return f.s.setGitLabReviewThreadResolved(ctx, prNumber, threadID, resolved)
+}
+
 func (s *Service) getGitLabAuthenticatedUsername(ctx context.Context) string {
 	raw := s.RunGit(ctx, []string{"glab", "api", "user"}, "", []int{0}, true, true)
 	if raw == "" {
@@ -41,6 +230,70 @@ func normalizeGitLabState(state string) string {
 	return state
 }
 
+// gitlabMRToInfo converts a merge request API object into a PRInfo.
+func gitlabMRToInfo(p map[string]any) *models.PRInfo {
+	state, _ := p["state"].(string)
+	iid, _ := p["iid"].(float64)
+	title, _ := p["title"].(string)
+	description, _ := p["description"].(string)
+	webURL, _ := p["web_url"].(string)
+	sourceBranch, _ := p["source_branch"].(string)
+	targetBranch, _ := p["target_branch"].(string)
+	author, authorName, authorAvatarURL, authorIsBot := extractAuthor(p, gitlabAuthorKeys)
+	// GitLab uses "draft" field for WIP/draft MRs
+	isDraft, _ := p["draft"].(bool)
+
+	return &models.PRInfo{
+		Number:          int(iid),
+		State:           normalizeGitLabState(state),
+		Title:           title,
+		Body:            description,
+		URL:             webURL,
+		Branch:          sourceBranch,
+		BaseBranch:      targetBranch,
+		Author:          author,
+		AuthorName:      authorName,
+		AuthorAvatarURL: authorAvatarURL,
+		AuthorIsBot:     authorIsBot,
+		IsDraft:         isDraft,
+		Assignees:       gitlabUsernames(p["assignees"]),
+		ReviewRequests:  gitlabUsernames(p["reviewers"]),
+	}
+}
+
+// gitlabUsernames extracts usernames from a list of GitLab user objects.
+func gitlabUsernames(raw any) []string {
+	users, _ := raw.([]any)
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		entry, _ := user.(map[string]any)
+		if name, _ := entry["username"].(string); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// gitlabIssueToInfo converts an issue API object into an IssueInfo.
+func gitlabIssueToInfo(i map[string]any) *models.IssueInfo {
+	iid, _ := i["iid"].(float64)
+	title, _ := i["title"].(string)
+	description, _ := i["description"].(string)
+	webURL, _ := i["web_url"].(string)
+	author, authorName, _, authorIsBot := extractAuthor(i, gitlabAuthorKeys)
+
+	return &models.IssueInfo{
+		Number:      int(iid),
+		State:       "open",
+		Title:       title,
+		Body:        description,
+		URL:         webURL,
+		Author:      author,
+		AuthorName:  authorName,
+		AuthorIsBot: authorIsBot,
+	}
+}
+
 func (s *Service) fetchGitLabPRs(ctx context.Context) (map[string]*models.PRInfo, error) {
 	prRaw := s.RunGit(ctx, []string{"glab", "api", "merge_requests?state=all&per_page=100"}, "", []int{0}, false, false)
 	if prRaw == "" {
@@ -56,31 +309,8 @@ func (s *Service) fetchGitLabPRs(ctx context.Context) (map[string]*models.PRInfo
 
 	prMap := make(map[string]*models.PRInfo)
 	for _, p := range prs {
-		state, _ := p["state"].(string)
-		state = normalizeGitLabState(state)
-
-		iid, _ := p["iid"].(float64)
-		title, _ := p["title"].(string)
-		description, _ := p["description"].(string)
-		webURL, _ := p["web_url"].(string)
-		sourceBranch, _ := p["source_branch"].(string)
-		author, authorName, authorAvatarURL, authorIsBot := extractAuthor(p, gitlabAuthorKeys)
-		isDraft, _ := p["draft"].(bool)
-
-		if sourceBranch != "" {
-			prMap[sourceBranch] = &models.PRInfo{
-				Number:          int(iid),
-				State:           state,
-				Title:           title,
-				Body:            description,
-				URL:             webURL,
-				Branch:          sourceBranch,
-				Author:          author,
-				AuthorName:      authorName,
-				AuthorAvatarURL: authorAvatarURL,
-				AuthorIsBot:     authorIsBot,
-				IsDraft:         isDraft,
-			}
+		if info := gitlabMRToInfo(p); info.Branch != "" {
+			prMap[info.Branch] = info
 		}
 	}
 
@@ -108,31 +338,7 @@ func (s *Service) fetchGitLabPRForWorktreeWithError(ctx context.Context, worktre
 		return nil, fmt.Errorf("failed to parse MR data: %w", err)
 	}
 
-	iid, _ := pr["iid"].(float64)
-	state, _ := pr["state"].(string)
-	state = normalizeGitLabState(state)
-	title, _ := pr["title"].(string)
-	description, _ := pr["description"].(string)
-	webURL, _ := pr["web_url"].(string)
-	sourceBranch, _ := pr["source_branch"].(string)
-	targetBranch, _ := pr["target_branch"].(string)
-	author, authorName, authorAvatarURL, authorIsBot := extractAuthor(pr, gitlabAuthorKeys)
-	isDraft, _ := pr["draft"].(bool)
-
-	return &models.PRInfo{
-		Number:          int(iid),
-		State:           state,
-		Title:           title,
-		Body:            description,
-		URL:             webURL,
-		Branch:          sourceBranch,
-		BaseBranch:      targetBranch,
-		Author:          author,
-		AuthorName:      authorName,
-		AuthorAvatarURL: authorAvatarURL,
-		AuthorIsBot:     authorIsBot,
-		IsDraft:         isDraft,
-	}, nil
+	return gitlabMRToInfo(pr), nil
 }
 
 func (s *Service) fetchGitLabOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
@@ -150,38 +356,13 @@ func (s *Service) fetchGitLabOpenPRs(ctx context.Context) ([]*models.PRInfo, err
 
 	result := make([]*models.PRInfo, 0, len(prs))
 	for _, p := range prs {
-		state, _ := p["state"].(string)
-		state = normalizeGitLabState(state)
-		if state != prStateOpen {
+		info := gitlabMRToInfo(p)
+		if info.State != prStateOpen {
 			continue
 		}
-
-		iid, _ := p["iid"].(float64)
-		title, _ := p["title"].(string)
-		description, _ := p["description"].(string)
-		webURL, _ := p["web_url"].(string)
-		sourceBranch, _ := p["source_branch"].(string)
-		author, authorName, authorAvatarURL, authorIsBot := extractAuthor(p, gitlabAuthorKeys)
-
-		// GitLab uses "draft" field for WIP/draft MRs
-		isDraft, _ := p["draft"].(bool)
 		// CI status would require additional API calls for GitLab, default to none
-		ciStatus := "none"
-
-		result = append(result, &models.PRInfo{
-			Number:          int(iid),
-			State:           state,
-			Title:           title,
-			Body:            description,
-			URL:             webURL,
-			Branch:          sourceBranch,
-			Author:          author,
-			AuthorName:      authorName,
-			AuthorAvatarURL: authorAvatarURL,
-			AuthorIsBot:     authorIsBot,
-			IsDraft:         isDraft,
-			CIStatus:        ciStatus,
-		})
+		info.CIStatus = "none"
+		result = append(result, info)
 	}
 
 	return result, nil
@@ -201,36 +382,12 @@ func (s *Service) fetchGitLabPR(ctx context.Context, prNumber int) (*models.PRIn
 		return nil, err
 	}
 
-	state, _ := pr["state"].(string)
-	state = normalizeGitLabState(state)
-	if state != prStateOpen {
-		return nil, fmt.Errorf("PR #%d is not open (state: %s)", prNumber, state)
+	info := gitlabMRToInfo(pr)
+	if info.State != prStateOpen {
+		return nil, fmt.Errorf("PR #%d is not open (state: %s)", prNumber, info.State)
 	}
-
-	iid, _ := pr["iid"].(float64)
-	title, _ := pr["title"].(string)
-	description, _ := pr["description"].(string)
-	webURL, _ := pr["web_url"].(string)
-	sourceBranch, _ := pr["source_branch"].(string)
-	targetBranch, _ := pr["target_branch"].(string)
-	author, authorName, authorAvatarURL, authorIsBot := extractAuthor(pr, gitlabAuthorKeys)
-	isDraft, _ := pr["draft"].(bool)
-
-	return &models.PRInfo{
-		Number:          int(iid),
-		State:           state,
-		Title:           title,
-		Body:            description,
-		URL:             webURL,
-		Branch:          sourceBranch,
-		BaseBranch:      targetBranch,
-		Author:          author,
-		AuthorName:      authorName,
-		AuthorAvatarURL: authorAvatarURL,
-		AuthorIsBot:     authorIsBot,
-		IsDraft:         isDraft,
-		CIStatus:        "none",
-	}, nil
+	info.CIStatus = "none"
+	return info, nil
 }
 
 func (s *Service) fetchGitLabOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
@@ -249,27 +406,10 @@ func (s *Service) fetchGitLabOpenIssues(ctx context.Context) ([]*models.IssueInf
 	result := make([]*models.IssueInfo, 0, len(issues))
 	for _, i := range issues {
 		state, _ := i["state"].(string)
-		state = normalizeGitLabState(state)
-		if state != prStateOpen {
+		if normalizeGitLabState(state) != prStateOpen {
 			continue
 		}
-
-		iid, _ := i["iid"].(float64)
-		title, _ := i["title"].(string)
-		description, _ := i["description"].(string)
-		webURL, _ := i["web_url"].(string)
-		author, authorName, _, authorIsBot := extractAuthor(i, gitlabAuthorKeys)
-
-		result = append(result, &models.IssueInfo{
-			Number:      int(iid),
-			State:       "open",
-			Title:       title,
-			Body:        description,
-			URL:         webURL,
-			Author:      author,
-			AuthorName:  authorName,
-			AuthorIsBot: authorIsBot,
-		})
+		result = append(result, gitlabIssueToInfo(i))
 	}
 
 	return result, nil
@@ -295,22 +435,7 @@ func (s *Service) fetchGitLabIssue(ctx context.Context, issueNumber int) (*model
 		return nil, fmt.Errorf("issue #%d is not open (state: %s)", issueNumber, state)
 	}
 
-	iid, _ := issue["iid"].(float64)
-	title, _ := issue["title"].(string)
-	description, _ := issue["description"].(string)
-	webURL, _ := issue["web_url"].(string)
-	author, authorName, _, authorIsBot := extractAuthor(issue, gitlabAuthorKeys)
-
-	return &models.IssueInfo{
-		Number:      int(iid),
-		State:       "open",
-		Title:       title,
-		Body:        description,
-		URL:         webURL,
-		Author:      author,
-		AuthorName:  authorName,
-		AuthorIsBot: authorIsBot,
-	}, nil
+	return gitlabIssueToInfo(issue), nil
 }
 
 func (s *Service) fetchGitLabCI(ctx context.Context, branch string) ([]*models.CICheck, error) {
@@ -377,6 +502,73 @@ func (s *Service) fetchGitLabCI(ctx context.Context, branch string) ([]*models.C
 	return result, nil
 }
 
+// GitLab CI link kinds, as found after "/-/" in job and pipeline URLs.
+const (
+	gitlabCIJobs      = "jobs"
+	gitlabCIPipelines = "pipelines"
+)
+
+// gitlabProjectPathRe matches the characters GitLab allows in a project path.
+var gitlabProjectPathRe = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+// gitlabCIRefFromLink extracts the host, project path, kind (jobs or
+// pipelines) and ID from a GitLab CI URL such as
+// https://gitlab.com/group/project/-/jobs/123.
+func gitlabCIRefFromLink(link string) (host, project, kind, id string) {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return "", "", "", ""
+	}
+	project, rest, ok := strings.Cut(strings.Trim(parsed.Path, "/"), "/-/")
+	if !ok || !gitlabProjectPathRe.MatchString(project) {
+		return "", "", "", ""
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) < 2 || (parts[0] != gitlabCIJobs && parts[0] != gitlabCIPipelines) {
+		return "", "", "", ""
+	}
+	if _, err := strconv.Atoi(parts[1]); err != nil {
+		return "", "", "", ""
+	}
+	return parsed.Host, project, parts[0], parts[1]
+}
+
+// gitlabCIRef parses a GitLab CI link like gitlabCIRefFromLink, keeping only
+// links on the host of the CI/PR remote. Check links come from the forge API
+// (e.g. commit status target URLs) and must not send glab elsewhere.
+func (s *Service) gitlabCIRef(ctx context.Context, link string) (host, project, kind, id string) {
+	host, project, kind, id = gitlabCIRefFromLink(link)
+	if kind == "" {
+		return "", "", "", ""
+	}
+	remoteHost, _ := splitRemoteURL(s.getRemoteURL(ctx))
+	if linkHostname, _, _ := strings.Cut(host, ":"); remoteHost == "" || !strings.EqualFold(linkHostname, remoteHost) {
+		return "", "", "", ""
+	}
+	return host, project, kind, id
+}
+
+// gitlabCIAction retries or cancels the job or pipeline of a check with
+// glab api and returns the web URL of the result.
+func (s *Service) gitlabCIAction(ctx context.Context, check *models.CICheck, worktreePath, action string, unsupported error) (string, error) {
+	host, project, kind, id := s.gitlabCIRef(ctx, check.Link)
+	if kind == "" {
+		return "", unsupported
+	}
+	endpoint := fmt.Sprintf("projects/%s/%s/%s/%s", url.PathEscape(project), kind, id, action)
+	out, err := s.RunGitWithCombinedOutput(ctx, []string{"glab", "api", "--hostname", host, "-X", "POST", endpoint}, worktreePath, nil)
+	if err != nil {
+		return "", fmt.Errorf("glab api %s failed: %s", action, strings.TrimSpace(string(out)))
+	}
+	var result struct {
+		WebURL string `json:"web_url"`
+	}
+	if json.Unmarshal(out, &result) == nil && result.WebURL != "" {
+		return result.WebURL, nil
+	}
+	return check.Link, nil
+}
+
 func (s *Service) gitlabStatusToConclusion(status string) string {
 	switch strings.ToLower(status) {
 	case "success", "passed":
@@ -0,0 +1,437 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// gitlabAPIForge implements Forge over the GitLab REST API, falling back to
+// the glab CLI when no token can be resolved.
+type gitlabAPIForge struct {
+	gitlabForge
+}
+
+// gitlabAPIClient returns the shared GitLab API client for the CI/PR remote.
+func (s *Service) gitlabAPIClient(ctx context.Context) *forgeAPIClient {
+	s.gitlabAPIOnce.Do(func() {
+		// Allow tests to pre-seed the client directly on the struct.
+		if s.gitlabAPI != nil {
+			return
+		}
+		host, _ := splitRemoteURL(s.getRemoteURL(ctx))
+		if host == "" {
+			host = "gitlab.com"
+		}
+		source := forgeTokenSource{
+			envVars:    []string{"GITLAB_TOKEN", "GITLAB_ACCESS_TOKEN"},
+			configFile: glabConfigToken,
+		}
+		s.gitlabAPI = &forgeAPIClient{
+			name:       "GitLab",
+			restBase:   "https://" + host + "/api/v4",
+			authScheme: "Bearer",
+			http:       newForgeHTTPClient(),
+		}
+		s.gitlabAPI.resolveToken = func(ctx context.Context) string {
+			token := s.resolveForgeToken(ctx, host, source)
+			if token == "" {
+				s.debugf("no GitLab token found for %s, falling back to glab", host)
+			}
+			return token
+		}
+	})
+	return s.gitlabAPI
+}
+
+// api returns the API client when a token is available, or nil to use glab.
+func (f *gitlabAPIForge) api(ctx context.Context) *forgeAPIClient {
+	if c := f.s.gitlabAPIClient(ctx); c.ready(ctx) {
+		return c
+	}
+	return nil
+}
+
+// project returns the REST path prefix of the CI/PR project, e.g.
+// "/projects/group%2Fsubgroup%2Frepo".
+func (f *gitlabAPIForge) project(ctx context.Context) (string, bool) {
+	_, repoPath := splitRemoteURL(f.s.getRemoteURL(ctx))
+	if !strings.Contains(repoPath, "/") {
+		return "", false
+	}
+	return "/projects/" + url.PathEscape(repoPath), true
+}
+
+func (f *gitlabAPIForge) AuthenticatedUsername(ctx context.Context) string {
+	c := f.api(ctx)
+	if c == nil {
+		return f.gitlabForge.AuthenticatedUsername(ctx)
+	}
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := c.get(ctx, "/user", &user); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(user.Username)
+}
+
+func (f *gitlabAPIForge) FetchPRMap(ctx context.Context) (map[string]*models.PRInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchPRMap(ctx)
+	}
+
+	var mrs []map[string]any
+	if err := c.get(ctx, project+"/merge_requests?state=all&per_page=100", &mrs); err != nil {
+		f.s.notifyOnce("gitlab_api_mr_list", fmt.Sprintf("Failed to fetch GitLab merge requests: %v", err), "error")
+		return nil, err
+	}
+
+	prMap := make(map[string]*models.PRInfo)
+	for _, mr := range mrs {
+		if info := gitlabMRToInfo(mr); info.Branch != "" && preferPR(prMap[info.Branch], info) {
+			prMap[info.Branch] = info
+		}
+	}
+	return prMap, nil
+}
+
+func (f *gitlabAPIForge) FetchPRForWorktree(ctx context.Context, worktreePath string) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchPRForWorktree(ctx, worktreePath)
+	}
+
+	var match *models.PRInfo
+	for _, branch := range f.s.prBranchCandidates(ctx, worktreePath) {
+		var mrs []map[string]any
+		path := project + "/merge_requests?state=all&per_page=5&source_branch=" + url.QueryEscape(branch)
+		if err := c.get(ctx, path, &mrs); err != nil {
+			return nil, fmt.Errorf("failed to fetch MR data: %w", err)
+		}
+		for _, mr := range mrs {
+			if info := gitlabMRToInfo(mr); preferPR(match, info) {
+				match = info
+			}
+		}
+	}
+	return match, nil
+}
+
+func (f *gitlabAPIForge) FetchOpenPRs(ctx context.Context) ([]*models.PRInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchOpenPRs(ctx)
+	}
+
+	var mrs []map[string]any
+	if err := c.get(ctx, project+"/merge_requests?state=opened&per_page=100", &mrs); err != nil {
+		f.s.notifyOnce("gitlab_api_mr_list", fmt.Sprintf("Failed to fetch GitLab merge requests: %v", err), "error")
+		return nil, err
+	}
+
+	result := make([]*models.PRInfo, 0, len(mrs))
+	for _, mr := range mrs {
+		info := gitlabMRToInfo(mr)
+		if info.State != prStateOpen {
+			continue
+		}
+		// CI status would require additional API calls for GitLab, default to none
+		info.CIStatus = "none"
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (f *gitlabAPIForge) FetchPR(ctx context.Context, number int) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchPR(ctx, number)
+	}
+
+	var mr map[string]any
+	if err := c.get(ctx, fmt.Sprintf("%s/merge_requests/%d", project, number), &mr); err != nil {
+		return nil, fmt.Errorf("PR #%d not found", number)
+	}
+	info := gitlabMRToInfo(mr)
+	if info.State != prStateOpen {
+		return nil, fmt.Errorf("PR #%d is not open (state: %s)", number, info.State)
+	}
+	info.CIStatus = "none"
+	return info, nil
+}
+
+func (f *gitlabAPIForge) FetchOpenIssues(ctx context.Context) ([]*models.IssueInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchOpenIssues(ctx)
+	}
+
+	var issues []map[string]any
+	if err := c.get(ctx, project+"/issues?state=opened&per_page=100", &issues); err != nil {
+		f.s.notifyOnce("gitlab_api_issue_list", fmt.Sprintf("Failed to fetch GitLab issues: %v", err), "error")
+		return nil, err
+	}
+
+	result := make([]*models.IssueInfo, 0, len(issues))
+	for _, issue := range issues {
+		state, _ := issue["state"].(string)
+		if normalizeGitLabState(state) != prStateOpen {
+			continue
+		}
+		result = append(result, gitlabIssueToInfo(issue))
+	}
+	return result, nil
+}
+
+func (f *gitlabAPIForge) FetchIssue(ctx context.Context, number int) (*models.IssueInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchIssue(ctx, number)
+	}
+
+	var issue map[string]any
+	if err := c.get(ctx, fmt.Sprintf("%s/issues/%d", project, number), &issue); err != nil {
+		return nil, fmt.Errorf("issue #%d not found", number)
+	}
+	state, _ := issue["state"].(string)
+	if state = normalizeGitLabState(state); state != prStateOpen {
+		return nil, fmt.Errorf("issue #%d is not open (state: %s)", number, state)
+	}
+	return gitlabIssueToInfo(issue), nil
+}
+
+// FetchCIStatus returns the jobs of the latest pipeline for branch.
+func (f *gitlabAPIForge) FetchCIStatus(ctx context.Context, prNumber int, branch string) ([]*models.CICheck, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchCIStatus(ctx, prNumber, branch)
+	}
+
+	var pipelines []struct {
+		ID int `json:"id"`
+	}
+	if err := c.get(ctx, project+"/pipelines?per_page=1&ref="+url.QueryEscape(branch), &pipelines); err != nil {
+		if errors.Is(err, errForgeNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(pipelines) == 0 {
+		return nil, nil
+	}
+
+	var jobs []struct {
+		Name      string `json:"name"`
+		Status    string `json:"status"`
+		WebURL    string `json:"web_url"`
+		StartedAt string `json:"started_at"`
+	}
+	if err := c.get(ctx, fmt.Sprintf("%s/pipelines/%d/jobs?per_page=100", project, pipelines[0].ID), &jobs); err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.CICheck, 0, len(jobs))
+	for _, j := range jobs {
+		var startedAt time.Time
+		if j.StartedAt != "" {
+			startedAt, _ = time.Parse(time.RFC3339, j.StartedAt)
+		}
+		result = append(result, &models.CICheck{
+			Name:       j.Name,
+			Status:     strings.ToLower(j.Status),
+			Conclusion: f.s.gitlabStatusToConclusion(j.Status),
+			Link:       j.WebURL,
+			StartedAt:  startedAt,
+		})
+	}
+	return result, nil
+}
+
+func (f *gitlabAPIForge) RerunCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	return f.ciAction(ctx, check, worktreePath, "retry", ErrCIRerunUnsupported)
+}
+
+func (f *gitlabAPIForge) CancelCICheck(ctx context.Context, check *models.CICheck, worktreePath string) (string, error) {
+	return f.ciAction(ctx, check, worktreePath, "cancel", ErrCICancelUnsupported)
+}
+
+func (f *gitlabAPIForge) FetchCICheckLog(ctx context.Context, check *models.CICheck, worktreePath string, offset int) (*CILog, error) {
+	c := f.api(ctx)
+	if c == nil {
+		return f.gitlabForge.FetchCICheckLog(ctx, check, worktreePath, offset)
+	}
+	_, project, kind, id := f.s.gitlabCIRef(ctx, check.Link)
+	if kind != gitlabCIJobs {
+		return nil, ErrCILogUnsupported
+	}
+
+	jobPath := fmt.Sprintf("/projects/%s/jobs/%s", url.PathEscape(project), id)
+	var job gitlabJob
+	if err := c.get(ctx, jobPath, &job); err != nil {
+		return nil, err
+	}
+	content, start, err := c.getRawFrom(ctx, jobPath+"/trace", offset)
+	if err != nil {
+		return nil, err
+	}
+	ciLog := f.s.gitlabJobLog(job, string(content))
+	ciLog.Offset = start
+	return ciLog, nil
+}
+
+// ciAction retries or cancels the job or pipeline of a check through the
+// REST API.
+func (f *gitlabAPIForge) ciAction(ctx context.Context, check *models.CICheck, worktreePath, action string, unsupported error) (string, error) {
+	c := f.api(ctx)
+	if c == nil {
+		return f.s.gitlabCIAction(ctx, check, worktreePath, action, unsupported)
+	}
+	_, project, kind, id := f.s.gitlabCIRef(ctx, check.Link)
+	if kind == "" {
+		return "", unsupported
+	}
+
+	var result struct {
+		WebURL string `json:"web_url"`
+	}
+	path := fmt.Sprintf("/projects/%s/%s/%s/%s", url.PathEscape(project), kind, id, action)
+	if err := c.send(ctx, http.MethodPost, path, nil, &result); err != nil {
+		return "", err
+	}
+	if result.WebURL != "" {
+		return result.WebURL, nil
+	}
+	return check.Link, nil
+}
+
+// CreatePR opens a merge request through the REST API. Reviewer usernames are
+// resolved to user IDs; unknown usernames are skipped.
+func (f *gitlabAPIForge) CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.CreatePR(ctx, worktreePath, opts)
+	}
+
+	title := opts.Title
+	if opts.Draft {
+		title = "Draft: " + title
+	}
+	payload := map[string]any{
+		"source_branch":        headBranchName(opts.Head),
+		"target_branch":        opts.Base,
+		"title":                title,
+		"description":          opts.Body,
+		"remove_source_branch": false,
+	}
+	if len(opts.Labels) > 0 {
+		payload["labels"] = strings.Join(opts.Labels, ",")
+	}
+	var reviewerIDs []int
+	for _, username := range opts.Reviewers {
+		var users []struct {
+			ID int `json:"id"`
+		}
+		if err := c.get(ctx, "/users?username="+url.QueryEscape(username), &users); err == nil && len(users) > 0 {
+			reviewerIDs = append(reviewerIDs, users[0].ID)
+		}
+	}
+	if len(reviewerIDs) > 0 {
+		payload["reviewer_ids"] = reviewerIDs
+	}
+
+	// Merge requests from a fork are created on the fork and point at the
+	// target project by ID.
+	source := project
+	if fork := f.s.headForkProject(ctx, worktreePath, opts.Head); fork != "" {
+		var target struct {
+			ID int `json:"id"`
+		}
+		if err := c.get(ctx, project, &target); err != nil {
+			return nil, fmt.Errorf("failed to resolve target project: %w", err)
+		}
+		source = "/projects/" + url.PathEscape(fork)
+		payload["target_project_id"] = target.ID
+	}
+
+	var mr map[string]any
+	if err := c.send(ctx, http.MethodPost, source+"/merge_requests", payload, &mr); err != nil {
+		return nil, fmt.Errorf("failed to create merge request: %w", err)
+	}
+	return gitlabMRToInfo(mr), nil
+}
+
+// FetchReviewThreads fetches every page of the diff discussions of a merge request.
+func (f *gitlabAPIForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.FetchReviewThreads(ctx, prNumber)
+	}
+	var discussions []gitlabDiscussion
+	for page := 1; ; page++ {
+		var batch []gitlabDiscussion
+		if err := c.get(ctx, fmt.Sprintf("%s/merge_requests/%d/discussions?per_page=%d&page=%d", project, prNumber, gitlabDiscussionsPerPage, page), &batch); err != nil {
+			return nil, err
+		}
+		discussions = append(discussions, batch...)
+		if len(batch) < gitlabDiscussionsPerPage {
+			break
+		}
+	}
+	return gitlabDiscussionsToThreads(discussions), nil
+}
+
+func (f *gitlabAPIForge) ReplyToReviewThread(ctx context.Context, prNumber int, threadID, body string) error {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.ReplyToReviewThread(ctx, prNumber, threadID, body)
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s/notes", project, prNumber, url.PathEscape(threadID))
+	return c.send(ctx, http.MethodPost, path, map[string]any{"body": body}, nil)
+}
+
+func (f *gitlabAPIForge) SetReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok {
+		return f.gitlabForge.SetReviewThreadResolved(ctx, prNumber, threadID, resolved)
+	}
+	path := fmt.Sprintf("%s/merge_requests/%d/discussions/%s", project, prNumber, url.PathEscape(threadID))
+	return c.send(ctx, http.MethodPut, path, map[string]any{"resolved": resolved}, nil)
+}
+
+// MergePR merges a merge request through the REST API. Rebasing is left to
+// glab, which rebases the source branch before merging.
+func (f *gitlabAPIForge) MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	c := f.api(ctx)
+	project, ok := f.project(ctx)
+	if c == nil || !ok || opts.Method == MergeMethodRebase {
+		return f.gitlabForge.MergePR(ctx, worktreePath, number, opts)
+	}
+
+	payload := map[string]any{
+		"squash":                       opts.Method == MergeMethodSquash,
+		"merge_when_pipeline_succeeds": opts.Auto,
+	}
+	if err := c.send(ctx, http.MethodPut, fmt.Sprintf("%s/merge_requests/%d/merge", project, number), payload, nil); err != nil {
+		return fmt.Errorf("failed to merge merge request: %w", err)
+	}
+	return nil
+}
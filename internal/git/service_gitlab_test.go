@@ -8,6 +8,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/models"
 )
 
 func writeStub(t *testing.T, name, script string) string {
@@ -349,3 +351,142 @@ func TestFetchGitLabCIInvalidJSON(t *testing.T) {
 	_, err := service.fetchGitLabCI(ctx, "main")
 	require.Error(t, err)
 }
+
+func TestGitLabCIRefFromLink(t *testing.T) {
+	host, project, kind, id := gitlabCIRefFromLink("https://gitlab.example.com/group/sub/repo/-/jobs/123")
+	assert.Equal(t, "gitlab.example.com", host)
+	assert.Equal(t, "group/sub/repo", project)
+	assert.Equal(t, gitlabCIJobs, kind)
+	assert.Equal(t, "123", id)
+
+	_, _, kind, id = gitlabCIRefFromLink("https://gitlab.com/group/repo/-/pipelines/9")
+	assert.Equal(t, gitlabCIPipelines, kind)
+	assert.Equal(t, "9", id)
+
+	for _, link := range []string{
+		"",
+		"https://github.com/o/r/actions/runs/1",
+		"https://gitlab.com/group/repo/-/merge_requests/1",
+		"https://gitlab.com/group/repo/-/jobs/artifacts",
+		"https://gitlab.com/group/%24%28touch%20%2Ftmp%2Fpwned%29/-/jobs/1",
+	} {
+		_, _, kind, _ = gitlabCIRefFromLink(link)
+		assert.Empty(t, kind, link)
+	}
+}
+
+func TestGitLabCICheckLogCommand(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "git@gitlab.com:group/repo.git"
+	ctx := context.Background()
+
+	check := &models.CICheck{Name: "test", Link: "https://gitlab.com/group/repo/-/jobs/77"}
+	assert.Equal(t, []string{"glab", "ci", "trace", "77", "-R", "https://gitlab.com/group/repo"}, service.CICheckLogCommand(ctx, check, true))
+	assert.Nil(t, service.CICheckLogCommand(ctx, &models.CICheck{Link: "https://gitlab.com/group/repo/-/pipelines/5"}, false))
+}
+
+func TestGitLabCICheckOnOtherHostIgnored(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "https://gitlab.example.com/group/repo.git"
+	ctx := context.Background()
+
+	assert.NotNil(t, service.CICheckLogCommand(ctx, &models.CICheck{Link: "https://gitlab.example.com/group/repo/-/jobs/7"}, false))
+	assert.Nil(t, service.CICheckLogCommand(ctx, &models.CICheck{Link: "https://evil.example.org/group/repo/-/jobs/7"}, false))
+	_, err := service.RerunCICheck(ctx, &models.CICheck{Link: "https://gitlab.com/group/repo/-/jobs/7"}, "")
+	assert.ErrorIs(t, err, ErrCIRerunUnsupported)
+}
+
+func TestGitLabRerunAndCancelCICheck(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	stub := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + argsFile + "\n" +
+		"case \"$6\" in\n" +
+		"  */retry) echo '{\"id\":78,\"web_url\":\"https://gitlab.com/group/repo/-/jobs/78\"}' ;;\n" +
+		"  */cancel) echo '{\"id\":77}' ;;\n" +
+		"esac\n"
+	withStubbedPath(t, writeStub(t, "glab", stub))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "git@gitlab.com:group/repo.git"
+	ctx := context.Background()
+	check := &models.CICheck{Name: "test", Link: "https://gitlab.com/group/repo/-/jobs/77"}
+
+	runURL, err := service.RerunCICheck(ctx, check, "")
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/group/repo/-/jobs/78", runURL)
+
+	runURL, err = service.CancelCICheck(ctx, check, "")
+	require.NoError(t, err)
+	assert.Equal(t, check.Link, runURL)
+
+	args, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Equal(t, "api --hostname gitlab.com -X POST projects/group%2Frepo/jobs/77/retry\n"+
+		"api --hostname gitlab.com -X POST projects/group%2Frepo/jobs/77/cancel\n", string(args))
+
+	_, err = service.CancelCICheck(ctx, &models.CICheck{Link: "https://gitlab.com/group/repo/-/merge_requests/1"}, "")
+	assert.ErrorIs(t, err, ErrCICancelUnsupported)
+}
+
+func TestGitLabRerunCICheckError(t *testing.T) {
+	withStubbedPath(t, writeStub(t, "glab", "#!/bin/sh\necho '403 Forbidden' >&2\nexit 1\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "git@gitlab.com:group/repo.git"
+	_, err := service.RerunCICheck(context.Background(), &models.CICheck{Link: "https://gitlab.com/group/repo/-/pipelines/5"}, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "403 Forbidden")
+}
+
+func TestGitLabFetchCICheckLog(t *testing.T) {
+	stub := "#!/bin/sh\n" +
+		"case \"$4\" in\n" +
+		"  */trace) printf 'section_start:1:build\\r\\033[0Kbuilding\\n' ;;\n" +
+		"  *) echo '{\"id\":77,\"status\":\"running\"}' ;;\n" +
+		"esac\n"
+	withStubbedPath(t, writeStub(t, "glab", stub))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "git@gitlab.com:group/repo.git"
+	ciLog, err := service.FetchCICheckLog(context.Background(), &models.CICheck{Link: "https://gitlab.com/group/repo/-/jobs/77"}, "", 0)
+	require.NoError(t, err)
+	assert.True(t, ciLog.Running)
+	assert.Equal(t, "section_start:1:build\r\033[0Kbuilding\n", ciLog.Content)
+
+	_, err = service.FetchCICheckLog(context.Background(), &models.CICheck{Link: "https://gitlab.com/group/repo/-/pipelines/5"}, "", 0)
+	assert.ErrorIs(t, err, ErrCILogUnsupported)
+}
+
+func TestGitLabFetchCICheckLogFromOffset(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	stub := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + argsFile + "\n" +
+		"case \"$*\" in\n" +
+		"  *'Range: bytes=5-'*) printf 'HTTP/1.1 206 Partial Content\\r\\nContent-Range: bytes 5-11/12\\r\\n\\r\\n\\nline 2\\n' ;;\n" +
+		"  *'Range: bytes=99-'*) exit 1 ;;\n" +
+		"  */trace) printf 'line 1\\nline 2\\n' ;;\n" +
+		"  *) echo '{\"id\":77,\"status\":\"running\"}' ;;\n" +
+		"esac\n"
+	withStubbedPath(t, writeStub(t, "glab", stub))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	service.remoteURL = "git@gitlab.com:group/repo.git"
+	check := &models.CICheck{Link: "https://gitlab.com/group/repo/-/jobs/77"}
+
+	ciLog, err := service.FetchCICheckLog(context.Background(), check, "", 6)
+	require.NoError(t, err)
+	assert.Equal(t, 6, ciLog.Offset)
+	assert.Equal(t, "line 2\n", ciLog.Content)
+
+	// A failed range request falls back to the whole trace.
+	ciLog, err = service.FetchCICheckLog(context.Background(), check, "", 100)
+	require.NoError(t, err)
+	assert.Zero(t, ciLog.Offset)
+	assert.Equal(t, "line 1\nline 2\n", ciLog.Content)
+}
@@ -10,7 +10,7 @@ import (
 	"strings"
 )
 
-// DetectHost detects the git host (github, gitlab, or unknown)
+// DetectHost detects the git host (github, gitlab, gitea, or unknown)
 func (s *Service) DetectHost(ctx context.Context) string {
 	s.gitHostOnce.Do(func() {
 		// Allow tests to pre-seed gitHost directly on the struct.
@@ -30,6 +30,12 @@ func (s *Service) DetectHost(ctx context.Context) string {
 				if strings.Contains(hostname, gitHostGithub) {
 					s.gitHost = gitHostGithub
 				}
+				if s.gitHost == gitHostUnknown {
+					if client := s.newGiteaClientForHost(ctx, hostname); client != nil {
+						s.gitea = client
+						s.gitHost = gitHostGitea
+					}
+				}
 			}
 		}
 	})
@@ -42,6 +48,12 @@ func (s *Service) IsGitHubOrGitLab(ctx context.Context) bool {
 	return host == gitHostGithub || host == gitHostGitLab
 }
 
+// HasForge returns true if the repository is connected to a registered forge
+// (GitHub, GitLab, Gitea/Forgejo, or one injected with SetForge).
+func (s *Service) HasForge(ctx context.Context) bool {
+	return s.Forge(ctx) != nil
+}
+
 // IsGitHub returns true if the repository is connected to GitHub.
 func (s *Service) IsGitHub(ctx context.Context) bool {
 	return s.DetectHost(ctx) == gitHostGithub
@@ -20,6 +20,7 @@ func TestDetectHost(t *testing.T) {
 		{name: "github", remote: "git@github.com:org/repo.git", want: gitHostGithub},
 		{name: "gitlab", remote: "https://gitlab.com/group/repo.git", want: gitHostGitLab},
 		{name: "unknown", remote: "ssh://example.com/repo.git", want: gitHostUnknown},
+		{name: "codeberg", remote: "https://codeberg.org/forgejo/forgejo.git", want: gitHostGitea},
 	}
 
 	for _, tc := range cases {
@@ -6,7 +6,7 @@ import (
 	"fmt"
 )
 
-// prRefInfo holds the result of fetching PR/MR ref information from GitHub or GitLab.
+// prRefInfo holds the result of fetching PR/MR ref information from GitHub, GitLab, or Gitea.
 type prRefInfo struct {
 	headCommit string
 	repoURL    string
@@ -99,6 +99,9 @@ func (s *Service) fetchPRRefInfo(ctx context.Context, prNumber int, remoteBranch
 			remoteName: "origin",
 			mergeRef:   "refs/heads/" + sourceBranch,
 		}, true
+
+	case gitHostGitea:
+		return s.fetchGiteaPRRefInfo(ctx, prNumber)
 	}
 	return nil, false
 }
@@ -109,7 +112,7 @@ func (s *Service) fetchPRRefInfo(ctx context.Context, prNumber int, remoteBranch
 func (s *Service) CreateWorktreeFromPR(ctx context.Context, prNumber int, remoteBranch, localBranch, targetPath string) bool {
 	host := s.DetectHost(ctx)
 
-	if host != gitHostGithub && host != gitHostGitLab {
+	if host != gitHostGithub && host != gitHostGitLab && host != gitHostGitea {
 		if !s.RunCommandChecked(ctx, []string{"git", "fetch", "origin", remoteBranch}, "", fmt.Sprintf("Failed to fetch remote branch %s", remoteBranch)) {
 			return false
 		}
@@ -145,7 +148,7 @@ func (s *Service) CreateWorktreeFromPR(ctx context.Context, prNumber int, remote
 func (s *Service) CheckoutPRBranch(ctx context.Context, prNumber int, remoteBranch, localBranch string) bool {
 	host := s.DetectHost(ctx)
 
-	if host != gitHostGithub && host != gitHostGitLab {
+	if host != gitHostGithub && host != gitHostGitLab && host != gitHostGitea {
 		if !s.RunCommandChecked(ctx, []string{"git", "fetch", "origin", remoteBranch}, "", fmt.Sprintf("Failed to fetch remote branch %s", remoteBranch)) {
 			return false
 		}
@@ -0,0 +1,277 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// CreatePROptions describes a PR/MR to open from a pushed branch.
+type CreatePROptions struct {
+	Head      string // Source branch; "owner:branch" when it lives on a fork
+	Base      string // Target branch
+	Title     string
+	Body      string
+	Draft     bool
+	Reviewers []string
+	Labels    []string
+}
+
+// prNumberFromURLRe matches the trailing number of a PR/MR web URL.
+var prNumberFromURLRe = regexp.MustCompile(`/(?:pull|pulls|merge_requests)/(\d+)`)
+
+// createdPRInfo builds the PRInfo of a freshly created PR/MR from its URL.
+func createdPRInfo(opts CreatePROptions, prURL string) *models.PRInfo {
+	info := &models.PRInfo{
+		State:      prStateOpen,
+		Title:      opts.Title,
+		Body:       opts.Body,
+		URL:        prURL,
+		Branch:     headBranchName(opts.Head),
+		BaseBranch: opts.Base,
+		IsDraft:    opts.Draft,
+		CIStatus:   "none",
+	}
+	if m := prNumberFromURLRe.FindStringSubmatch(prURL); m != nil {
+		info.Number, _ = strconv.Atoi(m[1])
+	}
+	return info
+}
+
+// headBranchName strips the "owner:" fork prefix from a head reference.
+func headBranchName(head string) string {
+	if _, branch, ok := strings.Cut(head, ":"); ok {
+		return branch
+	}
+	return head
+}
+
+// headForkProject returns the repository path of the fork a qualified
+// "owner:branch" head lives on, read from the upstream remote of the branch
+// checked out in worktreePath. Nested GitLab namespaces are kept, e.g.
+// "alice/tools/repo". It returns "" when head is on the base repository.
+func (s *Service) headForkProject(ctx context.Context, worktreePath, head string) string {
+	if !strings.Contains(head, ":") || worktreePath == "" {
+		return ""
+	}
+	branch := strings.TrimSpace(s.RunGit(ctx, []string{"git", "symbolic-ref", "--quiet", "--short", "HEAD"}, worktreePath, []int{0, 1}, true, true))
+	if branch == "" {
+		return ""
+	}
+	remote := strings.TrimSpace(s.RunGit(ctx, []string{"git", "config", "--get", "branch." + branch + ".remote"}, worktreePath, []int{0, 1}, true, true))
+	if remote == "" || remote == "." || remote == s.resolveRemoteName(ctx) {
+		return ""
+	}
+	remoteURL := strings.TrimSpace(s.RunGit(ctx, []string{"git", "remote", "get-url", remote}, worktreePath, []int{0, 1, 2, 128}, true, true))
+	_, repoPath := splitRemoteURL(remoteURL)
+	return repoPath
+}
+
+// lastURLLine returns the last line of CLI output that looks like a URL.
+func lastURLLine(out string) string {
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); strings.HasPrefix(line, "https://") || strings.HasPrefix(line, "http://") {
+			return line
+		}
+	}
+	return ""
+}
+
+// CreatePR opens a PR/MR on the detected forge and returns its details.
+func (s *Service) CreatePR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	if strings.TrimSpace(opts.Title) == "" {
+		return nil, fmt.Errorf("a title is required")
+	}
+	if opts.Head == "" || opts.Base == "" {
+		return nil, fmt.Errorf("both head and base branches are required")
+	}
+	return s.forgeOrGitHub(ctx).CreatePR(ctx, worktreePath, opts)
+}
+
+// PushBranchForPR pushes the branch checked out in a worktree so a PR/MR can
+// be opened from it. The upstream is set when missing; an up-to-date upstream
+// is left untouched. env is added to the push environment, e.g. to disable
+// credential prompts. It returns the head reference to pass to CreatePR and
+// whether a push happened.
+func (s *Service) PushBranchForPR(ctx context.Context, worktreePath, branch string, env map[string]string) (head string, pushed bool, err error) {
+	if branch == "" {
+		return "", false, fmt.Errorf("cannot open a PR/MR from a detached HEAD")
+	}
+
+	remote := strings.TrimSpace(s.RunGit(ctx, []string{"git", "config", "--get", "branch." + branch + ".remote"}, worktreePath, []int{0, 1}, true, true))
+	remoteBranch := strings.TrimPrefix(strings.TrimSpace(s.RunGit(ctx, []string{"git", "config", "--get", "branch." + branch + ".merge"}, worktreePath, []int{0, 1}, true, true)), "refs/heads/")
+
+	var args []string
+	if remote == "" || remote == "." || remoteBranch == "" {
+		remote = s.pushRemoteName(ctx, worktreePath)
+		remoteBranch = branch
+		args = []string{"git", "push", "--set-upstream", remote, "HEAD:" + branch}
+	} else {
+		ahead := strings.TrimSpace(s.RunGit(ctx, []string{"git", "rev-list", "--count", "@{upstream}..HEAD"}, worktreePath, []int{0}, true, true))
+		if ahead != "0" {
+			args = []string{"git", "push", remote, "HEAD:" + remoteBranch}
+		}
+	}
+
+	if args != nil {
+		out, runErr := s.RunGitWithCombinedOutput(ctx, args, worktreePath, env)
+		if runErr != nil {
+			detail := strings.TrimSpace(string(out))
+			if detail == "" {
+				detail = runErr.Error()
+			}
+			return "", false, fmt.Errorf("push failed: %s", detail)
+		}
+		pushed = true
+	}
+
+	return s.prHeadRef(ctx, remote, remoteBranch), pushed, nil
+}
+
+// pushRemoteName returns the remote new branches are pushed to: the
+// configured remote.pushDefault, or origin.
+func (s *Service) pushRemoteName(ctx context.Context, worktreePath string) string {
+	if remote := strings.TrimSpace(s.RunGit(ctx, []string{"git", "config", "--get", "remote.pushDefault"}, worktreePath, []int{0, 1}, true, true)); remote != "" {
+		return remote
+	}
+	return "origin"
+}
+
+// prHeadRef qualifies branch with the owner of pushRemote when PRs target a
+// different remote, as in fork workflows where origin is the fork.
+func (s *Service) prHeadRef(ctx context.Context, pushRemote, branch string) string {
+	if pushRemote == s.resolveRemoteName(ctx) {
+		return branch
+	}
+	remoteURL := strings.TrimSpace(s.RunGit(ctx, []string{"git", "remote", "get-url", pushRemote}, "", []int{0, 1, 2, 128}, true, true))
+	_, repoPath := splitRemoteURL(remoteURL)
+	if owner, _, ok := strings.Cut(repoPath, "/"); ok && owner != "" {
+		return owner + ":" + branch
+	}
+	return branch
+}
+
+// CommitSubjects returns the subjects of the commits on HEAD that are not on
+// base, oldest first. The remote-tracking base is preferred when it exists.
+func (s *Service) CommitSubjects(ctx context.Context, worktreePath, base string) []string {
+	if base == "" {
+		return nil
+	}
+	ref := base
+	remoteRef := s.resolveRemoteName(ctx) + "/" + base
+	if s.RunGit(ctx, []string{"git", "rev-parse", "--verify", "--quiet", remoteRef}, worktreePath, []int{0, 1}, true, true) != "" {
+		ref = remoteRef
+	}
+	raw := s.RunGit(ctx, []string{"git", "log", "--reverse", "--format=%s", ref + "..HEAD"}, worktreePath, []int{0}, true, true)
+	var subjects []string
+	for line := range strings.SplitSeq(raw, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			subjects = append(subjects, line)
+		}
+	}
+	return subjects
+}
+
+// createGitHubPR opens a pull request with gh pr create.
+func (s *Service) createGitHubPR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	args := []string{
+		"gh", "pr", "create",
+		"--head", opts.Head,
+		"--base", opts.Base,
+		"--title", opts.Title,
+		"--body", opts.Body,
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	for _, reviewer := range opts.Reviewers {
+		args = append(args, "--reviewer", reviewer)
+	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
+	}
+	args = append(args, s.ghRepoArgs(ctx)...)
+
+	out, err := s.RunGitWithCombinedOutput(ctx, args, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gh pr create failed: %s", strings.TrimSpace(string(out)))
+	}
+	prURL := lastURLLine(string(out))
+	if prURL == "" {
+		return nil, fmt.Errorf("gh pr create did not return a PR URL")
+	}
+	return createdPRInfo(opts, prURL), nil
+}
+
+// createGitLabMR opens a merge request with glab mr create. A head on a fork
+// is opened from the fork project, which --head selects.
+func (s *Service) createGitLabMR(ctx context.Context, worktreePath string, opts CreatePROptions) (*models.PRInfo, error) {
+	args := []string{
+		"glab", "mr", "create",
+		"--source-branch", headBranchName(opts.Head),
+		"--target-branch", opts.Base,
+		"--title", opts.Title,
+		"--description", opts.Body,
+		"--yes",
+	}
+	if fork := s.headForkProject(ctx, worktreePath, opts.Head); fork != "" {
+		args = append(args, "--head", fork)
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	if len(opts.Reviewers) > 0 {
+		args = append(args, "--reviewer", strings.Join(opts.Reviewers, ","))
+	}
+	if len(opts.Labels) > 0 {
+		args = append(args, "--label", strings.Join(opts.Labels, ","))
+	}
+
+	out, err := s.RunGitWithCombinedOutput(ctx, args, worktreePath, nil)
+	if err != nil {
+		return nil, fmt.Errorf("glab mr create failed: %s", strings.TrimSpace(string(out)))
+	}
+	mrURL := lastURLLine(string(out))
+	if mrURL == "" {
+		return nil, fmt.Errorf("glab mr create did not return a MR URL")
+	}
+	return createdPRInfo(opts, mrURL), nil
+}
+
+// createGiteaPR opens a pull request through the Gitea/Forgejo REST API.
+// Reviewers are requested and labels attached best-effort once it exists.
+func (s *Service) createGiteaPR(ctx context.Context, opts CreatePROptions) (*models.PRInfo, error) {
+	c := s.giteaAPI(ctx)
+	if c == nil {
+		return nil, fmt.Errorf("no Gitea/Forgejo instance configured for this repository")
+	}
+
+	title := opts.Title
+	if opts.Draft {
+		// Gitea and Forgejo mark pull requests as drafts through a title prefix.
+		title = "WIP: " + title
+	}
+	var pr giteaPullRequest
+	payload := map[string]any{"head": opts.Head, "base": opts.Base, "title": title, "body": opts.Body}
+	if err := c.send(ctx, http.MethodPost, c.repoPath("/pulls"), payload, &pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+
+	if len(opts.Reviewers) > 0 {
+		if err := c.send(ctx, http.MethodPost, c.repoPath("/pulls/%d/requested_reviewers", pr.Number), map[string]any{"reviewers": opts.Reviewers}, nil); err != nil {
+			s.notifyGiteaError("gitea_pr_reviewers", err)
+		}
+	}
+	if len(opts.Labels) > 0 {
+		if err := c.send(ctx, http.MethodPost, c.repoPath("/issues/%d/labels", pr.Number), map[string]any{"labels": opts.Labels}, nil); err != nil {
+			s.notifyGiteaError("gitea_pr_labels", err)
+		}
+	}
+	return giteaPRToInfo(&pr), nil
+}
@@ -0,0 +1,300 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRepoWithBareOrigin returns a repository on branch "feature" with one
+// commit ahead of main, whose origin is a local bare repository.
+func setupRepoWithBareOrigin(t *testing.T) (repo, origin string) {
+	t.Helper()
+
+	repo = t.TempDir()
+	setupGitRepo(t, repo)
+	runGit(t, repo, "branch", "-M", "main")
+	origin = filepath.Join(t.TempDir(), "origin.git")
+	runGit(t, repo, "init", "--bare", origin)
+	runGit(t, repo, "remote", "add", "origin", origin)
+	runGit(t, repo, "push", "origin", "main")
+	runGit(t, repo, "checkout", "-b", "feature")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "feature.txt"), []byte("one"), 0o600))
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-m", "Add feature")
+	withCwd(t, repo)
+	return repo, origin
+}
+
+func TestPushBranchForPR(t *testing.T) {
+	ctx := context.Background()
+	repo, origin := setupRepoWithBareOrigin(t)
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+
+	head, pushed, err := service.PushBranchForPR(ctx, repo, "feature", nil)
+	require.NoError(t, err)
+	assert.True(t, pushed)
+	assert.Equal(t, "feature", head)
+	assert.Equal(t, "origin/feature", runGit(t, repo, "rev-parse", "--abbrev-ref", "@{upstream}"))
+	assert.Equal(t, runGit(t, repo, "rev-parse", "HEAD"), runGit(t, origin, "rev-parse", "feature"))
+
+	_, pushed, err = service.PushBranchForPR(ctx, repo, "feature", nil)
+	require.NoError(t, err)
+	assert.False(t, pushed, "an up-to-date upstream should not be pushed again")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "feature.txt"), []byte("two"), 0o600))
+	runGit(t, repo, "commit", "-am", "Tweak feature")
+	_, pushed, err = service.PushBranchForPR(ctx, repo, "feature", nil)
+	require.NoError(t, err)
+	assert.True(t, pushed)
+	assert.Equal(t, runGit(t, repo, "rev-parse", "HEAD"), runGit(t, origin, "rev-parse", "feature"))
+
+	_, _, err = service.PushBranchForPR(ctx, repo, "", nil)
+	assert.Error(t, err)
+}
+
+func TestPushBranchForPRQualifiesForkHead(t *testing.T) {
+	ctx := context.Background()
+	repo, _ := setupRepoWithBareOrigin(t)
+	runGit(t, repo, "remote", "set-url", "--push", "origin", runGit(t, repo, "remote", "get-url", "origin"))
+	runGit(t, repo, "remote", "add", "upstream", "https://github.com/org/repo.git")
+	runGit(t, repo, "config", "remote.origin.url", "git@github.com:alice/repo.git")
+	runGit(t, repo, "config", "branch.feature.remote", "origin")
+	runGit(t, repo, "config", "branch.feature.merge", "refs/heads/feature")
+	runGit(t, repo, "update-ref", "refs/remotes/origin/feature", "HEAD")
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	head, pushed, err := service.PushBranchForPR(ctx, repo, "feature", nil)
+	require.NoError(t, err)
+	assert.False(t, pushed)
+	assert.Equal(t, "alice:feature", head)
+}
+
+func TestCommitSubjects(t *testing.T) {
+	repo, _ := setupRepoWithBareOrigin(t)
+	runGit(t, repo, "commit", "--allow-empty", "-m", "Second change")
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	assert.Equal(t, []string{"Add feature", "Second change"}, service.CommitSubjects(context.Background(), repo, "main"))
+	assert.Nil(t, service.CommitSubjects(context.Background(), repo, ""))
+}
+
+func TestCreatedPRInfo(t *testing.T) {
+	opts := CreatePROptions{Head: "alice:feature", Base: "main", Title: "Add feature", Draft: true}
+	for prURL, number := range map[string]int{
+		"https://github.com/org/repo/pull/12":              12,
+		"https://gitlab.com/group/repo/-/merge_requests/7": 7,
+		"https://codeberg.org/org/repo/pulls/3":            3,
+	} {
+		info := createdPRInfo(opts, prURL)
+		assert.Equal(t, number, info.Number, prURL)
+		assert.Equal(t, "feature", info.Branch)
+		assert.Equal(t, "main", info.BaseBranch)
+		assert.Equal(t, prStateOpen, info.State)
+		assert.True(t, info.IsDraft)
+	}
+}
+
+func TestCreatePRRequiresTitleAndBranches(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.SetForge(&fakeForge{name: "fake"})
+
+	_, err := service.CreatePR(context.Background(), "", CreatePROptions{Head: "feature", Base: "main"})
+	assert.Error(t, err)
+	_, err = service.CreatePR(context.Background(), "", CreatePROptions{Title: "Add", Base: "main"})
+	assert.Error(t, err)
+}
+
+func TestCreateGitHubPRWithStub(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	stub := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"echo 'Warning: 1 uncommitted change' >&2\n" +
+		"echo 'https://github.com/org/repo/pull/42'\n"
+	withStubbedPath(t, writeStub(t, "gh", stub))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGithub
+	pr, err := service.CreatePR(context.Background(), t.TempDir(), CreatePROptions{
+		Head:      "feature",
+		Base:      "main",
+		Title:     "Add feature",
+		Body:      "Body",
+		Draft:     true,
+		Reviewers: []string{"bob", "org/team"},
+		Labels:    []string{"good first issue"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, pr.Number)
+	assert.Equal(t, "https://github.com/org/repo/pull/42", pr.URL)
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, []string{
+		"pr", "create", "--head", "feature", "--base", "main", "--title", "Add feature", "--body", "Body",
+		"--draft", "--reviewer", "bob", "--reviewer", "org/team", "--label", "good first issue",
+	}, args)
+}
+
+func TestCreateGitLabMRWithStub(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	stub := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"echo 'Creating merge request for feature into main'\n" +
+		"echo 'https://gitlab.com/group/repo/-/merge_requests/9'\n"
+	withStubbedPath(t, writeStub(t, "glab", stub))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	pr, err := service.CreatePR(context.Background(), t.TempDir(), CreatePROptions{
+		Head: "feature", Base: "main", Title: "Add feature", Reviewers: []string{"bob", "carol"}, Labels: []string{"bug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 9, pr.Number)
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := strings.Join(strings.Split(strings.TrimSpace(string(data)), "\n"), " ")
+	assert.Contains(t, args, "--source-branch feature --target-branch main")
+	assert.Contains(t, args, "--reviewer bob,carol")
+	assert.Contains(t, args, "--label bug")
+	assert.NotContains(t, args, "--draft")
+}
+
+func TestCreateGiteaPR(t *testing.T) {
+	var requests []string
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/v1/repos/org/repo/pulls" {
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(giteaTestPull(5, "open", false, "feature"))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(srv.Close)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitea
+	service.gitea = newGiteaClient(srv.URL, "secret", "org", "repo", srv.Client())
+
+	pr, err := service.CreatePR(context.Background(), "", CreatePROptions{
+		Head: "feature", Base: "main", Title: "Add feature", Draft: true, Reviewers: []string{"bob"}, Labels: []string{"bug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, pr.Number)
+	assert.Equal(t, "WIP: Add feature", created["title"])
+	assert.Equal(t, []string{
+		"POST /api/v1/repos/org/repo/pulls",
+		"POST /api/v1/repos/org/repo/pulls/5/requested_reviewers",
+		"POST /api/v1/repos/org/repo/issues/5/labels",
+	}, requests)
+}
+
+func TestGitHubAPIForgeCreatePR(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	bodies := map[string]map[string]any{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies[r.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+		if r.URL.Path == "/repos/org/repo/pulls" {
+			_, _ = w.Write([]byte(`{"number": 8, "html_url": "https://github.com/org/repo/pull/8"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	pr, err := service.CreatePR(context.Background(), "", CreatePROptions{
+		Head: "feature", Base: "main", Title: "Add feature", Draft: true, Reviewers: []string{"bob", "org/core"}, Labels: []string{"bug"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 8, pr.Number)
+	assert.True(t, pr.IsDraft)
+	assert.Equal(t, true, bodies["/repos/org/repo/pulls"]["draft"])
+	assert.Equal(t, []any{"bob"}, bodies["/repos/org/repo/pulls/8/requested_reviewers"]["reviewers"])
+	assert.Equal(t, []any{"core"}, bodies["/repos/org/repo/pulls/8/requested_reviewers"]["team_reviewers"])
+	assert.Equal(t, []any{"bug"}, bodies["/repos/org/repo/issues/8/labels"]["labels"])
+}
+
+func TestGitLabAPIForgeCreatePR(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/repo.git")
+
+	var created map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/users":
+			_, _ = w.Write([]byte(`[{"id": 31}]`))
+		case "/projects/group/repo/merge_requests":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid": 4, "state": "opened", "title": "Draft: Add feature", "web_url": "https://gitlab.com/group/repo/-/merge_requests/4", "source_branch": "feature", "target_branch": "main", "draft": true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	pr, err := service.CreatePR(context.Background(), "", CreatePROptions{
+		Head: "feature", Base: "main", Title: "Add feature", Draft: true, Reviewers: []string{"bob"}, Labels: []string{"bug", "ui"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, pr.Number)
+	assert.True(t, pr.IsDraft)
+	assert.Equal(t, "Draft: Add feature", created["title"])
+	assert.Equal(t, "bug,ui", created["labels"])
+	assert.Equal(t, []any{float64(31)}, created["reviewer_ids"])
+}
+
+func TestGitLabAPIForgeCreatePRFromFork(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:alice/tools/repo.git")
+	repo, err := os.Getwd()
+	require.NoError(t, err)
+	runGit(t, repo, "remote", "add", "upstream", "git@gitlab.com:group/repo.git")
+	branch := runGit(t, repo, "symbolic-ref", "--short", "HEAD")
+	runGit(t, repo, "config", "branch."+branch+".remote", "origin")
+
+	var created map[string]any
+	var createdOn string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/group/repo":
+			_, _ = w.Write([]byte(`{"id": 77}`))
+		case r.Method == http.MethodPost:
+			createdOn = r.URL.Path
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid": 5, "state": "opened", "web_url": "https://gitlab.com/group/repo/-/merge_requests/5", "source_branch": "feature", "target_branch": "main"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	pr, err := service.CreatePR(context.Background(), repo, CreatePROptions{Head: "alice:feature", Base: "main", Title: "Add feature"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, pr.Number)
+	assert.Equal(t, "/projects/alice/tools/repo/merge_requests", createdOn)
+	assert.Equal(t, "feature", created["source_branch"])
+	assert.Equal(t, float64(77), created["target_project_id"])
+}
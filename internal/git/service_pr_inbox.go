@@ -0,0 +1,130 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// prInboxConcurrency bounds how many of the user's PRs/MRs have their checks
+// and review threads looked up at once.
+const prInboxConcurrency = 4
+
+// teamForge is implemented by forges where reviews can be requested from a
+// team. It returns the slugs of the teams of the authenticated user in the
+// organisation owning the repository.
+type teamForge interface {
+	AuthenticatedTeams(ctx context.Context) []string
+}
+
+// PRInbox groups the open PRs/MRs that need the attention of the
+// authenticated user. A PR/MR can appear in several groups.
+type PRInbox struct {
+	Username        string
+	ReviewRequested []*models.PRInfo // Review requested from the user
+	Assigned        []*models.PRInfo // Assigned to the user
+	FailingCI       []*models.PRInfo // Authored by the user with failing checks
+	NewComments     []*models.PRInfo // Authored by the user with unanswered review threads
+}
+
+// FetchPRInbox fetches the open PRs/MRs and groups the ones the authenticated
+// user has to act on.
+func (s *Service) FetchPRInbox(ctx context.Context) (*PRInbox, error) {
+	f := s.forgeOrGitHub(ctx)
+	username := f.AuthenticatedUsername(ctx)
+	if username == "" {
+		return nil, fmt.Errorf("unable to determine the authenticated %s user", f.Name())
+	}
+	prs, err := f.FetchOpenPRs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Review requests name either the user or one of their teams.
+	reviewers := []string{username}
+	if tf, ok := f.(teamForge); ok {
+		reviewers = append(reviewers, tf.AuthenticatedTeams(ctx)...)
+	}
+
+	inbox := &PRInbox{Username: username}
+	var mine []*models.PRInfo
+	for _, pr := range prs {
+		if slices.ContainsFunc(reviewers, func(reviewer string) bool { return containsLogin(pr.ReviewRequests, reviewer) }) {
+			inbox.ReviewRequested = append(inbox.ReviewRequested, pr)
+		}
+		if containsLogin(pr.Assignees, username) {
+			inbox.Assigned = append(inbox.Assigned, pr)
+		}
+		if strings.EqualFold(pr.Author, username) {
+			mine = append(mine, pr)
+		}
+	}
+
+	// Checks and review threads are only looked up for the user's own PRs/MRs,
+	// a few at a time, which keeps the forge requests and subprocesses bounded.
+	failing := make([]bool, len(mine))
+	commented := make([]bool, len(mine))
+	sem := make(chan struct{}, prInboxConcurrency)
+	var wg sync.WaitGroup
+	for i, pr := range mine {
+		wg.Go(func() {
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			failing[i] = prChecksFailing(ctx, f, pr)
+			threads, err := f.FetchReviewThreads(ctx, pr.Number)
+			if err == nil {
+				commented[i] = hasUnansweredThread(threads, username)
+			}
+		})
+	}
+	wg.Wait()
+
+	for i, pr := range mine {
+		if failing[i] {
+			inbox.FailingCI = append(inbox.FailingCI, pr)
+		}
+		if commented[i] {
+			inbox.NewComments = append(inbox.NewComments, pr)
+		}
+	}
+	return inbox, nil
+}
+
+// prChecksFailing reports whether the checks of a PR/MR fail. Forges that do
+// not return a CI status with the PR/MR list are queried for the checks.
+func prChecksFailing(ctx context.Context, f Forge, pr *models.PRInfo) bool {
+	if pr.CIStatus != "" && pr.CIStatus != "none" {
+		return pr.CIStatus == "failure"
+	}
+	checks, err := f.FetchCIStatus(ctx, pr.Number, pr.Branch)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(checks, func(check *models.CICheck) bool {
+		return check.Conclusion == "failure"
+	})
+}
+
+// hasUnansweredThread reports whether an unresolved review thread ends with a
+// comment from someone other than username.
+func hasUnansweredThread(threads []*models.PRReviewThread, username string) bool {
+	for _, thread := range threads {
+		if thread.IsResolved || len(thread.Comments) == 0 {
+			continue
+		}
+		if last := thread.Comments[len(thread.Comments)-1]; !strings.EqualFold(last.Author, username) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsLogin(logins []string, username string) bool {
+	return slices.ContainsFunc(logins, func(login string) bool {
+		return strings.EqualFold(login, username)
+	})
+}
@@ -0,0 +1,153 @@
+package git
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func prNumbers(prs []*models.PRInfo) []int {
+	numbers := make([]int, 0, len(prs))
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers
+}
+
+func TestFetchPRInboxGroupsPRs(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.SetForge(&fakeForge{
+		name: "fake",
+		openPRs: []*models.PRInfo{
+			{Number: 1, Author: "alice", ReviewRequests: []string{"fake-user"}},
+			{Number: 2, Author: "alice", Assignees: []string{"Fake-User"}},
+			{Number: 3, Author: "fake-user", CIStatus: "failure"},
+			{Number: 4, Author: "fake-user", CIStatus: "none"},
+			{Number: 5, Author: "bob", CIStatus: "failure"},
+		},
+		checks: []*models.CICheck{{Name: "build", Conclusion: "failure"}},
+		threads: []*models.PRReviewThread{
+			{ID: "t1", Comments: []models.PRReviewComment{{Author: "fake-user"}, {Author: "alice"}}},
+		},
+	})
+
+	inbox, err := service.FetchPRInbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "fake-user", inbox.Username)
+	assert.Equal(t, []int{1}, prNumbers(inbox.ReviewRequested))
+	assert.Equal(t, []int{2}, prNumbers(inbox.Assigned))
+	assert.Equal(t, []int{3, 4}, prNumbers(inbox.FailingCI))
+	assert.Equal(t, []int{3, 4}, prNumbers(inbox.NewComments))
+}
+
+// teamFakeForge is a fakeForge whose user belongs to teams and which records
+// how many review thread lookups run at once.
+type teamFakeForge struct {
+	*fakeForge
+	teams    []string
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (f *teamFakeForge) AuthenticatedTeams(context.Context) []string { return f.teams }
+
+func (f *teamFakeForge) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	current := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if current <= peak || f.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	return f.fakeForge.FetchReviewThreads(ctx, prNumber)
+}
+
+func TestFetchPRInboxMatchesTeamsAndBoundsLookups(t *testing.T) {
+	prs := []*models.PRInfo{
+		{Number: 1, Author: "alice", ReviewRequests: []string{"Core"}},
+		{Number: 2, Author: "alice", ReviewRequests: []string{"docs"}},
+	}
+	for i := range 3 * prInboxConcurrency {
+		prs = append(prs, &models.PRInfo{Number: 10 + i, Author: "fake-user", CIStatus: "success"})
+	}
+	forge := &teamFakeForge{fakeForge: &fakeForge{name: "fake", openPRs: prs}, teams: []string{"core"}}
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.SetForge(forge)
+
+	inbox, err := service.FetchPRInbox(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, prNumbers(inbox.ReviewRequested))
+	assert.LessOrEqual(t, forge.peak.Load(), int32(prInboxConcurrency))
+}
+
+func TestGitHubTeamSlugs(t *testing.T) {
+	teams := []githubTeam{{Slug: "core"}, {Slug: "docs"}, {Slug: "other"}}
+	teams[0].Organization.Login = "Org"
+	teams[1].Organization.Login = "org"
+	teams[2].Organization.Login = "elsewhere"
+	assert.Equal(t, []string{"core", "docs"}, githubTeamSlugs(teams, "org"))
+}
+
+func TestHasUnansweredThread(t *testing.T) {
+	tests := []struct {
+		name    string
+		threads []*models.PRReviewThread
+		want    bool
+	}{
+		{name: "no threads"},
+		{name: "answered", threads: []*models.PRReviewThread{
+			{Comments: []models.PRReviewComment{{Author: "alice"}, {Author: "me"}}},
+		}},
+		{name: "resolved", threads: []*models.PRReviewThread{
+			{IsResolved: true, Comments: []models.PRReviewComment{{Author: "alice"}}},
+		}},
+		{name: "waiting on me", want: true, threads: []*models.PRReviewThread{
+			{Comments: []models.PRReviewComment{{Author: "me"}}},
+			{Comments: []models.PRReviewComment{{Author: "alice"}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hasUnansweredThread(tt.threads, "me"))
+		})
+	}
+}
+
+func TestFetchGitHubOpenPRsParsesAssigneesAndReviewRequests(t *testing.T) {
+	stub := "#!/bin/sh\n" +
+		"if [ \"$1\" = \"pr\" ] && [ \"$2\" = \"list\" ]; then\n" +
+		"  echo '[{\"number\":1,\"state\":\"OPEN\",\"title\":\"One\",\"headRefName\":\"one\",\"author\":{\"login\":\"alice\"},\"assignees\":[{\"login\":\"bob\"}],\"reviewRequests\":[{\"login\":\"carol\"},{\"slug\":\"core\"}]}]'\n" +
+		"  exit 0\n" +
+		"fi\n" +
+		"exit 0\n"
+	dir := writeStub(t, "gh", stub)
+	withStubbedPath(t, dir)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGithub
+
+	prs, err := service.FetchAllOpenPRs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, []string{"bob"}, prs[0].Assignees)
+	assert.Equal(t, []string{"carol", "core"}, prs[0].ReviewRequests)
+}
+
+func TestGitLabMRToInfoParsesAssigneesAndReviewers(t *testing.T) {
+	info := gitlabMRToInfo(map[string]any{
+		"iid":       float64(4),
+		"state":     "opened",
+		"assignees": []any{map[string]any{"username": "bob"}},
+		"reviewers": []any{map[string]any{"username": "carol"}, map[string]any{"name": "no username"}},
+	})
+	assert.Equal(t, []string{"bob"}, info.Assignees)
+	assert.Equal(t, []string{"carol"}, info.ReviewRequests)
+}
@@ -0,0 +1,82 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// PR/MR merge methods.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// MergePROptions describes how a PR/MR is merged.
+type MergePROptions struct {
+	Method string // One of MergeMethodMerge, MergeMethodSquash, MergeMethodRebase
+	Auto   bool   // Merge once required checks pass instead of right away
+}
+
+// MergePR merges a PR/MR on the detected forge, or enables auto-merge for it
+// when opts.Auto is set.
+func (s *Service) MergePR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	if number <= 0 {
+		return fmt.Errorf("invalid PR/MR number %d", number)
+	}
+	switch opts.Method {
+	case MergeMethodMerge, MergeMethodSquash, MergeMethodRebase:
+	default:
+		return fmt.Errorf("unknown merge method %q", opts.Method)
+	}
+	return s.forgeOrGitHub(ctx).MergePR(ctx, worktreePath, number, opts)
+}
+
+// mergeGitHubPR merges a pull request with gh pr merge.
+func (s *Service) mergeGitHubPR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	args := []string{"gh", "pr", "merge", strconv.Itoa(number), "--" + opts.Method}
+	if opts.Auto {
+		args = append(args, "--auto")
+	}
+	args = append(args, s.ghRepoArgs(ctx)...)
+
+	out, err := s.RunGitWithCombinedOutput(ctx, args, worktreePath, nil)
+	if err != nil {
+		return fmt.Errorf("gh pr merge failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// mergeGitLabMR merges a merge request with glab mr merge. The merge method
+// "merge" defers to the project settings.
+func (s *Service) mergeGitLabMR(ctx context.Context, worktreePath string, number int, opts MergePROptions) error {
+	args := []string{"glab", "mr", "merge", strconv.Itoa(number), "--yes", "--auto-merge=" + strconv.FormatBool(opts.Auto)}
+	switch opts.Method {
+	case MergeMethodSquash:
+		args = append(args, "--squash")
+	case MergeMethodRebase:
+		args = append(args, "--rebase")
+	}
+
+	out, err := s.RunGitWithCombinedOutput(ctx, args, worktreePath, nil)
+	if err != nil {
+		return fmt.Errorf("glab mr merge failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// mergeGiteaPR merges a pull request through the Gitea/Forgejo REST API.
+func (s *Service) mergeGiteaPR(ctx context.Context, number int, opts MergePROptions) error {
+	c := s.giteaAPI(ctx)
+	if c == nil {
+		return fmt.Errorf("no Gitea/Forgejo instance configured for this repository")
+	}
+	payload := map[string]any{"Do": opts.Method, "merge_when_checks_succeed": opts.Auto}
+	if err := c.send(ctx, http.MethodPost, c.repoPath("/pulls/%d/merge", number), payload, nil); err != nil {
+		return fmt.Errorf("failed to merge pull request: %w", err)
+	}
+	return nil
+}
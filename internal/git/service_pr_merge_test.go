@@ -0,0 +1,152 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePRValidatesInput(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	fake := &fakeForge{name: "fake"}
+	service.SetForge(fake)
+
+	require.Error(t, service.MergePR(context.Background(), "", 0, MergePROptions{Method: MergeMethodSquash}))
+	require.Error(t, service.MergePR(context.Background(), "", 3, MergePROptions{Method: "fast-forward"}))
+	assert.Empty(t, fake.merged)
+
+	require.NoError(t, service.MergePR(context.Background(), "", 3, MergePROptions{Method: MergeMethodRebase, Auto: true}))
+	assert.Equal(t, []MergePROptions{{Method: MergeMethodRebase, Auto: true}}, fake.merged)
+}
+
+func TestMergeGitHubPRWithStub(t *testing.T) {
+	tests := []struct {
+		name string
+		opts MergePROptions
+		want []string
+	}{
+		{name: "squash", opts: MergePROptions{Method: MergeMethodSquash}, want: []string{"pr", "merge", "12", "--squash"}},
+		{name: "auto rebase", opts: MergePROptions{Method: MergeMethodRebase, Auto: true}, want: []string{"pr", "merge", "12", "--rebase", "--auto"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argsFile := filepath.Join(t.TempDir(), "args")
+			withStubbedPath(t, writeStub(t, "gh", "#!/bin/sh\nprintf '%s\\n' \"$@\" > "+argsFile+"\n"))
+
+			service := NewService(func(string, string) {}, func(string, string, string) {})
+			service.gitHost = gitHostGithub
+			require.NoError(t, service.MergePR(context.Background(), t.TempDir(), 12, tt.opts))
+
+			data, err := os.ReadFile(argsFile)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, strings.Split(strings.TrimSpace(string(data)), "\n"))
+		})
+	}
+}
+
+func TestMergeGitLabMRWithStub(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	withStubbedPath(t, writeStub(t, "glab", "#!/bin/sh\nprintf '%s\\n' \"$@\" > "+argsFile+"\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	require.NoError(t, service.MergePR(context.Background(), t.TempDir(), 9, MergePROptions{Method: MergeMethodSquash}))
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mr", "merge", "9", "--yes", "--auto-merge=false", "--squash"}, strings.Split(strings.TrimSpace(string(data)), "\n"))
+}
+
+func TestMergeGitHubPRFailureIncludesOutput(t *testing.T) {
+	withStubbedPath(t, writeStub(t, "gh", "#!/bin/sh\necho 'Pull request is not mergeable' >&2\nexit 1\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGithub
+	err := service.MergePR(context.Background(), t.TempDir(), 12, MergePROptions{Method: MergeMethodMerge})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not mergeable")
+}
+
+func TestMergeGiteaPR(t *testing.T) {
+	var path string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitea
+	service.gitea = newGiteaClient(srv.URL, "secret", "org", "repo", srv.Client())
+
+	require.NoError(t, service.MergePR(context.Background(), "", 5, MergePROptions{Method: MergeMethodSquash, Auto: true}))
+	assert.Equal(t, "POST /api/v1/repos/org/repo/pulls/5/merge", path)
+	assert.Equal(t, "squash", body["Do"])
+	assert.Equal(t, true, body["merge_when_checks_succeed"])
+}
+
+func TestGitHubAPIForgeMergePR(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	var requests []string
+	var mergeBody, graphqlBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/org/repo/pulls/8/merge":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&mergeBody))
+			_, _ = w.Write([]byte(`{"merged": true}`))
+		case "/repos/org/repo/pulls/8":
+			_, _ = w.Write([]byte(`{"node_id": "PR_node"}`))
+		case "/graphql":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&graphqlBody))
+			_, _ = w.Write([]byte(`{"data": {"enablePullRequestAutoMerge": {"clientMutationId": null}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	require.NoError(t, service.MergePR(context.Background(), "", 8, MergePROptions{Method: MergeMethodSquash}))
+	assert.Equal(t, "squash", mergeBody["merge_method"])
+
+	require.NoError(t, service.MergePR(context.Background(), "", 8, MergePROptions{Method: MergeMethodRebase, Auto: true}))
+	assert.Equal(t, map[string]any{"id": "PR_node", "method": "REBASE"}, graphqlBody["variables"])
+	assert.Equal(t, []string{
+		"PUT /repos/org/repo/pulls/8/merge",
+		"GET /repos/org/repo/pulls/8",
+		"POST /graphql",
+	}, requests)
+}
+
+func TestGitLabAPIForgeMergePR(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/repo.git")
+
+	var path string
+	var body map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.Method + " " + r.URL.Path
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"iid": 4, "state": "merged"}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	require.NoError(t, service.MergePR(context.Background(), "", 4, MergePROptions{Method: MergeMethodSquash, Auto: true}))
+	assert.Equal(t, "PUT /projects/group/repo/merge_requests/4/merge", path)
+	assert.Equal(t, true, body["squash"])
+	assert.Equal(t, true, body["merge_when_pipeline_succeeds"])
+}
@@ -0,0 +1,427 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const githubReviewThreadsQuery = `query($owner: String!, $name: String!, $number: Int!, $after: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id
+          path
+          line
+          originalLine
+          isResolved
+          isOutdated
+          comments(first: 100) {
+            pageInfo { hasNextPage endCursor }
+            nodes { body url createdAt author { login } }
+          }
+        }
+      }
+    }
+  }
+}`
+
+// githubThreadCommentsQuery fetches the comments of a thread past its first page.
+const githubThreadCommentsQuery = `query($id: ID!, $after: String) {
+  node(id: $id) {
+    ... on PullRequestReviewThread {
+      comments(first: 100, after: $after) {
+        pageInfo { hasNextPage endCursor }
+        nodes { body url createdAt author { login } }
+      }
+    }
+  }
+}`
+
+const (
+	githubReplyToThreadMutation = `mutation($id: ID!, $body: String!) {
+  addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $id, body: $body}) { comment { id } }
+}`
+	githubResolveThreadMutation = `mutation($id: ID!) {
+  resolveReviewThread(input: {threadId: $id}) { thread { id } }
+}`
+	githubUnresolveThreadMutation = `mutation($id: ID!) {
+  unresolveReviewThread(input: {threadId: $id}) { thread { id } }
+}`
+)
+
+// githubPageInfo is the pagination cursor of a GraphQL connection.
+type githubPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// githubReviewComments is a page of review thread comments.
+type githubReviewComments struct {
+	PageInfo githubPageInfo `json:"pageInfo"`
+	Nodes    []struct {
+		Body      string `json:"body"`
+		URL       string `json:"url"`
+		CreatedAt string `json:"createdAt"`
+		Author    *struct {
+			Login string `json:"login"`
+		} `json:"author"`
+	} `json:"nodes"`
+}
+
+// githubReviewThreadNode is a review thread with its first page of comments.
+type githubReviewThreadNode struct {
+	ID           string               `json:"id"`
+	Path         string               `json:"path"`
+	Line         int                  `json:"line"`
+	OriginalLine int                  `json:"originalLine"`
+	IsResolved   bool                 `json:"isResolved"`
+	IsOutdated   bool                 `json:"isOutdated"`
+	Comments     githubReviewComments `json:"comments"`
+}
+
+// githubReviewThreadsData is the "data" member of githubReviewThreadsQuery.
+type githubReviewThreadsData struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				PageInfo githubPageInfo           `json:"pageInfo"`
+				Nodes    []githubReviewThreadNode `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+// githubThreadCommentsData is the "data" member of githubThreadCommentsQuery.
+type githubThreadCommentsData struct {
+	Node struct {
+		Comments githubReviewComments `json:"comments"`
+	} `json:"node"`
+}
+
+// githubGraphQLFunc runs a GraphQL query and decodes its "data" member into out.
+type githubGraphQLFunc func(query string, variables map[string]any, out any) error
+
+// collectGitHubReviewThreads pages through the review threads of a PR, and
+// through the comments of the threads holding more than one page of them.
+func collectGitHubReviewThreads(run githubGraphQLFunc, owner, name string, prNumber int) ([]*models.PRReviewThread, error) {
+	var threads []*models.PRReviewThread
+	after := ""
+	for {
+		variables := map[string]any{"owner": owner, "name": name, "number": prNumber}
+		if after != "" {
+			variables["after"] = after
+		}
+		var data githubReviewThreadsData
+		if err := run(githubReviewThreadsQuery, variables, &data); err != nil {
+			return nil, err
+		}
+		page := data.Repository.PullRequest.ReviewThreads
+		for i := range page.Nodes {
+			node := &page.Nodes[i]
+			for cursor := node.Comments.PageInfo; cursor.HasNextPage && cursor.EndCursor != ""; {
+				var more githubThreadCommentsData
+				if err := run(githubThreadCommentsQuery, map[string]any{"id": node.ID, "after": cursor.EndCursor}, &more); err != nil {
+					return nil, err
+				}
+				node.Comments.Nodes = append(node.Comments.Nodes, more.Node.Comments.Nodes...)
+				cursor = more.Node.Comments.PageInfo
+			}
+			threads = append(threads, node.thread())
+		}
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == "" {
+			return threads, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+func (node *githubReviewThreadNode) thread() *models.PRReviewThread {
+	thread := &models.PRReviewThread{
+		ID:         node.ID,
+		Path:       node.Path,
+		Line:       node.Line,
+		IsResolved: node.IsResolved,
+		IsOutdated: node.IsOutdated,
+	}
+	if thread.Line == 0 {
+		// Outdated threads no longer map to a line of the new version.
+		thread.Line = node.OriginalLine
+	}
+	for _, c := range node.Comments.Nodes {
+		comment := models.PRReviewComment{Body: c.Body, URL: c.URL}
+		if c.Author != nil {
+			comment.Author = c.Author.Login
+		}
+		comment.CreatedAt, _ = time.Parse(time.RFC3339, c.CreatedAt)
+		thread.Comments = append(thread.Comments, comment)
+	}
+	return thread
+}
+
+// gitlabDiscussionsPerPage is the page size used to list merge request discussions.
+const gitlabDiscussionsPerPage = 100
+
+// gitlabDiscussion is a merge request discussion from the GitLab REST API.
+type gitlabDiscussion struct {
+	ID    string `json:"id"`
+	Notes []struct {
+		ID         int    `json:"id"`
+		Body       string `json:"body"`
+		System     bool   `json:"system"`
+		Resolvable bool   `json:"resolvable"`
+		Resolved   bool   `json:"resolved"`
+		CreatedAt  string `json:"created_at"`
+		Author     struct {
+			Username string `json:"username"`
+		} `json:"author"`
+		Position *struct {
+			NewPath string `json:"new_path"`
+			OldPath string `json:"old_path"`
+			NewLine int    `json:"new_line"`
+			OldLine int    `json:"old_line"`
+		} `json:"position"`
+	} `json:"notes"`
+}
+
+// gitlabDiscussionsToThreads keeps the discussions started on a diff line.
+func gitlabDiscussionsToThreads(discussions []gitlabDiscussion) []*models.PRReviewThread {
+	threads := make([]*models.PRReviewThread, 0, len(discussions))
+	for _, d := range discussions {
+		if len(d.Notes) == 0 || d.Notes[0].System || d.Notes[0].Position == nil {
+			continue
+		}
+		pos := d.Notes[0].Position
+		thread := &models.PRReviewThread{
+			ID:         d.ID,
+			Path:       pos.NewPath,
+			Line:       pos.NewLine,
+			IsResolved: d.Notes[0].Resolvable && d.Notes[0].Resolved,
+		}
+		if thread.Path == "" {
+			thread.Path = pos.OldPath
+		}
+		if thread.Line == 0 {
+			thread.Line = pos.OldLine
+		}
+		for _, note := range d.Notes {
+			if note.System {
+				continue
+			}
+			createdAt, _ := time.Parse(time.RFC3339, note.CreatedAt)
+			thread.Comments = append(thread.Comments, models.PRReviewComment{
+				Author:    note.Author.Username,
+				Body:      note.Body,
+				CreatedAt: createdAt,
+			})
+		}
+		threads = append(threads, thread)
+	}
+	return threads
+}
+
+// giteaReviewComment is a pull request review comment from the Gitea API.
+type giteaReviewComment struct {
+	Body             string     `json:"body"`
+	Path             string     `json:"path"`
+	Position         int        `json:"position"`
+	OriginalPosition int        `json:"original_position"`
+	HTMLURL          string     `json:"html_url"`
+	CreatedAt        time.Time  `json:"created_at"`
+	User             giteaUser  `json:"user"`
+	Resolver         *giteaUser `json:"resolver"`
+}
+
+// FetchReviewThreads returns the inline review threads of a PR/MR, sorted by
+// file and line.
+func (s *Service) FetchReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	threads, err := s.forgeOrGitHub(ctx).FetchReviewThreads(ctx, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		if threads[i].Path != threads[j].Path {
+			return threads[i].Path < threads[j].Path
+		}
+		return threads[i].Line < threads[j].Line
+	})
+	return threads, nil
+}
+
+// ReplyToReviewThread adds a comment to a review thread of a PR/MR.
+func (s *Service) ReplyToReviewThread(ctx context.Context, prNumber int, threadID, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("reply cannot be empty")
+	}
+	return s.forgeOrGitHub(ctx).ReplyToReviewThread(ctx, prNumber, threadID, body)
+}
+
+// SetReviewThreadResolved resolves or unresolves a review thread of a PR/MR.
+func (s *Service) SetReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error {
+	return s.forgeOrGitHub(ctx).SetReviewThreadResolved(ctx, prNumber, threadID, resolved)
+}
+
+// ghGraphQL runs a GraphQL query with gh api graphql. String variables are
+// passed verbatim and int variables as typed fields.
+func (s *Service) ghGraphQL(ctx context.Context, query string, variables map[string]any) ([]byte, error) {
+	args := []string{"gh", "api", "graphql", "-f", "query=" + query}
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		switch value := variables[key].(type) {
+		case int:
+			args = append(args, "-F", key+"="+strconv.Itoa(value))
+		default:
+			args = append(args, "-f", fmt.Sprintf("%s=%v", key, value))
+		}
+	}
+
+	out, err := s.RunGitWithCombinedOutput(ctx, args, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("gh api graphql failed: %s", strings.TrimSpace(string(out)))
+	}
+	return out, nil
+}
+
+// fetchGitHubReviewThreads fetches review threads with gh api graphql.
+func (s *Service) fetchGitHubReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	owner, name, ok := strings.Cut(s.ResolveCITargetRepoName(ctx), "/")
+	if !ok || owner == "" || name == "" {
+		return nil, fmt.Errorf("cannot determine the GitHub repository")
+	}
+	return collectGitHubReviewThreads(func(query string, variables map[string]any, out any) error {
+		raw, err := s.ghGraphQL(ctx, query, variables)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			Data json.RawMessage `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return fmt.Errorf("failed to parse review threads: %w", err)
+		}
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return fmt.Errorf("failed to parse review threads: %w", err)
+		}
+		return nil
+	}, owner, name, prNumber)
+}
+
+func (s *Service) replyToGitHubReviewThread(ctx context.Context, threadID, body string) error {
+	_, err := s.ghGraphQL(ctx, githubReplyToThreadMutation, map[string]any{"id": threadID, "body": body})
+	return err
+}
+
+func (s *Service) setGitHubReviewThreadResolved(ctx context.Context, threadID string, resolved bool) error {
+	mutation := githubUnresolveThreadMutation
+	if resolved {
+		mutation = githubResolveThreadMutation
+	}
+	_, err := s.ghGraphQL(ctx, mutation, map[string]any{"id": threadID})
+	return err
+}
+
+// fetchGitLabReviewThreads fetches every page of merge request discussions
+// with glab api.
+func (s *Service) fetchGitLabReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	raw := s.RunGit(ctx, []string{"glab", "api", "--paginate", fmt.Sprintf("projects/:id/merge_requests/%d/discussions?per_page=%d", prNumber, gitlabDiscussionsPerPage)}, "", []int{0}, false, false)
+	if raw == "" {
+		return nil, fmt.Errorf("failed to fetch discussions for MR !%d", prNumber)
+	}
+	// Paginated output may hold one JSON array per page.
+	var discussions []gitlabDiscussion
+	dec := json.NewDecoder(strings.NewReader(raw))
+	for {
+		var page []gitlabDiscussion
+		if err := dec.Decode(&page); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse discussions: %w", err)
+		}
+		discussions = append(discussions, page...)
+	}
+	return gitlabDiscussionsToThreads(discussions), nil
+}
+
+func (s *Service) replyToGitLabReviewThread(ctx context.Context, prNumber int, threadID, body string) error {
+	args := []string{"glab", "api", "-X", "POST", fmt.Sprintf("projects/:id/merge_requests/%d/discussions/%s/notes", prNumber, threadID), "-f", "body=" + body}
+	if out, err := s.RunGitWithCombinedOutput(ctx, args, "", nil); err != nil {
+		return fmt.Errorf("glab api failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (s *Service) setGitLabReviewThreadResolved(ctx context.Context, prNumber int, threadID string, resolved bool) error {
+	args := []string{"glab", "api", "-X", "PUT", fmt.Sprintf("projects/:id/merge_requests/%d/discussions/%s", prNumber, threadID), "-F", "resolved=" + strconv.FormatBool(resolved)}
+	if out, err := s.RunGitWithCombinedOutput(ctx, args, "", nil); err != nil {
+		return fmt.Errorf("glab api failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// fetchGiteaReviewThreads collects the comments of every review of a pull
+// request. Gitea has no thread identifiers, so comments on the same file line
+// form a thread, resolved once all of them are.
+func (s *Service) fetchGiteaReviewThreads(ctx context.Context, prNumber int) ([]*models.PRReviewThread, error) {
+	c := s.giteaAPI(ctx)
+	if c == nil {
+		return nil, fmt.Errorf("no Gitea/Forgejo instance configured for this repository")
+	}
+	type giteaReview struct {
+		ID int64 `json:"id"`
+	}
+	var reviews []giteaReview
+	for page := 1; page <= giteaMaxPages; page++ {
+		var batch []giteaReview
+		if err := c.get(ctx, c.repoPath("/pulls/%d/reviews?limit=%d&page=%d", prNumber, giteaPageLimit, page), &batch); err != nil {
+			return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+		}
+		reviews = append(reviews, batch...)
+		if len(batch) < giteaPageLimit {
+			break
+		}
+	}
+
+	var threads []*models.PRReviewThread
+	byLocation := make(map[string]*models.PRReviewThread)
+	for _, review := range reviews {
+		var comments []giteaReviewComment
+		if err := c.get(ctx, c.repoPath("/pulls/%d/reviews/%d/comments", prNumber, review.ID), &comments); err != nil {
+			return nil, fmt.Errorf("failed to fetch review comments: %w", err)
+		}
+		for _, comment := range comments {
+			line := comment.Position
+			if line == 0 {
+				line = comment.OriginalPosition
+			}
+			key := fmt.Sprintf("%s:%d", comment.Path, line)
+			thread, ok := byLocation[key]
+			if !ok {
+				thread = &models.PRReviewThread{ID: key, Path: comment.Path, Line: line, IsResolved: true, IsOutdated: comment.Position == 0}
+				byLocation[key] = thread
+				threads = append(threads, thread)
+			}
+			thread.IsResolved = thread.IsResolved && comment.Resolver != nil
+			thread.Comments = append(thread.Comments, models.PRReviewComment{
+				Author:    comment.User.Login,
+				Body:      comment.Body,
+				URL:       comment.HTMLURL,
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+	}
+	return threads, nil
+}
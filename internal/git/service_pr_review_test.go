@@ -0,0 +1,287 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+const githubReviewThreadsFixture = `{"data": {"repository": {"pullRequest": {"reviewThreads": {"nodes": [
+  {"id": "T2", "path": "main.go", "line": 40, "isResolved": true, "isOutdated": false,
+   "comments": {"nodes": [{"body": "Done", "url": "https://github.com/org/repo/pull/3#r2", "createdAt": "2026-01-02T10:00:00Z", "author": {"login": "alice"}}]}},
+  {"id": "T1", "path": "main.go", "line": 0, "originalLine": 12, "isResolved": false, "isOutdated": true,
+   "comments": {"nodes": [
+     {"body": "Rename this", "url": "https://github.com/org/repo/pull/3#r1", "createdAt": "2026-01-01T10:00:00Z", "author": {"login": "bob"}},
+     {"body": "Why?", "url": "https://github.com/org/repo/pull/3#r3", "createdAt": "2026-01-01T11:00:00Z", "author": null}
+   ]}}
+]}}}}}`
+
+func TestFetchReviewThreadsSortsByFileAndLine(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.SetForge(&fakeForge{name: "fake", threads: []*models.PRReviewThread{
+		{ID: "c", Path: "b.go", Line: 1},
+		{ID: "b", Path: "a.go", Line: 20},
+		{ID: "a", Path: "a.go", Line: 3},
+	}})
+
+	threads, err := service.FetchReviewThreads(context.Background(), 3)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(threads))
+	for _, thread := range threads {
+		ids = append(ids, thread.ID)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+}
+
+func TestReplyToReviewThreadRequiresBody(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	fake := &fakeForge{name: "fake"}
+	service.SetForge(fake)
+
+	require.Error(t, service.ReplyToReviewThread(context.Background(), 3, "T1", "  "))
+	require.NoError(t, service.ReplyToReviewThread(context.Background(), 3, "T1", "Fixed"))
+	assert.Equal(t, []string{"T1: Fixed"}, fake.replies)
+}
+
+func TestFetchGitHubReviewThreadsWithStub(t *testing.T) {
+	repo := t.TempDir()
+	setupGitRepo(t, repo)
+	runGit(t, repo, "remote", "add", "origin", "https://github.com/org/repo.git")
+	withCwd(t, repo)
+
+	argsFile := filepath.Join(t.TempDir(), "args")
+	fixture := filepath.Join(t.TempDir(), "threads.json")
+	require.NoError(t, os.WriteFile(fixture, []byte(githubReviewThreadsFixture), 0o600))
+	withStubbedPath(t, writeStub(t, "gh", "#!/bin/sh\nprintf '%s\\n' \"$@\" > "+argsFile+"\ncat "+fixture+"\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGithub
+	threads, err := service.FetchReviewThreads(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+
+	first := threads[0]
+	assert.Equal(t, "T1", first.ID)
+	assert.Equal(t, 12, first.Line, "outdated threads fall back to the original line")
+	assert.True(t, first.IsOutdated)
+	assert.False(t, first.IsResolved)
+	require.Len(t, first.Comments, 2)
+	assert.Equal(t, "bob", first.Comments[0].Author)
+	assert.Empty(t, first.Comments[1].Author)
+	assert.Equal(t, 2026, first.Comments[0].CreatedAt.Year())
+	assert.True(t, threads[1].IsResolved)
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := string(data)
+	assert.Contains(t, args, "api\ngraphql\n")
+	assert.Contains(t, args, "-F\nnumber=3\n")
+	assert.Contains(t, args, "-f\nname=repo\n")
+	assert.Contains(t, args, "-f\nowner=org\n")
+}
+
+func TestGitHubReviewThreadMutationsWithStub(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	withStubbedPath(t, writeStub(t, "gh", "#!/bin/sh\nprintf '%s\\n' \"$@\" >> "+argsFile+"\necho '{}'\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGithub
+	require.NoError(t, service.ReplyToReviewThread(context.Background(), 3, "T1", "Fixed in abc123"))
+	require.NoError(t, service.SetReviewThreadResolved(context.Background(), 3, "T1", true))
+	require.NoError(t, service.SetReviewThreadResolved(context.Background(), 3, "T1", false))
+
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	args := string(data)
+	assert.Contains(t, args, "addPullRequestReviewThreadReply")
+	assert.Contains(t, args, "body=Fixed in abc123")
+	assert.Contains(t, args, "resolveReviewThread(input")
+	assert.Contains(t, args, "unresolveReviewThread(input")
+	assert.Contains(t, args, "id=T1")
+}
+
+func TestGitLabReviewThreadsWithStub(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	fixture := filepath.Join(t.TempDir(), "discussions.json")
+	discussions := `[
+  {"id": "d1", "notes": [{"id": 1, "body": "General remark", "author": {"username": "bob"}}]},
+  {"id": "d2", "notes": [
+    {"id": 2, "body": "Typo", "resolvable": true, "resolved": false, "created_at": "2026-01-01T10:00:00Z", "author": {"username": "bob"}, "position": {"new_path": "README.md", "new_line": 7}},
+    {"id": 3, "body": "changed this line in version 2", "system": true, "author": {"username": "alice"}},
+    {"id": 4, "body": "Fixed", "resolvable": true, "resolved": false, "author": {"username": "alice"}}
+  ]},
+  {"id": "d3", "notes": [{"id": 5, "body": "Removed?", "resolvable": true, "resolved": true, "author": {"username": "bob"}, "position": {"old_path": "old.go", "old_line": 3}}]}
+]`
+	require.NoError(t, os.WriteFile(fixture, []byte(discussions), 0o600))
+	withStubbedPath(t, writeStub(t, "glab", "#!/bin/sh\nprintf '%s\\n' \"$@\" >> "+argsFile+"\ncat "+fixture+"\n"))
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitLab
+	threads, err := service.FetchReviewThreads(context.Background(), 9)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	assert.Equal(t, "README.md", threads[0].Path)
+	assert.Equal(t, 7, threads[0].Line)
+	assert.False(t, threads[0].IsResolved)
+	require.Len(t, threads[0].Comments, 2, "system notes are skipped")
+	assert.Equal(t, "old.go", threads[1].Path)
+	assert.Equal(t, 3, threads[1].Line)
+	assert.True(t, threads[1].IsResolved)
+
+	require.NoError(t, service.SetReviewThreadResolved(context.Background(), 9, "d2", true))
+	data, err := os.ReadFile(argsFile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Contains(t, lines, "projects/:id/merge_requests/9/discussions?per_page=100")
+	assert.Contains(t, lines, "projects/:id/merge_requests/9/discussions/d2")
+	assert.Contains(t, lines, "resolved=true")
+}
+
+func TestGiteaReviewThreadsGroupByLine(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/repos/org/repo/pulls/5/reviews":
+			_, _ = w.Write([]byte(`[{"id": 1}, {"id": 2}]`))
+		case "/api/v1/repos/org/repo/pulls/5/reviews/1/comments":
+			_, _ = w.Write([]byte(`[
+				{"body": "Nit", "path": "main.go", "position": 4, "user": {"login": "bob"}, "resolver": {"login": "alice"}},
+				{"body": "Old", "path": "util.go", "position": 0, "original_position": 9, "user": {"login": "bob"}}
+			]`))
+		case "/api/v1/repos/org/repo/pulls/5/reviews/2/comments":
+			_, _ = w.Write([]byte(`[{"body": "Agreed", "path": "main.go", "position": 4, "user": {"login": "carol"}}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	service.gitHost = gitHostGitea
+	service.gitea = newGiteaClient(srv.URL, "secret", "org", "repo", srv.Client())
+
+	threads, err := service.FetchReviewThreads(context.Background(), 5)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	assert.Equal(t, "main.go", threads[0].Path)
+	assert.Len(t, threads[0].Comments, 2)
+	assert.False(t, threads[0].IsResolved, "a thread is resolved only when every comment is")
+	assert.Equal(t, 9, threads[1].Line)
+	assert.True(t, threads[1].IsOutdated)
+
+	assert.ErrorIs(t, service.SetReviewThreadResolved(context.Background(), 5, threads[0].ID, true), ErrReviewThreadUnsupported)
+	assert.ErrorIs(t, service.ReplyToReviewThread(context.Background(), 5, threads[0].ID, "Thanks"), ErrReviewThreadUnsupported)
+}
+
+func TestGitHubAPIForgeReviewThreads(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	var queries []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		queries = append(queries, body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(body["query"].(string), "reviewThreads") {
+			_, _ = w.Write([]byte(githubReviewThreadsFixture))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"resolveReviewThread": {"thread": {"id": "T1"}}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	threads, err := service.FetchReviewThreads(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	assert.Equal(t, map[string]any{"owner": "org", "name": "repo", "number": float64(3)}, queries[0]["variables"])
+
+	require.NoError(t, service.SetReviewThreadResolved(context.Background(), 3, "T1", true))
+	assert.Contains(t, queries[1]["query"], "resolveReviewThread")
+	assert.Equal(t, map[string]any{"id": "T1"}, queries[1]["variables"])
+}
+
+func TestGitLabAPIForgeReviewThreads(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGitLab, "git@gitlab.com:group/repo.git")
+
+	var requests []string
+	var replyBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id": "d2", "notes": [{"body": "Typo", "resolvable": true, "author": {"username": "bob"}, "position": {"new_path": "README.md", "new_line": 7}}]}]`))
+		case http.MethodPost:
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&replyBody))
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.gitlabAPI = newForgeAPITestClient(srv)
+
+	threads, err := service.FetchReviewThreads(context.Background(), 4)
+	require.NoError(t, err)
+	require.Len(t, threads, 1)
+	assert.Equal(t, "README.md", threads[0].Path)
+
+	require.NoError(t, service.ReplyToReviewThread(context.Background(), 4, "d2", "Fixed"))
+	assert.Equal(t, "Fixed", replyBody["body"])
+	assert.Equal(t, []string{
+		"GET /projects/group/repo/merge_requests/4/discussions",
+		"POST /projects/group/repo/merge_requests/4/discussions/d2/notes",
+	}, requests)
+}
+
+func TestGitHubAPIForgeReviewThreadsPaginate(t *testing.T) {
+	service := newForgeHTTPTestService(t, gitHostGithub, "https://github.com/org/repo.git")
+
+	var variables []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		variables = append(variables, body.Variables)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(body.Query, "node(id: $id)"):
+			_, _ = w.Write([]byte(`{"data": {"node": {"comments": {"pageInfo": {"hasNextPage": false},
+				"nodes": [{"body": "Second page", "author": {"login": "bob"}}]}}}}`))
+		case body.Variables["after"] == nil:
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "T-CURSOR"},
+				"nodes": [{"id": "T1", "path": "a.go", "line": 1, "comments": {
+					"pageInfo": {"hasNextPage": true, "endCursor": "C-CURSOR"},
+					"nodes": [{"body": "First page", "author": {"login": "alice"}}]}}]}}}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"reviewThreads": {
+				"pageInfo": {"hasNextPage": false},
+				"nodes": [{"id": "T2", "path": "b.go", "line": 2, "comments": {"nodes": [{"body": "Later", "author": {"login": "carol"}}]}}]}}}}}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	service.githubAPI = newForgeAPITestClient(srv)
+
+	threads, err := service.FetchReviewThreads(context.Background(), 3)
+	require.NoError(t, err)
+	require.Len(t, threads, 2)
+	require.Len(t, threads[0].Comments, 2)
+	assert.Equal(t, "Second page", threads[0].Comments[1].Body)
+	assert.Equal(t, "T2", threads[1].ID)
+
+	require.Len(t, variables, 3)
+	assert.Equal(t, map[string]any{"id": "T1", "after": "C-CURSOR"}, variables[1])
+	assert.Equal(t, "T-CURSOR", variables[2]["after"])
+}
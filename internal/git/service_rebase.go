@@ -0,0 +1,231 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+	"github.com/chmouel/lazyworktree/internal/utils"
+)
+
+// ErrRebaseStopped reports a rebase that stopped before applying every
+// commit, usually on conflicts. It resumes with ContinueRebase or is undone
+// with AbortRebase.
+var ErrRebaseStopped = errors.New("rebase stopped")
+
+// rebaseStateDir is the directory, inside the worktree git directory, holding
+// the todo list and reword messages of a rebase started by lazyworktree. The
+// messages must outlive a rebase stopped on conflicts.
+const rebaseStateDir = "lazyworktree-rebase"
+
+// RebaseCommits returns the commits of the worktree at path since it forked
+// from base, oldest first as in a rebase todo list, with the merge base they
+// are replayed onto.
+func (s *Service) RebaseCommits(ctx context.Context, path, base string) ([]*models.RebaseCommit, string, error) {
+	mergeBase := s.RunGit(ctx, []string{"git", "merge-base", "HEAD", base}, path, []int{0}, true, true)
+	if mergeBase == "" {
+		return nil, "", fmt.Errorf("no common ancestor with %s", base)
+	}
+	raw := s.RunGit(ctx, []string{"git", "log", "--reverse", "--format=%H%x1f%P%x1f%an%x1f%B%x1e", mergeBase + "..HEAD"}, path, []int{0}, false, true)
+	commits, err := parseRebaseCommits(raw)
+	if err != nil {
+		return nil, "", err
+	}
+	return commits, mergeBase, nil
+}
+
+// parseRebaseCommits parses `git log` records of hash, parents, author and
+// message separated by \x1f and terminated by \x1e.
+func parseRebaseCommits(raw string) ([]*models.RebaseCommit, error) {
+	var commits []*models.RebaseCommit
+	for record := range strings.SplitSeq(raw, "\x1e") {
+		record = strings.TrimLeft(record, "\n")
+		parts := strings.SplitN(record, "\x1f", 4)
+		if len(parts) != 4 {
+			continue
+		}
+		commit := &models.RebaseCommit{
+			Hash:    parts[0],
+			Author:  parts[2],
+			Message: strings.TrimSpace(parts[3]),
+			Action:  models.RebaseActionPick,
+		}
+		if len(strings.Fields(parts[1])) > 1 {
+			return nil, fmt.Errorf("commit %s is a merge; interactive rebase only handles linear history", commit.ShortHash())
+		}
+		commit.Subject, _, _ = strings.Cut(commit.Message, "\n")
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// InteractiveRebase runs `git rebase -i onto` in the worktree at path, with
+// the todo list generated from commits instead of an editor. Rewords amend the
+// commit with its new message right after picking it. It returns an error
+// wrapping ErrRebaseStopped when the rebase stops on conflicts.
+func (s *Service) InteractiveRebase(ctx context.Context, path, onto string, commits []*models.RebaseCommit) error {
+	stateDir := s.rebaseStatePath(ctx, path)
+	if stateDir == "" {
+		return fmt.Errorf("cannot locate the git directory of %s", path)
+	}
+	if err := os.RemoveAll(stateDir); err != nil {
+		return fmt.Errorf("failed to clear %s: %w", stateDir, err)
+	}
+	if err := os.MkdirAll(stateDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", stateDir, err)
+	}
+
+	todo, messages, err := buildRebaseTodo(commits, stateDir)
+	if err != nil {
+		_ = os.RemoveAll(stateDir)
+		return err
+	}
+	for file, message := range messages {
+		if err := os.WriteFile(file, []byte(message+"\n"), 0o600); err != nil {
+			_ = os.RemoveAll(stateDir)
+			return fmt.Errorf("failed to write commit message: %w", err)
+		}
+	}
+	todoPath := filepath.Join(stateDir, "git-rebase-todo")
+	if err := os.WriteFile(todoPath, []byte(todo), 0o600); err != nil {
+		_ = os.RemoveAll(stateDir)
+		return fmt.Errorf("failed to write rebase todo: %w", err)
+	}
+
+	env := map[string]string{
+		// git appends the path of its todo file, which the copy overwrites
+		"GIT_SEQUENCE_EDITOR": "cp " + utils.ShellQuote(todoPath),
+		// ":" tells git to keep squash messages without opening an editor
+		"GIT_EDITOR": ":",
+	}
+	return s.runRebase(ctx, path, []string{"git", "rebase", "--interactive", onto}, env)
+}
+
+// ContinueRebase resumes a stopped rebase once its conflicts are staged.
+func (s *Service) ContinueRebase(ctx context.Context, path string) error {
+	return s.runRebase(ctx, path, []string{"git", "rebase", "--continue"}, map[string]string{"GIT_EDITOR": ":"})
+}
+
+// AbortRebase abandons a stopped rebase and restores the branch.
+func (s *Service) AbortRebase(ctx context.Context, path string) error {
+	return s.runRebase(ctx, path, []string{"git", "rebase", "--abort"}, nil)
+}
+
+// RebaseInProgress reports whether the worktree at path is in the middle of a
+// rebase. It only reads the git directory, so it is cheap enough to call on
+// every status refresh.
+func (s *Service) RebaseInProgress(path string) bool {
+	gitDir := worktreeGitDir(path)
+	if gitDir == "" {
+		return false
+	}
+	for _, name := range []string{"rebase-merge", "rebase-apply"} {
+		if info, err := os.Stat(filepath.Join(gitDir, name)); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Service) runRebase(ctx context.Context, path string, args []string, env map[string]string) error {
+	output, err := s.RunGitWithCombinedOutput(ctx, args, path, env)
+	if err != nil {
+		detail := strings.TrimSpace(string(output))
+		if s.RebaseInProgress(path) {
+			return fmt.Errorf("%w: %s", ErrRebaseStopped, detail)
+		}
+		_ = os.RemoveAll(s.rebaseStatePath(ctx, path))
+		if detail == "" {
+			return fmt.Errorf("git %s failed: %w", args[1], err)
+		}
+		return fmt.Errorf("git %s failed: %s", args[1], detail)
+	}
+	if !s.RebaseInProgress(path) {
+		_ = os.RemoveAll(s.rebaseStatePath(ctx, path))
+	}
+	return nil
+}
+
+// buildRebaseTodo renders the todo list for commits and returns the reword
+// messages to write, keyed by the file the todo list reads them from.
+func buildRebaseTodo(commits []*models.RebaseCommit, stateDir string) (string, map[string]string, error) {
+	var todo strings.Builder
+	messages := make(map[string]string)
+	picked := false
+	for _, commit := range commits {
+		action := commit.Action
+		if action == "" {
+			action = models.RebaseActionPick
+		}
+		switch action {
+		case models.RebaseActionDrop:
+		case models.RebaseActionSquash, models.RebaseActionFixup:
+			if !picked {
+				return "", nil, fmt.Errorf("cannot %s %s: there is no earlier commit to combine it with", action, commit.ShortHash())
+			}
+		case models.RebaseActionPick, models.RebaseActionReword:
+			picked = true
+		default:
+			return "", nil, fmt.Errorf("unknown rebase action %q", action)
+		}
+
+		if action == models.RebaseActionReword {
+			fmt.Fprintf(&todo, "pick %s %s\n", commit.Hash, commit.Subject)
+			file := filepath.Join(stateDir, "message-"+commit.Hash)
+			messages[file] = strings.TrimSpace(commit.Message)
+			fmt.Fprintf(&todo, "exec git commit --amend --only --allow-empty --quiet --file=%s\n", utils.ShellQuote(file))
+			continue
+		}
+		fmt.Fprintf(&todo, "%s %s %s\n", action, commit.Hash, commit.Subject)
+	}
+	if !picked {
+		// git aborts on a todo list without commands to run; noop makes
+		// dropping every commit reset the branch to onto instead
+		todo.WriteString("noop\n")
+	}
+	return todo.String(), messages, nil
+}
+
+// rebaseStatePath returns the absolute path of the rebase state directory of
+// the worktree at path.
+func (s *Service) rebaseStatePath(ctx context.Context, path string) string {
+	statePath := s.RunGit(ctx, []string{"git", "rev-parse", "--git-path", rebaseStateDir}, path, []int{0}, true, true)
+	if statePath == "" {
+		return ""
+	}
+	if !filepath.IsAbs(statePath) {
+		statePath = filepath.Join(path, statePath)
+	}
+	return statePath
+}
+
+// worktreeGitDir returns the git directory of the worktree at path: its .git
+// directory, or the directory its .git file points to for linked worktrees.
+func worktreeGitDir(path string) string {
+	dotGit := filepath.Join(path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return ""
+	}
+	if info.IsDir() {
+		return dotGit
+	}
+	// #nosec G304 -- path is a worktree listed by git
+	data, err := os.ReadFile(dotGit)
+	if err != nil {
+		return ""
+	}
+	gitDir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !found {
+		return ""
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(path, gitDir)
+	}
+	return gitDir
+}
@@ -0,0 +1,141 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+func TestBuildRebaseTodo(t *testing.T) {
+	t.Parallel()
+
+	commits := []*models.RebaseCommit{
+		{Hash: "aaaa", Subject: "first", Action: models.RebaseActionReword, Message: "better first\n\nbody"},
+		{Hash: "bbbb", Subject: "second", Action: models.RebaseActionFixup},
+		{Hash: "cccc", Subject: "third", Action: models.RebaseActionDrop},
+		{Hash: "dddd", Subject: "fourth"},
+	}
+	todo, messages, err := buildRebaseTodo(commits, "/state dir")
+	require.NoError(t, err)
+	assert.Equal(t, "pick aaaa first\n"+
+		"exec git commit --amend --only --allow-empty --quiet --file='/state dir/message-aaaa'\n"+
+		"fixup bbbb second\n"+
+		"drop cccc third\n"+
+		"pick dddd fourth\n", todo)
+	assert.Equal(t, map[string]string{"/state dir/message-aaaa": "better first\n\nbody"}, messages)
+
+	_, _, err = buildRebaseTodo([]*models.RebaseCommit{
+		{Hash: "aaaa", Action: models.RebaseActionDrop},
+		{Hash: "bbbb", Action: models.RebaseActionSquash},
+	}, "/state")
+	require.ErrorContains(t, err, "no earlier commit")
+
+	todo, _, err = buildRebaseTodo([]*models.RebaseCommit{
+		{Hash: "aaaa", Subject: "first", Action: models.RebaseActionDrop},
+		{Hash: "bbbb", Subject: "second", Action: models.RebaseActionDrop},
+	}, "/state")
+	require.NoError(t, err)
+	assert.Equal(t, "drop aaaa first\ndrop bbbb second\nnoop\n", todo)
+}
+
+func TestParseRebaseCommitsRefusesMerges(t *testing.T) {
+	t.Parallel()
+
+	commits, err := parseRebaseCommits("aaaa\x1fp1\x1falice\x1ffirst\n\nbody\n\x1e\nbbbb\x1fp2\x1fbob\x1fsecond\n\x1e\n")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	assert.Equal(t, "first", commits[0].Subject)
+	assert.Equal(t, "first\n\nbody", commits[0].Message)
+	assert.Equal(t, "bob", commits[1].Author)
+	assert.Equal(t, models.RebaseActionPick, commits[1].Action)
+
+	_, err = parseRebaseCommits("cccc\x1fp1 p2\x1falice\x1fMerge branch\x1e")
+	require.ErrorContains(t, err, "is a merge")
+}
+
+func TestInteractiveRebaseInWorktree(t *testing.T) {
+	service := NewService(func(string, string) {}, func(string, string, string) {})
+	ctx := context.Background()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o750))
+	setupGitRepo(t, repo)
+
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+		return strings.TrimSpace(string(output))
+	}
+	base := runGit(repo, "rev-parse", "--abbrev-ref", "HEAD")
+	wt := filepath.Join(root, "feature")
+	runGit(repo, "worktree", "add", "-b", "feature", wt)
+	commit := func(file, content, message string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(wt, file), []byte(content), 0o600))
+		runGit(wt, "add", file)
+		runGit(wt, "commit", "-m", message)
+	}
+	commit("a.txt", "a", "add a")
+	commit("b.txt", "b", "add b")
+	commit("c.txt", "c", "add c")
+	commit("d.txt", "d", "add d")
+
+	commits, onto, err := service.RebaseCommits(ctx, wt, base)
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+	assert.Equal(t, "add a", commits[0].Subject)
+
+	// Reorder d first, reword it, squash b into a and drop c.
+	commits[3].Action = models.RebaseActionReword
+	commits[3].Message = "add d first\n\nwith a body"
+	commits[1].Action = models.RebaseActionSquash
+	commits[2].Action = models.RebaseActionDrop
+	todo := []*models.RebaseCommit{commits[3], commits[0], commits[1], commits[2]}
+	require.NoError(t, service.InteractiveRebase(ctx, wt, onto, todo))
+
+	assert.Equal(t, "add a\n\nadd b", runGit(wt, "log", "--format=%B", "-1"), "squash keeps both messages")
+	assert.Equal(t, "add d first\n\nwith a body", runGit(wt, "log", "--format=%B", "-1", "HEAD~1"))
+	assert.NoFileExists(t, filepath.Join(wt, "c.txt"))
+	assert.FileExists(t, filepath.Join(wt, "b.txt"))
+	assert.False(t, service.RebaseInProgress(wt))
+	assert.NoDirExists(t, service.rebaseStatePath(ctx, wt))
+
+	// Swapping two commits editing the same line stops on a conflict.
+	commit("a.txt", "one", "a to one")
+	commit("a.txt", "two", "a to two")
+	commits, onto, err = service.RebaseCommits(ctx, wt, base)
+	require.NoError(t, err)
+	n := len(commits)
+	commits[n-2], commits[n-1] = commits[n-1], commits[n-2]
+	err = service.InteractiveRebase(ctx, wt, onto, commits)
+	require.ErrorIs(t, err, ErrRebaseStopped)
+	assert.Contains(t, err.Error(), "CONFLICT")
+	assert.True(t, service.RebaseInProgress(wt))
+
+	require.NoError(t, service.AbortRebase(ctx, wt))
+	assert.False(t, service.RebaseInProgress(wt))
+	assert.Equal(t, "a to two", runGit(wt, "log", "--format=%s", "-1"))
+
+	// Dropping every commit leaves the branch on its base.
+	commits, onto, err = service.RebaseCommits(ctx, wt, base)
+	require.NoError(t, err)
+	for _, c := range commits {
+		c.Action = models.RebaseActionDrop
+	}
+	require.NoError(t, service.InteractiveRebase(ctx, wt, onto, commits))
+	assert.Equal(t, runGit(repo, "rev-parse", base), runGit(wt, "rev-parse", "HEAD"))
+	assert.False(t, service.RebaseInProgress(wt))
+}
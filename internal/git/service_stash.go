@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/models"
+)
+
+// ListStashes returns the stash list of the repository, newest first. The
+// stash list is shared by every worktree.
+func (s *Service) ListStashes(ctx context.Context) []*models.StashEntry {
+	raw := s.RunGit(ctx, []string{"git", "stash", "list", "--format=%gd%x1f%H%x1f%ct%x1f%gs"}, "", []int{0}, true, true)
+	return parseStashList(raw)
+}
+
+// parseStashList parses `git stash list` output formatted as
+// ref, hash, commit time and reflog subject separated by \x1f. The subject
+// is "WIP on <branch>: <sha> <subject>" for stashes without a message and
+// "On <branch>: <message>" otherwise.
+func parseStashList(raw string) []*models.StashEntry {
+	var stashes []*models.StashEntry
+	for line := range strings.SplitSeq(raw, "\n") {
+		parts := strings.SplitN(line, "\x1f", 4)
+		if len(parts) != 4 || !strings.HasPrefix(parts[0], "stash@{") {
+			continue
+		}
+		entry := &models.StashEntry{Ref: parts[0], Hash: parts[1], Message: parts[3]}
+		entry.Timestamp, _ = strconv.ParseInt(parts[2], 10, 64)
+
+		subject, wip := strings.CutPrefix(parts[3], "WIP on ")
+		if !wip {
+			subject, _ = strings.CutPrefix(subject, "On ")
+		}
+		if branch, message, ok := strings.Cut(subject, ": "); ok {
+			if branch != "(no branch)" {
+				entry.Branch = branch
+			}
+			entry.Message = message
+			if wip {
+				entry.Message = "WIP " + message
+			}
+		}
+		stashes = append(stashes, entry)
+	}
+	return stashes
+}
+
+// StashDiff returns the patch of a stash, including its untracked files.
+func (s *Service) StashDiff(ctx context.Context, stash *models.StashEntry) string {
+	diff := s.RunGit(ctx, []string{"git", "stash", "show", "--patch", "--include-untracked", stash.Hash}, "", []int{0}, false, true)
+	if diff == "" {
+		// git before 2.32 cannot show untracked files
+		diff = s.RunGit(ctx, []string{"git", "stash", "show", "--patch", stash.Hash}, "", []int{0}, false, true)
+	}
+	return diff
+}
+
+// ApplyStash applies a stash to the worktree at path and, when pop is set,
+// drops it once applied. The error carries git's output, which lists the
+// conflicting files.
+func (s *Service) ApplyStash(ctx context.Context, stash *models.StashEntry, path string, pop bool) error {
+	ref, err := s.currentStashRef(ctx, stash)
+	if err != nil {
+		return err
+	}
+	action := "apply"
+	if pop {
+		action = "pop"
+	}
+	output, err := s.RunGitWithCombinedOutput(ctx, []string{"git", "stash", action, ref}, path, nil)
+	if err != nil {
+		if detail := strings.TrimSpace(string(output)); detail != "" {
+			return fmt.Errorf("git stash %s failed: %s", action, detail)
+		}
+		return fmt.Errorf("git stash %s failed: %w", action, err)
+	}
+	return nil
+}
+
+// DropStash deletes a stash.
+func (s *Service) DropStash(ctx context.Context, stash *models.StashEntry) error {
+	ref, err := s.currentStashRef(ctx, stash)
+	if err != nil {
+		return err
+	}
+	if !s.RunCommandChecked(ctx, []string{"git", "stash", "drop", ref}, "", fmt.Sprintf("Failed to drop %s", ref)) {
+		return fmt.Errorf("failed to drop %s", ref)
+	}
+	return nil
+}
+
+// currentStashRef looks the stash up by hash, since its stash@{n} selector
+// shifts whenever a stash is pushed or dropped after the list was read.
+func (s *Service) currentStashRef(ctx context.Context, stash *models.StashEntry) (string, error) {
+	for _, current := range s.ListStashes(ctx) {
+		if current.Hash == stash.Hash {
+			return current.Ref, nil
+		}
+	}
+	return "", fmt.Errorf("stash %q no longer exists", stash.Message)
+}
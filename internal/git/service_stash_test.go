@@ -0,0 +1,104 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStashList(t *testing.T) {
+	t.Parallel()
+
+	raw := "stash@{0}\x1faaaa\x1f1700000200\x1fOn feature: move to review\n" +
+		"stash@{1}\x1fbbbb\x1f1700000100\x1fWIP on main: 1234567 Initial commit\n" +
+		"stash@{2}\x1fcccc\x1f1700000000\x1fWIP on (no branch): 89abcde bisect\n" +
+		"garbage line"
+
+	stashes := parseStashList(raw)
+	require.Len(t, stashes, 3)
+
+	assert.Equal(t, "stash@{0}", stashes[0].Ref)
+	assert.Equal(t, "aaaa", stashes[0].Hash)
+	assert.Equal(t, "feature", stashes[0].Branch)
+	assert.Equal(t, "move to review", stashes[0].Message)
+	assert.Equal(t, int64(1700000200), stashes[0].Timestamp)
+
+	assert.Equal(t, "main", stashes[1].Branch)
+	assert.Equal(t, "WIP 1234567 Initial commit", stashes[1].Message)
+
+	assert.Empty(t, stashes[2].Branch)
+	assert.Equal(t, "WIP 89abcde bisect", stashes[2].Message)
+}
+
+func TestStashAcrossWorktrees(t *testing.T) {
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o750))
+	setupGitRepo(t, repo)
+
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+	}
+	other := filepath.Join(root, "other")
+	runGit(repo, "worktree", "add", "-b", "other", other)
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(repo))
+
+	require.NoError(t, os.WriteFile(filepath.Join(other, "README.md"), []byte("# Changed"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "new.txt"), []byte("untracked"), 0o600))
+	runGit(other, "stash", "push", "-u", "-m", "work in progress")
+
+	stashes := service.ListStashes(ctx)
+	require.Len(t, stashes, 1)
+	assert.Equal(t, "other", stashes[0].Branch)
+	assert.Equal(t, "work in progress", stashes[0].Message)
+
+	diff := service.StashDiff(ctx, stashes[0])
+	assert.Contains(t, diff, "+# Changed")
+
+	require.NoError(t, service.ApplyStash(ctx, stashes[0], repo, false))
+	content, err := os.ReadFile(filepath.Join(repo, "README.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "# Changed", string(content))
+	assert.FileExists(t, filepath.Join(repo, "new.txt"))
+	assert.Len(t, service.ListStashes(ctx), 1, "apply keeps the stash")
+
+	// Pushing another stash shifts stash@{0} to stash@{1}; the entry read
+	// before still resolves to the right stash.
+	require.NoError(t, os.WriteFile(filepath.Join(other, "README.md"), []byte("# Later"), 0o600))
+	runGit(other, "stash", "push", "-m", "later")
+
+	require.NoError(t, service.DropStash(ctx, stashes[0]))
+	remaining := service.ListStashes(ctx)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, "later", remaining[0].Message)
+
+	err = service.DropStash(ctx, stashes[0])
+	require.ErrorContains(t, err, "no longer exists")
+
+	runGit(repo, "checkout", "--", "README.md")
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "README.md"), []byte("# Conflict"), 0o600))
+	runGit(repo, "commit", "-am", "conflict")
+	err = service.ApplyStash(ctx, remaining[0], repo, true)
+	require.ErrorContains(t, err, "git stash pop failed")
+	assert.Len(t, service.ListStashes(ctx), 1, "a failed pop keeps the stash")
+}
@@ -14,44 +14,28 @@ import (
 
 // GetWorktrees parses git worktree metadata and returns the list of worktrees.
 // This method concurrently fetches status information for each worktree to improve performance.
-// The first worktree in the list is marked as the main worktree.
+// The first worktree in the list is marked as the main worktree, unless it is a
+// bare repository, which is left out of the list.
 func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, error) {
 	rawWts := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, false)
 	if rawWts == "" {
 		return []*models.WorktreeInfo{}, nil
 	}
 
-	type wtData struct {
-		path   string
-		branch string
-		isMain bool
-	}
-
-	var wts []wtData
-	var currentWt *wtData
-
-	lines := strings.SplitSeq(rawWts, "\n")
-	for line := range lines {
-		if strings.HasPrefix(line, "worktree ") {
-			if currentWt != nil {
-				wts = append(wts, *currentWt)
-			}
-			path := strings.TrimPrefix(line, "worktree ")
-			currentWt = &wtData{path: path}
-		} else if strings.HasPrefix(line, "branch ") {
-			if currentWt != nil {
-				branch := strings.TrimPrefix(line, "branch ")
-				branch = strings.TrimPrefix(branch, "refs/heads/")
-				currentWt.branch = branch
+	wts := parseWorktreePorcelain(rawWts)
+	if len(wts) > 0 && wts[0].bare {
+		// A bare repository has no working tree to show. The worktree on the
+		// default branch stands in for the main worktree instead.
+		wts = wts[1:]
+		mainBranch := s.GetMainBranch(ctx)
+		for i := range wts {
+			if wts[i].branch == mainBranch {
+				wts[i].isMain = true
+				break
 			}
 		}
-	}
-	if currentWt != nil {
-		wts = append(wts, *currentWt)
-	}
-
-	for i := range wts {
-		wts[i].isMain = i == 0
+	} else if len(wts) > 0 {
+		wts[0].isMain = true
 	}
 
 	branchRaw := s.RunGit(ctx, []string{
@@ -90,7 +74,7 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 
 	for _, wt := range wts {
 		wg.Add(1)
-		go func(wtData wtData) {
+		go func(wtData worktreeEntry) {
 			defer wg.Done()
 			s.acquireSemaphore()
 			defer s.releaseSemaphore()
@@ -127,6 +111,9 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 					}
 				case strings.HasPrefix(line, "?"):
 					untracked++
+				case strings.HasPrefix(line, "u "):
+					// Unmerged paths left by a conflict
+					modified++
 				case strings.HasPrefix(line, "1 "), strings.HasPrefix(line, "2 "):
 					parts := strings.Fields(line)
 					if len(parts) > 1 {
@@ -161,6 +148,16 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 				lastActiveTS = info.lastActiveTS
 			}
 
+			headTag := ""
+			if wtData.detached {
+				headTag = s.RunGit(ctx, []string{"git", "describe", "--tags", "--exact-match", "HEAD"}, path, []int{0, 128}, true, true)
+				commitRaw := s.RunGit(ctx, []string{"git", "log", "-1", "--format=%cr|%ct", "HEAD"}, path, []int{0}, true, true)
+				if relative, unix, ok := strings.Cut(commitRaw, "|"); ok {
+					lastActive = relative
+					lastActiveTS, _ = strconv.ParseInt(unix, 10, 64)
+				}
+			}
+
 			wt := &models.WorktreeInfo{
 				Path:           path,
 				Branch:         branch,
@@ -176,6 +173,13 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 				Untracked:      untracked,
 				Modified:       modified,
 				Staged:         staged,
+				Locked:         wtData.locked,
+				LockReason:     wtData.lockReason,
+				Prunable:       wtData.prunable,
+				PrunableReason: wtData.prunableReason,
+				Head:           wtData.head,
+				Detached:       wtData.detached,
+				HeadTag:        headTag,
 			}
 
 			results <- result{wt: wt, err: nil}
@@ -195,14 +199,23 @@ func (s *Service) GetWorktrees(ctx context.Context) ([]*models.WorktreeInfo, err
 	return worktrees, nil
 }
 
-// GetMainWorktreePath returns the path of the main worktree.
+// GetMainWorktreePath returns the path of the main worktree. When the
+// repository is bare, this is the worktree on the default branch, falling
+// back to the bare repository itself when no worktree has it checked out.
 func (s *Service) GetMainWorktreePath(ctx context.Context) string {
 	s.mainWorktreePathOnce.Do(func() {
 		rawWts := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, false)
-		for _, line := range strings.Split(rawWts, "\n") {
-			if strings.HasPrefix(line, "worktree ") {
-				s.mainWorktreePath = strings.TrimPrefix(line, "worktree ")
-				break
+		wts := parseWorktreePorcelain(rawWts)
+		if len(wts) > 0 {
+			s.mainWorktreePath = wts[0].path
+		}
+		if len(wts) > 0 && wts[0].bare {
+			mainBranch := s.GetMainBranch(ctx)
+			for _, wt := range wts[1:] {
+				if wt.branch == mainBranch {
+					s.mainWorktreePath = wt.path
+					break
+				}
 			}
 		}
 		if s.mainWorktreePath == "" {
@@ -212,11 +225,203 @@ func (s *Service) GetMainWorktreePath(ctx context.Context) string {
 	return s.mainWorktreePath
 }
 
+// bareRepositoryPath returns the path of the bare repository the worktrees
+// belong to, or "" when the main worktree is a regular checkout.
+func (s *Service) bareRepositoryPath(ctx context.Context) string {
+	rawWts := s.RunGit(ctx, []string{"git", "worktree", "list", "--porcelain"}, "", []int{0}, true, true)
+	wts := parseWorktreePorcelain(rawWts)
+	if len(wts) > 0 && wts[0].bare {
+		return wts[0].path
+	}
+	return ""
+}
+
+// worktreeEntry is a worktree as described by "git worktree list --porcelain".
+type worktreeEntry struct {
+	path           string
+	head           string
+	branch         string
+	isMain         bool
+	bare           bool
+	detached       bool
+	locked         bool
+	lockReason     string
+	prunable       bool
+	prunableReason string
+}
+
+// parseWorktreePorcelain parses the output of "git worktree list --porcelain".
+func parseWorktreePorcelain(raw string) []worktreeEntry {
+	var wts []worktreeEntry
+	var current *worktreeEntry
+
+	for line := range strings.SplitSeq(raw, "\n") {
+		if path, ok := strings.CutPrefix(line, "worktree "); ok {
+			if current != nil {
+				wts = append(wts, *current)
+			}
+			current = &worktreeEntry{path: path}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "HEAD "):
+			current.head = strings.TrimPrefix(line, "HEAD ")
+		case strings.HasPrefix(line, "branch "):
+			current.branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		case line == "bare":
+			current.bare = true
+		case line == "detached":
+			current.detached = true
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.locked = true
+			current.lockReason = strings.TrimSpace(strings.TrimPrefix(line, "locked"))
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.prunable = true
+			current.prunableReason = strings.TrimSpace(strings.TrimPrefix(line, "prunable"))
+		}
+	}
+	if current != nil {
+		wts = append(wts, *current)
+	}
+	return wts
+}
+
 // MoveWorktree renames a worktree directory without changing its branch.
 func (s *Service) MoveWorktree(ctx context.Context, oldPath, newPath string) bool {
 	return s.RunCommandChecked(ctx, []string{"git", "worktree", "move", oldPath, newPath}, "", fmt.Sprintf("Failed to move worktree from %s to %s", oldPath, newPath))
 }
 
+// LockWorktree locks a worktree so git refuses to move, remove or prune it.
+// An empty reason locks the worktree without one.
+func (s *Service) LockWorktree(ctx context.Context, path, reason string) bool {
+	args := []string{"git", "worktree", "lock"}
+	if reason != "" {
+		args = append(args, "--reason", reason)
+	}
+	args = append(args, path)
+	return s.RunCommandChecked(ctx, args, "", fmt.Sprintf("Failed to lock worktree %s", path))
+}
+
+// UnlockWorktree removes the lock from a worktree.
+func (s *Service) UnlockWorktree(ctx context.Context, path string) bool {
+	return s.RunCommandChecked(ctx, []string{"git", "worktree", "unlock", path}, "", fmt.Sprintf("Failed to unlock worktree %s", path))
+}
+
+// FindRelocatedWorktrees looks for linked worktrees whose recorded directory
+// no longer exists and matches them against candidates, the directories they
+// may have been moved to. A candidate matches when its .git file points back
+// to the administrative entry of the stale worktree, or to an entry of the
+// same name in a repository directory that no longer exists, as happens when
+// the main repository was moved along with its worktrees. Stale worktrees
+// without a matching candidate are returned as missing.
+func (s *Service) FindRelocatedWorktrees(ctx context.Context, candidates []string) ([]models.WorktreeRelocation, []string) {
+	commonDir := strings.TrimSpace(s.RunGit(ctx, []string{"git", "rev-parse", "--git-common-dir"}, "", []int{0}, true, false))
+	if commonDir == "" {
+		return nil, nil
+	}
+	if abs, err := filepath.Abs(commonDir); err == nil {
+		commonDir = abs
+	}
+	adminRoot := filepath.Join(commonDir, "worktrees")
+
+	entries, err := os.ReadDir(adminRoot)
+	if err != nil {
+		return nil, nil
+	}
+
+	stale := make(map[string]string)
+	var staleIDs []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		// #nosec G304 -- the gitdir file lives in the repository administrative directory
+		data, err := os.ReadFile(filepath.Join(adminRoot, entry.Name(), "gitdir"))
+		if err != nil {
+			continue
+		}
+		recorded := filepath.Dir(strings.TrimSpace(string(data)))
+		if _, err := os.Stat(filepath.Join(recorded, ".git")); err == nil {
+			continue
+		}
+		stale[entry.Name()] = recorded
+		staleIDs = append(staleIDs, entry.Name())
+	}
+	if len(stale) == 0 {
+		return nil, nil
+	}
+
+	var relocations []models.WorktreeRelocation
+	for _, candidate := range candidates {
+		id, ok := worktreeAdminID(candidate, adminRoot)
+		if !ok {
+			continue
+		}
+		oldPath, isStale := stale[id]
+		if !isStale {
+			continue
+		}
+		relocations = append(relocations, models.WorktreeRelocation{OldPath: oldPath, NewPath: filepath.Clean(candidate)})
+		delete(stale, id)
+	}
+
+	var missing []string
+	for _, id := range staleIDs {
+		if oldPath, ok := stale[id]; ok {
+			missing = append(missing, oldPath)
+		}
+	}
+	return relocations, missing
+}
+
+// worktreeAdminID returns the administrative entry name the .git file of dir
+// points to, provided the entry lives under adminRoot. An entry under a
+// worktrees directory that no longer exists is accepted too: the repository
+// was moved, and the entry name is the only link left to it.
+func worktreeAdminID(dir, adminRoot string) (string, bool) {
+	// #nosec G304 -- dir is a worktree candidate chosen by the caller
+	data, err := os.ReadFile(filepath.Join(dir, ".git"))
+	if err != nil {
+		return "", false
+	}
+	gitdir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !found {
+		return "", false
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(dir, gitdir)
+	}
+	recordedRoot := filepath.Dir(gitdir)
+	if canonicalPath(recordedRoot) == canonicalPath(adminRoot) {
+		return filepath.Base(gitdir), true
+	}
+	if filepath.Base(recordedRoot) != "worktrees" {
+		return "", false
+	}
+	if _, err := os.Stat(recordedRoot); !os.IsNotExist(err) {
+		return "", false
+	}
+	return filepath.Base(gitdir), true
+}
+
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return filepath.Clean(resolved)
+	}
+	return filepath.Clean(path)
+}
+
+// RepairWorktrees runs "git worktree repair" for the given worktree
+// directories, reconnecting them to the repository after they were moved.
+func (s *Service) RepairWorktrees(ctx context.Context, paths []string) bool {
+	args := append([]string{"git", "worktree", "repair"}, paths...)
+	return s.RunCommandChecked(ctx, args, "", "Failed to repair worktrees")
+}
+
 // RenameWorktree moves a worktree and renames its branch only when the
 // worktree directory name matches the old branch name.
 func (s *Service) RenameWorktree(ctx context.Context, oldPath, newPath, oldBranch, newBranch string) bool {
@@ -192,3 +192,280 @@ func TestCherryPickCommit(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestGetWorktreesParsesLockedAndPrunable(t *testing.T) {
+	t.Parallel()
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	porcelain := strings.Join([]string{
+		"worktree /repo",
+		"HEAD 1111111111111111111111111111111111111111",
+		"branch refs/heads/main",
+		"",
+		"worktree /repo-wt/usb",
+		"HEAD 2222222222222222222222222222222222222222",
+		"branch refs/heads/usb",
+		"locked on removable drive",
+		"",
+		"worktree /repo-wt/agent",
+		"HEAD 3333333333333333333333333333333333333333",
+		"branch refs/heads/agent",
+		"locked",
+		"",
+		"worktree /repo-wt/gone",
+		"HEAD 4444444444444444444444444444444444444444",
+		"branch refs/heads/gone",
+		"prunable gitdir file points to non-existent location",
+		"",
+	}, "\n")
+
+	service.SetCommandRunner(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		if len(args) >= 2 && args[0] == "worktree" && args[1] == "list" {
+			return exec.CommandContext(ctx, "printf", "%s", porcelain)
+		}
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	})
+
+	worktrees, err := service.GetWorktrees(ctx)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 4)
+
+	byPath := make(map[string]*models.WorktreeInfo, len(worktrees))
+	for _, wt := range worktrees {
+		byPath[wt.Path] = wt
+	}
+
+	assert.False(t, byPath["/repo"].Locked)
+	assert.True(t, byPath["/repo-wt/usb"].Locked)
+	assert.Equal(t, "on removable drive", byPath["/repo-wt/usb"].LockReason)
+	assert.True(t, byPath["/repo-wt/agent"].Locked)
+	assert.Empty(t, byPath["/repo-wt/agent"].LockReason)
+	assert.True(t, byPath["/repo-wt/gone"].Prunable)
+	assert.Equal(t, "gitdir file points to non-existent location", byPath["/repo-wt/gone"].PrunableReason)
+	assert.False(t, byPath["/repo-wt/gone"].Locked)
+}
+
+func TestLockAndUnlockWorktree(t *testing.T) {
+	t.Parallel()
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	var commands [][]string
+	service.SetCommandRunner(func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		commands = append(commands, append([]string{name}, args...))
+		return exec.CommandContext(ctx, "sh", "-c", "exit 0")
+	})
+
+	require.True(t, service.LockWorktree(ctx, "/repo-wt/usb", "on removable drive"))
+	require.True(t, service.LockWorktree(ctx, "/repo-wt/agent", ""))
+	require.True(t, service.UnlockWorktree(ctx, "/repo-wt/usb"))
+	require.Len(t, commands, 3)
+	assert.Equal(t, []string{"git", "worktree", "lock", "--reason", "on removable drive", "/repo-wt/usb"}, commands[0])
+	assert.Equal(t, []string{"git", "worktree", "lock", "/repo-wt/agent"}, commands[1])
+	assert.Equal(t, []string{"git", "worktree", "unlock", "/repo-wt/usb"}, commands[2])
+}
+
+func TestFindRelocatedWorktreesAndRepair(t *testing.T) {
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o750))
+	setupGitRepo(t, repo)
+
+	oldDir := filepath.Join(root, "worktrees")
+	for _, name := range []string{"feature", "gone"} {
+		cmd := exec.Command("git", "worktree", "add", "-b", name, filepath.Join(oldDir, name))
+		cmd.Dir = repo
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git worktree add failed: %s", output)
+	}
+
+	newDir := filepath.Join(root, "moved")
+	require.NoError(t, os.MkdirAll(newDir, 0o750))
+	require.NoError(t, os.Rename(filepath.Join(oldDir, "feature"), filepath.Join(newDir, "feature")))
+	require.NoError(t, os.RemoveAll(filepath.Join(oldDir, "gone")))
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(repo))
+
+	relocated, missing := service.FindRelocatedWorktrees(ctx, []string{
+		filepath.Join(newDir, "feature"),
+		repo,
+		filepath.Join(root, "does-not-exist"),
+	})
+	require.Len(t, relocated, 1)
+	assert.Equal(t, filepath.Join(oldDir, "feature"), relocated[0].OldPath)
+	assert.Equal(t, filepath.Join(newDir, "feature"), relocated[0].NewPath)
+	assert.Equal(t, []string{filepath.Join(oldDir, "gone")}, missing)
+
+	require.True(t, service.RepairWorktrees(ctx, []string{relocated[0].NewPath}))
+
+	worktrees, err := service.GetWorktrees(ctx)
+	require.NoError(t, err)
+	paths := map[string]bool{}
+	for _, wt := range worktrees {
+		paths[wt.Path] = wt.Prunable
+	}
+	prunable, ok := paths[filepath.Join(newDir, "feature")]
+	require.True(t, ok, "expected repaired worktree in %v", paths)
+	assert.False(t, prunable)
+	assert.True(t, paths[filepath.Join(oldDir, "gone")])
+
+	relocated, missing = service.FindRelocatedWorktrees(ctx, []string{filepath.Join(newDir, "feature")})
+	assert.Empty(t, relocated)
+	assert.Equal(t, []string{filepath.Join(oldDir, "gone")}, missing)
+}
+
+func TestFindRelocatedWorktreesWithMovedRepository(t *testing.T) {
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	oldParent := filepath.Join(root, "project")
+	repo := filepath.Join(oldParent, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o750))
+	setupGitRepo(t, repo)
+
+	cmd := exec.Command("git", "worktree", "add", "-b", "feature", filepath.Join(oldParent, "worktrees", "feature"))
+	cmd.Dir = repo
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git worktree add failed: %s", output)
+
+	// Rename the folder holding both the repository and its worktrees
+	newParent := filepath.Join(root, "renamed")
+	require.NoError(t, os.Rename(oldParent, newParent))
+	newRepo := filepath.Join(newParent, "repo")
+	newWorktree := filepath.Join(newParent, "worktrees", "feature")
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(newRepo))
+
+	relocated, missing := service.FindRelocatedWorktrees(ctx, []string{newWorktree, newRepo})
+	require.Len(t, relocated, 1)
+	assert.Equal(t, filepath.Join(oldParent, "worktrees", "feature"), relocated[0].OldPath)
+	assert.Equal(t, newWorktree, relocated[0].NewPath)
+	assert.Empty(t, missing)
+
+	require.True(t, service.RepairWorktrees(ctx, []string{newWorktree}))
+
+	worktrees, err := service.GetWorktrees(ctx)
+	require.NoError(t, err)
+	paths := map[string]bool{}
+	for _, wt := range worktrees {
+		paths[wt.Path] = wt.Prunable
+	}
+	prunable, ok := paths[newWorktree]
+	require.True(t, ok, "expected repaired worktree in %v", paths)
+	assert.False(t, prunable)
+
+	relocated, missing = service.FindRelocatedWorktrees(ctx, []string{newWorktree})
+	assert.Empty(t, relocated)
+	assert.Empty(t, missing)
+}
+
+func TestParseWorktreePorcelainBareAndDetached(t *testing.T) {
+	t.Parallel()
+
+	raw := "worktree /repo.git\nbare\n\n" +
+		"worktree /wt/main\nHEAD 1111111111111111111111111111111111111111\nbranch refs/heads/main\n\n" +
+		"worktree /wt/release\nHEAD 2222222222222222222222222222222222222222\ndetached\nlocked\n"
+
+	wts := parseWorktreePorcelain(raw)
+	require.Len(t, wts, 3)
+	assert.True(t, wts[0].bare)
+	assert.Equal(t, "/repo.git", wts[0].path)
+	assert.Equal(t, "main", wts[1].branch)
+	assert.Equal(t, "1111111111111111111111111111111111111111", wts[1].head)
+	assert.False(t, wts[1].detached)
+	assert.True(t, wts[2].detached)
+	assert.Empty(t, wts[2].branch)
+	assert.Equal(t, "2222222222222222222222222222222222222222", wts[2].head)
+	assert.True(t, wts[2].locked)
+}
+
+func TestGetWorktreesBareRepository(t *testing.T) {
+	notify := func(_ string, _ string) {}
+	notifyOnce := func(_ string, _ string, _ string) {}
+
+	service := NewService(notify, notifyOnce)
+	ctx := context.Background()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	origin := filepath.Join(root, "origin")
+	require.NoError(t, os.MkdirAll(origin, 0o750))
+	setupGitRepo(t, origin)
+
+	runGit := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, output)
+		return strings.TrimSpace(string(output))
+	}
+	runGit(origin, "branch", "-M", "trunk")
+	runGit(origin, "tag", "v1.0")
+	head := runGit(origin, "rev-parse", "HEAD")
+
+	bare := filepath.Join(root, "repo.git")
+	runGit(root, "clone", "--bare", origin, bare)
+	mainPath := filepath.Join(root, "repo", "trunk")
+	releasePath := filepath.Join(root, "repo", "release")
+	runGit(bare, "worktree", "add", mainPath, "trunk")
+	runGit(bare, "worktree", "add", "--detach", releasePath, "v1.0")
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	defer func() { _ = os.Chdir(origDir) }()
+	require.NoError(t, os.Chdir(releasePath))
+
+	assert.Equal(t, "trunk", service.GetMainBranch(ctx))
+	assert.Equal(t, mainPath, service.GetMainWorktreePath(ctx))
+
+	worktrees, err := service.GetWorktrees(ctx)
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+
+	byPath := map[string]*models.WorktreeInfo{}
+	for _, wt := range worktrees {
+		byPath[wt.Path] = wt
+	}
+	require.NotContains(t, byPath, bare)
+
+	mainWt := byPath[mainPath]
+	require.NotNil(t, mainWt)
+	assert.True(t, mainWt.IsMain)
+	assert.Equal(t, "trunk", mainWt.Branch)
+	assert.False(t, mainWt.Detached)
+
+	release := byPath[releasePath]
+	require.NotNil(t, release)
+	assert.False(t, release.IsMain)
+	assert.True(t, release.Detached)
+	assert.Equal(t, head, release.Head)
+	assert.Equal(t, "v1.0", release.HeadTag)
+	assert.Equal(t, "(detached at v1.0)", release.BranchLabel())
+	assert.NotEmpty(t, release.LastActive)
+}
@@ -21,7 +21,7 @@ type AgentHookEvent struct {
 }
 
 // Hook event names shared by the Claude Code, Codex CLI, and Copilot CLI
-// hook systems. Copilot CLI emits these when its hooks are configured with
+// hook systems; Gemini CLI events are normalised onto them. Copilot CLI emits these when its hooks are configured with
 // the PascalCase (VS Code compatible) event names.
 const (
 	AgentHookSessionStart     = "SessionStart"
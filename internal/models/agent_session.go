@@ -14,6 +14,12 @@ const (
 	AgentKindCodex AgentKind = "codex"
 	// AgentKindCopilot marks a session produced by the GitHub Copilot CLI.
 	AgentKindCopilot AgentKind = "copilot"
+	// AgentKindGemini marks a session produced by the Gemini CLI.
+	AgentKindGemini AgentKind = "gemini"
+	// AgentKindOpenCode marks a session produced by OpenCode.
+	AgentKindOpenCode AgentKind = "opencode"
+	// AgentKindAider marks a chat history written by Aider.
+	AgentKindAider AgentKind = "aider"
 )
 
 // AgentSessionStatus describes the last observable state of a transcript.
@@ -104,6 +110,8 @@ const (
 	AgentSessionLivenessSourceCWDHeuristic AgentSessionLivenessSource = "cwd_heuristic"
 	// AgentSessionLivenessSourceHook means an agent lifecycle hook reported the session.
 	AgentSessionLivenessSourceHook AgentSessionLivenessSource = "hook"
+	// AgentSessionLivenessSourceLaunch means lazyworktree started the agent and its session has not appeared yet.
+	AgentSessionLivenessSourceLaunch AgentSessionLivenessSource = "launch"
 	// AgentSessionLivenessSourceNone means no evidence was available.
 	AgentSessionLivenessSourceNone AgentSessionLivenessSource = "none"
 )
@@ -122,6 +130,11 @@ type AgentSession struct {
 	LastPromptText string
 	LastReplyText  string
 	LastTargetPath string
+	// EditedPaths lists every file the session wrote or edited, as reported
+	// by its tool calls. EditedAt holds when each was last written, for the
+	// agents whose transcripts timestamp tool calls.
+	EditedPaths    []string
+	EditedAt       map[string]time.Time
 	LastCommand    string
 	TaskLabel      string
 	CurrentTool    string
@@ -140,4 +153,29 @@ type AgentSession struct {
 	PID            int
 	IsOpen         bool
 	OpenConfidence AgentOpenConfidence
+	Usage          AgentTokenUsage
+}
+
+// AgentTokenUsage counts the tokens a session sent to and received from its
+// model. Input excludes tokens served from or written to the prompt cache.
+type AgentTokenUsage struct {
+	Input      int64
+	Output     int64
+	CacheWrite int64
+	CacheRead  int64
+}
+
+// Add returns the sum of two usages.
+func (u AgentTokenUsage) Add(other AgentTokenUsage) AgentTokenUsage {
+	return AgentTokenUsage{
+		Input:      u.Input + other.Input,
+		Output:     u.Output + other.Output,
+		CacheWrite: u.CacheWrite + other.CacheWrite,
+		CacheRead:  u.CacheRead + other.CacheRead,
+	}
+}
+
+// Total returns every token counted by the usage.
+func (u AgentTokenUsage) Total() int64 {
+	return u.Input + u.Output + u.CacheWrite + u.CacheRead
 }
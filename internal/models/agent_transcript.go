@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// AgentTranscriptEntryKind identifies what an agent transcript entry holds.
+type AgentTranscriptEntryKind string
+
+const (
+	// AgentTranscriptUser is a prompt written by the user.
+	AgentTranscriptUser AgentTranscriptEntryKind = "user"
+	// AgentTranscriptAssistant is a reply written by the agent.
+	AgentTranscriptAssistant AgentTranscriptEntryKind = "assistant"
+	// AgentTranscriptThinking is reasoning the agent recorded before acting.
+	AgentTranscriptThinking AgentTranscriptEntryKind = "thinking"
+	// AgentTranscriptToolCall is a tool invocation with its arguments.
+	AgentTranscriptToolCall AgentTranscriptEntryKind = "tool_call"
+	// AgentTranscriptToolResult is the output returned by a tool.
+	AgentTranscriptToolResult AgentTranscriptEntryKind = "tool_result"
+)
+
+// AgentTranscriptEntry is one turn, or one part of a turn, of an agent
+// conversation.
+type AgentTranscriptEntry struct {
+	Kind      AgentTranscriptEntryKind
+	Timestamp time.Time
+	// Text is the message, the reasoning or the tool output.
+	Text string
+	// ToolName is the normalised tool name of calls and, when known, results.
+	ToolName string
+	// ToolInput holds the indented JSON arguments of a tool call.
+	ToolInput string
+	IsError   bool
+}
@@ -2,6 +2,7 @@
 package models
 
 import (
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
@@ -21,14 +22,16 @@ type PRInfo struct {
 	Title           string
 	Body            string // For branch_name_script input
 	URL             string
-	Branch          string // Branch name (headRefName for GitHub, source_branch for GitLab)
-	BaseBranch      string // Base branch name (baseRefName for GitHub, target_branch for GitLab)
-	Author          string // PR/MR author username
-	AuthorName      string // PR/MR author full name
-	AuthorAvatarURL string // PR/MR author avatar URL
-	AuthorIsBot     bool   // Whether the author is a bot
-	IsDraft         bool   // Whether the PR is a draft
-	CIStatus        string // Computed CI status: "success", "failure", "pending", "none"
+	Branch          string   // Branch name (headRefName for GitHub, source_branch for GitLab)
+	BaseBranch      string   // Base branch name (baseRefName for GitHub, target_branch for GitLab)
+	Author          string   // PR/MR author username
+	AuthorName      string   // PR/MR author full name
+	AuthorAvatarURL string   // PR/MR author avatar URL
+	AuthorIsBot     bool     // Whether the author is a bot
+	IsDraft         bool     // Whether the PR is a draft
+	CIStatus        string   // Computed CI status: "success", "failure", "pending", "none"
+	Assignees       []string // Assignee usernames
+	ReviewRequests  []string // Usernames (or team slugs) asked to review
 }
 
 // EnsureAuthorAvatarURL backfills AuthorAvatarURL from the author login for
@@ -68,6 +71,34 @@ type CICheck struct {
 	StartedAt  time.Time // When the check started (zero if not available)
 }
 
+// CIFailure locates one cause of a failed CI check: a failing test, a
+// compiler error, or a failure reported by the forge.
+type CIFailure struct {
+	Check   string // Name of the failed check
+	Test    string // Failing test name (empty for build or forge failures)
+	File    string // File path as printed by the tool (empty if unknown)
+	Line    int    // Line in File (0 if unknown)
+	Message string // First line of the failure message
+}
+
+// PRReviewThread is an inline review discussion attached to a file of a PR/MR.
+type PRReviewThread struct {
+	ID         string // Forge identifier used to reply to or resolve the thread
+	Path       string // File path relative to the repository root
+	Line       int    // Line in the new version of the file (0 if unknown)
+	IsResolved bool
+	IsOutdated bool // The commented lines changed since the comment was made
+	Comments   []PRReviewComment
+}
+
+// PRReviewComment is a single comment of a review thread.
+type PRReviewComment struct {
+	Author    string
+	Body      string
+	URL       string
+	CreatedAt time.Time
+}
+
 // WorktreeInfo summarizes the information for a git worktree.
 type WorktreeInfo struct {
 	Path           string
@@ -89,6 +120,85 @@ type WorktreeInfo struct {
 	Modified       int
 	Staged         int
 	Divergence     string
+	Locked         bool
+	LockReason     string // Reason given to "git worktree lock --reason"
+	Prunable       bool
+	PrunableReason string // Why "git worktree prune" would remove this worktree
+	Head           string // Commit checked out in the worktree
+	Detached       bool
+	HeadTag        string // Tag pointing at Head, looked up for detached worktrees
+}
+
+// ShortHead returns the abbreviated commit checked out in the worktree.
+func (w *WorktreeInfo) ShortHead() string {
+	if len(w.Head) > 7 {
+		return w.Head[:7]
+	}
+	return w.Head
+}
+
+// HeadRef names what a detached worktree points at: its tag when there is
+// one, otherwise the abbreviated commit. It is empty for worktrees on a branch.
+func (w *WorktreeInfo) HeadRef() string {
+	if !w.Detached {
+		return ""
+	}
+	if w.HeadTag != "" {
+		return w.HeadTag
+	}
+	return w.ShortHead()
+}
+
+// BranchLabel returns the branch checked out in the worktree, or for a
+// detached HEAD the tag or commit it is detached at.
+func (w *WorktreeInfo) BranchLabel() string {
+	if ref := w.HeadRef(); ref != "" {
+		return fmt.Sprintf("(detached at %s)", ref)
+	}
+	return w.Branch
+}
+
+// WorktreeRelocation pairs the path git recorded for a worktree with the
+// directory it was moved to outside of git.
+type WorktreeRelocation struct {
+	OldPath string
+	NewPath string
+}
+
+// StashEntry is one entry of the stash list, which every worktree of a
+// repository shares.
+type StashEntry struct {
+	Ref       string // Reflog selector, e.g. stash@{0}; shifts as stashes come and go
+	Hash      string
+	Branch    string // Branch the stash was made on, empty for a detached HEAD
+	Message   string
+	Timestamp int64
+}
+
+// Interactive rebase actions, named as in a git rebase todo list.
+const (
+	RebaseActionPick   = "pick"
+	RebaseActionReword = "reword"
+	RebaseActionSquash = "squash"
+	RebaseActionFixup  = "fixup"
+	RebaseActionDrop   = "drop"
+)
+
+// RebaseCommit is a commit of an interactive rebase todo list.
+type RebaseCommit struct {
+	Hash    string
+	Author  string
+	Subject string
+	Message string // Full commit message, replaced by the new one for reword
+	Action  string // One of the RebaseAction values
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c *RebaseCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
 }
 
 // WorktreeNote stores user-authored metadata for a worktree.
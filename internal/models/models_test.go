@@ -274,3 +274,18 @@ func TestConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestWorktreeInfoBranchLabel(t *testing.T) {
+	onBranch := &WorktreeInfo{Branch: "feature", Head: "0123456789abcdef"}
+	assert.Empty(t, onBranch.HeadRef())
+	assert.Equal(t, "feature", onBranch.BranchLabel())
+
+	detached := &WorktreeInfo{Branch: "(detached)", Head: "0123456789abcdef", Detached: true}
+	assert.Equal(t, "0123456", detached.ShortHead())
+	assert.Equal(t, "0123456", detached.HeadRef())
+	assert.Equal(t, "(detached at 0123456)", detached.BranchLabel())
+
+	detached.HeadTag = "v1.2.0"
+	assert.Equal(t, "v1.2.0", detached.HeadRef())
+	assert.Equal(t, "(detached at v1.2.0)", detached.BranchLabel())
+}
@@ -2,7 +2,10 @@ package models
 
 // StatusFile represents a file entry from git status.
 type StatusFile struct {
-	Filename    string
-	Status      string // XY status code (e.g., ".M", "M.", " ?")
-	IsUntracked bool
+	Filename     string
+	Status       string // XY status code (e.g., ".M", "M.", " ?")
+	IsUntracked  bool
+	IsConflicted bool // Unmerged path left by a conflicting merge, rebase or stash
+	// Agent is the coding agent that last edited the file, if any.
+	Agent AgentKind
 }
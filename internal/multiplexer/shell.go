@@ -4,15 +4,14 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+
+	"github.com/chmouel/lazyworktree/internal/utils"
 )
 
 // ShellQuote quotes a string for use in a shell command.
 // Returns an empty quoted string for empty input.
 func ShellQuote(input string) string {
-	if input == "" {
-		return "''"
-	}
-	return "'" + strings.ReplaceAll(input, "'", "'\"'\"'") + "'"
+	return utils.ShellQuote(input)
 }
 
 // ExportEnvCommand builds a shell command string that exports environment variables.
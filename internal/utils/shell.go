@@ -0,0 +1,12 @@
+package utils
+
+import "strings"
+
+// ShellQuote quotes a string for use in a shell command.
+// Returns an empty quoted string for empty input.
+func ShellQuote(input string) string {
+	if input == "" {
+		return "''"
+	}
+	return "'" + strings.ReplaceAll(input, "'", "'\"'\"'") + "'"
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/chmouel/lazyworktree/internal/models"
@@ -47,6 +49,44 @@ func GenerateIssueWorktreeName(issue *models.IssueInfo, template, generatedTitle
 	return applyWorktreeTemplate(template, replacements)
 }
 
+// IssueNumberFromWorktreeName recovers the issue number from a worktree or
+// branch name generated with the given issue template. It returns 0 when the
+// name does not follow the template or the template has no {number}.
+func IssueNumberFromWorktreeName(name, template string) int {
+	if !strings.Contains(template, "{number}") {
+		return 0
+	}
+	// Only the part up to the number is matched: the title may have been
+	// truncated or regenerated.
+	prefix, suffix, _ := strings.Cut(template, "{number}")
+	pattern := regexp.QuoteMeta(prefix)
+	for _, placeholder := range []string{"{title}", "{generated}", "{pr_author}"} {
+		pattern = strings.ReplaceAll(pattern, regexp.QuoteMeta(placeholder), ".*?")
+	}
+	// The number ends at the next template character, or at the end of the
+	// name when an empty title left nothing after it.
+	terminator := "$"
+	switch {
+	case strings.HasPrefix(suffix, "{"):
+		terminator = "(?:[^0-9]|$)"
+	case suffix != "":
+		terminator = "(?:" + regexp.QuoteMeta(string([]rune(suffix)[0])) + "|$)"
+	}
+	re, err := regexp.Compile("^" + pattern + `(\d+)` + terminator)
+	if err != nil {
+		return 0
+	}
+	match := re.FindStringSubmatch(name)
+	if match == nil {
+		return 0
+	}
+	number, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
 type placeholderReplacement struct {
 	placeholder string
 	value       string
@@ -73,3 +73,29 @@ func TestGenerateIssueWorktreeName_UsesGeneratedTitleWhenProvided(t *testing.T)
 		t.Fatalf("GenerateIssueWorktreeName() = %q, want %q", got, want)
 	}
 }
+
+func TestIssueNumberFromWorktreeName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		template string
+		want     int
+	}{
+		{name: "default template", input: "issue-42-fix-the-login", template: "issue-{number}-{title}", want: 42},
+		{name: "number only", input: "issue-7", template: "issue-{number}-{title}", want: 7},
+		{name: "title before number", input: "fix-login-gh42", template: "{title}-gh{number}", want: 42},
+		{name: "unrelated branch", input: "feature-42", template: "issue-{number}-{title}", want: 0},
+		{name: "digits must end", input: "issue-42x", template: "issue-{number}", want: 0},
+		{name: "template without number", input: "issue-42", template: "issue-{title}", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IssueNumberFromWorktreeName(tt.input, tt.template); got != tt.want {
+				t.Fatalf("IssueNumberFromWorktreeName(%q, %q) = %d, want %d", tt.input, tt.template, got, tt.want)
+			}
+		})
+	}
+}